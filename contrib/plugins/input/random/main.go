@@ -18,7 +18,11 @@
 //   tinct plugins enable random
 //   tinct generate -i random -o tailwind
 //
-// Plugin Args:
+// Options:
+//   --input-count N: Number of colours to generate (default: 32)
+//   --input-seed-mode manual --input-seed-value N: Random seed for reproducible generation
+//
+// Plugin Args (used when the options above are not given):
 //   count: Number of colours to generate (default: 32)
 //   seed: Random seed for reproducible generation
 //
@@ -61,9 +65,11 @@ type RandomPlugin struct{}
 
 // Generate creates a random color palette.
 func (p *RandomPlugin) Generate(ctx context.Context, opts tinctplugin.InputOptions) ([]color.Color, error) {
-	// Extract configuration from plugin args
+	// Extract configuration from the well-known options, falling back to plugin args
 	seed := uint64(0)
-	if seedArg, ok := opts.PluginArgs["seed"].(float64); ok {
+	if opts.SeedValue != nil {
+		seed = uint64(*opts.SeedValue)
+	} else if seedArg, ok := opts.PluginArgs["seed"].(float64); ok {
 		seed = uint64(seedArg)
 	} else {
 		// Generate a truly random seed from crypto/rand
@@ -81,7 +87,9 @@ func (p *RandomPlugin) Generate(ctx context.Context, opts tinctplugin.InputOptio
 
 	// Number of colors to generate (default 32)
 	colorCount := 32
-	if count, ok := opts.PluginArgs["count"].(float64); ok {
+	if opts.Count > 0 {
+		colorCount = opts.Count
+	} else if count, ok := opts.PluginArgs["count"].(float64); ok {
 		colorCount = int(count)
 	}
 
@@ -6,7 +6,6 @@ import (
 	"os"
 	"path/filepath"
 	"syscall"
-	"time"
 )
 
 // RuntimePaths holds all runtime file paths
@@ -130,7 +129,7 @@ func mergeConfigs(paths *RuntimePaths, baseConfig string, appendConfigs []string
 	defer tmpFile.Close()
 
 	// Write header
-	fmt.Fprintf(tmpFile, "# Auto-generated merged wob config (%s)\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintln(tmpFile, "# Auto-generated merged wob config")
 	fmt.Fprintf(tmpFile, "# Base: %s\n\n", baseConfig)
 
 	// Copy base config
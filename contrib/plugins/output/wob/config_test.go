@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMergeConfigsIsByteStable(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.ini")
+	extra := filepath.Join(dir, "tinct.ini")
+	if err := os.WriteFile(base, []byte("anchor = center\n"), 0o600); err != nil {
+		t.Fatalf("failed to write base config: %v", err)
+	}
+	if err := os.WriteFile(extra, []byte("[style.default]\nbar_color = 7aa2f7\n"), 0o600); err != nil {
+		t.Fatalf("failed to write append config: %v", err)
+	}
+	paths := &RuntimePaths{Dir: dir, Config: filepath.Join(dir, "merged.ini")}
+
+	merge := func() []byte {
+		t.Helper()
+		path, err := mergeConfigs(paths, base, []string{extra})
+		if err != nil {
+			t.Fatalf("mergeConfigs() error = %v", err)
+		}
+		data, err := os.ReadFile(path) // #nosec G304 - test file in a temp directory
+		if err != nil {
+			t.Fatalf("failed to read merged config: %v", err)
+		}
+		return data
+	}
+
+	first, second := merge(), merge()
+	if !bytes.Equal(first, second) {
+		t.Errorf("merging the same configs twice differs:\n%s\n---\n%s", first, second)
+	}
+	if !bytes.HasPrefix(first, []byte("# Auto-generated merged wob config\n")) {
+		t.Errorf("merged config header = %q", first[:bytes.IndexByte(first, '\n')+1])
+	}
+}
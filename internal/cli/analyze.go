@@ -0,0 +1,124 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+var (
+	// Analyze command flags.
+	analyzeInputPlugin string
+	analyzeJSON        bool
+)
+
+// analyzeCmd represents the analyze command.
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Audit a palette for contrast and colour vision deficiency issues",
+	Long: `Analyze the accessibility of a generated palette.
+
+The analyze command categorises a palette exactly as generate would, then reports
+WCAG contrast ratios for the foreground/background pairs themes render, with AA and
+AAA pass/fail results, and whether colours with different meanings (danger, success,
+warning, accents) remain distinguishable under protanopia, deuteranopia and tritanopia.
+
+Use --json for a machine-readable report suitable for CI checks.
+
+Examples:
+  # Audit the theme generated from a wallpaper
+  tinct analyze -i image -p wallpaper.jpg
+
+  # Machine-readable audit, failing CI when foreground contrast is below AA
+  tinct analyze -i image -p wallpaper.jpg --json | jq -e '.summary.foreground_pass_aa'
+
+  # Audit a saved palette with colour-blind friendly semantics
+  tinct analyze -i file --file.path palette.txt --semantic-palette cvd-safe`,
+	Args: cobra.NoArgs,
+	RunE: runAnalyze,
+}
+
+func init() {
+	analyzeCmd.Flags().StringVarP(&analyzeInputPlugin, "input", "i", "image", "Input plugin (image, file, remote-css, remote-json, harmony, named, pywal, clipboard, video)")
+	analyzeCmd.Flags().BoolVar(&analyzeJSON, "json", false, "output the audit as JSON")
+}
+
+// runAnalyze executes the analyze command.
+func runAnalyze(cmd *cobra.Command, _ []string) error {
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return fmt.Errorf("failed to get verbose flag: %w", err)
+	}
+
+	categorised, err := categoriseFromInput(cmd.Context(), analyzeInputPlugin, verbose)
+	if err != nil {
+		return err
+	}
+
+	audit := colour.AuditAccessibility(categorised)
+
+	if analyzeJSON {
+		data, err := audit.ToJSON()
+		if err != nil {
+			return fmt.Errorf("failed to convert audit to JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Print(formatAccessibilityAudit(audit))
+	return nil
+}
+
+// formatAccessibilityAudit renders an audit as human-readable tables.
+func formatAccessibilityAudit(audit colour.AccessibilityAudit) string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "Theme Type: %s\n\n", audit.ThemeType)
+
+	out.WriteString("Contrast:\n")
+	contrast := NewTable([]string{"FOREGROUND", "BACKGROUND", "RATIO", "AA LARGE", "AA", "AAA"})
+	for _, check := range audit.Contrast {
+		contrast.AddRow([]string{
+			fmt.Sprintf("%s (%s)", check.Foreground, check.ForegroundHex),
+			fmt.Sprintf("%s (%s)", check.Background, check.BackgroundHex),
+			fmt.Sprintf("%.2f:1", check.Ratio),
+			passFail(check.PassAALarge),
+			passFail(check.PassAA),
+			passFail(check.PassAAA),
+		})
+	}
+	out.WriteString(contrast.Render())
+
+	out.WriteString("\nColour vision deficiency:\n")
+	cvd := NewTable([]string{"ROLES", "DEFICIENCY", "DELTA E", "DISTINGUISHABLE"})
+	for _, check := range audit.CVD {
+		cvd.AddRow([]string{
+			fmt.Sprintf("%s / %s", check.RoleA, check.RoleB),
+			string(check.Deficiency),
+			fmt.Sprintf("%.1f", check.DeltaE),
+			passFail(check.Distinguishable),
+		})
+	}
+	out.WriteString(cvd.Render())
+
+	fmt.Fprintf(&out, "\nSummary: foreground AA %s, AAA %s; all pairs AA %s; CVD safe %s\n",
+		passFail(audit.Summary.ForegroundPassAA),
+		passFail(audit.Summary.ForegroundPassAAA),
+		passFail(audit.Summary.AllPassAA),
+		passFail(audit.Summary.CVDSafe))
+
+	return out.String()
+}
+
+// passFail formats a check result.
+func passFail(ok bool) string {
+	if ok {
+		return "pass"
+	}
+	return "fail"
+}
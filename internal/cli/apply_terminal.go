@@ -0,0 +1,155 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/image"
+)
+
+// oscTerminator ends an OSC sequence (ST, ESC \).
+const oscTerminator = "\x1b\\"
+
+var (
+	// Apply-terminal command flags.
+	applyTerminalInputPlugin string
+	applyTerminalReset       bool
+)
+
+// applyTerminalCmd represents the apply-terminal command.
+var applyTerminalCmd = &cobra.Command{
+	Use:   "apply-terminal [image]",
+	Short: "Preview a palette live in the current terminal",
+	Long: `Extract a palette and apply it to the current terminal session.
+
+The apply-terminal command categorises a palette exactly as generate would, then
+sets the terminal's 16 ANSI colours (OSC 4) and its foreground and background
+(OSC 10 and 11) with escape sequences. No plugin runs and no file is written, so
+it is a quick way to try a theme; the colours last until the terminal is reset or
+closed. Inside tmux the sequences are passed through to the outer terminal, which
+needs "set -g allow-passthrough on" in tmux 3.3 and later.
+
+Examples:
+  # Try the theme from a wallpaper
+  tinct apply-terminal wallpaper.jpg
+
+  # Try a saved palette
+  tinct apply-terminal -i file --file.path palette.txt
+
+  # Restore the terminal's own colours
+  tinct apply-terminal --reset`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runApplyTerminal,
+}
+
+func init() {
+	applyTerminalCmd.Flags().StringVarP(&applyTerminalInputPlugin, "input", "i", "image", "Input plugin (image, file, remote-css, remote-json, harmony, named, pywal, clipboard, video)")
+	applyTerminalCmd.Flags().BoolVar(&applyTerminalReset, "reset", false, "restore the terminal's default colours instead of applying a palette")
+}
+
+// runApplyTerminal executes the apply-terminal command.
+func runApplyTerminal(cmd *cobra.Command, args []string) error {
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return fmt.Errorf("failed to get verbose flag: %w", err)
+	}
+
+	var sequences string
+	if applyTerminalReset {
+		if len(args) > 0 {
+			return fmt.Errorf("--reset does not take an image")
+		}
+		sequences = terminalResetSequences()
+	} else {
+		if len(args) > 0 {
+			if !image.IsImagePath(args[0]) {
+				return fmt.Errorf("%s is not an image file (use --input to choose another input plugin)", args[0])
+			}
+			if applyTerminalInputPlugin != "image" {
+				return fmt.Errorf("image path %s cannot be used with --input %s", args[0], applyTerminalInputPlugin)
+			}
+			if err := cmd.Flags().Set("image.path", args[0]); err != nil {
+				return fmt.Errorf("failed to set image path: %w", err)
+			}
+		}
+
+		categorised, err := categoriseFromInput(cmd.Context(), applyTerminalInputPlugin, verbose)
+		if err != nil {
+			return err
+		}
+		sequences = terminalSequences(categorised)
+	}
+
+	stdoutIsTerminal := term.IsTerminal(int(os.Stdout.Fd()))
+	if warning := terminalSupportWarning(os.Getenv, stdoutIsTerminal); warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if os.Getenv("TMUX") != "" {
+		sequences = tmuxPassthrough(sequences)
+	}
+	fmt.Print(sequences)
+
+	return nil
+}
+
+// oscColour formats a colour for an OSC colour sequence (rgb:rr/gg/bb).
+func oscColour(cv colour.ColorValue) string {
+	return fmt.Sprintf("rgb:%02x/%02x/%02x", cv.R(), cv.G(), cv.B())
+}
+
+// terminalSequences returns the OSC sequences that set the 16 ANSI colours (OSC 4)
+// and the default foreground (OSC 10) and background (OSC 11) to the palette's.
+func terminalSequences(palette *colour.CategorisedPalette) string {
+	var b strings.Builder
+	for i, cv := range palette.ANSI16() {
+		fmt.Fprintf(&b, "\x1b]4;%d;%s%s", i, oscColour(cv), oscTerminator)
+	}
+
+	ph := colour.NewPaletteHelper(palette)
+	if fg, ok := ph.GetSafe(colour.RoleForeground); ok {
+		fmt.Fprintf(&b, "\x1b]10;%s%s", oscColour(fg), oscTerminator)
+	}
+	if bg, ok := ph.GetSafe(colour.RoleBackground); ok {
+		fmt.Fprintf(&b, "\x1b]11;%s%s", oscColour(bg), oscTerminator)
+	}
+
+	return b.String()
+}
+
+// terminalResetSequences returns the OSC sequences that restore the terminal's own
+// ANSI colours (OSC 104), foreground (OSC 110) and background (OSC 111).
+func terminalResetSequences() string {
+	return "\x1b]104" + oscTerminator + "\x1b]110" + oscTerminator + "\x1b]111" + oscTerminator
+}
+
+// tmuxPassthrough wraps sequences in a tmux DCS passthrough so they reach the outer
+// terminal; escape characters inside the passthrough are doubled.
+func tmuxPassthrough(sequences string) string {
+	return "\x1bPtmux;" + strings.ReplaceAll(sequences, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
+
+// terminalSupportWarning explains why the terminal may ignore the colour sequences,
+// or returns "" if it is expected to apply them. getenv looks up environment variables.
+func terminalSupportWarning(getenv func(string) string, stdoutIsTerminal bool) string {
+	if !stdoutIsTerminal {
+		return "standard output is not a terminal; the colour sequences will be written as text"
+	}
+
+	switch termName := getenv("TERM"); {
+	case termName == "" || termName == "dumb":
+		return "the terminal type is unknown or dumb and probably cannot change its colours"
+	case termName == "linux":
+		return "the Linux console does not support OSC colour sequences"
+	case strings.HasPrefix(termName, "screen") && getenv("TMUX") == "" && getenv("STY") != "":
+		return "GNU screen does not pass colour sequences to the outer terminal"
+	}
+
+	return ""
+}
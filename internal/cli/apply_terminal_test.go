@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+// oscSequence matches one OSC colour sequence and captures its parameters and colour.
+var oscSequence = regexp.MustCompile(`\x1b\]([\d;]+);rgb:([0-9a-f]{2})/([0-9a-f]{2})/([0-9a-f]{2})\x1b\\`)
+
+// terminalTestPalette returns a categorised dark palette.
+func terminalTestPalette() *colour.CategorisedPalette {
+	colors := []color.Color{
+		color.RGBA{R: 26, G: 27, B: 38, A: 255},
+		color.RGBA{R: 192, G: 202, B: 245, A: 255},
+		color.RGBA{R: 122, G: 162, B: 247, A: 255},
+		color.RGBA{R: 187, G: 154, B: 247, A: 255},
+		color.RGBA{R: 158, G: 206, B: 106, A: 255},
+		color.RGBA{R: 247, G: 118, B: 142, A: 255},
+	}
+	config := colour.DefaultCategorisationConfig()
+	config.ThemeType = colour.ThemeDark
+	return colour.Categorise(&colour.Palette{Colors: colors}, config)
+}
+
+func TestTerminalSequences(t *testing.T) {
+	palette := terminalTestPalette()
+	ph := colour.NewPaletteHelper(palette)
+	sequences := terminalSequences(palette)
+
+	// The output must consist of OSC sequences only.
+	if rest := oscSequence.ReplaceAllString(sequences, ""); rest != "" {
+		t.Fatalf("unexpected output outside OSC sequences: %q", rest)
+	}
+
+	matches := oscSequence.FindAllStringSubmatch(sequences, -1)
+	if len(matches) != 18 {
+		t.Fatalf("got %d sequences, want 18 (16 ANSI, foreground, background)", len(matches))
+	}
+
+	want := make([]string, 0, 18)
+	for i, cv := range palette.ANSI16() {
+		want = append(want, "4;"+strconv.Itoa(i)+";"+cv.Hex())
+	}
+	want = append(want,
+		"10;"+ph.Get(colour.RoleForeground).Hex(),
+		"11;"+ph.Get(colour.RoleBackground).Hex(),
+	)
+	for i, m := range matches {
+		got := fmt.Sprintf("%s;#%s%s%s", m[1], m[2], m[3], m[4])
+		if got != want[i] {
+			t.Errorf("sequence %d = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestOSCColour(t *testing.T) {
+	cv := colour.NewColorValue(colour.RGBA{R: 0x0a, G: 0xbc, B: 0xff, A: 0x80}, colour.RoleAccent1, 0)
+	if got := oscColour(cv); got != "rgb:0a/bc/ff" {
+		t.Errorf("oscColour() = %q, want rgb:0a/bc/ff", got)
+	}
+}
+
+func TestTerminalResetSequences(t *testing.T) {
+	want := "\x1b]104\x1b\\\x1b]110\x1b\\\x1b]111\x1b\\"
+	if got := terminalResetSequences(); got != want {
+		t.Errorf("terminalResetSequences() = %q, want %q", got, want)
+	}
+}
+
+func TestTmuxPassthrough(t *testing.T) {
+	got := tmuxPassthrough("\x1b]4;1;rgb:ff/00/00\x1b\\")
+	want := "\x1bPtmux;\x1b\x1b]4;1;rgb:ff/00/00\x1b\x1b\\\x1b\\"
+	if got != want {
+		t.Errorf("tmuxPassthrough() = %q, want %q", got, want)
+	}
+	if !strings.HasSuffix(got, "\x1b\\") {
+		t.Error("passthrough should end with ST")
+	}
+}
+
+func TestTerminalSupportWarning(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		notTerminal bool
+		wantWarning bool
+	}{
+		{name: "xterm", env: map[string]string{"TERM": "xterm-256color"}},
+		{name: "kitty", env: map[string]string{"TERM": "xterm-kitty"}},
+		{name: "tmux", env: map[string]string{"TERM": "screen-256color", "TMUX": "/tmp/tmux-1000/default,1,0"}},
+		{name: "not a terminal", env: map[string]string{"TERM": "xterm-256color"}, notTerminal: true, wantWarning: true},
+		{name: "no TERM", env: map[string]string{}, wantWarning: true},
+		{name: "dumb", env: map[string]string{"TERM": "dumb"}, wantWarning: true},
+		{name: "linux console", env: map[string]string{"TERM": "linux"}, wantWarning: true},
+		{name: "GNU screen", env: map[string]string{"TERM": "screen", "STY": "1234.pts-0"}, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			warning := terminalSupportWarning(getenv, !tt.notTerminal)
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("terminalSupportWarning() = %q, want warning %v", warning, tt.wantWarning)
+			}
+		})
+	}
+}
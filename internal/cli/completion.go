@@ -0,0 +1,65 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// completionShells lists the shells completion scripts can be generated for.
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// completionCmd represents the completion command.
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `Generate a shell completion script for tinct.
+
+The script is written to stdout. Load it in the current shell, or save it where
+your shell loads completions from to enable it permanently.
+
+Examples:
+  # Bash (current shell)
+  source <(tinct completion bash)
+
+  # Bash (permanent)
+  tinct completion bash > ~/.local/share/bash-completion/completions/tinct
+
+  # Zsh (permanent, with compinit enabled)
+  tinct completion zsh > "${fpath[1]}/_tinct"
+
+  # Fish
+  tinct completion fish > ~/.config/fish/completions/tinct.fish
+
+  # PowerShell
+  tinct completion powershell | Out-String | Invoke-Expression`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             completionShells,
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeCompletion(cmd.Root(), args[0], cmd.OutOrStdout())
+	},
+}
+
+// writeCompletion writes the completion script for shell to w.
+func writeCompletion(root *cobra.Command, shell string, w io.Writer) error {
+	var err error
+	switch shell {
+	case "bash":
+		err = root.GenBashCompletionV2(w, true)
+	case "zsh":
+		err = root.GenZshCompletion(w)
+	case "fish":
+		err = root.GenFishCompletion(w, true)
+	case "powershell":
+		err = root.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %q (valid: bash, zsh, fish, powershell)", shell)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate %s completion: %w", shell, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Package cli provides command-line interface utilities.
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// newTestCommandTree returns a small command tree for completion and man page tests.
+func newTestCommandTree() *cobra.Command {
+	root := &cobra.Command{Use: "tinct", Short: "A modern color palette generator"}
+	root.PersistentFlags().BoolP("verbose", "v", false, "enable verbose output")
+
+	generate := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate configuration files from a colour palette",
+		Long:  "Generate configuration files.\n\nExamples:\n  tinct generate -o kitty",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+	generate.Flags().StringP("outputs", "o", "all", "output plugins")
+	root.AddCommand(generate)
+
+	return root
+}
+
+func TestWriteCompletion(t *testing.T) {
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeCompletion(newTestCommandTree(), shell, &buf); err != nil {
+				t.Fatalf("writeCompletion() error = %v", err)
+			}
+			if buf.Len() == 0 {
+				t.Fatal("writeCompletion() produced an empty script")
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := writeCompletion(newTestCommandTree(), "bash", &buf); err != nil {
+		t.Fatalf("writeCompletion() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "__start_tinct") {
+		t.Error("bash completion script does not register tinct")
+	}
+}
+
+func TestWriteCompletionUnsupportedShell(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCompletion(newTestCommandTree(), "tcsh", &buf); err == nil {
+		t.Error("writeCompletion() expected error for unsupported shell")
+	}
+}
+
+func TestCompletionCommandArgs(t *testing.T) {
+	if err := completionCmd.Args(completionCmd, []string{"zsh"}); err != nil {
+		t.Errorf("Args(zsh) error = %v", err)
+	}
+	if err := completionCmd.Args(completionCmd, []string{"tcsh"}); err == nil {
+		t.Error("Args(tcsh) expected error")
+	}
+	if err := completionCmd.Args(completionCmd, nil); err == nil {
+		t.Error("Args() expected error without a shell")
+	}
+}
@@ -0,0 +1,101 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/image"
+	"github.com/jmylchreest/tinct/internal/plugin/input/shared/seed"
+)
+
+// Dominant command flags.
+var dominantColours int
+
+// dominantCmd represents the dominant command.
+var dominantCmd = &cobra.Command{
+	Use:   "dominant <image>",
+	Short: "Print the most prevalent colour of an image",
+	Long: `Print the single most prevalent colour of an image as hex.
+
+The dominant command clusters the image's colours with k-means, exactly as the image
+input plugin does, and prints the colour of the largest cluster. It skips
+categorisation entirely, so it is fast and its output is easy to use in scripts.
+
+The image can be a file, a URL, a data URI, or a directory (a random image is selected).
+The result is deterministic for a given image.
+
+Examples:
+  # Print the main colour
+  tinct dominant wallpaper.jpg
+
+  # Use it in a script
+  BORDER=$(tinct dominant wallpaper.jpg)
+
+  # Coarser clusters merge similar shades into one dominant colour
+  tinct dominant wallpaper.jpg --colours 4`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDominant,
+}
+
+func init() {
+	dominantCmd.Flags().IntVarP(&dominantColours, "colours", "c", 8, "number of colour clusters to choose from (1-256)")
+}
+
+// runDominant executes the dominant command.
+func runDominant(cmd *cobra.Command, args []string) error {
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return fmt.Errorf("failed to get verbose flag: %w", err)
+	}
+
+	path, err := image.ResolveImagePath(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve image path: %w", err)
+	}
+	if verbose && path != args[0] {
+		fmt.Fprintf(os.Stderr, "→ Selected random image from directory: %s\n", path)
+	}
+
+	hex, err := dominantColour(path, dominantColours)
+	if err != nil {
+		return err
+	}
+	fmt.Println(hex)
+	return nil
+}
+
+// dominantColour returns the hex of the highest-weight colour k-means extracts
+// from the image at path, seeded by the image content.
+func dominantColour(path string, colours int) (string, error) {
+	if colours < 1 || colours > 256 {
+		return "", fmt.Errorf("--colours must be between 1 and 256, got %d", colours)
+	}
+
+	img, err := image.NewSmartLoader().Load(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to load image: %w", err)
+	}
+
+	contentSeed, err := seed.Calculate(img, path, seed.Config{Mode: seed.ModeContent})
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate seed: %w", err)
+	}
+	extractor, err := colour.NewExtractor(colour.AlgorithmKMeans, colour.ExtractorOptions{Seed: &contentSeed})
+	if err != nil {
+		return "", fmt.Errorf("failed to create extractor: %w", err)
+	}
+	palette, err := extractor.Extract(img, colours)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract colours: %w", err)
+	}
+
+	dominant, err := palette.Dominant()
+	if err != nil {
+		return "", err
+	}
+	return colour.ToRGB(dominant).Hex(), nil
+}
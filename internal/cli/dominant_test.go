@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDominantColour(t *testing.T) {
+	// 70% blue, 20% orange and 10% near-white.
+	img := image.NewRGBA(image.Rect(0, 0, 100, 10))
+	for x := range 100 {
+		c := color.RGBA{R: 0x1e, G: 0x3a, B: 0x8a, A: 0xff}
+		switch {
+		case x >= 90:
+			c = color.RGBA{R: 0xf0, G: 0xf0, B: 0xe8, A: 0xff}
+		case x >= 70:
+			c = color.RGBA{R: 0xe0, G: 0x80, B: 0x20, A: 0xff}
+		}
+		for y := range 10 {
+			img.Set(x, y, c)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "wallpaper.png")
+	file, err := os.Create(path) // #nosec G304 - test file in a temp directory
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", path, err)
+	}
+	if err := png.Encode(file, img); err != nil {
+		t.Fatalf("failed to encode %s: %v", path, err)
+	}
+	file.Close()
+
+	for _, colours := range []int{1, 3, 8} {
+		got, err := dominantColour(path, colours)
+		if err != nil {
+			t.Fatalf("dominantColour(%d) error = %v", colours, err)
+		}
+		if colours > 1 && got != "#1e3a8a" {
+			t.Errorf("dominantColour(%d) = %s, want #1e3a8a", colours, got)
+		}
+	}
+
+	if _, err := dominantColour(path, 0); err == nil {
+		t.Error("dominantColour() with 0 colours should fail")
+	}
+}
+
+func TestDominantColourFewUniqueColours(t *testing.T) {
+	// Flat blue with a few red outliers, the first at (0,0): fewer unique colours
+	// than clusters, so the extractor returns every colour instead of clustering.
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	for y := range 100 {
+		for x := range 100 {
+			img.Set(x, y, color.RGBA{B: 0xff, A: 0xff})
+		}
+	}
+	for _, p := range []image.Point{{0, 0}, {50, 50}, {99, 99}} {
+		img.Set(p.X, p.Y, color.RGBA{R: 0xff, A: 0xff})
+	}
+
+	path := filepath.Join(t.TempDir(), "flat.png")
+	file, err := os.Create(path) // #nosec G304 - test file in a temp directory
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", path, err)
+	}
+	if err := png.Encode(file, img); err != nil {
+		t.Fatalf("failed to encode %s: %v", path, err)
+	}
+	file.Close()
+
+	got, err := dominantColour(path, 8)
+	if err != nil {
+		t.Fatalf("dominantColour() error = %v", err)
+	}
+	if got != "#0000ff" {
+		t.Errorf("dominantColour() = %s, want #0000ff", got)
+	}
+}
@@ -2,6 +2,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -73,7 +74,7 @@ func init() {
 	// Define extract-specific flags.
 
 	// Input plugin selection (required).
-	extractCmd.Flags().StringVarP(&extractInputPlugin, "input", "i", "image", "Input plugin (image, file, remote-css, remote-json)")
+	extractCmd.Flags().StringVarP(&extractInputPlugin, "input", "i", "image", "Input plugin (image, file, remote-css, remote-json, harmony, named, pywal, clipboard, video)")
 	_ = extractCmd.MarkFlagRequired("input") // Error only occurs if flag doesn't exist, which is impossible here
 
 	extractCmd.Flags().StringVarP(&extractFormat, "format", "f", "palette", "output format (palette, hex, rgb, json, categorised)")
@@ -89,6 +90,54 @@ func runExtract(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get verbose flag: %w", err)
 	}
 
+	categorised, err := categoriseFromInput(ctx, extractInputPlugin, verbose)
+	if err != nil {
+		return err
+	}
+
+	// Format the output.
+	var output string
+	switch extractFormat {
+	case "palette":
+		// File input plugin compatible format (role=hex).
+		output = formatPaletteFile(categorised)
+	case "hex":
+		output = formatHexFromCategorised(categorised, extractShowPreview)
+	case "rgb":
+		output = formatRGBFromCategorised(categorised, extractShowPreview)
+	case "json":
+		jsonBytes, err := categorised.ToJSON()
+		if err != nil {
+			return fmt.Errorf("failed to convert to JSON: %w", err)
+		}
+		output = string(jsonBytes) + "\n"
+	case "categorised":
+		output = categorised.StringWithPreview(extractShowPreview)
+	default:
+		return fmt.Errorf("unsupported format: %s (supported: palette, hex, rgb, json, categorised)", extractFormat)
+	}
+
+	// Write output to file or stdout.
+	if extractOutput != "" {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Writing output to: %s\n", extractOutput)
+		}
+		if err := os.WriteFile(extractOutput, []byte(output), 0o600); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Successfully wrote palette to %s\n", extractOutput)
+		}
+	} else {
+		fmt.Print(output)
+	}
+
+	return nil
+}
+
+// categoriseFromInput runs an input plugin and categorises the resulting palette
+// using the global theme and categorisation flags.
+func categoriseFromInput(ctx context.Context, pluginName string, verbose bool) (*colour.CategorisedPalette, error) {
 	// Reload plugin manager config from lock file if available (overrides env).
 	// Load plugin lock and apply configuration to shared manager.
 	if err := loadAndApplyPluginLock(); err != nil && verbose {
@@ -97,18 +146,18 @@ func runExtract(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get input plugin from shared manager.
-	inputPlugin, ok := sharedPluginManager.GetInputPlugin(extractInputPlugin)
+	inputPlugin, ok := sharedPluginManager.GetInputPlugin(pluginName)
 	if !ok {
 		availablePlugins := make([]string, 0)
 		for pluginName := range sharedPluginManager.AllInputPlugins() {
 			availablePlugins = append(availablePlugins, pluginName)
 		}
-		return fmt.Errorf("unknown input plugin: %s (available: %s)", extractInputPlugin, strings.Join(availablePlugins, ", "))
+		return nil, fmt.Errorf("unknown input plugin: %s (available: %s)", pluginName, strings.Join(availablePlugins, ", "))
 	}
 
 	// Validate input plugin.
 	if err := inputPlugin.Validate(); err != nil {
-		return fmt.Errorf("input plugin validation failed: %w", err)
+		return nil, fmt.Errorf("input plugin validation failed: %w", err)
 	}
 
 	// Generate palette using input plugin.
@@ -128,7 +177,7 @@ func runExtract(cmd *cobra.Command, args []string) error {
 	// Generate raw palette from input plugin.
 	palette, err := inputPlugin.Generate(ctx, inputOpts)
 	if err != nil {
-		return fmt.Errorf("failed to extract colours: %w", err)
+		return nil, fmt.Errorf("failed to extract colours: %w", err)
 	}
 
 	if verbose {
@@ -156,52 +205,18 @@ func runExtract(cmd *cobra.Command, args []string) error {
 	}
 
 	// Categorize the palette (auto-detection uses weighted color distribution).
-	config := colour.DefaultCategorisationConfig()
-	config.ThemeType = themeType
+	config, err := newCategorisationConfig(themeType)
+	if err != nil {
+		return nil, err
+	}
 	categorised := colour.Categorise(palette, config)
+	printExplanation(categorised)
 
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Categorized palette with theme: %s\n", categorised.ThemeType.String())
 	}
 
-	// Format the output.
-	var output string
-	switch extractFormat {
-	case "palette":
-		// File input plugin compatible format (role=hex).
-		output = formatPaletteFile(categorised)
-	case "hex":
-		output = formatHexFromCategorised(categorised, extractShowPreview)
-	case "rgb":
-		output = formatRGBFromCategorised(categorised, extractShowPreview)
-	case "json":
-		jsonBytes, err := categorised.ToJSON()
-		if err != nil {
-			return fmt.Errorf("failed to convert to JSON: %w", err)
-		}
-		output = string(jsonBytes) + "\n"
-	case "categorised":
-		output = categorised.StringWithPreview(extractShowPreview)
-	default:
-		return fmt.Errorf("unsupported format: %s (supported: palette, hex, rgb, json, categorised)", extractFormat)
-	}
-
-	// Write output to file or stdout.
-	if extractOutput != "" {
-		if verbose {
-			fmt.Fprintf(os.Stderr, "Writing output to: %s\n", extractOutput)
-		}
-		if err := os.WriteFile(extractOutput, []byte(output), 0o600); err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
-		}
-		if verbose {
-			fmt.Fprintf(os.Stderr, "Successfully wrote palette to %s\n", extractOutput)
-		}
-	} else {
-		fmt.Print(output)
-	}
-
-	return nil
+	return categorised, nil
 }
 
 // formatPaletteFile formats a categorised palette as a simple text file.
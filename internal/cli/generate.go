@@ -15,8 +15,11 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/image"
 	"github.com/jmylchreest/tinct/internal/plugin/input"
 	"github.com/jmylchreest/tinct/internal/plugin/manager"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/security"
 	"github.com/jmylchreest/tinct/internal/version"
 )
 
@@ -38,54 +41,113 @@ func isValidPath(path string) bool {
 
 var (
 	// Generate command flags.
-	generateInputPlugin string
-	generateOutputs     []string
-	generateDryRun      bool
-	generatePreview     bool
-	generateSavePalette string
-	generateVerbose     bool
-	generatePluginArgs  map[string]string
-	generateBackend     string
+	generateInputPlugin   string
+	generateOutputs       []string
+	generateDryRun        bool
+	generateNoReload      bool
+	generatePreview       bool
+	generateSavePalette   string
+	generateVerbose       bool
+	generatePluginArgs    map[string]string
+	generateBackend       string
+	generateReportPath    string
+	generateStableAccents bool
+	generateSkipUnchanged bool
+	generateOnError       string
+	generateColorSpace    string
+	generateAllowUnsafe   bool
+	generateOutputThemes  map[string]string
+
+	// Well-known extraction options sent to external input plugins.
+	generateInputCount     int
+	generateInputSeedMode  string
+	generateInputSeedValue int64
+	generateInputAmbience  bool
+	generateInputRegions   int
 )
 
 // generateCmd represents the generate command.
 var generateCmd = &cobra.Command{
-	Use:   "generate",
+	Use:   "generate [image]",
 	Short: "Generate configuration files from a colour palette",
 	Long:  "", // Set dynamically in Help()
+	Args:  cobra.MaximumNArgs(1),
 	RunE:  runGenerate,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		extensions := make([]string, 0, len(image.SupportedImageExtensions()))
+		for _, ext := range image.SupportedImageExtensions() {
+			extensions = append(extensions, strings.TrimPrefix(ext, "."))
+		}
+		return extensions, cobra.ShellCompDirectiveFilterFileExt
+	},
 }
 
 func init() {
 	// Note: Plugin manager is initialised in root.go and flags are registered there.
 
-	// Input plugin selection (required).
-	generateCmd.Flags().StringVarP(&generateInputPlugin, "input", "i", "", "Input plugin (required: image, file)")
-	_ = generateCmd.MarkFlagRequired("input") // Error only occurs if flag doesn't exist, which is impossible here
+	// Input plugin selection (required unless an image path is given as an argument).
+	generateCmd.Flags().StringVarP(&generateInputPlugin, "input", "i", "", "Input plugin (required: image, file; implied by an image path argument)")
 
 	// Output plugin selection.
 	generateCmd.Flags().StringSliceVarP(&generateOutputs, "outputs", "o", []string{pluginTypeAll}, "Output plugins (comma-separated or 'all')")
+	generateCmd.Flags().StringToStringVar(&generateOutputThemes, "output-theme", nil, "Theme type for a single output plugin (plugin=dark|light, repeatable)")
 
 	// General options.
 	generateCmd.Flags().BoolVar(&generateDryRun, "dry-run", false, "Preview without writing files")
+	generateCmd.Flags().BoolVar(&generateNoReload, "no-reload", false, "Write files without reloading running applications (plugins that support it)")
 	generateCmd.Flags().BoolVar(&generatePreview, "preview", false, "Show colour palette preview")
 	generateCmd.Flags().StringVar(&generateSavePalette, "save-palette", "", "Save palette to file (JSON)")
-	generateCmd.Flags().StringVar(&generateBackend, "backend", "kmeans", "Colour extraction backend (kmeans)")
+	generateCmd.Flags().StringVar(&generateReportPath, "report", "", "Write a Markdown report of the generated theme to file")
+	generateCmd.Flags().BoolVar(&generateStableAccents, "stable-accents", false, "Keep accent slots close in hue to the previous run's palette (cached)")
+	generateCmd.Flags().BoolVar(&generateSkipUnchanged, "skip-if-unchanged", false, "Exit without generating if the input file and options match the last run (cached)")
+	generateCmd.Flags().StringVar(&generateOnError, "on-error", string(input.OnErrorFail), "When the input is rate limited: fail, use-cache, or fallback:<plugin>")
+	generateCmd.Flags().StringVar(&generateBackend, "backend", "kmeans", "Colour extraction backend (kmeans, mediancut, octree, wu)")
+	generateCmd.Flags().StringVar(&generateColorSpace, "color-space", string(colour.ColorSpaceSRGB), "Colour space for templates that support it: srgb, display-p3, linear")
+	generateCmd.Flags().BoolVar(&generateAllowUnsafe, "allow-unsafe-paths", false, "Write to protected paths (e.g. /etc, ~/.bashrc) without confirmation")
 	generateCmd.Flags().BoolVarP(&generateVerbose, "verbose", "v", false, "Verbose output")
 	generateCmd.Flags().StringToStringVar(&generatePluginArgs, "plugin-args", nil, "Plugin-specific arguments (key=value format, repeatable for multiple plugins)")
 
+	// Extraction options for external input plugins (built-in plugins use their own flags, e.g. --image.colours).
+	generateCmd.Flags().IntVar(&generateInputCount, "input-count", 0, "Number of colours for external input plugins to produce (0 = plugin default)")
+	generateCmd.Flags().StringVar(&generateInputSeedMode, "input-seed-mode", "", "Seed mode for external input plugins: content, filepath, name, manual, random")
+	generateCmd.Flags().Int64Var(&generateInputSeedValue, "input-seed-value", 0, "Seed value for external input plugins (with --input-seed-mode manual)")
+	generateCmd.Flags().BoolVar(&generateInputAmbience, "input-ambience", false, "Ask external input plugins for edge/corner colours for ambient lighting")
+	generateCmd.Flags().IntVar(&generateInputRegions, "input-regions", 0, "Number of ambient regions for external input plugins (4, 8, 12, 16)")
+
 	// Override Help method to generate dynamic help text with filtered flags.
 	generateCmd.SetHelpFunc(customGenerateHelp)
 }
 
 // runGenerate executes the generate command.
-func runGenerate(cmd *cobra.Command, _ []string) error {
+func runGenerate(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
+	if err := applyImageShorthand(cmd, args); err != nil {
+		return err
+	}
+
+	colorSpace, err := colour.ParseColorSpace(generateColorSpace)
+	if err != nil {
+		return err
+	}
+
+	outputThemes, err := parseOutputThemes(generateOutputThemes)
+	if err != nil {
+		return err
+	}
+
 	// Phase 1: Load and configure plugins.
 	if err := loadAndConfigurePlugins(); err != nil {
 		return err
 	}
+	for name := range outputThemes {
+		if _, ok := sharedPluginManager.GetOutputPlugin(name); !ok {
+			return fmt.Errorf("unknown output plugin in --output-theme: %s", name)
+		}
+	}
 
 	// Phase 2: Get and validate input plugin.
 	inputPlugin, err := getAndValidateInputPlugin()
@@ -93,24 +155,51 @@ func runGenerate(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	// Skip the run entirely if nothing changed since the last --skip-if-unchanged run.
+	// The selected output plugins are part of the fingerprint, so select them first.
+	var outputPlugins []output.Plugin
+	if generateSkipUnchanged {
+		if outputPlugins, err = selectOutputPlugins(); err != nil {
+			return err
+		}
+	}
+	fingerprint, unchanged := checkUnchangedInput(cmd, inputPlugin, outputPlugins)
+	if unchanged {
+		fmt.Fprintln(os.Stderr, "Input unchanged since the last run, nothing to generate")
+		return nil
+	}
+
 	// Phase 3: Generate input palette.
-	rawPalette, wallpaperPath, err := generateInputPalette(ctx, inputPlugin)
+	// The source plugin differs from inputPlugin when --on-error fell back to another plugin.
+	rawPalette, sourcePlugin, wallpaperPath, err := generateInputPalette(ctx, inputPlugin)
 	if err != nil {
 		return err
 	}
 
 	// Phase 4: Categorize the palette.
-	palette := categorizePalette(rawPalette, inputPlugin)
+	palette, err := categorizePalette(rawPalette, sourcePlugin)
+	if err != nil {
+		return err
+	}
+
+	// Re-categorise the colours for outputs with a --output-theme override.
+	// Plugins that write both a light and a dark theme categorise through the same variants.
+	variants := newThemeVariants(rawPalette, palette)
+	themeOverrides, err := categoriseThemeOverrides(variants, outputThemes)
+	if err != nil {
+		return err
+	}
 
 	// Phase 5: Handle palette output (preview/save).
 	if err := handlePaletteOutput(palette); err != nil {
 		return err
 	}
 
-	// Phase 6: Select output plugins.
-	outputPlugins, err := selectOutputPlugins()
-	if err != nil {
-		return err
+	// Phase 6: Select output plugins, unless already selected for --skip-if-unchanged.
+	if outputPlugins == nil {
+		if outputPlugins, err = selectOutputPlugins(); err != nil {
+			return err
+		}
 	}
 
 	// Phase 7: Run global pre-hook.
@@ -124,7 +213,7 @@ func runGenerate(cmd *cobra.Command, _ []string) error {
 	executions := preparePluginExecutions(ctx, outputPlugins)
 
 	// Phase 9: Generate and write files.
-	successCount := generateAndWriteFiles(executions, palette, wallpaperPath)
+	successCount := generateAndWriteFiles(executions, palette, themeOverrides, variants, wallpaperPath, colorSpace)
 
 	// Phase 10: Run post-execute hooks.
 	if !generateDryRun {
@@ -138,8 +227,46 @@ func runGenerate(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
-	// Phase 11: Print summary.
-	return printGenerationSummary(successCount)
+	// Phase 11: Write Markdown report if requested.
+	if err := handleGenerateReport(palette, sourcePlugin, wallpaperPath, executions); err != nil {
+		return err
+	}
+
+	// Phase 12: Print summary.
+	if err := printGenerationSummary(successCount); err != nil {
+		return err
+	}
+	recordLastRun(fingerprint, executions)
+	return nil
+}
+
+// applyImageShorthand turns `tinct generate wallpaper.jpg` into
+// `tinct generate -i image -p wallpaper.jpg`. Without an argument it only checks
+// that an input plugin was chosen.
+func applyImageShorthand(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		if generateInputPlugin == "" {
+			return fmt.Errorf("an input plugin is required: use --input (-i), or pass an image path")
+		}
+		return nil
+	}
+
+	path := args[0]
+	if !image.IsImagePath(path) {
+		return fmt.Errorf("%s is not an image file (use --input to choose another input plugin)", path)
+	}
+	if generateInputPlugin != "" && generateInputPlugin != "image" {
+		return fmt.Errorf("image path %s cannot be used with --input %s", path, generateInputPlugin)
+	}
+	if cmd.Flags().Changed("image.path") {
+		return fmt.Errorf("image path given twice: use either the argument or --image.path")
+	}
+
+	generateInputPlugin = "image"
+	if err := cmd.Flags().Set("image.path", path); err != nil {
+		return fmt.Errorf("failed to set image path: %w", err)
+	}
+	return nil
 }
 
 // runGlobalHookScript executes a global hook script if it exists.
@@ -399,6 +526,9 @@ Examples:
   # From image - generate all outputs
   tinct generate --input image -p wallpaper.jpg
 
+  # Same, with the image path as an argument
+  tinct generate wallpaper.jpg -o kitty
+
   # From palette file - specific output
   tinct generate --input file -p theme.json --outputs hyprland
 
@@ -415,31 +545,32 @@ Examples:
   # Extract from image with custom colour count
   tinct generate -i image -p wallpaper.jpg -c 32 --preview
 
+  # Document the generated theme in a Markdown report
+  tinct generate -i image -p wallpaper.jpg --report theme-report.md
+
+  # Reuse the last generated image if the AI provider is rate limited
+  tinct generate -i google-genai --prompt "misty forest" --on-error use-cache
+
+  # Keep accent colours in familiar slots when switching wallpapers
+  tinct generate -i image -p wallpaper.jpg --stable-accents
+
+  # Run on every login, regenerating only when the wallpaper or options change
+  tinct generate wallpaper.jpg --skip-if-unchanged
+
 Use 'tinct generate -i <plugin> --help' to see plugin-specific options.`)
 
 	return help.String()
 }
 
 // savePalette saves a categorised palette to a JSON file.
+// It goes through writeFile, so protected paths are refused and an existing file is backed up.
 func savePalette(palette *colour.CategorisedPalette, path string) error {
 	data, err := palette.ToJSON()
 	if err != nil {
 		return fmt.Errorf("failed to marshal palette: %w", err)
 	}
 
-	// Ensure directory exists.
-	dir := filepath.Dir(path)
-	if dir != "." && dir != "" {
-		if err := os.MkdirAll(dir, 0o755); err != nil { // #nosec G301 - Output directory needs standard permissions
-			return fmt.Errorf("failed to create directory: %w", err)
-		}
-	}
-
-	if err := os.WriteFile(path, data, 0o600); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	return nil
+	return writeFile(path, data, generateVerbose)
 }
 
 // setPluginArgs sets custom arguments for a plugin.
@@ -497,6 +628,11 @@ func writeFile(path string, content []byte, verbose bool) error {
 		path = filepath.Join(home, path[2:])
 	}
 
+	// Refuse to clobber critical files unless the user allows it.
+	if err := checkProtectedPath(path); err != nil {
+		return err
+	}
+
 	// Ensure directory exists.
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0o755); err != nil { // #nosec G301 - Output directory needs standard permissions
@@ -523,3 +659,41 @@ func writeFile(path string, content []byte, verbose bool) error {
 
 	return nil
 }
+
+// confirmProtectedWrite asks the user whether to write to a protected path.
+// It is a variable so tests can replace the interactive prompt.
+var confirmProtectedWrite = func(path, match string) (bool, error) {
+	// Without a terminal there is nobody to ask.
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "%s is a protected path (matches %s). Overwrite it? (y/N): ", path, match)
+	var response string
+	if _, err := fmt.Scanln(&response); err != nil {
+		return false, fmt.Errorf("failed to read user input: %w", err)
+	}
+	return strings.EqualFold(response, "y"), nil
+}
+
+// checkProtectedPath returns an error if path matches a protected path and the
+// write was neither allowed with --allow-unsafe-paths nor confirmed by the user.
+func checkProtectedPath(path string) error {
+	if generateAllowUnsafe {
+		return nil
+	}
+
+	match, protected := security.MatchProtectedPath(path, security.ProtectedPaths())
+	if !protected {
+		return nil
+	}
+
+	confirmed, err := confirmProtectedWrite(path, match)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("refusing to write protected path %s (matches %s); use --allow-unsafe-paths to override", path, match)
+	}
+	return nil
+}
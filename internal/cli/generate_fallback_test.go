@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"image/color"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/manager"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/spf13/cobra"
+)
+
+// stubInputPlugin is a minimal input plugin that returns a fixed palette or error.
+type stubInputPlugin struct {
+	name      string
+	err       error
+	hint      string
+	wallpaper string
+}
+
+func (p *stubInputPlugin) Name() string                 { return p.name }
+func (p *stubInputPlugin) Description() string          { return "stub " + p.name }
+func (p *stubInputPlugin) Version() string              { return "0.0.0" }
+func (p *stubInputPlugin) RegisterFlags(*cobra.Command) {}
+func (p *stubInputPlugin) Validate() error              { return nil }
+func (p *stubInputPlugin) GetFlagHelp() []input.FlagHelp {
+	return nil
+}
+func (p *stubInputPlugin) ThemeHint() string     { return p.hint }
+func (p *stubInputPlugin) WallpaperPath() string { return p.wallpaper }
+
+func (p *stubInputPlugin) Generate(context.Context, input.GenerateOptions) (*colour.Palette, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return colour.NewPalette([]color.Color{
+		color.RGBA{R: 26, G: 27, B: 38, A: 255},
+		color.RGBA{R: 192, G: 202, B: 245, A: 255},
+	}), nil
+}
+
+// captureStderr returns everything written to os.Stderr while fn runs.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	orig := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = orig }()
+
+	fn()
+
+	_ = w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("reading stderr: %v", err)
+	}
+	return string(out)
+}
+
+func TestGenerateInputPalette_FallbackDrivesReportAndThemeHint(t *testing.T) {
+	primary := &stubInputPlugin{name: "primary", err: input.ErrRateLimited, hint: "light", wallpaper: "/tmp/primary.png"}
+	fallback := &stubInputPlugin{name: "backup", hint: "dark", wallpaper: "/tmp/backup.png"}
+
+	inputReg := input.NewRegistry()
+	inputReg.Register(primary)
+	inputReg.Register(fallback)
+
+	origManager, origOnError, origVerbose, origTheme := sharedPluginManager, generateOnError, generateVerbose, globalTheme
+	t.Cleanup(func() {
+		sharedPluginManager, generateOnError, generateVerbose, globalTheme = origManager, origOnError, origVerbose, origTheme
+	})
+	sharedPluginManager = manager.NewBuilder().WithCustomRegistries(inputReg, output.NewRegistry()).Build()
+	generateOnError = "fallback:backup"
+	generateVerbose = true
+	globalTheme = "auto"
+
+	var (
+		rawPalette    *colour.Palette
+		sourcePlugin  input.Plugin
+		wallpaperPath string
+		err           error
+	)
+	stderr := captureStderr(t, func() {
+		rawPalette, sourcePlugin, wallpaperPath, err = generateInputPalette(context.Background(), primary)
+		if err == nil {
+			_, err = categorizePalette(rawPalette, sourcePlugin)
+		}
+	})
+	if err != nil {
+		t.Fatalf("generateInputPalette() error = %v", err)
+	}
+
+	if sourcePlugin != fallback {
+		t.Fatalf("source plugin = %v, want the fallback plugin", sourcePlugin.Name())
+	}
+	if wallpaperPath != fallback.wallpaper {
+		t.Errorf("wallpaper path = %q, want %q", wallpaperPath, fallback.wallpaper)
+	}
+	if !strings.Contains(stderr, "Plugin suggests theme: dark") {
+		t.Errorf("theme hint should come from the fallback plugin, stderr:\n%s", stderr)
+	}
+	if strings.Contains(stderr, "Plugin suggests theme: light") {
+		t.Errorf("theme hint should not come from the failed plugin, stderr:\n%s", stderr)
+	}
+
+	if got := describeInputSource(sourcePlugin.Name(), wallpaperPath); got != "backup (/tmp/backup.png)" {
+		t.Errorf("report input source = %q, want the fallback plugin", got)
+	}
+}
@@ -4,21 +4,28 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jmylchreest/tinct/internal/colour"
 	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/input/shared/seed"
 	"github.com/jmylchreest/tinct/internal/plugin/output"
 )
 
 // loadAndConfigurePlugins loads the plugin lock file and configures plugins.
 func loadAndConfigurePlugins() error {
 	if err := loadAndApplyPluginLock(); err != nil {
-		return nil // No lock file is OK
+		// No lock file is OK, but a lock that cannot be read or migrated is reported.
+		if !isPluginLockNotFound(err) {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load plugin lock: %v\n", err)
+		}
+		return nil
 	}
 
 	lock, _, err := loadPluginLock()
@@ -63,20 +70,38 @@ func getAndValidateInputPlugin() (input.Plugin, error) {
 	return plugin, nil
 }
 
-// generateInputPalette generates a raw palette from the input plugin.
-func generateInputPalette(ctx context.Context, inputPlugin input.Plugin) (*colour.Palette, string, error) {
+// generateInputPalette generates a raw palette from the input plugin. It also returns the
+// plugin that produced the palette, which is the fallback plugin when --on-error fell back.
+func generateInputPalette(ctx context.Context, inputPlugin input.Plugin) (*colour.Palette, input.Plugin, string, error) {
 	if generateVerbose {
 		fmt.Fprintf(os.Stderr, " Input plugin: %s\n", inputPlugin.Name())
 		fmt.Fprintf(os.Stderr, "   %s\n", inputPlugin.Description())
 	}
 
 	// Prepare options for input plugin.
-	inputOpts := buildInputOptions()
+	inputOpts, err := buildInputOptions()
+	if err != nil {
+		return nil, nil, "", err
+	}
 
 	// Generate raw palette from input plugin.
 	rawPalette, err := inputPlugin.Generate(ctx, inputOpts)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to generate palette: %w", err)
+		fallback, fallbackErr := fallbackInputPlugin(err, inputOpts.OnError)
+		if fallbackErr != nil {
+			return nil, nil, "", fallbackErr
+		}
+		if fallback == nil {
+			return nil, nil, "", fmt.Errorf("failed to generate palette: %w", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Warning: %s failed (%v), falling back to %s\n", inputPlugin.Name(), err, fallback.Name())
+		inputOpts.PluginArgs = make(map[string]any)
+		rawPalette, err = fallback.Generate(ctx, inputOpts)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("fallback input plugin %s failed: %w", fallback.Name(), err)
+		}
+		inputPlugin = fallback
 	}
 
 	if generateVerbose {
@@ -89,17 +114,26 @@ func generateInputPalette(ctx context.Context, inputPlugin input.Plugin) (*colou
 		fmt.Fprintf(os.Stderr, "   Wallpaper source: %s\n", wallpaperPath)
 	}
 
-	return rawPalette, wallpaperPath, nil
+	return rawPalette, inputPlugin, wallpaperPath, nil
 }
 
 // buildInputOptions creates input plugin options.
-func buildInputOptions() input.GenerateOptions {
+func buildInputOptions() (input.GenerateOptions, error) {
+	onError, err := input.ParseErrorPolicy(generateOnError)
+	if err != nil {
+		return input.GenerateOptions{}, err
+	}
+
 	inputOpts := input.GenerateOptions{
 		Verbose:         generateVerbose,
 		DryRun:          generateDryRun,
 		Backend:         generateBackend,
 		ColourOverrides: []string{},
 		PluginArgs:      make(map[string]any),
+		OnError:         onError,
+	}
+	if err := applyInputExtractionOptions(&inputOpts); err != nil {
+		return input.GenerateOptions{}, err
 	}
 
 	// Extract plugin-specific args if provided.
@@ -115,7 +149,54 @@ func buildInputOptions() input.GenerateOptions {
 		}
 	}
 
-	return inputOpts
+	return inputOpts, nil
+}
+
+// applyInputExtractionOptions validates the --input-* flags and sets the well-known
+// extraction options sent to external input plugins.
+func applyInputExtractionOptions(opts *input.GenerateOptions) error {
+	if generateInputCount < 0 || generateInputCount > 256 {
+		return fmt.Errorf("invalid --input-count %d (must be 1-256, or 0 for the plugin default)", generateInputCount)
+	}
+	if generateInputSeedMode != "" {
+		if _, err := seed.ParseMode(generateInputSeedMode); err != nil {
+			return fmt.Errorf("invalid --input-seed-mode: %w", err)
+		}
+	}
+	if generateInputSeedValue != 0 && generateInputSeedMode != string(seed.ModeManual) {
+		return fmt.Errorf("--input-seed-value requires --input-seed-mode manual")
+	}
+	if !slices.Contains([]int{0, 4, 8, 12, 16}, generateInputRegions) {
+		return fmt.Errorf("invalid --input-regions %d (must be 4, 8, 12 or 16)", generateInputRegions)
+	}
+
+	opts.Count = generateInputCount
+	opts.SeedMode = generateInputSeedMode
+	opts.ExtractAmbience = generateInputAmbience
+	opts.Regions = generateInputRegions
+	if generateInputSeedMode == string(seed.ModeManual) {
+		seedValue := generateInputSeedValue
+		opts.SeedValue = &seedValue
+	}
+	return nil
+}
+
+// fallbackInputPlugin returns the secondary input plugin to run when the primary plugin
+// was rate limited and the error policy names a fallback. Returns nil if no fallback applies.
+func fallbackInputPlugin(err error, policy input.ErrorPolicy) (input.Plugin, error) {
+	if policy.Mode != input.OnErrorFallback || !errors.Is(err, input.ErrRateLimited) {
+		return nil, nil
+	}
+
+	fallback, ok := sharedPluginManager.GetInputPlugin(policy.Plugin)
+	if !ok {
+		return nil, fmt.Errorf("fallback input plugin not found: %s (original error: %w)", policy.Plugin, err)
+	}
+	if err := fallback.Validate(); err != nil {
+		return nil, fmt.Errorf("fallback input plugin %s validation failed: %w", policy.Plugin, err)
+	}
+
+	return fallback, nil
 }
 
 // extractWallpaperPath extracts wallpaper path from input plugin if it provides one.
@@ -127,12 +208,18 @@ func extractWallpaperPath(inputPlugin input.Plugin) string {
 }
 
 // categorizePalette categorizes a raw palette based on theme settings.
-func categorizePalette(rawPalette *colour.Palette, inputPlugin input.Plugin) *colour.CategorisedPalette {
+func categorizePalette(rawPalette *colour.Palette, inputPlugin input.Plugin) (*colour.CategorisedPalette, error) {
 	themeType := determineThemeType(inputPlugin)
 
-	config := colour.DefaultCategorisationConfig()
-	config.ThemeType = themeType
+	config, err := newCategorisationConfig(themeType)
+	if err != nil {
+		return nil, err
+	}
+	applyStableAccents(&config)
+
 	palette := colour.Categorise(rawPalette, config)
+	cacheStableAccentsPalette(palette)
+	printExplanation(palette)
 
 	if generateVerbose {
 		fmt.Fprintf(os.Stderr, "   Categorized palette (%d colours, %s theme)\n",
@@ -140,7 +227,63 @@ func categorizePalette(rawPalette *colour.Palette, inputPlugin input.Plugin) *co
 		fmt.Fprintf(os.Stderr, "   Plugin execution complete.\n")
 	}
 
-	return palette
+	return palette, nil
+}
+
+// printExplanation prints the categorisation decisions to stderr when --explain is set.
+func printExplanation(palette *colour.CategorisedPalette) {
+	if !globalExplain {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Categorisation decisions (%s theme):\n", palette.ThemeType)
+	fmt.Fprint(os.Stderr, palette.Explain())
+	fmt.Fprintln(os.Stderr)
+}
+
+// newCategorisationConfig builds the categorisation config from global flags.
+func newCategorisationConfig(themeType colour.ThemeType) (colour.CategorisationConfig, error) {
+	config := colour.DefaultCategorisationConfig()
+	config.ThemeType = themeType
+
+	backgroundMode, err := colour.ParseBackgroundMode(globalBackground)
+	if err != nil {
+		return config, err
+	}
+	config.BackgroundMode = backgroundMode
+
+	semanticPalette, err := colour.ParseSemanticPalette(globalSemanticPalette)
+	if err != nil {
+		return config, err
+	}
+	config.SemanticPalette = semanticPalette
+
+	contrastModel, err := colour.ParseContrastModel(globalContrastModel)
+	if err != nil {
+		return config, err
+	}
+	config.ContrastModel = contrastModel
+
+	if globalMaxOutputColors < 0 {
+		return config, fmt.Errorf("max-output-colors must be 0 or greater, got %d", globalMaxOutputColors)
+	}
+	config.MaxOutputColors = globalMaxOutputColors
+
+	if globalAccentCount < 0 || globalAccentCount > colour.MaxAccentCount {
+		return config, fmt.Errorf("accent-count must be between 0 and %d, got %d", colour.MaxAccentCount, globalAccentCount)
+	}
+	config.AccentCount = globalAccentCount
+
+	if globalMutedSaturation < 0 || globalMutedSaturation > 1 {
+		return config, fmt.Errorf("muted-saturation must be between 0 and 1, got %g", globalMutedSaturation)
+	}
+	config.MutedSaturationReduction = globalMutedSaturation
+	config.UseOKLCH = globalOKLCH
+
+	if err := config.ApplyVibrancy(globalVibrancy); err != nil {
+		return config, err
+	}
+
+	return config, nil
 }
 
 // determineThemeType determines the theme type from global flag and plugin hints.
@@ -307,7 +450,8 @@ func shouldSkipFromPreHook(ctx context.Context, plugin output.Plugin, exec *plug
 }
 
 // generateAndWriteFiles generates files from plugins and writes them to disk.
-func generateAndWriteFiles(executions []pluginExecution, palette *colour.CategorisedPalette, wallpaperPath string) int {
+// Plugins with a --output-theme override are generated from their override palette.
+func generateAndWriteFiles(executions []pluginExecution, palette *colour.CategorisedPalette, themeOverrides map[string]*colour.CategorisedPalette, variants *themeVariants, wallpaperPath string, colorSpace colour.ColorSpace) int {
 	successCount := 0
 	firstOutputPlugin := true
 
@@ -322,7 +466,8 @@ func generateAndWriteFiles(executions []pluginExecution, palette *colour.Categor
 			firstOutputPlugin = false
 		}
 
-		if processPluginGeneration(exec, palette, wallpaperPath) {
+		pluginPalette := paletteForPlugin(exec.plugin.Name(), palette, themeOverrides)
+		if processPluginGeneration(exec, pluginPalette, variants, wallpaperPath, colorSpace) {
 			successCount++
 		}
 	}
@@ -331,7 +476,7 @@ func generateAndWriteFiles(executions []pluginExecution, palette *colour.Categor
 }
 
 // processPluginGeneration generates and writes files for a single plugin.
-func processPluginGeneration(exec *pluginExecution, palette *colour.CategorisedPalette, wallpaperPath string) bool {
+func processPluginGeneration(exec *pluginExecution, palette *colour.CategorisedPalette, variants *themeVariants, wallpaperPath string, colorSpace colour.ColorSpace) bool {
 	plugin := exec.plugin
 
 	if generateVerbose {
@@ -341,6 +486,8 @@ func processPluginGeneration(exec *pluginExecution, palette *colour.CategorisedP
 
 	// Create theme data with wallpaper context.
 	themeData := colour.NewThemeData(palette, wallpaperPath, "")
+	themeData.ColorSpace = colorSpace
+	themeData.SetCategoriser(variants.get)
 
 	// Generate files.
 	files, err := plugin.Generate(themeData)
@@ -414,6 +561,7 @@ func runPostExecutionHooks(ctx context.Context, executions []pluginExecution, wa
 		// Build execution context for the hook.
 		execContext := output.ExecutionContext{
 			DryRun:        generateDryRun,
+			NoReload:      generateNoReload,
 			Verbose:       generateVerbose,
 			OutputDir:     plugin.DefaultOutputDir(),
 			WallpaperPath: wallpaperPath,
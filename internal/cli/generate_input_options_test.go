@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/plugin/manager"
+)
+
+// parseInputFlags parses generate flags, restoring the --input-* options afterwards.
+func parseInputFlags(t *testing.T, args ...string) error {
+	t.Helper()
+	count, seedMode, seedValue, ambience, regions := generateInputCount, generateInputSeedMode, generateInputSeedValue, generateInputAmbience, generateInputRegions
+	t.Cleanup(func() {
+		generateInputCount, generateInputSeedMode, generateInputSeedValue, generateInputAmbience, generateInputRegions = count, seedMode, seedValue, ambience, regions
+	})
+	return generateCmd.ParseFlags(args)
+}
+
+func TestBuildInputOptions_ExtractionFlags(t *testing.T) {
+	if err := parseInputFlags(t,
+		"--input-count", "24",
+		"--input-seed-mode", "manual",
+		"--input-seed-value", "42",
+		"--input-ambience",
+		"--input-regions", "12",
+	); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+
+	opts, err := buildInputOptions()
+	if err != nil {
+		t.Fatalf("buildInputOptions() error = %v", err)
+	}
+
+	// External input plugins receive the options as typed protocol fields.
+	got := manager.NewExternalInputPlugin("mock", "", "/bin/true").ProtocolOptions(opts)
+	if got.Count != 24 || got.SeedMode != "manual" || !got.ExtractAmbience || got.Regions != 12 {
+		t.Errorf("ProtocolOptions() = %+v, want count 24, seed mode manual, ambience, 12 regions", got)
+	}
+	if got.SeedValue == nil || *got.SeedValue != 42 {
+		t.Errorf("SeedValue = %v, want 42", got.SeedValue)
+	}
+	if got.Backend != generateBackend {
+		t.Errorf("Backend = %q, want %q", got.Backend, generateBackend)
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for _, want := range []string{`"count":24`, `"seed_mode":"manual"`, `"seed_value":42`, `"extract_ambience":true`, `"regions":12`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON %s missing %s", data, want)
+		}
+	}
+}
+
+func TestBuildInputOptions_ExtractionDefaults(t *testing.T) {
+	if err := parseInputFlags(t); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+
+	opts, err := buildInputOptions()
+	if err != nil {
+		t.Fatalf("buildInputOptions() error = %v", err)
+	}
+
+	// Unset options are omitted so plugins keep their own defaults.
+	data, err := json.Marshal(manager.NewExternalInputPlugin("mock", "", "/bin/true").ProtocolOptions(opts))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for _, key := range []string{"count", "seed_mode", "seed_value", "extract_ambience", "regions"} {
+		if strings.Contains(string(data), `"`+key+`"`) {
+			t.Errorf("JSON %s should omit %s", data, key)
+		}
+	}
+}
+
+func TestBuildInputOptions_InvalidExtractionFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"--input-count", "300"},
+		{"--input-seed-mode", "sometimes"},
+		{"--input-seed-value", "42"},
+		{"--input-regions", "5"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			if err := parseInputFlags(t, args...); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			if _, err := buildInputOptions(); err == nil {
+				t.Errorf("buildInputOptions() with %v should fail", args)
+			}
+		})
+	}
+}
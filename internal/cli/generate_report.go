@@ -0,0 +1,127 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+)
+
+// generateReport holds the information summarised in a generate Markdown report.
+type generateReport struct {
+	palette     *colour.CategorisedPalette
+	inputSource string
+	files       []string
+	generatedAt time.Time
+}
+
+// newGenerateReport collects report data from the generate run.
+func newGenerateReport(palette *colour.CategorisedPalette, inputSource string, executions []pluginExecution) generateReport {
+	files := make([]string, 0)
+	for _, exec := range executions {
+		files = append(files, exec.writtenFiles...)
+	}
+
+	return generateReport{
+		palette:     palette,
+		inputSource: inputSource,
+		files:       files,
+		generatedAt: time.Now(),
+	}
+}
+
+// describeInputSource returns a human-readable description of the palette source.
+func describeInputSource(pluginName, wallpaperPath string) string {
+	if wallpaperPath == "" {
+		return pluginName
+	}
+	return fmt.Sprintf("%s (%s)", pluginName, wallpaperPath)
+}
+
+// Markdown renders the report as a Markdown document.
+func (r generateReport) Markdown() string {
+	var sb strings.Builder
+	helper := colour.NewPaletteHelper(r.palette)
+
+	sb.WriteString("# Tinct Theme Report\n\n")
+	fmt.Fprintf(&sb, "- **Input source:** %s\n", r.inputSource)
+	fmt.Fprintf(&sb, "- **Theme type:** %s\n", r.palette.ThemeType.String())
+	fmt.Fprintf(&sb, "- **Generated:** %s\n", r.generatedAt.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "- **Tinct version:** %s\n\n", getVersion())
+
+	bg, hasBg := r.palette.Get(colour.RoleBackground)
+
+	sb.WriteString("## Palette\n\n")
+	sb.WriteString("| Role | Hex | RGB | Contrast vs background | WCAG |\n")
+	sb.WriteString("|------|-----|-----|------------------------|------|\n")
+	for _, role := range helper.AllRoles() {
+		cc, ok := r.palette.Get(role)
+		if !ok {
+			continue
+		}
+
+		contrast, rating := "-", "-"
+		if hasBg && role != colour.RoleBackground {
+			check := colour.CheckContrast(role, colour.RoleBackground, cc, bg)
+			contrast = fmt.Sprintf("%.2f:1", check.Ratio)
+			rating = check.Rating()
+		}
+
+		fmt.Fprintf(&sb, "| %s | `%s` | %s | %s | %s |\n", role, cc.Hex, cc.RGB.String(), contrast, rating)
+	}
+
+	sb.WriteString("\n## Contrast\n\n")
+	if fg, ok := r.palette.Get(colour.RoleForeground); ok && hasBg {
+		check := colour.CheckContrast(colour.RoleForeground, colour.RoleBackground, fg, bg)
+		fmt.Fprintf(&sb, "Foreground on background: **%.2f:1** (%s)\n", check.Ratio, check.Rating())
+	} else {
+		sb.WriteString("Foreground or background colour not available.\n")
+	}
+
+	sb.WriteString("\n## Written Files\n\n")
+	if len(r.files) == 0 {
+		sb.WriteString("_No files were written._\n")
+	}
+	for _, file := range r.files {
+		fmt.Fprintf(&sb, "- `%s`\n", file)
+	}
+
+	return sb.String()
+}
+
+// writeGenerateReport writes the Markdown report to path.
+// It goes through writeFile, so protected paths are refused and an existing report is backed up.
+func writeGenerateReport(path string, report generateReport) error {
+	if err := writeFile(path, []byte(report.Markdown()), generateVerbose); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+// handleGenerateReport writes the generate report if --report was given.
+func handleGenerateReport(palette *colour.CategorisedPalette, inputPlugin input.Plugin, wallpaperPath string, executions []pluginExecution) error {
+	if generateReportPath == "" {
+		return nil
+	}
+
+	if generateDryRun {
+		fmt.Printf("   Would write report: %s\n", generateReportPath)
+		return nil
+	}
+
+	report := newGenerateReport(palette, describeInputSource(inputPlugin.Name(), wallpaperPath), executions)
+	if err := writeGenerateReport(generateReportPath, report); err != nil {
+		return err
+	}
+
+	if generateVerbose {
+		fmt.Fprintf(os.Stderr, " Saved report to: %s\n", generateReportPath)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+func testCategorisedPalette() *colour.CategorisedPalette {
+	palette := colour.NewPalette([]color.Color{
+		color.RGBA{R: 26, G: 27, B: 38, A: 255},
+		color.RGBA{R: 192, G: 202, B: 245, A: 255},
+		color.RGBA{R: 122, G: 162, B: 247, A: 255},
+		color.RGBA{R: 187, G: 154, B: 247, A: 255},
+	})
+	return colour.Categorise(palette, colour.DefaultCategorisationConfig())
+}
+
+func TestGenerateReportMarkdown(t *testing.T) {
+	palette := testCategorisedPalette()
+	executions := []pluginExecution{
+		{writtenFiles: []string{"/home/user/.config/kitty/tinct.conf"}},
+		{skip: true},
+		{writtenFiles: []string{"/home/user/.config/dunst/tinct.conf", "/home/user/.config/dunst/dunstrc"}},
+	}
+
+	report := newGenerateReport(palette, describeInputSource("image", "/tmp/wall.png"), executions)
+	md := report.Markdown()
+
+	if !strings.Contains(md, "**Input source:** image (/tmp/wall.png)") {
+		t.Error("report should include the input source")
+	}
+	if !strings.Contains(md, "**Theme type:** "+palette.ThemeType.String()) {
+		t.Error("report should include the theme type")
+	}
+	if !strings.Contains(md, "Foreground on background:") {
+		t.Error("report should include foreground/background contrast")
+	}
+
+	// One table row per assigned role.
+	rows := 0
+	for role, cc := range palette.Colours {
+		prefix := "| " + string(role) + " | `" + cc.Hex + "` |"
+		if !strings.Contains(md, prefix) {
+			t.Errorf("report missing table row for role %s", role)
+		}
+	}
+	for line := range strings.SplitSeq(md, "\n") {
+		if strings.HasPrefix(line, "| ") && strings.Contains(line, "`#") {
+			rows++
+		}
+	}
+	if rows != len(palette.Colours) {
+		t.Errorf("expected %d role rows, got %d", len(palette.Colours), rows)
+	}
+
+	for _, file := range []string{
+		"/home/user/.config/kitty/tinct.conf",
+		"/home/user/.config/dunst/tinct.conf",
+		"/home/user/.config/dunst/dunstrc",
+	} {
+		if !strings.Contains(md, "- `"+file+"`") {
+			t.Errorf("report missing written file %s", file)
+		}
+	}
+}
+
+func TestGenerateReportNoFiles(t *testing.T) {
+	report := newGenerateReport(testCategorisedPalette(), "file", nil)
+	if !strings.Contains(report.Markdown(), "_No files were written._") {
+		t.Error("report should note when no files were written")
+	}
+}
+
+func TestWriteGenerateReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs", "report.md")
+	report := newGenerateReport(testCategorisedPalette(), "image", nil)
+
+	if err := writeGenerateReport(path, report); err != nil {
+		t.Fatalf("writeGenerateReport() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# Tinct Theme Report") {
+		t.Error("report file should start with the report heading")
+	}
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	imageplugin "github.com/jmylchreest/tinct/internal/plugin/input/image"
+)
+
+// shorthandCommand returns a generate-like command with the image plugin's flags and
+// resets the selected input plugin for the duration of the test.
+func shorthandCommand(t *testing.T, input string) *cobra.Command {
+	t.Helper()
+	previous := generateInputPlugin
+	t.Cleanup(func() { generateInputPlugin = previous })
+	generateInputPlugin = input
+
+	cmd := &cobra.Command{Use: "generate"}
+	imageplugin.New().RegisterFlags(cmd)
+	return cmd
+}
+
+// writePNG writes a 1x1 PNG to path.
+func writePNG(t *testing.T, path string) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.RGBA{R: 0x40, G: 0x80, B: 0xc0, A: 0xff})
+
+	file, err := os.Create(path) // #nosec G304 - test file in a temp directory
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", path, err)
+	}
+	defer file.Close()
+	if err := png.Encode(file, img); err != nil {
+		t.Fatalf("failed to encode %s: %v", path, err)
+	}
+}
+
+func TestApplyImageShorthand(t *testing.T) {
+	dir := t.TempDir()
+	wallpaper := filepath.Join(dir, "wallpaper.png")
+	writePNG(t, wallpaper)
+	// No extension: detected by content.
+	extensionless := filepath.Join(dir, "wallpaper")
+	writePNG(t, extensionless)
+
+	for _, path := range []string{wallpaper, extensionless} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			cmd := shorthandCommand(t, "")
+			if err := applyImageShorthand(cmd, []string{path}); err != nil {
+				t.Fatalf("applyImageShorthand() error = %v", err)
+			}
+			if generateInputPlugin != "image" {
+				t.Errorf("input plugin = %q, want image", generateInputPlugin)
+			}
+			paths, err := cmd.Flags().GetStringArray("image.path")
+			if err != nil {
+				t.Fatalf("GetStringArray() error = %v", err)
+			}
+			if len(paths) != 1 || paths[0] != path {
+				t.Errorf("image.path = %v, want [%s]", paths, path)
+			}
+		})
+	}
+
+	t.Run("ExplicitImageInput", func(t *testing.T) {
+		cmd := shorthandCommand(t, "image")
+		if err := applyImageShorthand(cmd, []string{wallpaper}); err != nil {
+			t.Errorf("applyImageShorthand() with -i image error = %v", err)
+		}
+	})
+
+	t.Run("NoArgumentKeepsInput", func(t *testing.T) {
+		cmd := shorthandCommand(t, "file")
+		if err := applyImageShorthand(cmd, nil); err != nil {
+			t.Fatalf("applyImageShorthand() error = %v", err)
+		}
+		if generateInputPlugin != "file" {
+			t.Errorf("input plugin = %q, want file", generateInputPlugin)
+		}
+	})
+}
+
+func TestApplyImageShorthandErrors(t *testing.T) {
+	dir := t.TempDir()
+	wallpaper := filepath.Join(dir, "wallpaper.png")
+	writePNG(t, wallpaper)
+	notImage := filepath.Join(dir, "palette.json")
+	if err := os.WriteFile(notImage, []byte(`{"colours":{}}`), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", notImage, err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		args    []string
+		setPath bool
+		wantErr string
+	}{
+		{name: "NoInput", wantErr: "input plugin is required"},
+		{name: "NotAnImage", args: []string{notImage}, wantErr: "not an image file"},
+		{name: "MissingFile", args: []string{filepath.Join(dir, "missing")}, wantErr: "not an image file"},
+		{name: "OtherInput", input: "file", args: []string{wallpaper}, wantErr: "cannot be used with --input file"},
+		{name: "PathTwice", args: []string{wallpaper}, setPath: true, wantErr: "given twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := shorthandCommand(t, tt.input)
+			if tt.setPath {
+				if err := cmd.Flags().Set("image.path", wallpaper); err != nil {
+					t.Fatalf("Set() error = %v", err)
+				}
+			}
+
+			err := applyImageShorthand(cmd, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("applyImageShorthand() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+// lastRunFilename is the cache file recording the input of the last --skip-if-unchanged run.
+const lastRunFilename = "last-run.json"
+
+// fingerprintIgnoredFlags are flags that do not change what a run generates.
+var fingerprintIgnoredFlags = map[string]bool{
+	"skip-if-unchanged": true,
+	"verbose":           true,
+}
+
+// fingerprintEnvVars are environment variables that change which outputs a run writes.
+var fingerprintEnvVars = []string{"TINCT_ENABLED_PLUGINS", "TINCT_DISABLED_PLUGINS"}
+
+// lastRun records the input of the last successful --skip-if-unchanged run.
+type lastRun struct {
+	Fingerprint string    `json:"fingerprint"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Outputs     []string  `json:"outputs,omitempty"`
+}
+
+// inputFingerprint hashes everything that decides a run's output: the tinct version,
+// the input plugin, every flag set on the command line, the content of each local
+// file passed to the input plugin, the selected output plugins with their output
+// directories and custom template overrides, the plugin lock file, and the
+// environment variables that enable or disable plugins. ok is
+// false when no input flag names a local file (a URL, a prompt or a random source),
+// as the input may then differ between runs.
+func inputFingerprint(flags *pflag.FlagSet, inputName string, inputFlags []input.FlagHelp, outputPlugins []output.Plugin) (fingerprint string, ok bool, err error) {
+	isInputFlag := make(map[string]bool, len(inputFlags))
+	for _, fh := range inputFlags {
+		isInputFlag[fh.Name] = true
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "version=%s\ninput=%s\n", getVersion(), inputName)
+	for _, name := range fingerprintEnvVars {
+		fmt.Fprintf(h, "env %s=%s\n", name, os.Getenv(name))
+	}
+
+	outputs := make([]string, 0, len(outputPlugins))
+	for _, plugin := range outputPlugins {
+		outputs = append(outputs, fmt.Sprintf("output %s=%s\n", plugin.Name(), plugin.DefaultOutputDir()))
+	}
+	slices.Sort(outputs)
+	for _, line := range outputs {
+		fmt.Fprint(h, line)
+	}
+	for _, plugin := range outputPlugins {
+		if err := hashTemplateOverrides(h, plugin.Name()); err != nil {
+			return "", false, err
+		}
+	}
+	if lockPath, lockErr := findPluginLock(); lockErr == nil {
+		if _, statErr := os.Stat(lockPath); statErr == nil {
+			fmt.Fprintf(h, "lock=%s\n", lockPath)
+			if err := hashFile(h, lockPath); err != nil {
+				return "", false, err
+			}
+		}
+	}
+
+	// Visit walks the flags set on the command line in name order.
+	flags.Visit(func(f *pflag.Flag) {
+		if err != nil || fingerprintIgnoredFlags[f.Name] {
+			return
+		}
+		fmt.Fprintf(h, "flag %s=%s\n", f.Name, f.Value.String())
+
+		if !isInputFlag[f.Name] {
+			return
+		}
+		values := []string{f.Value.String()}
+		if slice, isSlice := f.Value.(pflag.SliceValue); isSlice {
+			values = slice.GetSlice()
+		}
+		for _, value := range values {
+			info, statErr := os.Stat(value)
+			if statErr != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			if err = hashFile(h, value); err != nil {
+				return
+			}
+			ok = true
+		}
+	})
+	if err != nil {
+		return "", false, err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), ok, nil
+}
+
+// hashFile writes the content of the file at path to w.
+func hashFile(w io.Writer, path string) error {
+	file, err := os.Open(path) // #nosec G304 - Path is the user's input file
+	if err != nil {
+		return fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+	return nil
+}
+
+// hashTemplateOverrides writes the name and content of each custom template under
+// ~/.config/tinct/templates/<pluginName>/ to w, in lexical order.
+func hashTemplateOverrides(w io.Writer, pluginName string) error {
+	dir := template.New(pluginName, embed.FS{}).CustomDir()
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "template %s/%s\n", pluginName, filepath.ToSlash(rel))
+		return hashFile(w, path)
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read custom templates for %s: %w", pluginName, err)
+	}
+	return nil
+}
+
+// loadLastRun reads the last run record.
+// Returns nil without error if no run has been recorded yet.
+func loadLastRun(path string) (*lastRun, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - Path is the tinct cache file
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read last run: %w", err)
+	}
+
+	var run lastRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("failed to parse last run: %w", err)
+	}
+
+	return &run, nil
+}
+
+// saveLastRun writes the last run record to path.
+func saveLastRun(run lastRun, path string) error {
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal last run: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { // #nosec G301 - Cache directory needs standard permissions
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write last run: %w", err)
+	}
+
+	return nil
+}
+
+// checkUnchangedInput fingerprints the input when --skip-if-unchanged is set and reports
+// whether it matches the last recorded run and every file that run wrote still exists.
+// The fingerprint is empty when the flag is not set or the input cannot be
+// fingerprinted; such runs are never skipped.
+func checkUnchangedInput(cmd *cobra.Command, inputPlugin input.Plugin, outputPlugins []output.Plugin) (fingerprint string, unchanged bool) {
+	if !generateSkipUnchanged {
+		return "", false
+	}
+
+	fingerprint, ok, err := inputFingerprint(cmd.Flags(), inputPlugin.Name(), inputPlugin.GetFlagHelp(), outputPlugins)
+	if err != nil || !ok {
+		if generateVerbose {
+			if err == nil {
+				err = fmt.Errorf("%s input has no local file to compare", inputPlugin.Name())
+			}
+			fmt.Fprintf(os.Stderr, "   Skip if unchanged disabled: %v\n", err)
+		}
+		return "", false
+	}
+
+	path, err := tinctCachePath(lastRunFilename)
+	if err != nil {
+		return fingerprint, false
+	}
+	last, err := loadLastRun(path)
+	if err != nil {
+		if generateVerbose {
+			fmt.Fprintf(os.Stderr, "   Ignoring last run: %v\n", err)
+		}
+		return fingerprint, false
+	}
+
+	if last == nil || last.Fingerprint != fingerprint {
+		return fingerprint, false
+	}
+	for _, path := range last.Outputs {
+		if _, err := os.Stat(path); err != nil {
+			if generateVerbose {
+				fmt.Fprintf(os.Stderr, "   Output %s is missing, regenerating\n", path)
+			}
+			return fingerprint, false
+		}
+	}
+
+	if generateVerbose {
+		fmt.Fprintf(os.Stderr, "   Input unchanged since %s\n", last.GeneratedAt.Format(time.RFC3339))
+	}
+	return fingerprint, true
+}
+
+// recordLastRun stores the fingerprint of a successful run and the files it wrote
+// for the next --skip-if-unchanged run.
+func recordLastRun(fingerprint string, executions []pluginExecution) {
+	if fingerprint == "" || generateDryRun {
+		return
+	}
+
+	var outputs []string
+	for _, exec := range executions {
+		outputs = append(outputs, exec.writtenFiles...)
+	}
+
+	path, err := tinctCachePath(lastRunFilename)
+	if err == nil {
+		err = saveLastRun(lastRun{Fingerprint: fingerprint, GeneratedAt: time.Now(), Outputs: outputs}, path)
+	}
+	if err != nil && generateVerbose {
+		fmt.Fprintf(os.Stderr, "   Failed to record run for --skip-if-unchanged: %v\n", err)
+	}
+}
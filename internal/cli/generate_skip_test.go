@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	imageplugin "github.com/jmylchreest/tinct/internal/plugin/input/image"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/dunst"
+	"github.com/jmylchreest/tinct/internal/plugin/output/kitty"
+)
+
+func TestSkipIfUnchanged(t *testing.T) {
+	previous, previousDryRun := generateSkipUnchanged, generateDryRun
+	t.Cleanup(func() { generateSkipUnchanged, generateDryRun = previous, previousDryRun })
+	generateSkipUnchanged, generateDryRun = true, false
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	previousLock := pluginLockPath
+	t.Cleanup(func() { pluginLockPath = previousLock })
+	pluginLockPath = filepath.Join(t.TempDir(), PluginLockFile)
+
+	wallpaper := filepath.Join(t.TempDir(), "wallpaper.png")
+	writePNG(t, wallpaper)
+	plugin := imageplugin.New()
+	outputs := []output.Plugin{kitty.New()}
+	written := filepath.Join(t.TempDir(), "kitty.conf")
+	writePNG(t, written)
+
+	// run simulates a generate run with the given flags, recording it unless skipped.
+	run := func(args ...string) (skipped bool) {
+		t.Helper()
+		cmd := shorthandCommand(t, "image")
+		cmd.Flags().Bool("preview", false, "")
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", args, err)
+		}
+		fingerprint, unchanged := checkUnchangedInput(cmd, plugin, outputs)
+		if fingerprint == "" {
+			t.Fatalf("input %v should be fingerprinted", args)
+		}
+		if !unchanged {
+			recordLastRun(fingerprint, []pluginExecution{{writtenFiles: []string{written}}})
+		}
+		return unchanged
+	}
+
+	if run("--image.path", wallpaper) {
+		t.Fatal("first run should not be skipped")
+	}
+	if !run("--image.path", wallpaper) {
+		t.Error("identical second run should be skipped")
+	}
+
+	// Changing an option regenerates, and is then the run to compare against.
+	if run("--image.path", wallpaper, "--preview") {
+		t.Error("run with a changed option should not be skipped")
+	}
+	if run("--image.path", wallpaper) {
+		t.Error("run with the option removed again should not be skipped")
+	}
+
+	// Changing the image content (but not its path) regenerates.
+	if err := os.WriteFile(wallpaper, []byte("different content"), 0o600); err != nil {
+		t.Fatalf("failed to rewrite %s: %v", wallpaper, err)
+	}
+	if run("--image.path", wallpaper) {
+		t.Error("run with changed image content should not be skipped")
+	}
+	if !run("--image.path", wallpaper) {
+		t.Error("identical run after a change should be skipped")
+	}
+
+	// Enabling another output plugin regenerates.
+	outputs = append(outputs, dunst.New())
+	if run("--image.path", wallpaper) {
+		t.Error("run with an extra output plugin should not be skipped")
+	}
+
+	// Changing which plugins the environment enables regenerates.
+	t.Setenv("TINCT_DISABLED_PLUGINS", "output:waybar")
+	if run("--image.path", wallpaper) {
+		t.Error("run with changed TINCT_DISABLED_PLUGINS should not be skipped")
+	}
+	if !run("--image.path", wallpaper) {
+		t.Error("identical run after an environment change should be skipped")
+	}
+
+	// Adding or editing a custom template for a selected output regenerates.
+	templateDir := filepath.Join(home, ".config", "tinct", "templates", "kitty")
+	if err := os.MkdirAll(templateDir, 0o755); err != nil {
+		t.Fatalf("failed to create %s: %v", templateDir, err)
+	}
+	override := filepath.Join(templateDir, "tinct.conf.tmpl")
+	for _, content := range []string{"foreground {{ .Foreground }}", "background {{ .Background }}"} {
+		if err := os.WriteFile(override, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", override, err)
+		}
+		if run("--image.path", wallpaper) {
+			t.Errorf("run after writing template %q should not be skipped", content)
+		}
+		if !run("--image.path", wallpaper) {
+			t.Error("identical run after a template change should be skipped")
+		}
+	}
+
+	// Changing the plugin lock file regenerates.
+	if err := os.WriteFile(pluginLockPath, []byte(`{"version": "1", "disabled_plugins": ["output:kitty"]}`), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", pluginLockPath, err)
+	}
+	if run("--image.path", wallpaper) {
+		t.Error("run with a changed plugin lock file should not be skipped")
+	}
+	if !run("--image.path", wallpaper) {
+		t.Error("identical run after a lock file change should be skipped")
+	}
+
+	// Deleting a file the last run wrote regenerates.
+	if err := os.Remove(written); err != nil {
+		t.Fatalf("failed to remove %s: %v", written, err)
+	}
+	if run("--image.path", wallpaper) {
+		t.Error("run with a missing output file should not be skipped")
+	}
+}
+
+func TestSkipIfUnchangedDisabled(t *testing.T) {
+	previous := generateSkipUnchanged
+	t.Cleanup(func() { generateSkipUnchanged = previous })
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	plugin := imageplugin.New()
+	tests := []struct {
+		name string
+		flag bool
+		args []string
+	}{
+		{name: "flag not set", flag: false, args: []string{"--image.path", "testdata/does-not-matter.png"}},
+		{name: "remote image", flag: true, args: []string{"--image.path", "https://example.com/wallpaper.jpg"}},
+		{name: "missing file", flag: true, args: []string{"--image.path", filepath.Join(t.TempDir(), "missing.png")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generateSkipUnchanged = tt.flag
+			cmd := shorthandCommand(t, "image")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			for range 2 {
+				fingerprint, unchanged := checkUnchangedInput(cmd, plugin, nil)
+				if fingerprint != "" || unchanged {
+					t.Fatalf("checkUnchangedInput() = (%q, %v), want no fingerprint", fingerprint, unchanged)
+				}
+				recordLastRun(fingerprint, nil)
+			}
+		})
+	}
+}
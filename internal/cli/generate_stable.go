@@ -0,0 +1,116 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+// lastPaletteFilename is the cache file holding the most recent palette for --stable-accents.
+const lastPaletteFilename = "last-palette.json"
+
+// lastPalettePath returns the location of the cached previous palette.
+// Uses the user cache directory (e.g. ~/.cache/tinct/last-palette.json).
+func lastPalettePath() (string, error) {
+	return tinctCachePath(lastPaletteFilename)
+}
+
+// tinctCachePath returns the location of filename in tinct's user cache directory.
+func tinctCachePath(filename string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine cache directory: %w", err)
+		}
+		cacheDir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(cacheDir, "tinct", filename), nil
+}
+
+// loadPreviousPalette reads a cached palette.
+// Returns nil without error if no palette has been cached yet.
+func loadPreviousPalette(path string) (*colour.CategorisedPalette, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - Path is the tinct cache file
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read previous palette: %w", err)
+	}
+
+	var palette colour.CategorisedPalette
+	if err := json.Unmarshal(data, &palette); err != nil {
+		return nil, fmt.Errorf("failed to parse previous palette: %w", err)
+	}
+
+	return &palette, nil
+}
+
+// applyStableAccents loads the cached palette into config when --stable-accents is set.
+// A missing or unreadable cache is not fatal; accents are then assigned as normal.
+func applyStableAccents(config *colour.CategorisationConfig) {
+	if !generateStableAccents {
+		return
+	}
+
+	path, err := lastPalettePath()
+	if err != nil {
+		if generateVerbose {
+			fmt.Fprintf(os.Stderr, "   Stable accents disabled: %v\n", err)
+		}
+		return
+	}
+
+	previous, err := loadPreviousPalette(path)
+	if err != nil {
+		if generateVerbose {
+			fmt.Fprintf(os.Stderr, "   Ignoring previous palette: %v\n", err)
+		}
+		return
+	}
+
+	if previous != nil && generateVerbose {
+		fmt.Fprintf(os.Stderr, "   Stabilising accents against: %s\n", path)
+	}
+	config.PreviousPalette = previous
+}
+
+// cacheStableAccentsPalette stores the palette for the next --stable-accents run.
+func cacheStableAccentsPalette(palette *colour.CategorisedPalette) {
+	if !generateStableAccents || generateDryRun {
+		return
+	}
+
+	path, err := lastPalettePath()
+	if err == nil {
+		err = saveCachedPalette(palette, path)
+	}
+	if err != nil && generateVerbose {
+		fmt.Fprintf(os.Stderr, "   Failed to cache palette for stable accents: %v\n", err)
+	}
+}
+
+// saveCachedPalette writes palette to the tinct cache file at path.
+// Unlike savePalette it keeps no backup, as the cache is overwritten on every run.
+func saveCachedPalette(palette *colour.CategorisedPalette, path string) error {
+	data, err := palette.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal palette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { // #nosec G301 - Cache directory needs standard permissions
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cached palette: %w", err)
+	}
+
+	return nil
+}
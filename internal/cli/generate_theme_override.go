@@ -0,0 +1,90 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+// parseOutputThemes validates --output-theme values and returns the theme type per plugin name.
+func parseOutputThemes(overrides map[string]string) (map[string]colour.ThemeType, error) {
+	themes := make(map[string]colour.ThemeType, len(overrides))
+	for name, value := range overrides {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid --output-theme =%s: plugin name is required", value)
+		}
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "dark":
+			themes[name] = colour.ThemeDark
+		case "light":
+			themes[name] = colour.ThemeLight
+		default:
+			return nil, fmt.Errorf("invalid --output-theme for %s: %q (must be dark or light)", name, value)
+		}
+	}
+	return themes, nil
+}
+
+// themeVariants categorises the extracted colours for other theme types on demand,
+// with the same options as the base palette, and keeps each result for reuse.
+type themeVariants struct {
+	rawPalette *colour.Palette
+	byTheme    map[colour.ThemeType]*colour.CategorisedPalette
+}
+
+// newThemeVariants returns the theme variants of base, categorised from rawPalette.
+func newThemeVariants(rawPalette *colour.Palette, base *colour.CategorisedPalette) *themeVariants {
+	return &themeVariants{
+		rawPalette: rawPalette,
+		byTheme:    map[colour.ThemeType]*colour.CategorisedPalette{base.ThemeType: base},
+	}
+}
+
+// get returns the palette categorised for themeType, categorising it on first use.
+func (v *themeVariants) get(themeType colour.ThemeType) (*colour.CategorisedPalette, error) {
+	if palette, ok := v.byTheme[themeType]; ok {
+		return palette, nil
+	}
+
+	config, err := newCategorisationConfig(themeType)
+	if err != nil {
+		return nil, err
+	}
+	applyStableAccents(&config)
+
+	palette := colour.Categorise(v.rawPalette, config)
+	v.byTheme[themeType] = palette
+
+	if generateVerbose {
+		fmt.Fprintf(os.Stderr, "   Categorized %s palette variant (%d colours)\n",
+			themeType.String(), len(palette.AllColours))
+	}
+	return palette, nil
+}
+
+// categoriseThemeOverrides returns the palette to use for each plugin with a
+// --output-theme override. Plugins not in the map use the base palette.
+func categoriseThemeOverrides(variants *themeVariants, themes map[string]colour.ThemeType) (map[string]*colour.CategorisedPalette, error) {
+	palettes := make(map[string]*colour.CategorisedPalette, len(themes))
+	for name, themeType := range themes {
+		palette, err := variants.get(themeType)
+		if err != nil {
+			return nil, err
+		}
+		palettes[name] = palette
+	}
+
+	return palettes, nil
+}
+
+// paletteForPlugin returns the palette an output plugin is generated from.
+func paletteForPlugin(name string, base *colour.CategorisedPalette, overrides map[string]*colour.CategorisedPalette) *colour.CategorisedPalette {
+	if palette, ok := overrides[name]; ok {
+		return palette
+	}
+	return base
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+func TestParseOutputThemes(t *testing.T) {
+	themes, err := parseOutputThemes(map[string]string{"kitty": "dark", "waybar": "Light"})
+	if err != nil {
+		t.Fatalf("parseOutputThemes() error = %v", err)
+	}
+	if themes["kitty"] != colour.ThemeDark || themes["waybar"] != colour.ThemeLight {
+		t.Errorf("parseOutputThemes() = %v, want kitty=dark waybar=light", themes)
+	}
+
+	for _, overrides := range []map[string]string{
+		{"kitty": "auto"},
+		{"kitty": "dim"},
+		{"": "dark"},
+	} {
+		if _, err := parseOutputThemes(overrides); err == nil {
+			t.Errorf("parseOutputThemes(%v) expected error", overrides)
+		}
+	}
+}
+
+func TestCategoriseThemeOverrides(t *testing.T) {
+	rawPalette := &colour.Palette{Colors: []color.Color{
+		color.RGBA{R: 0x1a, G: 0x1b, B: 0x26, A: 0xff},
+		color.RGBA{R: 0xf0, G: 0xee, B: 0xe8, A: 0xff},
+		color.RGBA{R: 0xd0, G: 0x40, B: 0x40, A: 0xff},
+		color.RGBA{R: 0x40, G: 0x90, B: 0xd0, A: 0xff},
+		color.RGBA{R: 0x50, G: 0xb0, B: 0x60, A: 0xff},
+		color.RGBA{R: 0xe0, G: 0xb0, B: 0x40, A: 0xff},
+	}}
+
+	config := colour.DefaultCategorisationConfig()
+	config.ThemeType = colour.ThemeLight
+	base := colour.Categorise(rawPalette, config)
+
+	overrides, err := categoriseThemeOverrides(newThemeVariants(rawPalette, base), map[string]colour.ThemeType{
+		"kitty":  colour.ThemeDark,
+		"tmux":   colour.ThemeDark,
+		"waybar": colour.ThemeLight,
+	})
+	if err != nil {
+		t.Fatalf("categoriseThemeOverrides() error = %v", err)
+	}
+
+	kitty := paletteForPlugin("kitty", base, overrides)
+	if kitty.ThemeType != colour.ThemeDark {
+		t.Errorf("kitty theme = %s, want dark", kitty.ThemeType)
+	}
+	if paletteForPlugin("tmux", base, overrides) != kitty {
+		t.Error("plugins sharing an override theme should share one palette")
+	}
+	if paletteForPlugin("waybar", base, overrides) != base {
+		t.Error("an override matching the base theme should reuse the base palette")
+	}
+	if paletteForPlugin("dunst", base, overrides) != base {
+		t.Error("plugins without an override should use the base palette")
+	}
+
+	// The dark override must have a dark background with light text, the base the reverse.
+	luminance := func(p *colour.CategorisedPalette, role colour.Role) float64 {
+		c, ok := p.Get(role)
+		if !ok {
+			t.Fatalf("palette has no %s", role)
+		}
+		return c.Luminance
+	}
+	if bg, fg := luminance(kitty, colour.RoleBackground), luminance(kitty, colour.RoleForeground); bg >= fg {
+		t.Errorf("dark override background luminance %.2f should be below foreground %.2f", bg, fg)
+	}
+	if bg, fg := luminance(base, colour.RoleBackground), luminance(base, colour.RoleForeground); bg <= fg {
+		t.Errorf("light base background luminance %.2f should be above foreground %.2f", bg, fg)
+	}
+}
+
+func TestThemeVariantsKeepCategorisationOptions(t *testing.T) {
+	rawPalette := colour.NewPaletteWithRoleHints([]color.Color{
+		color.RGBA{R: 0x1a, G: 0x1b, B: 0x26, A: 0xff},
+		color.RGBA{R: 0xf0, G: 0xee, B: 0xe8, A: 0xff},
+		color.RGBA{R: 0xd0, G: 0x40, B: 0x40, A: 0xff},
+		color.RGBA{R: 0x40, G: 0x90, B: 0xd0, A: 0xff},
+		color.RGBA{R: 0x50, G: 0xb0, B: 0x60, A: 0xff},
+		color.RGBA{R: 0xe0, G: 0xb0, B: 0x40, A: 0xff},
+	}, map[colour.Role]int{colour.RoleAccent1: 4})
+
+	origAccentCount, origMaxOutput := globalAccentCount, globalMaxOutputColors
+	globalAccentCount, globalMaxOutputColors = 6, 3
+	t.Cleanup(func() { globalAccentCount, globalMaxOutputColors = origAccentCount, origMaxOutput })
+
+	config, err := newCategorisationConfig(colour.ThemeDark)
+	if err != nil {
+		t.Fatalf("newCategorisationConfig() error = %v", err)
+	}
+	base := colour.Categorise(rawPalette, config)
+	variants := newThemeVariants(rawPalette, base)
+
+	if got, err := variants.get(colour.ThemeDark); err != nil || got != base {
+		t.Errorf("get(dark) = %p, %v, want the base palette", got, err)
+	}
+
+	light, err := variants.get(colour.ThemeLight)
+	if err != nil {
+		t.Fatalf("get(light) error = %v", err)
+	}
+	if light.ThemeType != colour.ThemeLight {
+		t.Errorf("variant theme = %s, want light", light.ThemeType)
+	}
+	if _, ok := light.Get(colour.AccentRole(6)); !ok {
+		t.Error("variant should keep --accent-count 6")
+	}
+	if accent, _ := light.Get(colour.RoleAccent1); accent.Hex != "#50b060" {
+		t.Errorf("variant accent1 = %s, want the hinted #50b060", accent.Hex)
+	}
+	if len(light.AllColours) > 3 {
+		t.Errorf("variant has %d colours, want --max-output-colors 3", len(light.AllColours))
+	}
+	if again, _ := variants.get(colour.ThemeLight); again != light {
+		t.Error("a variant should be categorised once and reused")
+	}
+}
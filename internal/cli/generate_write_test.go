@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/security"
+)
+
+func TestWriteFileProtectedPath(t *testing.T) {
+	protectedDir := t.TempDir()
+	t.Setenv(security.ProtectedPathsEnv, protectedDir)
+	target := filepath.Join(protectedDir, "theme.conf")
+
+	prompted := false
+	origConfirm := confirmProtectedWrite
+	confirmProtectedWrite = func(_, _ string) (bool, error) {
+		prompted = true
+		return false, nil
+	}
+	t.Cleanup(func() {
+		confirmProtectedWrite = origConfirm
+		generateAllowUnsafe = false
+	})
+
+	t.Run("Refused", func(t *testing.T) {
+		err := writeFile(target, []byte("colours"), false)
+		if err == nil || !strings.Contains(err.Error(), "--allow-unsafe-paths") {
+			t.Fatalf("writeFile() error = %v, want protected path error", err)
+		}
+		if !prompted {
+			t.Error("expected the user to be asked for confirmation")
+		}
+		if _, err := os.Stat(target); !os.IsNotExist(err) {
+			t.Errorf("protected file was written")
+		}
+	})
+
+	t.Run("AllowUnsafePaths", func(t *testing.T) {
+		prompted = false
+		generateAllowUnsafe = true
+		if err := writeFile(target, []byte("colours"), false); err != nil {
+			t.Fatalf("writeFile() error = %v", err)
+		}
+		if prompted {
+			t.Error("--allow-unsafe-paths should not prompt")
+		}
+		if got, err := os.ReadFile(target); err != nil || string(got) != "colours" {
+			t.Errorf("written content = %q, %v", got, err)
+		}
+	})
+
+	t.Run("UnprotectedPath", func(t *testing.T) {
+		generateAllowUnsafe = false
+		if err := writeFile(filepath.Join(t.TempDir(), "theme.conf"), []byte("colours"), false); err != nil {
+			t.Errorf("writeFile() error = %v", err)
+		}
+	})
+}
+
+func TestGenerateOutputsProtectedPath(t *testing.T) {
+	protectedDir := t.TempDir()
+	t.Setenv(security.ProtectedPathsEnv, protectedDir)
+
+	origConfirm := confirmProtectedWrite
+	confirmProtectedWrite = func(_, _ string) (bool, error) { return false, nil }
+	t.Cleanup(func() { confirmProtectedWrite = origConfirm })
+
+	t.Run("Report", func(t *testing.T) {
+		target := filepath.Join(protectedDir, "report.md")
+		report := newGenerateReport(testCategorisedPalette(), "image", nil)
+		err := writeGenerateReport(target, report)
+		if err == nil || !strings.Contains(err.Error(), "--allow-unsafe-paths") {
+			t.Fatalf("writeGenerateReport() error = %v, want protected path error", err)
+		}
+		if _, err := os.Stat(target); !os.IsNotExist(err) {
+			t.Errorf("protected report was written")
+		}
+	})
+
+	t.Run("SavePalette", func(t *testing.T) {
+		target := filepath.Join(protectedDir, "palette.json")
+		err := savePalette(testCategorisedPalette(), target)
+		if err == nil || !strings.Contains(err.Error(), "--allow-unsafe-paths") {
+			t.Fatalf("savePalette() error = %v, want protected path error", err)
+		}
+		if _, err := os.Stat(target); !os.IsNotExist(err) {
+			t.Errorf("protected palette was written")
+		}
+	})
+}
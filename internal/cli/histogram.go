@@ -0,0 +1,145 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/image"
+)
+
+// histogramBarWidth is the width of the largest bar in the chart, in characters.
+const histogramBarWidth = 40
+
+var (
+	// Histogram command flags.
+	histogramBuckets   int
+	histogramLuminance bool
+	histogramJSON      bool
+)
+
+// histogramCmd represents the histogram command.
+var histogramCmd = &cobra.Command{
+	Use:   "histogram <image>",
+	Short: "Show the hue and luminance distribution of an image",
+	Long: `Show how an image's colours are distributed before extracting a palette.
+
+The histogram command bins every opaque pixel of the image by hue into equal-width
+buckets and prints a bar chart, with a swatch of each bucket's hue when output is
+a terminal. Pixels too grey to
+have a meaningful hue are counted separately as achromatic. With --luminance, a
+second chart bins the pixels by relative luminance.
+
+The image can be a file, a URL, or a directory (a random image is selected).
+
+Examples:
+  # Hue distribution in 12 buckets of 30 degrees
+  tinct histogram wallpaper.jpg
+
+  # Finer hue buckets plus the luminance distribution
+  tinct histogram wallpaper.jpg --buckets 36 --luminance
+
+  # Machine-readable output
+  tinct histogram wallpaper.jpg --json | jq '.hue[] | select(.share > 0.1)'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistogram,
+}
+
+func init() {
+	histogramCmd.Flags().IntVar(&histogramBuckets, "buckets", 12, fmt.Sprintf("number of buckets (1-%d)", colour.MaxHistogramBuckets))
+	histogramCmd.Flags().BoolVar(&histogramLuminance, "luminance", false, "also show the luminance histogram")
+	histogramCmd.Flags().BoolVar(&histogramJSON, "json", false, "output the histogram as JSON")
+}
+
+// runHistogram executes the histogram command.
+func runHistogram(cmd *cobra.Command, args []string) error {
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return fmt.Errorf("failed to get verbose flag: %w", err)
+	}
+
+	path, err := image.ResolveImagePath(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve image path: %w", err)
+	}
+	if verbose && path != args[0] {
+		fmt.Fprintf(os.Stderr, "→ Selected random image from directory: %s\n", path)
+	}
+
+	img, err := image.NewSmartLoader().Load(path)
+	if err != nil {
+		return fmt.Errorf("failed to load image: %w", err)
+	}
+
+	hist, err := colour.ComputeHistogram(img, histogramBuckets)
+	if err != nil {
+		return err
+	}
+	if !histogramLuminance {
+		hist.Luminance = nil
+	}
+
+	if histogramJSON {
+		data, err := json.MarshalIndent(hist, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to convert histogram to JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	// Only draw colour swatches for a terminal, so redirected output stays plain text.
+	fmt.Print(formatHistogram(hist, term.IsTerminal(int(os.Stdout.Fd()))))
+	return nil
+}
+
+// formatHistogram renders a histogram as bar charts. With swatches, each row starts
+// with an ANSI 24-bit colour swatch of its bucket.
+func formatHistogram(hist *colour.Histogram, swatches bool) string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "Hue (%d pixels, %d achromatic):\n", hist.Pixels, hist.Achromatic)
+	for _, bucket := range hist.Hue {
+		swatch := colour.HSLToRGB((bucket.Start+bucket.End)/2, 0.7, 0.5)
+		label := fmt.Sprintf("%3.0f-%3.0f°", bucket.Start, bucket.End)
+		writeHistogramRow(&out, swatch, swatches, label, bucket, hist.Hue)
+	}
+
+	if len(hist.Luminance) > 0 {
+		fmt.Fprintf(&out, "\nLuminance (%d pixels):\n", hist.Pixels)
+		for _, bucket := range hist.Luminance {
+			grey := uint8((bucket.Start + bucket.End) / 2 * 255)
+			label := fmt.Sprintf("%.2f-%.2f", bucket.Start, bucket.End)
+			writeHistogramRow(&out, colour.RGB{R: grey, G: grey, B: grey}, swatches, label, bucket, hist.Luminance)
+		}
+	}
+
+	return out.String()
+}
+
+// writeHistogramRow writes one bar, scaled so the largest bucket in all fills the bar width.
+// The swatch is drawn only if showSwatch is set.
+func writeHistogramRow(out *strings.Builder, swatch colour.RGB, showSwatch bool, label string, bucket colour.HistogramBucket, all []colour.HistogramBucket) {
+	largest := 0
+	for _, b := range all {
+		largest = max(largest, b.Count)
+	}
+
+	width := 0
+	if largest > 0 {
+		width = bucket.Count * histogramBarWidth / largest
+	}
+
+	bar := strings.Repeat("█", width) + strings.Repeat(" ", histogramBarWidth-width)
+	out.WriteString("  ")
+	if showSwatch {
+		out.WriteString(colour.Preview(swatch, 2) + " ")
+	}
+	fmt.Fprintf(out, "%s %s %5.1f%%\n", label, bar, bucket.Share*100)
+}
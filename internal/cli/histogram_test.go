@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+func TestFormatHistogramSwatches(t *testing.T) {
+	hist := &colour.Histogram{
+		Pixels: 4,
+		Hue: []colour.HistogramBucket{
+			{Start: 0, End: 180, Count: 3, Share: 0.75},
+			{Start: 180, End: 360, Count: 1, Share: 0.25},
+		},
+	}
+
+	if plain := formatHistogram(hist, false); strings.Contains(plain, "\x1b[") {
+		t.Errorf("histogram without swatches contains ANSI escapes:\n%q", plain)
+	}
+	if coloured := formatHistogram(hist, true); !strings.Contains(coloured, "\x1b[48;2;") {
+		t.Errorf("histogram with swatches has no colour swatch:\n%q", coloured)
+	}
+}
@@ -0,0 +1,251 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/jmylchreest/tinct/internal/version"
+)
+
+// manCmd represents the man command.
+var manCmd = &cobra.Command{
+	Use:   "man [dir]",
+	Short: "Generate man pages",
+	Long: `Generate roff man pages for tinct and each of its subcommands.
+
+One page is written per command (tinct.1, tinct-generate.1, tinct-plugins-list.1, ...)
+to the given directory, which defaults to the current directory and is created if missing.
+
+Examples:
+  # Generate man pages for packaging
+  tinct man ./man
+
+  # Install for the current user and view
+  tinct man ~/.local/share/man/man1
+  man tinct-generate`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMan,
+}
+
+// runMan executes the man command.
+func runMan(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	written, err := writeManPages(cmd.Root(), dir, manDate())
+	if err != nil {
+		return err
+	}
+
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	if !quiet {
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d man pages to %s\n", written, dir)
+	}
+	return nil
+}
+
+// manDate returns the date shown in man page headers. The build date is preferred
+// so that pages generated from the same release are identical.
+func manDate() time.Time {
+	if date, err := time.Parse(time.RFC3339, version.Date); err == nil {
+		return date
+	}
+	return time.Now()
+}
+
+// writeManPages writes a man page for root and every available subcommand to dir.
+// Returns the number of pages written.
+func writeManPages(root *cobra.Command, dir string, date time.Time) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil { // #nosec G301 - Man page directory needs standard permissions
+		return 0, fmt.Errorf("failed to create man page directory: %w", err)
+	}
+
+	written := 0
+	var walk func(cmd *cobra.Command) error
+	walk = func(cmd *cobra.Command) error {
+		path := filepath.Join(dir, manPageName(cmd)+".1")
+		f, err := os.Create(path) // #nosec G304 - Path is built from the output directory and command names
+		if err != nil {
+			return fmt.Errorf("failed to create man page: %w", err)
+		}
+		if err := writeManPage(f, cmd, date); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write man page %s: %w", path, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write man page %s: %w", path, err)
+		}
+		written++
+
+		for _, child := range manSubcommands(cmd) {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(root); err != nil {
+		return written, err
+	}
+	return written, nil
+}
+
+// manSubcommands returns the subcommands of cmd that get their own page.
+func manSubcommands(cmd *cobra.Command) []*cobra.Command {
+	var children []*cobra.Command
+	for _, child := range cmd.Commands() {
+		if child.IsAvailableCommand() && !child.IsAdditionalHelpTopicCommand() {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// manPageName returns the page name for cmd, e.g. "tinct-plugins-list".
+func manPageName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+// writeManPage writes the roff source of the man page for cmd to w.
+func writeManPage(w io.Writer, cmd *cobra.Command, date time.Time) error {
+	var b strings.Builder
+
+	name := manPageName(cmd)
+	fmt.Fprintf(&b, ".TH \"%s\" \"1\" \"%s\" \"tinct %s\" \"Tinct Manual\"\n",
+		strings.ToUpper(name), date.Format("Jan 2006"), roffEscape(version.Short()))
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", roffEscape(name), roffEscape(cmd.Short))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, ".B %s\n", roffEscape(cmd.CommandPath()))
+	if use := strings.TrimSpace(strings.TrimPrefix(cmd.UseLine(), cmd.CommandPath())); use != "" {
+		b.WriteString(roffLine(use) + "\n")
+	}
+
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	b.WriteString(".SH DESCRIPTION\n")
+	writeRoffText(&b, description)
+
+	writeRoffFlags(&b, "OPTIONS", cmd.NonInheritedFlags())
+	writeRoffFlags(&b, "OPTIONS INHERITED FROM PARENT COMMANDS", cmd.InheritedFlags())
+
+	var seeAlso []string
+	if cmd.HasParent() {
+		seeAlso = append(seeAlso, manPageName(cmd.Parent()))
+	}
+	for _, child := range manSubcommands(cmd) {
+		seeAlso = append(seeAlso, manPageName(child))
+	}
+	if len(seeAlso) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		for i, page := range seeAlso {
+			sep := ","
+			if i == len(seeAlso)-1 {
+				sep = ""
+			}
+			fmt.Fprintf(&b, "\\fB%s\\fP(1)%s\n", roffEscape(page), sep)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeRoffText converts help text to roff. Blank lines start new paragraphs and
+// indented lines (such as examples) are kept verbatim.
+func writeRoffText(b *strings.Builder, text string) {
+	verbatim := false
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		switch {
+		case strings.TrimSpace(line) == "":
+			if verbatim {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(".PP\n")
+			}
+			continue
+		case indented && !verbatim:
+			b.WriteString(".nf\n")
+			verbatim = true
+		case !indented && verbatim:
+			b.WriteString(".fi\n.PP\n")
+			verbatim = false
+		}
+		b.WriteString(roffLine(line) + "\n")
+	}
+	if verbatim {
+		b.WriteString(".fi\n")
+	}
+}
+
+// writeRoffFlags writes a section listing the visible flags in flags.
+func writeRoffFlags(b *strings.Builder, title string, flags *pflag.FlagSet) {
+	if !flags.HasAvailableFlags() {
+		return
+	}
+
+	fmt.Fprintf(b, ".SH %s\n", title)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+
+		varName, usage := pflag.UnquoteUsage(flag)
+
+		b.WriteString(".TP\n")
+		if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+			fmt.Fprintf(b, "\\fB\\-%s\\fP, ", roffEscape(flag.Shorthand))
+		}
+		fmt.Fprintf(b, "\\fB\\-\\-%s\\fP", roffEscape(flag.Name))
+		if varName != "" {
+			fmt.Fprintf(b, " \\fI%s\\fP", roffEscape(varName))
+		}
+		b.WriteString("\n")
+
+		if flagHasDefault(flag) {
+			usage += fmt.Sprintf(" (default %s)", flag.DefValue)
+		}
+		b.WriteString(roffLine(usage) + "\n")
+	})
+}
+
+// flagHasDefault reports whether a flag's default is worth showing.
+func flagHasDefault(flag *pflag.Flag) bool {
+	switch flag.DefValue {
+	case "", "false", "0", "[]":
+		return false
+	default:
+		return true
+	}
+}
+
+// roffLine escapes a line of text, guarding leading characters roff treats as requests.
+func roffLine(s string) string {
+	s = roffEscape(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// roffEscape escapes backslashes and hyphens for roff.
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	return strings.ReplaceAll(s, "-", `\-`)
+}
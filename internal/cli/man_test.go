@@ -0,0 +1,69 @@
+// Package cli provides command-line interface utilities.
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteManPages(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "man")
+	date := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	written, err := writeManPages(newTestCommandTree(), dir, date)
+	if err != nil {
+		t.Fatalf("writeManPages() error = %v", err)
+	}
+	if written != 2 {
+		t.Errorf("writeManPages() wrote %d pages, want 2", written)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "tinct-generate.1"))
+	if err != nil {
+		t.Fatalf("failed to read man page: %v", err)
+	}
+	page := string(data)
+
+	for _, want := range []string{
+		`.TH "TINCT-GENERATE" "1" "Mar 2025"`,
+		`tinct\-generate \- Generate configuration files from a colour palette`,
+		".nf\n  tinct generate \\-o kitty\n.fi",
+		`\fB\-o\fP, \fB\-\-outputs\fP \fIstring\fP`,
+		"output plugins (default all)",
+		".SH OPTIONS INHERITED FROM PARENT COMMANDS",
+		`\fBtinct\fP(1)`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("man page missing %q", want)
+		}
+	}
+
+	root, err := os.ReadFile(filepath.Join(dir, "tinct.1"))
+	if err != nil {
+		t.Fatalf("failed to read man page: %v", err)
+	}
+	if !strings.Contains(string(root), `\fBtinct\-generate\fP(1)`) {
+		t.Error("root man page does not reference tinct-generate")
+	}
+}
+
+func TestRoffLine(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain text", "plain text"},
+		{"--flag", `\-\-flag`},
+		{`C:\path`, `C:\epath`},
+		{".starts with dot", `\&.starts with dot`},
+		{"'quoted", `\&'quoted`},
+	}
+
+	for _, tt := range tests {
+		if got := roffLine(tt.in); got != tt.want {
+			t.Errorf("roffLine(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -3,11 +3,13 @@ package cli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -76,6 +78,12 @@ var (
 	pluginSourceType string
 	pluginNoCopy     bool
 	pluginShowPath   bool
+
+	// Plugin list filters.
+	pluginListType         string
+	pluginListEnabledOnly  bool
+	pluginListExternalOnly bool
+	pluginListGroup        bool
 )
 
 // pluginsCmd represents the plugins command.
@@ -91,6 +99,11 @@ Plugins can be controlled via:
 
 Priority order: lock file > environment variables > plugin defaults
 
+External plugins are installed to ~/.local/share/tinct/plugins. Set
+TINCT_PLUGIN_DIR or pass --plugin-dir to install them elsewhere, for example in
+a sandbox or a shared multi-user location. The lock file records each plugin's
+absolute path, so later commands find it wherever it was installed.
+
 When TINCT_ENABLED_PLUGINS is set, only those plugins are enabled (whitelist mode).
 When TINCT_DISABLED_PLUGINS is set, those plugins are disabled (blacklist mode).
 
@@ -108,7 +121,13 @@ var pluginListCmd = &cobra.Command{
 	Short: "List all available plugins",
 	Long: `List all available plugins including their enabled/disabled state.
 
-Shows both built-in and external plugins with their type and description.`,
+Shows both built-in and external plugins with their type and description.
+
+Examples:
+  tinct plugins list
+  tinct plugins list --type output --enabled-only
+  tinct plugins list --external-only --show-path
+  tinct plugins list --group`,
 	RunE: runPluginList,
 }
 
@@ -123,9 +142,10 @@ Examples:
   tinct plugins enable waybar
   tinct plugins enable image
   tinct plugins enable all
+  tinct plugins enable --type output all  # Enable all output plugins only
   tinct plugins enable hyprland --clear  # Remove from disabled list only`,
 	Args: cobra.ExactArgs(1),
-	RunE: runPluginEnable,
+	RunE: withPluginLockFile(runPluginEnable),
 }
 
 // pluginDisableCmd disables a plugin.
@@ -139,9 +159,10 @@ Examples:
   tinct plugins disable waybar
   tinct plugins disable image
   tinct plugins disable all
+  tinct plugins disable --type input all  # Disable all input plugins only
   tinct plugins disable hyprland --clear  # Remove from enabled list only`,
 	Args: cobra.ExactArgs(1),
-	RunE: runPluginDisable,
+	RunE: withPluginLockFile(runPluginDisable),
 }
 
 // pluginClearCmd clears plugin configuration.
@@ -158,7 +179,7 @@ Examples:
   tinct plugins clear waybar    # Clear waybar config
   tinct plugins clear           # Clear all plugin config`,
 	Args: cobra.MaximumNArgs(1),
-	RunE: runPluginClear,
+	RunE: withPluginLockFile(runPluginClear),
 }
 
 // pluginAddCmd adds an external plugin.
@@ -178,9 +199,9 @@ installation to ensure it is safe.
 The command will:
   1. Verify source and destination are not the same file
   2. Query plugin metadata (name, version, type, protocol)
-  3. Check protocol compatibility
+  3. Check protocol compatibility and that the plugin implements its declared type
   4. Check for version conflicts (upgrades proceed automatically)
-  5. Copy plugin to ~/.local/share/tinct/plugins/ (unless --no-copy is used)
+  5. Copy plugin to the plugin directory (unless --no-copy is used)
   6. Register plugin in lock file
 
 Plugin upgrades (newer versions) proceed automatically.
@@ -196,7 +217,7 @@ Examples:
   tinct plugins add ./my-plugin.sh --force  # Force overwrite
   tinct plugins add /usr/bin/tinct-plugin-random --no-copy  # System package`,
 	Args: cobra.ExactArgs(1),
-	RunE: runPluginAdd,
+	RunE: withPluginLockFile(runPluginAdd),
 }
 
 // pluginDeleteCmd removes an external plugin.
@@ -211,7 +232,7 @@ Examples:
   tinct plugins delete notify
   tinct plugins delete custom-theme`,
 	Args: cobra.ExactArgs(1),
-	RunE: runPluginDelete,
+	RunE: withPluginLockFile(runPluginDelete),
 }
 
 // pluginUpdateCmd updates external plugins from lock file.
@@ -227,17 +248,22 @@ changes to the lock file.
 Examples:
   tinct plugins update
   tinct plugins update --lock-file /path/to/.tinct-plugins.json`,
-	RunE: runPluginUpdate,
+	RunE: withPluginLockFile(runPluginUpdate),
 }
 
 func init() {
 	// Add plugins command flags.
 	pluginsCmd.PersistentFlags().StringVar(&pluginLockPath, "lock-file", "", "path to plugin lock file (default: .tinct-plugins.json in current or home directory)")
+	pluginsCmd.PersistentFlags().StringVar(&pluginDirFlag, "plugin-dir", "", "directory plugins are installed to (default: $TINCT_PLUGIN_DIR or ~/.local/share/tinct/plugins)")
 
 	// Add type flag to relevant commands (no shorthand to avoid conflict with global -t theme flag).
 	pluginEnableCmd.Flags().StringVar(&pluginType, "type", "", "plugin type (input or output)")
 	pluginDisableCmd.Flags().StringVar(&pluginType, "type", "", "plugin type (input or output)")
 	pluginListCmd.Flags().BoolVar(&pluginShowPath, "show-path", false, "show the actual file path used when loading each plugin")
+	pluginListCmd.Flags().StringVar(&pluginListType, "type", "", "only list plugins of this type (input or output)")
+	pluginListCmd.Flags().BoolVar(&pluginListEnabledOnly, "enabled-only", false, "only list plugins enabled in the lock file")
+	pluginListCmd.Flags().BoolVar(&pluginListExternalOnly, "external-only", false, "only list external plugins")
+	pluginListCmd.Flags().BoolVar(&pluginListGroup, "group", false, "group plugins into a table per type")
 	pluginAddCmd.Flags().StringVar(&pluginType, "type", "output", "plugin type (input or output)")
 	pluginAddCmd.Flags().BoolVarP(&pluginForce, "force", "f", false, "force overwrite if plugin already exists")
 	pluginAddCmd.Flags().StringVar(&pluginSourceType, "source-type", "", "force source type (local, http, git) - auto-detected if not specified")
@@ -265,6 +291,15 @@ func runPluginList(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get verbose flag: %w", err)
 	}
 
+	filter := pluginListFilter{
+		pluginType:   pluginListType,
+		enabledOnly:  pluginListEnabledOnly,
+		externalOnly: pluginListExternalOnly,
+	}
+	if err := filter.validate(); err != nil {
+		return err
+	}
+
 	// Load plugin lock and create manager.
 	lock, lockPath, err := loadPluginLock()
 	if err != nil && verbose {
@@ -278,10 +313,14 @@ func runPluginList(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Collect all plugins.
-	allPlugins := collectAllPlugins(mgr, lock)
+	plugins := filter.apply(collectAllPlugins(mgr, lock))
+	if len(plugins) == 0 {
+		fmt.Println("No plugins match the given filters.")
+		return nil
+	}
 
 	// Display plugins.
-	displayPluginTable(allPlugins, pluginShowPath)
+	displayPluginTable(plugins, pluginShowPath, pluginListGroup)
 
 	return nil
 }
@@ -295,7 +334,10 @@ func runPluginAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load or create plugin lock.
-	lock, lockPath := loadOrCreatePluginLock()
+	lock, lockPath, err := loadOrCreatePluginLock()
+	if err != nil {
+		return fmt.Errorf("failed to load plugin lock: %w", err)
+	}
 
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Using lock file: %s\n", lockPath)
@@ -346,10 +388,13 @@ func runPluginAdd(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Protocol version: %s\n", pluginInfo.ProtocolVersion)
 	}
 
-	// Stage 3: Check protocol compatibility
+	// Stage 3: Check protocol compatibility and that the plugin serves its declared type
 	if err := checkProtocolCompatibility(pluginInfo.ProtocolVersion, verbose); err != nil {
 		return err
 	}
+	if err := verifyPluginType(sourcePath, pluginInfo.Type, verbose); err != nil {
+		return err
+	}
 
 	// Stage 4: Check for conflicts and version comparisons
 	action, existingMeta, err := determinePluginAction(lock, pluginInfo, pluginForce)
@@ -391,12 +436,12 @@ func runPluginAdd(cmd *cobra.Command, args []string) error {
 
 	// Stage 6: Update lock file
 	lock.ExternalPlugins[pluginInfo.Name] = &ExternalPluginMeta{
-		Name:         pluginInfo.Name,
-		Path:         finalPath,
-		Type:         pluginInfo.Type,
-		SourceLegacy: source,
-		Version:      pluginInfo.Version,
-		Description:  pluginInfo.Description,
+		Name:        pluginInfo.Name,
+		Path:        finalPath,
+		Type:        pluginInfo.Type,
+		Source:      addedPluginSource(source, sourcePath, pluginSourceType),
+		Version:     pluginInfo.Version,
+		Description: pluginInfo.Description,
 	}
 
 	if err := savePluginLock(lockPath, lock); err != nil {
@@ -689,26 +734,47 @@ func runPluginUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// errPluginLockNotFound is returned by findPluginLock when no lock file exists.
+var errPluginLockNotFound = errors.New("no plugin lock file found")
+
+// findPluginLock returns the path of the plugin lock file to load.
+func findPluginLock() (string, error) {
+	if pluginLockPath != "" {
+		return pluginLockPath, nil
+	}
+
+	// Try current directory first.
+	if _, err := os.Stat(PluginLockFile); !os.IsNotExist(err) {
+		return PluginLockFile, nil
+	}
+
+	// Try home directory.
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errPluginLockNotFound
+	}
+
+	homeLockPath := filepath.Join(home, PluginLockFile)
+	if _, err := os.Stat(homeLockPath); err != nil {
+		return "", errPluginLockNotFound
+	}
+	return homeLockPath, nil
+}
+
+// writablePluginLockPath returns the plugin lock file that commands modify: the
+// existing lock file, or a new one in the current directory.
+func writablePluginLockPath() string {
+	if lockPath, err := findPluginLock(); err == nil {
+		return lockPath
+	}
+	return PluginLockFile
+}
+
 // loadPluginLock loads the plugin lock file.
 func loadPluginLock() (*PluginLock, string, error) {
-	lockPath := pluginLockPath
-
-	if lockPath == "" {
-		// Try current directory first.
-		lockPath = PluginLockFile
-		if _, err := os.Stat(lockPath); os.IsNotExist(err) {
-			// Try home directory.
-			home, err := os.UserHomeDir()
-			if err != nil {
-				return nil, "", fmt.Errorf("no plugin lock file found")
-			}
-
-			homeLockPath := filepath.Join(home, PluginLockFile)
-			if _, err := os.Stat(homeLockPath); err != nil {
-				return nil, "", fmt.Errorf("no plugin lock file found")
-			}
-			lockPath = homeLockPath
-		}
+	lockPath, err := findPluginLock()
+	if err != nil {
+		return nil, "", err
 	}
 
 	data, err := os.ReadFile(lockPath) // #nosec G304 - Lock file path controlled by application
@@ -721,34 +787,47 @@ func loadPluginLock() (*PluginLock, string, error) {
 		return nil, "", fmt.Errorf("failed to parse plugin lock file: %w", err)
 	}
 
+	if err := migratePluginLock(&lock); err != nil {
+		return nil, "", fmt.Errorf("failed to migrate plugin lock file: %w", err)
+	}
+
 	return &lock, lockPath, nil
 }
 
-// loadOrCreatePluginLock loads or creates a plugin lock file.
-// Always succeeds by creating a new lock if one doesn't exist.
-func loadOrCreatePluginLock() (lock *PluginLock, lockPath string) {
-	lock, lockPath, err := loadPluginLock()
+// isPluginLockNotFound reports whether err from loadPluginLock means there is no
+// lock file, as opposed to one that cannot be read, parsed or migrated.
+func isPluginLockNotFound(err error) bool {
+	return errors.Is(err, errPluginLockNotFound) || errors.Is(err, os.ErrNotExist)
+}
+
+// loadOrCreatePluginLock loads the plugin lock file, or creates a new lock if
+// none exists. Any other load error is returned, so a lock that cannot be read
+// or migrated is never replaced by an empty one on the next save.
+func loadOrCreatePluginLock() (lock *PluginLock, lockPath string, err error) {
+	lock, lockPath, err = loadPluginLock()
 	if err == nil {
-		return lock, lockPath
+		return lock, lockPath, nil
+	}
+	if !isPluginLockNotFound(err) {
+		return nil, "", err
 	}
 
 	// Create new lock file.
-	lockPath = pluginLockPath
-	if lockPath == "" {
-		lockPath = PluginLockFile
-	}
+	lockPath = writablePluginLockPath()
 
 	lock = &PluginLock{
+		Version:         strconv.Itoa(pluginLockVersion),
 		EnabledPlugins:  []string{},
 		DisabledPlugins: []string{},
 		ExternalPlugins: make(map[string]*ExternalPluginMeta),
 	}
 
-	return lock, lockPath
+	return lock, lockPath, nil
 }
 
 // savePluginLock saves the plugin lock file.
 func savePluginLock(path string, lock *PluginLock) error {
+	lock.Version = strconv.Itoa(pluginLockVersion)
 	data, err := json.MarshalIndent(lock, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal plugin lock: %w", err)
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/jmylchreest/tinct/internal/plugin/executor"
 	"github.com/jmylchreest/tinct/internal/plugin/protocol"
 )
 
@@ -138,6 +139,27 @@ func checkProtocolCompatibility(protocolVersion string, verbose bool) error {
 	return nil
 }
 
+// verifyPluginType checks that the plugin serves the interface its declared type
+// requires, so a plugin claiming to be an output while implementing an input fails
+// at install time rather than when it is first run.
+func verifyPluginType(pluginPath, pluginType string, verbose bool) error {
+	pluginExecutor, err := executor.NewWithVerbose(pluginPath, verbose)
+	if err != nil {
+		return fmt.Errorf("failed to load plugin: %w", err)
+	}
+	defer pluginExecutor.Close()
+
+	if err := pluginExecutor.VerifyType(pluginType); err != nil {
+		return fmt.Errorf("plugin type check failed: %w", err)
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Plugin serves the %s interface\n", pluginType)
+	}
+
+	return nil
+}
+
 // determinePluginAction determines what action to take based on existing plugin state.
 func determinePluginAction(lock *PluginLock, pluginInfo *pluginMetadata, force bool) (pluginAction, *ExternalPluginMeta, error) {
 	existingMeta, exists := lock.ExternalPlugins[pluginInfo.Name]
@@ -0,0 +1,172 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/plugin/protocol"
+)
+
+// pluginCompatStatus is the protocol compatibility of an installed external plugin.
+type pluginCompatStatus string
+
+const (
+	compatCompatible  pluginCompatStatus = "compatible"
+	compatTooOld      pluginCompatStatus = "too old"
+	compatTooNew      pluginCompatStatus = "too new"
+	compatInvalid     pluginCompatStatus = "invalid"
+	compatUnreachable pluginCompatStatus = "unreachable"
+)
+
+// pluginCompatResult is one row of the compatibility report.
+type pluginCompatResult struct {
+	name            string
+	pluginType      string
+	version         string
+	protocolVersion string
+	status          pluginCompatStatus
+	detail          string
+}
+
+// pluginCheckCompatCmd reports whether installed external plugins speak a supported protocol.
+var pluginCheckCompatCmd = &cobra.Command{
+	Use:   "check-compat",
+	Short: "Check installed external plugins against tinct's protocol version",
+	Long: `Query every external plugin in the lock file with --plugin-info and compare its
+protocol version with the range this tinct build supports.
+
+Each plugin is reported as:
+  compatible   the protocol version is supported
+  too old      the plugin predates the minimum compatible protocol version
+  too new      the plugin needs a newer tinct (different major version)
+  invalid      the plugin reported a malformed protocol version
+  unreachable  the plugin could not be run or its --plugin-info was not valid JSON
+
+Run this after upgrading tinct to find plugins that need updating. The command
+exits with an error if any plugin is not compatible.
+
+Examples:
+  tinct plugins check-compat`,
+	Args: cobra.NoArgs,
+	RunE: runPluginCheckCompat,
+}
+
+func init() {
+	pluginsCmd.AddCommand(pluginCheckCompatCmd)
+}
+
+// runPluginCheckCompat executes the check-compat command.
+func runPluginCheckCompat(_ *cobra.Command, _ []string) error {
+	lock, _, err := loadPluginLock()
+	if err != nil && !isPluginLockNotFound(err) {
+		return fmt.Errorf("failed to load plugin lock: %w", err)
+	}
+	if err != nil || len(lock.ExternalPlugins) == 0 {
+		fmt.Println("No external plugins installed.")
+		return nil
+	}
+
+	fmt.Printf("Tinct protocol version: %s (minimum compatible: %s)\n\n", protocol.ProtocolVersion, protocol.MinCompatibleVersion)
+
+	results := checkPluginCompat(lock)
+	fmt.Print(renderPluginCompatTable(results))
+
+	incompatible := 0
+	for _, r := range results {
+		if r.status != compatCompatible {
+			incompatible++
+		}
+	}
+	if incompatible > 0 {
+		return fmt.Errorf("%d of %d external plugins are not compatible", incompatible, len(results))
+	}
+	return nil
+}
+
+// checkPluginCompat queries each external plugin in the lock file, sorted by name.
+func checkPluginCompat(lock *PluginLock) []pluginCompatResult {
+	names := make([]string, 0, len(lock.ExternalPlugins))
+	for name := range lock.ExternalPlugins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	results := make([]pluginCompatResult, 0, len(names))
+	for _, name := range names {
+		meta := lock.ExternalPlugins[name]
+		result := pluginCompatResult{
+			name:       name,
+			pluginType: meta.Type,
+			version:    meta.Version,
+		}
+
+		info, err := queryFullPluginMetadata(meta.Path)
+		if err != nil {
+			result.status, result.detail = compatUnreachable, err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		result.version = info.Version
+		result.protocolVersion = info.ProtocolVersion
+		result.status, result.detail = classifyProtocolVersion(info.ProtocolVersion)
+		results = append(results, result)
+	}
+	return results
+}
+
+// classifyProtocolVersion compares a plugin's protocol version with the supported range.
+// The detail explains why a version is not compatible.
+func classifyProtocolVersion(protocolVersion string) (pluginCompatStatus, string) {
+	compatible, err := protocol.IsCompatible(protocolVersion)
+	if compatible {
+		return compatCompatible, ""
+	}
+
+	pluginVersion, parseErr := protocol.Parse(protocolVersion)
+	if parseErr != nil {
+		return compatInvalid, parseErr.Error()
+	}
+	currentVersion, parseErr := protocol.Parse(protocol.ProtocolVersion)
+	if parseErr != nil {
+		return compatInvalid, parseErr.Error()
+	}
+
+	detail := ""
+	if err != nil {
+		detail = err.Error()
+	}
+	if pluginVersion.Major > currentVersion.Major {
+		return compatTooNew, detail
+	}
+	return compatTooOld, detail
+}
+
+// renderPluginCompatTable formats the compatibility report as a table.
+func renderPluginCompatTable(results []pluginCompatResult) string {
+	table := NewTable([]string{"NAME", "TYPE", "VERSION", "PROTOCOL", "STATUS", "DETAIL"})
+	table.EnableTerminalAwareWidth(5, 20)
+
+	for _, r := range results {
+		table.AddRow([]string{
+			r.name,
+			r.pluginType,
+			valueOrDash(r.version),
+			valueOrDash(r.protocolVersion),
+			string(r.status),
+			r.detail,
+		})
+	}
+	return table.Render()
+}
+
+// valueOrDash returns s, or "-" when it is empty.
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
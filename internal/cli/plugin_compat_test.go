@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/plugin/protocol"
+)
+
+// writeMockPlugin writes an executable plugin script that prints the given
+// --plugin-info output and exit status.
+func writeMockPlugin(t *testing.T, dir, name, info string, exitCode int) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	script := fmt.Sprintf("#!/bin/sh\necho '%s'\nexit %d\n", info, exitCode)
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil { // #nosec G306 - Test plugin needs execute permission
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+	return path
+}
+
+// pluginInfoJSON returns --plugin-info output for an output plugin.
+func pluginInfoJSON(name, protocolVersion string) string {
+	return fmt.Sprintf(`{"name": "%s", "type": "output", "version": "1.2.3", "protocol_version": "%s"}`, name, protocolVersion)
+}
+
+// olderProtocolVersion returns a protocol version just below the minimum compatible one.
+func olderProtocolVersion(t *testing.T) string {
+	t.Helper()
+	v, err := protocol.Parse(protocol.MinCompatibleVersion)
+	if err != nil {
+		t.Fatalf("failed to parse minimum version: %v", err)
+	}
+	switch {
+	case v.Patch > 0:
+		v.Patch--
+	case v.Minor > 0:
+		v.Minor, v.Patch = v.Minor-1, 99
+	case v.Major > 0:
+		v.Major, v.Minor, v.Patch = v.Major-1, 99, 99
+	default:
+		t.Skip("no protocol version is older than 0.0.0")
+	}
+	return v.String()
+}
+
+func TestCheckPluginCompat(t *testing.T) {
+	dir := t.TempDir()
+	current, err := protocol.Parse(protocol.ProtocolVersion)
+	if err != nil {
+		t.Fatalf("failed to parse protocol version: %v", err)
+	}
+	newer := protocol.Version{Major: current.Major + 1}
+
+	lock := &PluginLock{ExternalPlugins: map[string]*ExternalPluginMeta{
+		"current": {Type: "output", Path: writeMockPlugin(t, dir, "current", pluginInfoJSON("current", protocol.ProtocolVersion), 0)},
+		"old":     {Type: "output", Path: writeMockPlugin(t, dir, "old", pluginInfoJSON("old", olderProtocolVersion(t)), 0)},
+		"new":     {Type: "output", Path: writeMockPlugin(t, dir, "new", pluginInfoJSON("new", newer.String()), 0)},
+		"garbled": {Type: "output", Path: writeMockPlugin(t, dir, "garbled", pluginInfoJSON("garbled", "one.two"), 0)},
+		"broken":  {Type: "input", Version: "0.9.0", Path: writeMockPlugin(t, dir, "broken", "not json", 1)},
+		"missing": {Type: "output", Path: filepath.Join(dir, "does-not-exist")},
+	}}
+
+	want := map[string]pluginCompatStatus{
+		"broken":  compatUnreachable,
+		"current": compatCompatible,
+		"garbled": compatInvalid,
+		"missing": compatUnreachable,
+		"new":     compatTooNew,
+		"old":     compatTooOld,
+	}
+
+	results := checkPluginCompat(lock)
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, r := range results {
+		if i > 0 && results[i-1].name > r.name {
+			t.Errorf("results are not sorted by name: %s before %s", results[i-1].name, r.name)
+		}
+		if r.status != want[r.name] {
+			t.Errorf("%s: status = %q, want %q (%s)", r.name, r.status, want[r.name], r.detail)
+		}
+		if r.status != compatCompatible && r.detail == "" {
+			t.Errorf("%s: expected a detail explaining the status", r.name)
+		}
+	}
+
+	// Unreachable plugins keep the version recorded in the lock file.
+	table := renderPluginCompatTable(results)
+	for _, row := range []string{"broken", "input", "0.9.0", "unreachable"} {
+		if !strings.Contains(table, row) {
+			t.Errorf("table missing %q:\n%s", row, table)
+		}
+	}
+	if !strings.Contains(table, protocol.ProtocolVersion) || !strings.Contains(table, "too new") {
+		t.Errorf("table missing protocol versions or statuses:\n%s", table)
+	}
+}
+
+func TestRunPluginCheckCompatLockErrors(t *testing.T) {
+	if _, _, err := loadTestPluginLock(t, `{"version": "2"}`); err == nil {
+		t.Fatal("loadPluginLock() with version 2 should fail")
+	}
+	if err := runPluginCheckCompat(nil, nil); err == nil || !strings.Contains(err.Error(), "failed to load plugin lock") {
+		t.Errorf("runPluginCheckCompat() with an unsupported lock error = %v, want the load error", err)
+	}
+
+	pluginLockPath = filepath.Join(t.TempDir(), PluginLockFile)
+	if err := runPluginCheckCompat(nil, nil); err != nil {
+		t.Errorf("runPluginCheckCompat() without a lock file error = %v, want nil", err)
+	}
+}
+
+func TestClassifyProtocolVersion(t *testing.T) {
+	if status, detail := classifyProtocolVersion(protocol.ProtocolVersion); status != compatCompatible || detail != "" {
+		t.Errorf("current version = %q (%s), want compatible", status, detail)
+	}
+	if status, _ := classifyProtocolVersion(""); status != compatInvalid {
+		t.Errorf("empty version = %q, want invalid", status)
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/plugin/manager"
 )
 
 // runPluginEnable enables a plugin.
@@ -18,12 +21,20 @@ func runPluginEnable(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load or create plugin lock.
-	lock, lockPath := loadOrCreatePluginLock()
+	lock, lockPath, err := loadOrCreatePluginLock()
+	if err != nil {
+		return fmt.Errorf("failed to load plugin lock: %w", err)
+	}
 
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Using lock file: %s\n", lockPath)
 	}
 
+	// Handle type-scoped "all" (--type input|output).
+	if pluginName == pluginTypeAll && cmd.Flags().Changed("type") {
+		return setAllOfType(lock, lockPath, pluginType, true)
+	}
+
 	// Check if plugin is an input plugin (only allow output plugins to be enabled/disabled).
 	if pluginName != pluginTypeAll {
 		if pluginType := getPluginType(lock, pluginName); pluginType == "input" {
@@ -91,12 +102,20 @@ func runPluginDisable(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load or create plugin lock.
-	lock, lockPath := loadOrCreatePluginLock()
+	lock, lockPath, err := loadOrCreatePluginLock()
+	if err != nil {
+		return fmt.Errorf("failed to load plugin lock: %w", err)
+	}
 
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Using lock file: %s\n", lockPath)
 	}
 
+	// Handle type-scoped "all" (--type input|output).
+	if pluginName == pluginTypeAll && cmd.Flags().Changed("type") {
+		return setAllOfType(lock, lockPath, pluginType, false)
+	}
+
 	// Check if plugin is an input plugin (only allow output plugins to be enabled/disabled).
 	if pluginName != pluginTypeAll && pluginName != "all" {
 		if pluginType := getPluginType(lock, pluginName); pluginType == "input" {
@@ -155,6 +174,65 @@ func runPluginDisable(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// setAllOfType enables or disables every plugin of one type, leaving plugins of
+// other types unchanged. With --clear it only removes "<type>:all" from the
+// opposite list.
+func setAllOfType(lock *PluginLock, lockPath, pluginType string, enable bool) error {
+	if pluginType != "input" && pluginType != pluginTypeOutput {
+		return fmt.Errorf("invalid plugin type %q (valid: input, output)", pluginType)
+	}
+	typeAll := pluginType + ":" + pluginTypeAll
+
+	switch {
+	case enable && pluginClear:
+		lock.DisabledPlugins = removeFromList(lock.DisabledPlugins, typeAll)
+	case enable:
+		lock.DisabledPlugins = removePluginsOfType(lock, manager.WithoutAll(lock.DisabledPlugins, pluginType), pluginType)
+		if !containsPlugin(lock.EnabledPlugins, typeAll) {
+			lock.EnabledPlugins = append(lock.EnabledPlugins, typeAll)
+		}
+	case pluginClear:
+		lock.EnabledPlugins = removeFromList(lock.EnabledPlugins, typeAll)
+	default:
+		lock.EnabledPlugins = removePluginsOfType(lock, manager.WithoutAll(lock.EnabledPlugins, pluginType), pluginType)
+		if !containsPlugin(lock.DisabledPlugins, typeAll) {
+			lock.DisabledPlugins = append(lock.DisabledPlugins, typeAll)
+		}
+	}
+
+	if err := savePluginLock(lockPath, lock); err != nil {
+		return fmt.Errorf("failed to save plugin lock: %w", err)
+	}
+
+	switch {
+	case pluginClear && enable:
+		fmt.Printf("Cleared '%s' from disabled list\n", typeAll)
+	case pluginClear:
+		fmt.Printf("Cleared '%s' from enabled list\n", typeAll)
+	case enable:
+		fmt.Printf("All %s plugins enabled\n", pluginType)
+	default:
+		fmt.Printf("All %s plugins disabled\n", pluginType)
+	}
+	return nil
+}
+
+// removePluginsOfType removes the plugins of pluginType from a list, matching
+// both "<type>:<name>" entries and bare plugin names.
+func removePluginsOfType(lock *PluginLock, list []string, pluginType string) []string {
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if strings.HasPrefix(item, pluginType+":") {
+			continue
+		}
+		if !strings.Contains(item, ":") && getPluginType(lock, item) == pluginType {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
 // runPluginClear clears plugin configuration.
 func runPluginClear(cmd *cobra.Command, args []string) error {
 	verbose, err := cmd.Flags().GetBool("verbose")
@@ -163,7 +241,10 @@ func runPluginClear(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load or create plugin lock.
-	lock, lockPath := loadOrCreatePluginLock()
+	lock, lockPath, err := loadOrCreatePluginLock()
+	if err != nil {
+		return fmt.Errorf("failed to load plugin lock: %w", err)
+	}
 
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Using lock file: %s\n", lockPath)
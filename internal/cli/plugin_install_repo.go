@@ -44,7 +44,7 @@ Examples:
   tinct plugins install random --version 0.0.2         # Install specific version
   tinct plugins install random --force                 # Force reinstall`,
 	Args: cobra.ExactArgs(1),
-	RunE: runPluginInstall,
+	RunE: withPluginLockFile(runPluginInstall),
 }
 
 func init() {
@@ -115,7 +115,10 @@ func runPluginInstall(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load or create plugin lock.
-	lock, lockPath := loadOrCreatePluginLock()
+	lock, lockPath, err := loadOrCreatePluginLock()
+	if err != nil {
+		return fmt.Errorf("failed to load plugin lock: %w", err)
+	}
 
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Using lock file: %s\n", lockPath)
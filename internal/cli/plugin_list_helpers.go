@@ -4,6 +4,7 @@ package cli
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/jmylchreest/tinct/internal/plugin/manager"
 	"github.com/jmylchreest/tinct/internal/plugin/protocol"
@@ -81,19 +82,19 @@ func (c *pluginCollector) buildPluginInfo(pluginType, name, version, description
 }
 
 // determinePluginStatus determines the status of a plugin (enabled/disabled/on-demand).
-func (c *pluginCollector) determinePluginStatus(_ /* pluginType */, pluginName string) string {
+func (c *pluginCollector) determinePluginStatus(pluginType, pluginName string) string {
 	if c.lock == nil {
 		return "O" // on-demand
 	}
 
 	// Check disabled list.
-	if c.isInList(c.lock.DisabledPlugins, pluginName) {
+	if c.isInList(c.lock.DisabledPlugins, pluginType, pluginName) {
 		return "D" // disabled
 	}
 
 	// Check enabled list.
 	if len(c.lock.EnabledPlugins) > 0 {
-		if c.isInList(c.lock.EnabledPlugins, pluginName) {
+		if c.isInList(c.lock.EnabledPlugins, pluginType, pluginName) {
 			return "E" // enabled
 		}
 		return "O" // on-demand
@@ -102,10 +103,10 @@ func (c *pluginCollector) determinePluginStatus(_ /* pluginType */, pluginName s
 	return "O" // on-demand
 }
 
-// isInList checks if a plugin name is in a list.
-func (c *pluginCollector) isInList(list []string, name string) bool {
+// isInList checks if a plugin name is in a list, including "all" and "<type>:all".
+func (c *pluginCollector) isInList(list []string, pluginType, name string) bool {
 	for _, item := range list {
-		if item == name || item == pluginTypeAll {
+		if item == name || item == pluginType+":"+name || item == pluginTypeAll || item == pluginType+":"+pluginTypeAll {
 			return true
 		}
 	}
@@ -251,26 +252,67 @@ func collectAllPlugins(mgr *manager.Manager, lock *PluginLock) []pluginInfo {
 	return collector.getSortedPlugins()
 }
 
-// displayPluginTable displays plugins in a formatted table.
-func displayPluginTable(plugins []pluginInfo, showPath bool) {
-	var headers []string
-	if showPath {
-		headers = []string{"", "S", "TYPE", "PLUGIN", "VERSION", "C", "PATH"}
-	} else {
-		headers = []string{"", "S", "TYPE", "PLUGIN", "VERSION", "C", "DESCRIPTION"}
-	}
-
-	tbl := NewTable(headers)
+// pluginListFilter narrows the plugins shown by "tinct plugins list".
+type pluginListFilter struct {
+	pluginType   string // input or output; empty for both
+	enabledOnly  bool
+	externalOnly bool
+}
 
-	// Enable terminal-aware column sizing
-	// Last column (description or path) will automatically size to fit terminal width
-	tbl.EnableTerminalAwareWidth(6, 40) // Min width of 40 chars
+// validate checks the filter's plugin type.
+func (f pluginListFilter) validate() error {
+	switch f.pluginType {
+	case "", "input", "output":
+		return nil
+	default:
+		return fmt.Errorf("invalid plugin type %q (valid: input, output)", f.pluginType)
+	}
+}
 
+// apply returns the plugins that match every filter, in their original order.
+func (f pluginListFilter) apply(plugins []pluginInfo) []pluginInfo {
+	matched := make([]pluginInfo, 0, len(plugins))
 	for _, p := range plugins {
-		addPluginToTable(tbl, p, showPath)
+		if f.pluginType != "" && p.pluginType != f.pluginType {
+			continue
+		}
+		if f.enabledOnly && p.status != "E" {
+			continue
+		}
+		if f.externalOnly && !p.isExternal {
+			continue
+		}
+		matched = append(matched, p)
 	}
+	return matched
+}
+
+// groupPluginsByType splits sorted plugins into consecutive runs of the same type.
+func groupPluginsByType(plugins []pluginInfo) [][]pluginInfo {
+	var groups [][]pluginInfo
+	for i, p := range plugins {
+		if i == 0 || p.pluginType != plugins[i-1].pluginType {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], p)
+	}
+	return groups
+}
 
-	fmt.Print(tbl.Render())
+// displayPluginTable displays plugins in a formatted table, or a table per plugin
+// type when grouped.
+func displayPluginTable(plugins []pluginInfo, showPath, group bool) {
+	if group {
+		for i, g := range groupPluginsByType(plugins) {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s%s plugins (%d)\n\n", strings.ToUpper(g[0].pluginType[:1]), g[0].pluginType[1:], len(g))
+			fmt.Print(renderPluginTable(g, showPath))
+		}
+	} else {
+		fmt.Print(renderPluginTable(plugins, showPath))
+	}
 
 	// Print legends
 	fmt.Println()
@@ -286,6 +328,28 @@ func displayPluginTable(plugins []pluginInfo, showPath bool) {
 	fmt.Println("C = Compatible with current tinct (Y/N)")
 }
 
+// renderPluginTable renders plugins as a table.
+func renderPluginTable(plugins []pluginInfo, showPath bool) string {
+	var headers []string
+	if showPath {
+		headers = []string{"", "S", "TYPE", "PLUGIN", "VERSION", "C", "PATH"}
+	} else {
+		headers = []string{"", "S", "TYPE", "PLUGIN", "VERSION", "C", "DESCRIPTION"}
+	}
+
+	tbl := NewTable(headers)
+
+	// Enable terminal-aware column sizing
+	// Last column (description or path) will automatically size to fit terminal width
+	tbl.EnableTerminalAwareWidth(6, 40) // Min width of 40 chars
+
+	for _, p := range plugins {
+		addPluginToTable(tbl, p, showPath)
+	}
+
+	return tbl.Render()
+}
+
 // addPluginToTable adds a single plugin to the table.
 func addPluginToTable(tbl *Table, p pluginInfo, showPath bool) {
 	marker := ""
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+)
+
+// pluginNames returns "type:name" for each plugin.
+func pluginNames(plugins []pluginInfo) []string {
+	names := make([]string, len(plugins))
+	for i, p := range plugins {
+		names[i] = p.pluginType + ":" + p.name
+	}
+	return names
+}
+
+func TestPluginListFilter(t *testing.T) {
+	plugins := []pluginInfo{
+		{pluginType: "input", name: "image", status: "E"},
+		{pluginType: "input", name: "remote", status: "O", isExternal: true},
+		{pluginType: "output", name: "kitty", status: "E"},
+		{pluginType: "output", name: "notify", status: "E", isExternal: true},
+		{pluginType: "output", name: "waybar", status: "D"},
+	}
+
+	tests := []struct {
+		name   string
+		filter pluginListFilter
+		want   []string
+	}{
+		{"no filters", pluginListFilter{}, []string{"input:image", "input:remote", "output:kitty", "output:notify", "output:waybar"}},
+		{"input", pluginListFilter{pluginType: "input"}, []string{"input:image", "input:remote"}},
+		{"output", pluginListFilter{pluginType: "output"}, []string{"output:kitty", "output:notify", "output:waybar"}},
+		{"enabled only", pluginListFilter{enabledOnly: true}, []string{"input:image", "output:kitty", "output:notify"}},
+		{"external only", pluginListFilter{externalOnly: true}, []string{"input:remote", "output:notify"}},
+		{"combined", pluginListFilter{pluginType: "output", enabledOnly: true, externalOnly: true}, []string{"output:notify"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pluginNames(tt.filter.apply(plugins)); !slices.Equal(got, tt.want) {
+				t.Errorf("apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPluginListFilter_Validate(t *testing.T) {
+	for _, pluginType := range []string{"", "input", "output"} {
+		if err := (pluginListFilter{pluginType: pluginType}).validate(); err != nil {
+			t.Errorf("validate() with type %q error = %v", pluginType, err)
+		}
+	}
+	if err := (pluginListFilter{pluginType: "all"}).validate(); err == nil {
+		t.Error("validate() with type \"all\" should fail")
+	}
+}
+
+func TestPluginListFilter_EnabledFromLock(t *testing.T) {
+	lock := &PluginLock{
+		EnabledPlugins:  []string{"output:kitty", "image"},
+		DisabledPlugins: []string{"output:waybar"},
+	}
+
+	plugins := collectAllPlugins(createManagerFromLock(lock), lock)
+	got := pluginNames(pluginListFilter{enabledOnly: true}.apply(plugins))
+	if want := []string{"input:image", "output:kitty"}; !slices.Equal(got, want) {
+		t.Errorf("enabled plugins = %v, want %v", got, want)
+	}
+
+	got = pluginNames(pluginListFilter{pluginType: "output", enabledOnly: true}.apply(plugins))
+	if want := []string{"output:kitty"}; !slices.Equal(got, want) {
+		t.Errorf("enabled output plugins = %v, want %v", got, want)
+	}
+}
+
+func TestGroupPluginsByType(t *testing.T) {
+	plugins := []pluginInfo{
+		{pluginType: "input", name: "file"},
+		{pluginType: "input", name: "image"},
+		{pluginType: "output", name: "kitty"},
+	}
+
+	groups := groupPluginsByType(plugins)
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+	if got := pluginNames(groups[0]); !slices.Equal(got, []string{"input:file", "input:image"}) {
+		t.Errorf("input group = %v", got)
+	}
+	if got := pluginNames(groups[1]); !slices.Equal(got, []string{"output:kitty"}) {
+		t.Errorf("output group = %v", got)
+	}
+	if groups := groupPluginsByType(nil); len(groups) != 0 {
+		t.Errorf("groupPluginsByType(nil) = %v, want no groups", groups)
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jmylchreest/tinct/internal/plugin/repository"
+)
+
+// pluginLockVersion is the current plugin lock file schema version.
+// Lock files without a version are treated as version 0.
+const pluginLockVersion = 1
+
+// pluginLockMigrations upgrades a lock file by one schema version.
+// The migration at index i upgrades a version i lock file to version i+1.
+var pluginLockMigrations = []func(*PluginLock){
+	migratePluginLockV0,
+}
+
+// migratePluginLock upgrades lock to the current schema version in place.
+// The upgraded lock is written with the new version the next time it is saved.
+func migratePluginLock(lock *PluginLock) error {
+	version := 0
+	if lock.Version != "" {
+		v, err := strconv.Atoi(lock.Version)
+		if err != nil || v < 0 {
+			return fmt.Errorf("invalid plugin lock file version %q", lock.Version)
+		}
+		version = v
+	}
+
+	if version > pluginLockVersion {
+		return fmt.Errorf("plugin lock file version %d is newer than supported version %d, upgrade tinct", version, pluginLockVersion)
+	}
+
+	for ; version < pluginLockVersion; version++ {
+		pluginLockMigrations[version](lock)
+	}
+	// Older builds wrote version "1" lock files that still carry source_legacy,
+	// so the (idempotent) source conversion also runs for current-version locks.
+	migratePluginLockV0(lock)
+	lock.Version = strconv.Itoa(pluginLockVersion)
+
+	return nil
+}
+
+// migratePluginLockV0 converts string-based legacy sources to structured sources.
+// It is idempotent: plugins without a legacy source are left untouched.
+func migratePluginLockV0(lock *PluginLock) {
+	for _, meta := range lock.ExternalPlugins {
+		if meta == nil || meta.SourceLegacy == "" {
+			continue
+		}
+		if meta.Source == nil {
+			meta.Source = pluginSourceFromString(meta.SourceLegacy)
+		}
+		meta.SourceLegacy = ""
+	}
+}
+
+// pluginSourceFromString converts a source as given to "tinct plugins add" into a
+// structured source. It classifies the source without touching the network or
+// filesystem: HTTP(S) URLs are http sources, other ".git" or "git@" sources are git
+// repositories and anything else is a local path.
+func pluginSourceFromString(source string) *repository.PluginSource {
+	switch {
+	case isHTTPURL(source):
+		return &repository.PluginSource{Type: sourceTypeHTTP, URL: source}
+	case strings.HasPrefix(source, "git@") || strings.HasSuffix(source, ".git") || strings.Contains(source, ".git:"):
+		return &repository.PluginSource{Type: sourceTypeGit, URL: source}
+	default:
+		return &repository.PluginSource{Type: sourceTypeLocal, OriginalPath: source}
+	}
+}
+
+// addedPluginSource returns the structured source recorded for "tinct plugins add".
+// Local sources record the resolved absolute path so the plugin can be reinstalled
+// from any working directory.
+func addedPluginSource(source, resolvedPath, forcedSourceType string) *repository.PluginSource {
+	ps := pluginSourceFromString(source)
+	if forcedSourceType != "" && forcedSourceType != ps.Type {
+		ps = &repository.PluginSource{Type: forcedSourceType}
+		if forcedSourceType == sourceTypeLocal {
+			ps.OriginalPath = source
+		} else {
+			ps.URL = source
+		}
+	}
+	if ps.Type == sourceTypeLocal && filepath.IsAbs(resolvedPath) {
+		ps.OriginalPath = resolvedPath
+	}
+	return ps
+}
@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/plugin/repository"
+)
+
+// v0PluginLock is a lock file written before the schema was versioned.
+const v0PluginLock = `{
+  "enabled_plugins": ["output:notify"],
+  "external_plugins": {
+    "notify": {
+      "name": "notify",
+      "path": "/home/user/.local/share/tinct/plugins/notify",
+      "type": "output",
+      "source_legacy": "/home/user/src/notify.sh"
+    },
+    "wob": {
+      "name": "wob",
+      "path": "/home/user/.local/share/tinct/plugins/wob",
+      "type": "output",
+      "source_legacy": "https://example.com/releases/wob.tar.gz"
+    },
+    "pywal": {
+      "name": "pywal",
+      "path": "/home/user/.local/share/tinct/plugins/pywal.sh",
+      "type": "output",
+      "source_legacy": "https://github.com/user/tinct-plugins.git:pywal.sh"
+    },
+    "random": {
+      "name": "random",
+      "path": "/home/user/.local/share/tinct/plugins/random",
+      "type": "input",
+      "source": {"type": "repository", "repository": "official", "plugin": "random", "version": "1.0.0"},
+      "source_legacy": "random"
+    },
+    "manual": {
+      "name": "manual",
+      "path": "/home/user/bin/manual",
+      "type": "output"
+    }
+  }
+}`
+
+// loadTestPluginLock writes content to a temporary lock file and loads it.
+func loadTestPluginLock(t *testing.T, content string) (*PluginLock, string, error) {
+	t.Helper()
+	previous := pluginLockPath
+	t.Cleanup(func() { pluginLockPath = previous })
+
+	pluginLockPath = filepath.Join(t.TempDir(), PluginLockFile)
+	if err := os.WriteFile(pluginLockPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write lock file: %v", err)
+	}
+	return loadPluginLock()
+}
+
+func TestLoadPluginLock_MigratesV0(t *testing.T) {
+	lock, lockPath, err := loadTestPluginLock(t, v0PluginLock)
+	if err != nil {
+		t.Fatalf("loadPluginLock() error = %v", err)
+	}
+
+	if lock.Version != "1" {
+		t.Errorf("Version = %q, want 1", lock.Version)
+	}
+
+	want := map[string]*repository.PluginSource{
+		"notify": {Type: sourceTypeLocal, OriginalPath: "/home/user/src/notify.sh"},
+		"wob":    {Type: sourceTypeHTTP, URL: "https://example.com/releases/wob.tar.gz"},
+		"pywal":  {Type: sourceTypeHTTP, URL: "https://github.com/user/tinct-plugins.git:pywal.sh"},
+		"random": {Type: sourceTypeRepository, Repository: "official", Plugin: "random", Version: "1.0.0"},
+	}
+	for name, meta := range lock.ExternalPlugins {
+		if meta.SourceLegacy != "" {
+			t.Errorf("%s: SourceLegacy = %q, want it cleared", name, meta.SourceLegacy)
+		}
+		if want[name] == nil {
+			if meta.Source != nil {
+				t.Errorf("%s: Source = %+v, want nil for a plugin without a source", name, meta.Source)
+			}
+			continue
+		}
+		if meta.Source == nil || *meta.Source != *want[name] {
+			t.Errorf("%s: Source = %+v, want %+v", name, meta.Source, want[name])
+		}
+	}
+
+	// Saving writes the current schema, which loads back unchanged.
+	if err := savePluginLock(lockPath, lock); err != nil {
+		t.Fatalf("savePluginLock() error = %v", err)
+	}
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		t.Fatalf("failed to read saved lock file: %v", err)
+	}
+	if !strings.Contains(string(data), `"version": "1"`) || strings.Contains(string(data), "source_legacy") {
+		t.Errorf("saved lock file is not in the current schema:\n%s", data)
+	}
+
+	reloaded, _, err := loadTestPluginLock(t, string(data))
+	if err != nil {
+		t.Fatalf("loadPluginLock() of saved lock error = %v", err)
+	}
+	if got := reloaded.ExternalPlugins["notify"].Source; got == nil || *got != *want["notify"] {
+		t.Errorf("reloaded notify Source = %+v, want %+v", got, want["notify"])
+	}
+}
+
+func TestLoadPluginLock_MigratesLegacySourceInV1(t *testing.T) {
+	lock, _, err := loadTestPluginLock(t, `{
+  "version": "1",
+  "external_plugins": {
+    "notify": {"name": "notify", "path": "/bin/notify", "type": "output", "source_legacy": "/home/user/src/notify.sh"}
+  }
+}`)
+	if err != nil {
+		t.Fatalf("loadPluginLock() error = %v", err)
+	}
+
+	meta := lock.ExternalPlugins["notify"]
+	want := repository.PluginSource{Type: sourceTypeLocal, OriginalPath: "/home/user/src/notify.sh"}
+	if meta.SourceLegacy != "" || meta.Source == nil || *meta.Source != want {
+		t.Errorf("notify = {Source: %+v, SourceLegacy: %q}, want Source %+v and no legacy source", meta.Source, meta.SourceLegacy, want)
+	}
+}
+
+func TestLoadAndConfigurePlugins_WarnsOnLockError(t *testing.T) {
+	if _, _, err := loadTestPluginLock(t, `{"version": "1.0"}`); err == nil {
+		t.Fatal("loadPluginLock() with version 1.0 should fail")
+	}
+
+	var err error
+	stderr := captureStderr(t, func() { err = loadAndConfigurePlugins() })
+	if err != nil {
+		t.Errorf("loadAndConfigurePlugins() error = %v, want nil", err)
+	}
+	if !strings.Contains(stderr, "Warning: failed to load plugin lock") || !strings.Contains(stderr, `"1.0"`) {
+		t.Errorf("loadAndConfigurePlugins() should warn about the invalid lock, stderr:\n%s", stderr)
+	}
+
+	pluginLockPath = filepath.Join(t.TempDir(), PluginLockFile)
+	stderr = captureStderr(t, func() { err = loadAndConfigurePlugins() })
+	if err != nil || stderr != "" {
+		t.Errorf("loadAndConfigurePlugins() without a lock file = %v, stderr %q, want no error or warning", err, stderr)
+	}
+}
+
+func TestLoadPluginLock_UnsupportedVersion(t *testing.T) {
+	for _, version := range []string{"2", "v1", "-1"} {
+		_, _, err := loadTestPluginLock(t, `{"version": "`+version+`"}`)
+		if err == nil {
+			t.Errorf("loadPluginLock() with version %q should fail", version)
+		}
+	}
+}
+
+func TestLoadOrCreatePluginLock_RefusesUnsupportedLock(t *testing.T) {
+	const tooNew = `{"version": "2", "enabled_plugins": ["kitty"], "external_plugins": {"notify": {"name": "notify", "path": "/bin/notify", "type": "output"}}}`
+	if _, _, err := loadTestPluginLock(t, tooNew); err == nil {
+		t.Fatal("loadPluginLock() with version 2 should fail")
+	}
+
+	if _, _, err := loadOrCreatePluginLock(); err == nil || !strings.Contains(err.Error(), "upgrade tinct") {
+		t.Errorf("loadOrCreatePluginLock() error = %v, want a hint to upgrade tinct", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("verbose", false, "")
+	if err := runPluginEnable(cmd, []string{"hyprland"}); err == nil {
+		t.Error("runPluginEnable() with an unsupported lock file should fail")
+	}
+
+	data, err := os.ReadFile(pluginLockPath)
+	if err != nil {
+		t.Fatalf("failed to read lock file: %v", err)
+	}
+	if string(data) != tooNew {
+		t.Errorf("unsupported lock file was modified:\n%s", data)
+	}
+}
+
+func TestLoadOrCreatePluginLock_MissingLock(t *testing.T) {
+	previous := pluginLockPath
+	t.Cleanup(func() { pluginLockPath = previous })
+	pluginLockPath = filepath.Join(t.TempDir(), PluginLockFile)
+
+	lock, lockPath, err := loadOrCreatePluginLock()
+	if err != nil {
+		t.Fatalf("loadOrCreatePluginLock() error = %v", err)
+	}
+	if lockPath != pluginLockPath || lock.Version != "1" || len(lock.ExternalPlugins) != 0 {
+		t.Errorf("loadOrCreatePluginLock() = %+v at %s, want a new lock at %s", lock, lockPath, pluginLockPath)
+	}
+}
+
+func TestPluginSourceFromString(t *testing.T) {
+	tests := []struct {
+		source string
+		want   repository.PluginSource
+	}{
+		{"./plugins/notify.sh", repository.PluginSource{Type: sourceTypeLocal, OriginalPath: "./plugins/notify.sh"}},
+		{"http://example.com/plugin", repository.PluginSource{Type: sourceTypeHTTP, URL: "http://example.com/plugin"}},
+		{"git@github.com:user/plugins.git", repository.PluginSource{Type: sourceTypeGit, URL: "git@github.com:user/plugins.git"}},
+		{"/src/plugins.git:bin/notify", repository.PluginSource{Type: sourceTypeGit, URL: "/src/plugins.git:bin/notify"}},
+	}
+
+	for _, tt := range tests {
+		if got := pluginSourceFromString(tt.source); *got != tt.want {
+			t.Errorf("pluginSourceFromString(%q) = %+v, want %+v", tt.source, *got, tt.want)
+		}
+	}
+}
+
+func TestAddedPluginSource(t *testing.T) {
+	got := addedPluginSource("notify.sh", "/home/user/notify.sh", "")
+	if want := (repository.PluginSource{Type: sourceTypeLocal, OriginalPath: "/home/user/notify.sh"}); *got != want {
+		t.Errorf("local source = %+v, want %+v", *got, want)
+	}
+
+	got = addedPluginSource("https://example.com/plugins", "/tmp/tinct-plugin-1/notify", sourceTypeGit)
+	if want := (repository.PluginSource{Type: sourceTypeGit, URL: "https://example.com/plugins"}); *got != want {
+		t.Errorf("forced git source = %+v, want %+v", *got, want)
+	}
+}
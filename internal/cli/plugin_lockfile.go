@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// withPluginLockFile wraps a command that modifies the plugin lock file so it runs
+// while holding an exclusive lock on it. Concurrent tinct processes then serialise
+// their read-modify-write of the lock file instead of overwriting each other's changes.
+func withPluginLockFile(run func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		unlock, err := lockPluginLock(writablePluginLockPath())
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		return run(cmd, args)
+	}
+}
+
+// lockPluginLock blocks until it holds an exclusive lock on the plugin lock file at
+// path, and returns a function that releases it. The lock is taken on a separate
+// "<path>.lock" file, which is left in place, because the lock file itself may not
+// exist yet.
+func lockPluginLock(path string) (unlock func(), err error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600) // #nosec G304 - Lock file path controlled by application
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin lock file lock: %w", err)
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock plugin lock file: %w", err)
+	}
+
+	return func() {
+		_ = unlockFile(f)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/plugin/protocol"
+)
+
+// Environment variables used to run TestPluginAddProcess as a child tinct process.
+const (
+	envTestPluginSource = "TINCT_TEST_PLUGIN_SOURCE"
+	envTestPluginLock   = "TINCT_TEST_PLUGIN_LOCK"
+)
+
+// TestPluginAddProcess runs "tinct plugins add" for TestConcurrentPluginAdd in a
+// separate process. It does nothing when run directly.
+func TestPluginAddProcess(t *testing.T) {
+	source := os.Getenv(envTestPluginSource)
+	if source == "" {
+		t.Skip("helper process for TestConcurrentPluginAdd")
+	}
+
+	pluginLockPath = os.Getenv(envTestPluginLock)
+	cmd := &cobra.Command{RunE: withPluginLockFile(runPluginAdd)}
+	cmd.Flags().Bool("verbose", false, "")
+	if err := cmd.RunE(cmd, []string{source}); err != nil {
+		t.Fatalf("plugins add %s: %v", source, err)
+	}
+}
+
+func TestConcurrentPluginAdd(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, PluginLockFile)
+
+	// Start several processes adding a different plugin each at the same time.
+	const plugins = 6
+	var wg sync.WaitGroup
+	errs := make([]error, plugins)
+	for i := range plugins {
+		source := filepath.Join(dir, fmt.Sprintf("plugin%d.sh", i))
+		script := fmt.Sprintf("#!/bin/sh\necho '{\"name\": \"plugin%d\", \"type\": \"output\", \"version\": \"1.0.0\", \"protocol_version\": \"%s\"}'\n",
+			i, protocol.ProtocolVersion)
+		if err := os.WriteFile(source, []byte(script), 0o755); err != nil { // #nosec G306 - Test plugin needs execute permission
+			t.Fatalf("failed to write plugin: %v", err)
+		}
+
+		cmd := exec.Command(os.Args[0], "-test.run=^TestPluginAddProcess$") // #nosec G204 - Re-runs the test binary
+		cmd.Env = append(os.Environ(),
+			"HOME="+dir,
+			envTestPluginSource+"="+source,
+			envTestPluginLock+"="+lockPath,
+		)
+		wg.Go(func() {
+			if out, err := cmd.CombinedOutput(); err != nil {
+				errs[i] = fmt.Errorf("%w\n%s", err, out)
+			}
+		})
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("adding plugin%d failed: %v", i, err)
+		}
+	}
+
+	previous := pluginLockPath
+	t.Cleanup(func() { pluginLockPath = previous })
+	pluginLockPath = lockPath
+
+	lock, _, err := loadPluginLock()
+	if err != nil {
+		t.Fatalf("loadPluginLock() error = %v", err)
+	}
+	for i := range plugins {
+		if _, ok := lock.ExternalPlugins[fmt.Sprintf("plugin%d", i)]; !ok {
+			t.Errorf("plugin%d missing from the lock file, a concurrent add overwrote it", i)
+		}
+	}
+}
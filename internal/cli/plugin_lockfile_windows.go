@@ -0,0 +1,18 @@
+//go:build windows
+
+package cli
+
+import (
+	"os"
+)
+
+// lockFile is a no-op on Windows, where concurrent plugin lock file updates are
+// not serialised.
+func lockFile(_ *os.File) error {
+	return nil
+}
+
+// unlockFile is a no-op on Windows.
+func unlockFile(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,192 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/manager"
+	"github.com/jmylchreest/tinct/internal/plugin/protocol"
+)
+
+var (
+	// Dump-protocol command flags.
+	dumpProtocolType string
+	dumpProtocolArgs string
+	dumpProtocolRaw  bool
+)
+
+// pluginDumpProtocolCmd prints the data tinct sends to an external plugin.
+var pluginDumpProtocolCmd = &cobra.Command{
+	Use:   "dump-protocol <plugin-name>",
+	Short: "Show the data tinct sends to an external plugin",
+	Long: `Show the exact data tinct sends to an external plugin, for plugin developers.
+
+Input plugins receive InputOptions and output plugins receive PaletteData, built
+here from a fixed sample palette. For go-plugin plugins the handshake config is
+included; json-stdio plugins read the payload from stdin.
+
+The plugin is queried with --plugin-info to detect its protocol but is not run.
+
+Examples:
+  tinct plugins dump-protocol my-plugin
+  tinct plugins dump-protocol my-plugin --args '{"mode":"fast"}'
+  tinct plugins dump-protocol my-plugin --raw | ./my-plugin  # replay the payload`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPluginDumpProtocol,
+}
+
+func init() {
+	pluginDumpProtocolCmd.Flags().StringVarP(&dumpProtocolType, "type", "t", "", "plugin type (input or output) when a name is registered as both")
+	pluginDumpProtocolCmd.Flags().StringVar(&dumpProtocolArgs, "args", "", "plugin arguments as a JSON object, as given to generate --plugin-args")
+	pluginDumpProtocolCmd.Flags().BoolVar(&dumpProtocolRaw, "raw", false, "print only the payload, exactly as written to a json-stdio plugin's stdin")
+
+	pluginsCmd.AddCommand(pluginDumpProtocolCmd)
+}
+
+// protocolDump is the document printed by dump-protocol.
+type protocolDump struct {
+	Plugin    string             `json:"plugin"`
+	Type      string             `json:"type"`
+	Protocol  string             `json:"protocol"`
+	Handshake *protocolHandshake `json:"handshake,omitempty"`
+	Payload   json.RawMessage    `json:"payload"`
+}
+
+// protocolHandshake describes the go-plugin handshake a plugin must serve.
+type protocolHandshake struct {
+	ProtocolVersion  uint   `json:"protocol_version"`
+	MagicCookieKey   string `json:"magic_cookie_key"`
+	MagicCookieValue string `json:"magic_cookie_value"`
+	PluginName       string `json:"plugin_name"`
+	Protocol         string `json:"protocol"`
+}
+
+// runPluginDumpProtocol executes the dump-protocol command.
+func runPluginDumpProtocol(_ *cobra.Command, args []string) error {
+	var pluginArgs map[string]any
+	if dumpProtocolArgs != "" {
+		if err := json.Unmarshal([]byte(dumpProtocolArgs), &pluginArgs); err != nil {
+			return fmt.Errorf("invalid --args (must be a JSON object): %w", err)
+		}
+	}
+
+	dump, err := buildProtocolDump(sharedPluginManager, args[0], dumpProtocolType, pluginArgs)
+	if err != nil {
+		return err
+	}
+
+	if dumpProtocolRaw {
+		fmt.Println(string(dump.Payload))
+		return nil
+	}
+
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to convert protocol dump to JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// buildProtocolDump builds the payload for the named external plugin the same way the
+// executor does when tinct runs it. pluginType may be empty unless the name is
+// registered as both an input and an output plugin.
+func buildProtocolDump(mgr *manager.Manager, name, pluginType string, pluginArgs map[string]any) (*protocolDump, error) {
+	inputPlugin, isInput := mgr.GetInputPlugin(name)
+	outputPlugin, isOutput := mgr.GetOutputPlugin(name)
+
+	switch pluginType {
+	case "":
+		if isInput && isOutput {
+			return nil, fmt.Errorf("%s is both an input and an output plugin, use --type to choose", name)
+		}
+	case "input":
+		isOutput = false
+	case "output":
+		isInput = false
+	default:
+		return nil, fmt.Errorf("invalid plugin type: %s (must be input or output)", pluginType)
+	}
+
+	var (
+		path    string
+		payload any
+	)
+	switch {
+	case isInput:
+		external, ok := inputPlugin.(*manager.ExternalInputPlugin)
+		if !ok {
+			return nil, fmt.Errorf("%s is a built-in plugin and does not use the plugin protocol", name)
+		}
+		pluginType, path = "input", external.Path()
+		if pluginArgs != nil {
+			external.SetArgs(pluginArgs)
+		}
+		payload = external.ProtocolOptions(input.GenerateOptions{})
+	case isOutput:
+		external, ok := outputPlugin.(*manager.ExternalOutputPlugin)
+		if !ok {
+			return nil, fmt.Errorf("%s is a built-in plugin and does not use the plugin protocol", name)
+		}
+		pluginType, path = "output", external.Path()
+		if pluginArgs != nil {
+			external.SetArgs(pluginArgs)
+		}
+		payload = external.ProtocolPalette(sampleProtocolPalette())
+	default:
+		return nil, fmt.Errorf("plugin not found: %s", name)
+	}
+
+	// The executor sends compact JSON, so the payload is kept exactly as marshalled.
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	detected, err := protocol.DetectProtocol(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect plugin protocol: %w", err)
+	}
+
+	dump := &protocolDump{
+		Plugin:   name,
+		Type:     pluginType,
+		Protocol: string(detected.Type),
+		Payload:  data,
+	}
+	if detected.Type == protocol.PluginTypeGoPlugin {
+		dump.Handshake = &protocolHandshake{
+			ProtocolVersion:  protocol.Handshake.ProtocolVersion,
+			MagicCookieKey:   protocol.Handshake.MagicCookieKey,
+			MagicCookieValue: protocol.Handshake.MagicCookieValue,
+			PluginName:       pluginType,
+			Protocol:         "netrpc",
+		}
+	}
+
+	return dump, nil
+}
+
+// sampleProtocolPalette returns the fixed dark palette used for dump-protocol output.
+func sampleProtocolPalette() *colour.CategorisedPalette {
+	raw := colour.NewPalette([]color.Color{
+		color.RGBA{R: 0x1e, G: 0x1e, B: 0x2e, A: 0xff},
+		color.RGBA{R: 0xcd, G: 0xd6, B: 0xf4, A: 0xff},
+		color.RGBA{R: 0x89, G: 0xb4, B: 0xfa, A: 0xff},
+		color.RGBA{R: 0xcb, G: 0xa6, B: 0xf7, A: 0xff},
+		color.RGBA{R: 0x94, G: 0xe2, B: 0xd5, A: 0xff},
+		color.RGBA{R: 0xf3, G: 0x8b, B: 0xa8, A: 0xff},
+		color.RGBA{R: 0xf9, G: 0xe2, B: 0xaf, A: 0xff},
+		color.RGBA{R: 0xa6, G: 0xe3, B: 0xa1, A: 0xff},
+	})
+
+	config := colour.DefaultCategorisationConfig()
+	config.ThemeType = colour.ThemeDark
+	return colour.Categorise(raw, config)
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/manager"
+)
+
+// writeCapturePlugin writes a json-stdio plugin script that copies its stdin to capture
+// and, for input plugins, replies with a single colour.
+func writeCapturePlugin(t *testing.T, dir, name, pluginType, pluginProtocol, capture string) string {
+	t.Helper()
+	script := `#!/bin/sh
+if [ "$1" = "--plugin-info" ]; then
+  echo '{"name":"` + name + `","type":"` + pluginType + `","version":"1.0.0","protocol_version":"0.0.1","plugin_protocol":"` + pluginProtocol + `"}'
+  exit 0
+fi
+cat > "` + capture + `"
+echo '{"colors":[{"r":1,"g":2,"b":3}]}'
+`
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil { // #nosec G306 - Test plugin must be executable
+		t.Fatalf("failed to write plugin script: %v", err)
+	}
+	return path
+}
+
+func TestBuildProtocolDumpMatchesExecutor(t *testing.T) {
+	dir := t.TempDir()
+	outputCapture := filepath.Join(dir, "output.json")
+	inputCapture := filepath.Join(dir, "input.json")
+
+	mgr := manager.NewBuilder().Build()
+	if err := mgr.RegisterExternalPlugin("capture-out", "output",
+		writeCapturePlugin(t, dir, "capture-out", "output", "json-stdio", outputCapture), ""); err != nil {
+		t.Fatalf("RegisterExternalPlugin(output) error = %v", err)
+	}
+	if err := mgr.RegisterExternalPlugin("capture-in", "input",
+		writeCapturePlugin(t, dir, "capture-in", "input", "json-stdio", inputCapture), ""); err != nil {
+		t.Fatalf("RegisterExternalPlugin(input) error = %v", err)
+	}
+
+	args := map[string]any{"mode": "fast"}
+
+	// Output plugin: the dumped payload must equal what Generate writes to stdin.
+	dump, err := buildProtocolDump(mgr, "capture-out", "", args)
+	if err != nil {
+		t.Fatalf("buildProtocolDump(output) error = %v", err)
+	}
+	if dump.Type != "output" || dump.Protocol != "json-stdio" || dump.Handshake != nil {
+		t.Errorf("dump = %+v, want output json-stdio without handshake", dump)
+	}
+	outputPlugin, _ := mgr.GetOutputPlugin("capture-out")
+	if _, err := outputPlugin.Generate(colour.NewThemeData(sampleProtocolPalette(), "", "")); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	assertCaptured(t, outputCapture, dump.Payload)
+
+	// Input plugin.
+	dump, err = buildProtocolDump(mgr, "capture-in", "input", args)
+	if err != nil {
+		t.Fatalf("buildProtocolDump(input) error = %v", err)
+	}
+	inputPlugin, _ := mgr.GetInputPlugin("capture-in")
+	if _, err := inputPlugin.Generate(context.Background(), input.GenerateOptions{}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	assertCaptured(t, inputCapture, dump.Payload)
+
+	var opts map[string]any
+	if err := json.Unmarshal(dump.Payload, &opts); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if got := opts["plugin_args"].(map[string]any)["mode"]; got != "fast" {
+		t.Errorf("plugin_args.mode = %v, want fast", got)
+	}
+}
+
+func TestBuildProtocolDumpGoPluginHandshake(t *testing.T) {
+	dir := t.TempDir()
+	mgr := manager.NewBuilder().Build()
+	if err := mgr.RegisterExternalPlugin("rpc-out", "output",
+		writeCapturePlugin(t, dir, "rpc-out", "output", "go-plugin", filepath.Join(dir, "unused")), ""); err != nil {
+		t.Fatalf("RegisterExternalPlugin() error = %v", err)
+	}
+
+	dump, err := buildProtocolDump(mgr, "rpc-out", "", nil)
+	if err != nil {
+		t.Fatalf("buildProtocolDump() error = %v", err)
+	}
+	if dump.Handshake == nil {
+		t.Fatal("go-plugin dump should include the handshake")
+	}
+	if dump.Handshake.MagicCookieKey != "TINCT_PLUGIN" || dump.Handshake.PluginName != "output" {
+		t.Errorf("handshake = %+v", dump.Handshake)
+	}
+}
+
+func TestBuildProtocolDumpErrors(t *testing.T) {
+	mgr := manager.NewBuilder().Build()
+
+	if _, err := buildProtocolDump(mgr, "kitty", "", nil); err == nil {
+		t.Error("expected error for a built-in plugin")
+	}
+	if _, err := buildProtocolDump(mgr, "missing", "", nil); err == nil {
+		t.Error("expected error for an unknown plugin")
+	}
+	if _, err := buildProtocolDump(mgr, "kitty", "filter", nil); err == nil {
+		t.Error("expected error for an invalid type")
+	}
+}
+
+// assertCaptured checks the bytes a plugin received on stdin equal want.
+func assertCaptured(t *testing.T, capture string, want []byte) {
+	t.Helper()
+	got, err := os.ReadFile(capture) // #nosec G304 - Test capture file
+	if err != nil {
+		t.Fatalf("failed to read captured stdin: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("plugin received:\n%s\ndump payload:\n%s", got, want)
+	}
+}
@@ -277,6 +277,8 @@ func reinstallPlugin(meta *ExternalPluginMeta) error {
 			return reinstallFromHTTP(meta)
 		case "local":
 			return reinstallFromLocal(meta)
+		case sourceTypeGit:
+			return reinstallFromGit(meta)
 		default:
 			return fmt.Errorf("unknown source type: %s", meta.Source.Type)
 		}
@@ -353,6 +355,20 @@ func reinstallFromLocal(meta *ExternalPluginMeta) error {
 	return nil
 }
 
+// reinstallFromGit installs a plugin from a git repository.
+func reinstallFromGit(meta *ExternalPluginMeta) error {
+	pluginDir, err := getPluginDirectory()
+	if err != nil {
+		return err
+	}
+
+	if _, err := installPluginFromSource(meta.Source.URL, meta.Name, pluginDir, "", false); err != nil {
+		return fmt.Errorf("failed to install from git: %w", err)
+	}
+
+	return nil
+}
+
 // reinstallFromLegacySource installs from legacy source string.
 func reinstallFromLegacySource(meta *ExternalPluginMeta) error {
 	source := meta.SourceLegacy
@@ -462,15 +478,13 @@ func calculateChecksum(path string) (string, error) {
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
-// getPluginDirectory returns the plugin installation directory.
+// getPluginDirectory returns the plugin installation directory, creating it if needed.
 func getPluginDirectory() (string, error) {
-	dataDir, err := os.UserHomeDir()
+	pluginDir, err := getPluginDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", fmt.Errorf("failed to get plugin directory: %w", err)
 	}
 
-	pluginDir := filepath.Join(dataDir, ".local", "share", "tinct", "plugins")
-
 	// Ensure directory exists.
 	if err := os.MkdirAll(pluginDir, 0o755); err != nil { // #nosec G301 - Plugin directory needs standard permissions
 		return "", fmt.Errorf("failed to create plugin directory: %w", err)
@@ -493,6 +507,8 @@ func formatPluginSource(source *repository.PluginSource) string {
 		return source.URL
 	case "local":
 		return source.OriginalPath
+	case sourceTypeGit:
+		return source.URL
 	default:
 		return source.Type
 	}
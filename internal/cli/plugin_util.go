@@ -11,13 +11,27 @@ import (
 	"github.com/jmylchreest/tinct/internal/plugin/repository"
 )
 
-// getPluginDir returns the plugin directory path.
+// PluginDirEnv names the environment variable that relocates the plugin directory.
+const PluginDirEnv = "TINCT_PLUGIN_DIR"
+
+// pluginDirFlag is the --plugin-dir flag value.
+var pluginDirFlag string
+
+// getPluginDir returns the absolute plugin directory path: --plugin-dir if set,
+// then TINCT_PLUGIN_DIR, then ~/.local/share/tinct/plugins.
 func getPluginDir() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+	dir := pluginDirFlag
+	if dir == "" {
+		dir = os.Getenv(PluginDirEnv)
 	}
-	return filepath.Join(home, ".local", "share", "tinct", "plugins"), nil
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "share", "tinct", "plugins")
+	}
+	return filepath.Abs(dir)
 }
 
 // queryPluginMetadata queries a plugin for its name, description, type, and version.
@@ -55,6 +69,8 @@ func formatPluginSourceString(source *repository.PluginSource) string {
 		return source.URL
 	case sourceTypeLocal:
 		return source.OriginalPath
+	case sourceTypeGit:
+		return source.URL
 	default:
 		return source.Type
 	}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/plugin/protocol"
+)
+
+// setPluginDirFlag sets --plugin-dir for the duration of the test.
+func setPluginDirFlag(t *testing.T, dir string) {
+	t.Helper()
+	previous := pluginDirFlag
+	t.Cleanup(func() { pluginDirFlag = previous })
+	pluginDirFlag = dir
+}
+
+func TestGetPluginDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		name string
+		env  string
+		flag string
+		want string
+	}{
+		{name: "default", want: filepath.Join(home, ".local", "share", "tinct", "plugins")},
+		{name: "env", env: "/srv/tinct/plugins", want: "/srv/tinct/plugins"},
+		{name: "flag overrides env", env: "/srv/tinct/plugins", flag: "/opt/plugins", want: "/opt/plugins"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(PluginDirEnv, tt.env)
+			setPluginDirFlag(t, tt.flag)
+
+			got, err := getPluginDir()
+			if err != nil {
+				t.Fatalf("getPluginDir() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("getPluginDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("relative paths are made absolute", func(t *testing.T) {
+		t.Setenv(PluginDirEnv, "sandbox")
+		setPluginDirFlag(t, "")
+
+		got, err := getPluginDir()
+		if err != nil {
+			t.Fatalf("getPluginDir() error = %v", err)
+		}
+		cwd, _ := os.Getwd()
+		if want := filepath.Join(cwd, "sandbox"); got != want {
+			t.Errorf("getPluginDir() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestPluginAddInstallsToPluginDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name string
+		flag bool
+	}{
+		{name: "env"},
+		{name: "flag", flag: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envDir := filepath.Join(t.TempDir(), "env-plugins")
+			flagDir := filepath.Join(t.TempDir(), "flag-plugins")
+			t.Setenv(PluginDirEnv, envDir)
+
+			wantDir := envDir
+			if tt.flag {
+				setPluginDirFlag(t, flagDir)
+				wantDir = flagDir
+			} else {
+				setPluginDirFlag(t, "")
+			}
+
+			previousLock := pluginLockPath
+			t.Cleanup(func() { pluginLockPath = previousLock })
+			pluginLockPath = filepath.Join(t.TempDir(), PluginLockFile)
+
+			source := writeMockPlugin(t, t.TempDir(), "sandboxed", pluginInfoJSON("sandboxed", protocol.ProtocolVersion), 0)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("verbose", false, "")
+			if err := runPluginAdd(cmd, []string{source}); err != nil {
+				t.Fatalf("runPluginAdd() error = %v", err)
+			}
+
+			wantPath := filepath.Join(wantDir, "sandboxed")
+			if _, err := os.Stat(wantPath); err != nil {
+				t.Errorf("plugin not installed to %s: %v", wantDir, err)
+			}
+
+			lock, _, err := loadPluginLock()
+			if err != nil {
+				t.Fatalf("loadPluginLock() error = %v", err)
+			}
+			meta, ok := lock.ExternalPlugins["sandboxed"]
+			if !ok {
+				t.Fatal("plugin missing from lock file")
+			}
+			if meta.Path != wantPath {
+				t.Errorf("lock file path = %q, want %q", meta.Path, wantPath)
+			}
+		})
+	}
+}
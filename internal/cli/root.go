@@ -3,10 +3,14 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/jmylchreest/tinct/internal/colour"
 	"github.com/jmylchreest/tinct/internal/plugin/manager"
+	"github.com/jmylchreest/tinct/internal/plugin/protocol"
 	"github.com/jmylchreest/tinct/internal/version"
 )
 
@@ -14,6 +18,36 @@ var (
 	// Global theme flag.
 	globalTheme string
 
+	// Global background selection mode flag.
+	globalBackground string
+
+	// Global semantic palette flag.
+	globalSemanticPalette string
+
+	// Global limit on the number of colours in the full palette list.
+	globalMaxOutputColors int
+
+	// Global fraction of saturation removed for muted colour variants.
+	globalMutedSaturation = colour.DefaultCategorisationConfig().MutedSaturationReduction
+
+	// Global number of accent slots to fill (0 = up to 4 extracted accents).
+	globalAccentCount int
+
+	// Global text contrast model (wcag, apca).
+	globalContrastModel string
+
+	// Global flag generating muted and surface colours in OKLCH instead of HSL.
+	globalOKLCH bool
+
+	// Global accent and semantic saturation dial (0 = muted, 100 = vivid).
+	globalVibrancy = colour.DefaultVibrancy
+
+	// Global flag enabling the external plugin execution audit log.
+	globalAudit bool
+
+	// Global flag printing why each role was assigned its colour.
+	globalExplain bool
+
 	// Shared plugin manager instance used by all commands.
 	sharedPluginManager *manager.Manager
 
@@ -26,8 +60,9 @@ and generates configuration files for your favorite applications.
 
 Extract vibrant color schemes from wallpapers and apply them system-wide to
 terminal emulators, window managers, application launchers, and more.`,
-		Version:      version.Short(),
-		SilenceUsage: true,
+		Version:           version.Short(),
+		SilenceUsage:      true,
+		PersistentPreRunE: enableAuditIfRequested,
 	}
 )
 
@@ -47,15 +82,32 @@ func NewRootCmd() *cobra.Command {
 	RootCmd.PersistentFlags().BoolP("verbose", "v", false, "enable verbose output")
 	RootCmd.PersistentFlags().BoolP("quiet", "q", false, "suppress non-error output")
 	RootCmd.PersistentFlags().StringVarP(&globalTheme, "theme", "t", "auto", "theme type (auto, dark, light)")
-
-	// Set version template.
-	RootCmd.SetVersionTemplate(version.String() + "\n")
+	RootCmd.PersistentFlags().StringVar(&globalBackground, "background", string(colour.BackgroundAuto), "background selection (auto, darkest, lightest)")
+	RootCmd.PersistentFlags().StringVar(&globalSemanticPalette, "semantic-palette", string(colour.SemanticPaletteStandard), "semantic colour set (standard, cvd-safe)")
+	RootCmd.PersistentFlags().IntVar(&globalMaxOutputColors, "max-output-colors", 0, "limit the full colour list to the N most significant colours (0 = unlimited)")
+	RootCmd.PersistentFlags().Float64Var(&globalMutedSaturation, "muted-saturation", colour.DefaultCategorisationConfig().MutedSaturationReduction, "fraction of saturation removed for muted variants (0 = as saturated as the original, 1 = grey)")
+	RootCmd.PersistentFlags().IntVar(&globalAccentCount, "accent-count", 0, fmt.Sprintf("fill N accent slots (accent1..accentN, 1-%d), generating accents the image lacks (0 = up to 4 extracted accents)", colour.MaxAccentCount))
+	RootCmd.PersistentFlags().StringVar(&globalContrastModel, "contrast-model", string(colour.ContrastWCAG), "text contrast measure (wcag = WCAG 2 ratio, apca = APCA Lc, better on mid-tone colours)")
+	RootCmd.PersistentFlags().BoolVar(&globalOKLCH, "oklch", false, "adjust muted and surface colour lightness in OKLCH, keeping hue truer on saturated colours")
+	RootCmd.PersistentFlags().Float64Var(&globalVibrancy, "vibrancy", colour.DefaultVibrancy, "accent and semantic colour saturation from 0 (muted) to 100 (vivid), 50 = unchanged")
+	RootCmd.PersistentFlags().BoolVar(&globalAudit, "audit", false, "record external plugin executions to ~/.local/share/tinct/audit.jsonl (or set TINCT_AUDIT=true)")
+	RootCmd.PersistentFlags().BoolVar(&globalExplain, "explain", false, "print why each role was assigned its colour (to stderr)")
+
+	// Make --version print the same build metadata as the version command.
+	RootCmd.SetVersionTemplate(versionTemplate())
 
 	// Add subcommands.
 	RootCmd.AddCommand(versionCmd)
 	RootCmd.AddCommand(extractCmd)
 	RootCmd.AddCommand(generateCmd)
+	RootCmd.AddCommand(analyzeCmd)
+	RootCmd.AddCommand(histogramCmd)
+	RootCmd.AddCommand(dominantCmd)
+	RootCmd.AddCommand(transitionCmd)
+	RootCmd.AddCommand(applyTerminalCmd)
 	RootCmd.AddCommand(pluginsCmd)
+	RootCmd.AddCommand(completionCmd)
+	RootCmd.AddCommand(manCmd)
 
 	return RootCmd
 }
@@ -63,12 +115,25 @@ func NewRootCmd() *cobra.Command {
 func init() {
 }
 
+// enableAuditIfRequested turns on the plugin execution audit log when --audit is set.
+func enableAuditIfRequested(_ *cobra.Command, _ []string) error {
+	if !globalAudit {
+		return nil
+	}
+	if err := sharedPluginManager.EnableAudit(""); err != nil {
+		return fmt.Errorf("failed to enable audit log: %w", err)
+	}
+	return nil
+}
+
 // registerPluginFlags registers plugin-specific flags with commands that use them.
 func registerPluginFlags() {
 	// Register input plugin flags.
 	for _, plugin := range sharedPluginManager.AllInputPlugins() {
 		plugin.RegisterFlags(extractCmd)
 		plugin.RegisterFlags(generateCmd)
+		plugin.RegisterFlags(analyzeCmd)
+		plugin.RegisterFlags(applyTerminalCmd)
 	}
 
 	// Register output plugin flags.
@@ -81,15 +146,29 @@ func registerPluginFlags() {
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
-	Long:  `Print detailed version information including build date, commit hash, and Go version.`,
-	Run: func(_ *cobra.Command, _ []string) {
-		info := version.GetInfo()
-
-		// Print version information in a structured format.
-		fmt.Printf("Version:    %s\n", info.Version)
-		fmt.Printf("Commit:     %s\n", info.Commit)
-		fmt.Printf("Build Date: %s\n", info.Date)
-		fmt.Printf("Go Version: %s\n", info.GoVersion)
-		fmt.Printf("Platform:   %s\n", info.Platform)
+	Long: `Print detailed version information including build date, commit hash, Go version,
+and the plugin protocol version this build supports.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		writeVersionInfo(cmd.OutOrStdout())
 	},
 }
+
+// versionTemplate returns the --version output. The build metadata is fixed at
+// link time, so it is rendered once rather than through cobra's template data.
+func versionTemplate() string {
+	var info strings.Builder
+	writeVersionInfo(&info)
+	return info.String()
+}
+
+// writeVersionInfo writes the version information in a structured format.
+func writeVersionInfo(w io.Writer) {
+	info := version.GetInfo()
+
+	fmt.Fprintf(w, "Version:    %s\n", info.Version)
+	fmt.Fprintf(w, "Commit:     %s\n", info.Commit)
+	fmt.Fprintf(w, "Build Date: %s\n", info.Date)
+	fmt.Fprintf(w, "Go Version: %s\n", info.GoVersion)
+	fmt.Fprintf(w, "Platform:   %s\n", info.Platform)
+	fmt.Fprintf(w, "Protocol:   %s (minimum compatible: %s)\n", protocol.ProtocolVersion, protocol.MinCompatibleVersion)
+}
@@ -0,0 +1,151 @@
+// Package cli provides the command-line interface for Tinct.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+// maxTransitionSteps bounds --steps so a typo cannot write thousands of files.
+const maxTransitionSteps = 1000
+
+var (
+	// Transition command flags.
+	transitionSteps     int
+	transitionOutputDir string
+)
+
+// transitionCmd represents the transition command.
+var transitionCmd = &cobra.Command{
+	Use:   "transition <from.json> <to.json>",
+	Short: "Interpolate between two palettes for smooth theme transitions",
+	Long: `Emit a sequence of palettes moving from one theme to another.
+
+The transition command reads two categorised palettes, as written by
+'tinct extract --format json', and interpolates every role they share in OKLCH:
+lightness and chroma linearly, hue the short way round. The first step is the
+starting palette and the last step the target palette; roles in only one of them
+are dropped.
+
+By default each step is printed as one line of JSON (JSON Lines) with its index,
+its position t from 0 to 1, and its role colours. With --output-dir each step is
+written as a categorised palette file instead, which 'tinct generate -i file'
+can load, so a script can regenerate themes step by step.
+
+Examples:
+  # Save the current and next palettes
+  tinct extract -i image -p old.jpg --format json -o old.json
+  tinct extract -i image -p new.jpg --format json -o new.json
+
+  # 30 steps as JSON Lines
+  tinct transition old.json new.json --steps 30
+
+  # Drive a compositor's border colour through the transition
+  tinct transition old.json new.json | jq -r '.colours.accent1'
+
+  # Write step files and regenerate a theme from each
+  tinct transition old.json new.json --steps 10 --output-dir /tmp/fade
+  for f in /tmp/fade/*.json; do tinct generate -i file --file.path "$f" -o hyprland; done`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTransition,
+}
+
+func init() {
+	transitionCmd.Flags().IntVar(&transitionSteps, "steps", 30, fmt.Sprintf("number of palettes to emit, including both ends (2-%d)", maxTransitionSteps))
+	transitionCmd.Flags().StringVar(&transitionOutputDir, "output-dir", "", "write each step to step-NNN.json in this directory instead of printing JSON Lines")
+}
+
+// transitionStep is one line of the transition's JSON Lines output.
+type transitionStep struct {
+	Step    int                    `json:"step"`
+	T       float64                `json:"t"`
+	Colours map[colour.Role]string `json:"colours"`
+}
+
+// runTransition executes the transition command.
+func runTransition(cmd *cobra.Command, args []string) error {
+	if transitionSteps < 2 || transitionSteps > maxTransitionSteps {
+		return fmt.Errorf("--steps must be between 2 and %d, got %d", maxTransitionSteps, transitionSteps)
+	}
+
+	from, err := loadCategorisedPalette(args[0])
+	if err != nil {
+		return err
+	}
+	to, err := loadCategorisedPalette(args[1])
+	if err != nil {
+		return err
+	}
+
+	palettes := colour.Transition(from, to, transitionSteps)
+	if len(palettes[0].Colours) == 0 {
+		return fmt.Errorf("%s and %s have no roles in common", args[0], args[1])
+	}
+
+	if transitionOutputDir != "" {
+		return writeTransitionFiles(palettes, transitionOutputDir, cmd.OutOrStderr())
+	}
+	return writeTransitionLines(palettes, cmd.OutOrStdout())
+}
+
+// loadCategorisedPalette reads a categorised palette JSON file.
+func loadCategorisedPalette(path string) (*colour.CategorisedPalette, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - User-specified palette file, intended to be read
+	if err != nil {
+		return nil, fmt.Errorf("failed to read palette: %w", err)
+	}
+
+	var palette colour.CategorisedPalette
+	if err := json.Unmarshal(data, &palette); err != nil || len(palette.Colours) == 0 {
+		return nil, fmt.Errorf("%s is not a categorised palette (create one with 'tinct extract --format json')", path)
+	}
+	return &palette, nil
+}
+
+// writeTransitionLines writes each palette as one line of JSON.
+func writeTransitionLines(palettes []*colour.CategorisedPalette, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for i, palette := range palettes {
+		step := transitionStep{
+			Step:    i,
+			T:       float64(i) / float64(len(palettes)-1),
+			Colours: make(map[colour.Role]string, len(palette.Colours)),
+		}
+		for role, c := range palette.Colours {
+			step.Colours[role] = c.Hex
+		}
+		if err := enc.Encode(step); err != nil {
+			return fmt.Errorf("failed to write step %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// writeTransitionFiles writes each palette to dir as step-NNN.json, reporting
+// the directory to status.
+func writeTransitionFiles(palettes []*colour.CategorisedPalette, dir string, status io.Writer) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil { // #nosec G301 - Output directory needs standard permissions
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for i, palette := range palettes {
+		data, err := palette.ToJSON()
+		if err != nil {
+			return fmt.Errorf("failed to convert step %d to JSON: %w", i, err)
+		}
+		path := filepath.Join(dir, fmt.Sprintf("step-%03d.json", i))
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write step %d: %w", i, err)
+		}
+	}
+
+	fmt.Fprintf(status, "Wrote %d palettes to %s\n", len(palettes), dir)
+	return nil
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+// writeCategorisedPalette categorises colours and saves them as JSON in dir.
+func writeCategorisedPalette(t *testing.T, dir, name string, colours ...color.Color) string {
+	t.Helper()
+	data, err := colour.Categorise(colour.NewPalette(colours), colour.DefaultCategorisationConfig()).ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestTransitionLines(t *testing.T) {
+	dir := t.TempDir()
+	fromPath := writeCategorisedPalette(t, dir, "from.json",
+		color.RGBA{R: 0x1a, G: 0x1b, B: 0x26, A: 0xff},
+		color.RGBA{R: 0xc0, G: 0xca, B: 0xf5, A: 0xff},
+		color.RGBA{R: 0x7a, G: 0xa2, B: 0xf7, A: 0xff})
+	toPath := writeCategorisedPalette(t, dir, "to.json",
+		color.RGBA{R: 0x28, G: 0x28, B: 0x28, A: 0xff},
+		color.RGBA{R: 0xeb, G: 0xdb, B: 0xb2, A: 0xff},
+		color.RGBA{R: 0xfe, G: 0x80, B: 0x19, A: 0xff})
+
+	from, err := loadCategorisedPalette(fromPath)
+	if err != nil {
+		t.Fatalf("loadCategorisedPalette() error = %v", err)
+	}
+	to, err := loadCategorisedPalette(toPath)
+	if err != nil {
+		t.Fatalf("loadCategorisedPalette() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeTransitionLines(colour.Transition(from, to, 5), &out); err != nil {
+		t.Fatalf("writeTransitionLines() error = %v", err)
+	}
+
+	var steps []transitionStep
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var step transitionStep
+		if err := json.Unmarshal(scanner.Bytes(), &step); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		steps = append(steps, step)
+	}
+
+	if len(steps) != 5 || steps[0].T != 0 || steps[4].T != 1 || steps[2].T != 0.5 {
+		t.Fatalf("steps = %+v, want 5 steps from t=0 to t=1", steps)
+	}
+	if got, want := steps[0].Colours[colour.RoleBackground], from.Colours[colour.RoleBackground].Hex; got != want {
+		t.Errorf("first background = %s, want %s", got, want)
+	}
+	if got, want := steps[4].Colours[colour.RoleBackground], to.Colours[colour.RoleBackground].Hex; got != want {
+		t.Errorf("last background = %s, want %s", got, want)
+	}
+}
+
+func TestLoadCategorisedPaletteRejectsOtherFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "palette.txt")
+	if err := os.WriteFile(path, []byte("background=#1a1b26\n"), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	if _, err := loadCategorisedPalette(path); err == nil {
+		t.Error("loadCategorisedPalette() of a text palette should fail")
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/plugin/protocol"
+	"github.com/jmylchreest/tinct/internal/version"
+)
+
+// setBuildInfo sets the version variables as -ldflags "-X ..." would for the test.
+func setBuildInfo(t *testing.T, v, commit, date string) {
+	t.Helper()
+	prevVersion, prevCommit, prevDate := version.Version, version.Commit, version.Date
+	t.Cleanup(func() {
+		version.Version, version.Commit, version.Date = prevVersion, prevCommit, prevDate
+	})
+	version.Version, version.Commit, version.Date = v, commit, date
+}
+
+func TestVersionCommand(t *testing.T) {
+	setBuildInfo(t, "1.2.3", "0123456789abcdef0123456789abcdef01234567", "2025-03-01T12:00:00Z")
+
+	var out bytes.Buffer
+	versionCmd.SetOut(&out)
+	t.Cleanup(func() { versionCmd.SetOut(nil) })
+	versionCmd.Run(versionCmd, nil)
+
+	for _, want := range []string{
+		"Version:    1.2.3\n",
+		"Commit:     0123456789abcdef0123456789abcdef01234567\n",
+		"Build Date: 2025-03-01T12:00:00Z\n",
+		"Go Version: " + version.GoVersion + "\n",
+		"Protocol:   " + protocol.ProtocolVersion + " (minimum compatible: " + protocol.MinCompatibleVersion + ")\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("version output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestVersionTemplate(t *testing.T) {
+	setBuildInfo(t, "1.2.3", "abc1234", "2025-03-01T12:00:00Z")
+
+	// --version prints the same information as the version command.
+	var out bytes.Buffer
+	writeVersionInfo(&out)
+	if got := versionTemplate(); got != out.String() {
+		t.Errorf("versionTemplate() = %q, want %q", got, out.String())
+	}
+	if strings.Contains(versionTemplate(), "{{") {
+		t.Error("versionTemplate() must not contain template actions")
+	}
+}
+
+func TestVersionString_ShortCommit(t *testing.T) {
+	setBuildInfo(t, "1.2.3", "abc1234", "2025-03-01T12:00:00Z")
+
+	if got, want := version.String(), "tinct version 1.2.3 (commit: abc1234, built: 2025-03-01T12:00:00Z"; !strings.HasPrefix(got, want) {
+		t.Errorf("String() = %q, want prefix %q", got, want)
+	}
+}
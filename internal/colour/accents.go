@@ -2,9 +2,35 @@
 package colour
 
 import (
+	"fmt"
 	"math"
 )
 
+// Accent slot counts.
+const (
+	DefaultAccentCount = 4  // Accent slots filled by default (accent1-accent4)
+	MaxAccentCount     = 16 // Largest supported CategorisationConfig.AccentCount
+)
+
+// goldenAngle spreads the hues of synthetic accents beyond the fourth, so any
+// number of them stay apart.
+const goldenAngle = 137.50776405
+
+// AccentRole returns the role of accent slot n (1-based), e.g. "accent5".
+func AccentRole(n int) Role {
+	return Role(fmt.Sprintf("accent%d", n))
+}
+
+// AccentMutedRole returns the muted role of accent slot n, e.g. "accent5Muted".
+func AccentMutedRole(n int) Role {
+	return Role(fmt.Sprintf("accent%dMuted", n))
+}
+
+// OnAccentRole returns the role of text on accent slot n, e.g. "onAccent5".
+func OnAccentRole(n int) Role {
+	return Role(fmt.Sprintf("onAccent%d", n))
+}
+
 // Minimum contrast requirements for accents.
 const (
 	MinAccentBgContrast     = 3.0  // Minimum contrast between accent and background (WCAG AA for large text)
@@ -225,13 +251,17 @@ func generateSyntheticAccents(bg CategorisedColour, theme ThemeType, count int)
 
 	// Generate accents with varied hues.
 	for i := range count {
-		// Calculate hue with offset for diversity.
+		// Calculate hue with offset for diversity. Accents beyond the fourth
+		// step round the wheel by the golden angle so their hues stay apart.
 		offset := hueOffsets[i%len(hueOffsets)]
+		if i >= len(hueOffsets) {
+			offset = hueOffsets[0] + goldenAngle*float64(i)
+		}
 		newHue := math.Mod(h+offset, 360.0)
 
 		// Vary luminance slightly across accents for visual progression.
 		// accent1 = highest contrast, accent4 = closer to background.
-		lumAdjust := float64(i) * 0.05
+		lumAdjust := float64(min(i, len(hueOffsets)-1)) * 0.05
 		var accentLum float64
 		if theme == ThemeDark {
 			accentLum = baseLum - lumAdjust // Get progressively darker
@@ -264,3 +294,83 @@ func generateSyntheticAccents(bg CategorisedColour, theme ThemeType, count int)
 
 	return accents
 }
+
+// accentSlots lists the accent roles in slot order.
+var accentSlots = []Role{RoleAccent1, RoleAccent2, RoleAccent3, RoleAccent4}
+
+// stabiliseAccentSlots reorders the leading accents so each lands in the slot whose
+// colour in the previous palette is closest in hue.
+//
+// This keeps accent1 "the green one" across wallpaper changes where possible, avoiding
+// jarring UI colour jumps. The permutation with the smallest total hue distance wins;
+// ties keep the original order. Slots missing from the previous palette cost nothing,
+// so they take whichever accents remain.
+func stabiliseAccentSlots(accents []CategorisedColour, previous *CategorisedPalette) {
+	if previous == nil {
+		return
+	}
+
+	n := min(len(accents), len(accentSlots))
+	if n < 2 {
+		return
+	}
+
+	prevHues := make([]float64, n)
+	hasPrev := make([]bool, n)
+	anyPrev := false
+	for slot := range n {
+		if prev, ok := previous.Get(accentSlots[slot]); ok {
+			prevHues[slot] = prev.Hue
+			hasPrev[slot] = true
+			anyPrev = true
+		}
+	}
+	if !anyPrev {
+		return
+	}
+
+	cost := func(perm []int) float64 {
+		total := 0.0
+		for slot, idx := range perm {
+			if hasPrev[slot] {
+				total += HueDistance(accents[idx].Hue, prevHues[slot])
+			}
+		}
+		return total
+	}
+
+	// Search all assignments (at most 4! = 24) in lexicographic order, starting
+	// from the identity so ties keep the existing order.
+	perm := make([]int, 0, n)
+	used := make([]bool, n)
+	var best []int
+	bestCost := math.Inf(1)
+
+	var search func()
+	search = func() {
+		if len(perm) == n {
+			if c := cost(perm); c < bestCost {
+				bestCost = c
+				best = append(best[:0], perm...)
+			}
+			return
+		}
+		for idx := range n {
+			if used[idx] {
+				continue
+			}
+			used[idx] = true
+			perm = append(perm, idx)
+			search()
+			perm = perm[:len(perm)-1]
+			used[idx] = false
+		}
+	}
+	search()
+
+	reordered := make([]CategorisedColour, n)
+	for slot, idx := range best {
+		reordered[slot] = accents[idx]
+	}
+	copy(accents, reordered)
+}
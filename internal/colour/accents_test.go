@@ -0,0 +1,168 @@
+package colour
+
+import (
+	"image/color"
+	"strconv"
+	"testing"
+)
+
+func testAccent(hue float64) CategorisedColour {
+	rgb := HSLToRGB(hue, 0.6, 0.6)
+	return CategorisedColour{Hex: rgb.Hex(), RGB: rgb, Hue: hue, Saturation: 0.6}
+}
+
+func previousAccentPalette(hues ...float64) *CategorisedPalette {
+	previous := NewCategorisedPalette(ThemeDark)
+	for slot, hue := range hues {
+		previous.Set(accentSlots[slot], testAccent(hue))
+	}
+	return previous
+}
+
+func TestStabiliseAccentSlotsHueNearest(t *testing.T) {
+	// Previous run: accent1 green, accent2 red, accent3 blue, accent4 yellow.
+	previous := previousAccentPalette(120, 0, 230, 55)
+
+	// New accents arrive in a different order with slightly shifted hues.
+	accents := []CategorisedColour{
+		testAccent(350), // red
+		testAccent(60),  // yellow
+		testAccent(130), // green
+		testAccent(220), // blue
+		testAccent(300), // extra candidate, never in a slot
+	}
+
+	stabiliseAccentSlots(accents, previous)
+
+	want := []float64{130, 350, 220, 60, 300}
+	for i, hue := range want {
+		if accents[i].Hue != hue {
+			t.Errorf("slot %d hue = %.0f, want %.0f", i, accents[i].Hue, hue)
+		}
+	}
+}
+
+func TestStabiliseAccentSlotsPartialPrevious(t *testing.T) {
+	// Only accent1 existed previously; it was blue.
+	previous := previousAccentPalette(230)
+
+	accents := []CategorisedColour{testAccent(0), testAccent(120), testAccent(225)}
+	stabiliseAccentSlots(accents, previous)
+
+	if accents[0].Hue != 225 {
+		t.Errorf("accent1 hue = %.0f, want 225 (nearest previous accent1)", accents[0].Hue)
+	}
+	// Remaining accents keep their relative order.
+	if accents[1].Hue != 0 || accents[2].Hue != 120 {
+		t.Errorf("remaining accents reordered: %.0f, %.0f", accents[1].Hue, accents[2].Hue)
+	}
+}
+
+func TestStabiliseAccentSlotsNoPrevious(t *testing.T) {
+	accents := []CategorisedColour{testAccent(0), testAccent(120), testAccent(240)}
+	stabiliseAccentSlots(accents, nil)
+	stabiliseAccentSlots(accents, NewCategorisedPalette(ThemeDark))
+
+	for i, hue := range []float64{0, 120, 240} {
+		if accents[i].Hue != hue {
+			t.Errorf("slot %d hue = %.0f, want unchanged %.0f", i, accents[i].Hue, hue)
+		}
+	}
+}
+
+func TestCategoriseStableAccents(t *testing.T) {
+	config := DefaultCategorisationConfig()
+	config.ThemeType = ThemeDark
+
+	first := Categorise(weightedTestPalette(), config)
+
+	// Rotate the previous accents so every slot prefers a different colour.
+	previous := NewCategorisedPalette(ThemeDark)
+	for slot, role := range accentSlots {
+		cc, ok := first.Get(accentSlots[(slot+1)%len(accentSlots)])
+		if !ok {
+			t.Fatalf("missing %s in first palette", role)
+		}
+		previous.Set(role, cc)
+	}
+
+	config.PreviousPalette = previous
+	second := Categorise(weightedTestPalette(), config)
+
+	for _, role := range accentSlots {
+		got, _ := second.Get(role)
+		want, _ := previous.Get(role)
+		if got.Hex != want.Hex {
+			t.Errorf("%s = %s, want %s (hue-nearest previous slot)", role, got.Hex, want.Hex)
+		}
+	}
+}
+
+func TestCategoriseAccentCount(t *testing.T) {
+	// Background, foreground and only two candidate accents.
+	palette := NewPalette([]color.Color{
+		color.RGBA{R: 0x1a, G: 0x1b, B: 0x26, A: 0xff},
+		color.RGBA{R: 0xc0, G: 0xca, B: 0xf5, A: 0xff},
+		color.RGBA{R: 0x7a, G: 0xa2, B: 0xf7, A: 0xff},
+		color.RGBA{R: 0x9e, G: 0xce, B: 0x6a, A: 0xff},
+	})
+
+	for _, count := range []int{1, 6, MaxAccentCount} {
+		t.Run(strconv.Itoa(count), func(t *testing.T) {
+			config := DefaultCategorisationConfig()
+			config.ThemeType = ThemeDark
+			config.AccentCount = count
+			result := Categorise(palette, config)
+
+			slots := max(count, DefaultAccentCount)
+			hues := make(map[string]bool)
+			for n := 1; n <= count; n++ {
+				accent, ok := result.Get(AccentRole(n))
+				if !ok {
+					t.Fatalf("%s missing", AccentRole(n))
+				}
+				hues[accent.Hex] = true
+				for _, role := range []Role{AccentMutedRole(n), OnAccentRole(n)} {
+					if _, ok := result.Get(role); !ok {
+						t.Errorf("%s missing", role)
+					}
+				}
+			}
+			if len(hues) < min(count, 2) {
+				t.Errorf("accents are not distinct: %v", hues)
+			}
+			if _, ok := result.Get(AccentRole(slots + 1)); ok {
+				t.Errorf("%s filled beyond %d slots", AccentRole(slots+1), slots)
+			}
+		})
+	}
+}
+
+func TestAllRolesOrdersExtraAccents(t *testing.T) {
+	palette := NewPalette([]color.Color{
+		color.RGBA{R: 0x1a, G: 0x1b, B: 0x26, A: 0xff},
+		color.RGBA{R: 0xc0, G: 0xca, B: 0xf5, A: 0xff},
+	})
+	config := DefaultCategorisationConfig()
+	config.ThemeType = ThemeDark
+	config.AccentCount = 10
+	roles := NewPaletteHelper(Categorise(palette, config)).AllRoles()
+
+	index := make(map[Role]int, len(roles))
+	for i, role := range roles {
+		index[role] = i
+	}
+	for _, pair := range [][2]Role{
+		{RoleAccent4Muted, AccentRole(5)},
+		{AccentMutedRole(9), AccentRole(10)},
+		{AccentMutedRole(10), RoleDanger},
+		{RoleOnAccent4, OnAccentRole(5)},
+		{OnAccentRole(10), RoleOnDanger},
+	} {
+		a, aOk := index[pair[0]]
+		b, bOk := index[pair[1]]
+		if !aOk || !bOk || a >= b {
+			t.Errorf("AllRoles() does not order %s before %s: %v", pair[0], pair[1], roles)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package colour provides an accessibility audit for categorised palettes.
+package colour
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// WCAG 2.x contrast thresholds.
+const (
+	WCAGAALarge = 3.0 // AA for large text and UI components
+	WCAGAA      = 4.5 // AA for normal text
+	WCAGAAA     = 7.0 // AAA for normal text
+)
+
+// rolePair is an ordered pair of roles checked together.
+type rolePair struct {
+	a, b Role
+}
+
+// contrastPairs are the foreground/background combinations themes actually render.
+var contrastPairs = []rolePair{
+	{RoleForeground, RoleBackground},
+	{RoleForegroundMuted, RoleBackground},
+	{RoleForeground, RoleBackgroundMuted},
+	{RoleAccent1, RoleBackground},
+	{RoleAccent2, RoleBackground},
+	{RoleAccent3, RoleBackground},
+	{RoleAccent4, RoleBackground},
+	{RoleDanger, RoleBackground},
+	{RoleWarning, RoleBackground},
+	{RoleSuccess, RoleBackground},
+	{RoleInfo, RoleBackground},
+	{RoleNotification, RoleBackground},
+	{RoleOnSurface, RoleSurface},
+	{RoleOnSurfaceVariant, RoleSurfaceVariant},
+	{RoleInverseOnSurface, RoleInverseSurface},
+	{RoleOnAccent1, RoleAccent1},
+	{RoleOnAccent2, RoleAccent2},
+	{RoleOnAccent3, RoleAccent3},
+	{RoleOnAccent4, RoleAccent4},
+	{RoleOnDanger, RoleDanger},
+	{RoleOnWarning, RoleWarning},
+	{RoleOnSuccess, RoleSuccess},
+	{RoleOnInfo, RoleInfo},
+}
+
+// cvdPairs are colours that must stay distinguishable because they carry different meanings.
+var cvdPairs = []rolePair{
+	{RoleDanger, RoleSuccess},
+	{RoleDanger, RoleWarning},
+	{RoleWarning, RoleSuccess},
+	{RoleInfo, RoleSuccess},
+	{RoleAccent1, RoleAccent2},
+	{RoleAccent2, RoleAccent3},
+	{RoleAccent3, RoleAccent4},
+}
+
+// ContrastCheck is the WCAG contrast result for one foreground/background pair.
+type ContrastCheck struct {
+	Foreground    Role    `json:"foreground"`
+	Background    Role    `json:"background"`
+	ForegroundHex string  `json:"foreground_hex"`
+	BackgroundHex string  `json:"background_hex"`
+	Ratio         float64 `json:"ratio"`
+	PassAALarge   bool    `json:"pass_aa_large"`
+	PassAA        bool    `json:"pass_aa"`
+	PassAAA       bool    `json:"pass_aaa"`
+}
+
+// CVDCheck is the distinguishability of two roles under one colour vision deficiency.
+type CVDCheck struct {
+	RoleA           Role    `json:"role_a"`
+	RoleB           Role    `json:"role_b"`
+	Deficiency      CVDType `json:"deficiency"`
+	DeltaE          float64 `json:"delta_e"`
+	Distinguishable bool    `json:"distinguishable"`
+}
+
+// AccessibilitySummary aggregates the individual checks.
+type AccessibilitySummary struct {
+	ForegroundPassAA  bool `json:"foreground_pass_aa"`  // Foreground on background meets AA
+	ForegroundPassAAA bool `json:"foreground_pass_aaa"` // Foreground on background meets AAA
+	AllPassAALarge    bool `json:"all_pass_aa_large"`   // Every checked pair meets 3:1
+	AllPassAA         bool `json:"all_pass_aa"`         // Every checked pair meets 4.5:1
+	AllPassAAA        bool `json:"all_pass_aaa"`        // Every checked pair meets 7:1
+	CVDSafe           bool `json:"cvd_safe"`            // Every CVD pair is distinguishable
+}
+
+// AccessibilityAudit is a structured contrast and colour vision deficiency report.
+type AccessibilityAudit struct {
+	ThemeType string               `json:"theme_type"`
+	Contrast  []ContrastCheck      `json:"contrast"`
+	CVD       []CVDCheck           `json:"cvd"`
+	Summary   AccessibilitySummary `json:"summary"`
+}
+
+// AuditAccessibility checks contrast and CVD distinguishability for the roles in palette.
+// Pairs whose roles are missing from the palette are skipped.
+func AuditAccessibility(palette *CategorisedPalette) AccessibilityAudit {
+	audit := AccessibilityAudit{
+		ThemeType: palette.ThemeType.String(),
+		Contrast:  make([]ContrastCheck, 0, len(contrastPairs)),
+		CVD:       make([]CVDCheck, 0, len(cvdPairs)*len(CVDTypes)),
+		Summary: AccessibilitySummary{
+			AllPassAALarge: true,
+			AllPassAA:      true,
+			AllPassAAA:     true,
+			CVDSafe:        true,
+		},
+	}
+
+	for _, pair := range contrastPairs {
+		fg, fgOk := palette.Get(pair.a)
+		bg, bgOk := palette.Get(pair.b)
+		if !fgOk || !bgOk {
+			continue
+		}
+
+		check := CheckContrast(pair.a, pair.b, fg, bg)
+		audit.Contrast = append(audit.Contrast, check)
+
+		audit.Summary.AllPassAALarge = audit.Summary.AllPassAALarge && check.PassAALarge
+		audit.Summary.AllPassAA = audit.Summary.AllPassAA && check.PassAA
+		audit.Summary.AllPassAAA = audit.Summary.AllPassAAA && check.PassAAA
+		if pair.a == RoleForeground && pair.b == RoleBackground {
+			audit.Summary.ForegroundPassAA = check.PassAA
+			audit.Summary.ForegroundPassAAA = check.PassAAA
+		}
+	}
+
+	for _, pair := range cvdPairs {
+		a, aOk := palette.Get(pair.a)
+		b, bOk := palette.Get(pair.b)
+		if !aOk || !bOk {
+			continue
+		}
+
+		for _, cvd := range CVDTypes {
+			// CVDTypes only contains known deficiencies, so simulation cannot fail.
+			simA, _ := SimulateCVD(a.RGB, cvd)
+			simB, _ := SimulateCVD(b.RGB, cvd)
+			deltaE := DeltaE(simA, simB)
+
+			check := CVDCheck{
+				RoleA:           pair.a,
+				RoleB:           pair.b,
+				Deficiency:      cvd,
+				DeltaE:          roundTo(deltaE, 2),
+				Distinguishable: deltaE >= MinCVDDeltaE,
+			}
+			audit.CVD = append(audit.CVD, check)
+			audit.Summary.CVDSafe = audit.Summary.CVDSafe && check.Distinguishable
+		}
+	}
+
+	return audit
+}
+
+// CheckContrast returns the WCAG contrast result for fg (in role fgRole) on bg.
+func CheckContrast(fgRole, bgRole Role, fg, bg CategorisedColour) ContrastCheck {
+	ratio := ContrastRatio(RGBToColor(fg.RGB), RGBToColor(bg.RGB))
+	return ContrastCheck{
+		Foreground:    fgRole,
+		Background:    bgRole,
+		ForegroundHex: fg.Hex,
+		BackgroundHex: bg.Hex,
+		Ratio:         roundTo(ratio, 2),
+		PassAALarge:   ratio >= WCAGAALarge,
+		PassAA:        ratio >= WCAGAA,
+		PassAAA:       ratio >= WCAGAAA,
+	}
+}
+
+// Rating returns the highest WCAG level the pair meets for normal text:
+// "AAA", "AA", "AA Large" or "Fail".
+func (c ContrastCheck) Rating() string {
+	switch {
+	case c.PassAAA:
+		return "AAA"
+	case c.PassAA:
+		return "AA"
+	case c.PassAALarge:
+		return "AA Large"
+	default:
+		return "Fail"
+	}
+}
+
+// ToJSON converts the audit to indented JSON.
+func (a AccessibilityAudit) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(a, "", "  ")
+}
+
+// roundTo rounds v to the given number of decimal places for stable report output.
+func roundTo(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
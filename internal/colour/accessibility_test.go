@@ -0,0 +1,152 @@
+package colour
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func auditTestPalette(colours map[Role]RGB) *CategorisedPalette {
+	palette := NewCategorisedPalette(ThemeDark)
+	for role, rgb := range colours {
+		palette.Set(role, CategorisedColour{Colour: RGBToColor(rgb), Hex: rgb.Hex(), RGB: rgb})
+	}
+	return palette
+}
+
+func TestAuditAccessibilityJSONSchema(t *testing.T) {
+	palette := auditTestPalette(map[Role]RGB{
+		RoleBackground: {R: 0, G: 0, B: 0},
+		RoleForeground: {R: 255, G: 255, B: 255},
+		RoleAccent1:    {R: 90, G: 90, B: 90}, // ~3:1 on black, AA large only
+	})
+
+	data, err := AuditAccessibility(palette).ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+
+	var decoded struct {
+		ThemeType string `json:"theme_type"`
+		Contrast  []map[string]any
+		Summary   map[string]any
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("audit JSON does not parse: %v", err)
+	}
+
+	if decoded.ThemeType != "dark" {
+		t.Errorf("theme_type = %q, want dark", decoded.ThemeType)
+	}
+	if len(decoded.Contrast) != 2 {
+		t.Fatalf("expected 2 contrast checks for the roles present, got %d", len(decoded.Contrast))
+	}
+
+	want := map[string]map[string]bool{
+		"foreground": {"pass_aa_large": true, "pass_aa": true, "pass_aaa": true},
+		"accent1":    {"pass_aa_large": true, "pass_aa": false, "pass_aaa": false},
+	}
+	for _, check := range decoded.Contrast {
+		fg, _ := check["foreground"].(string)
+		expected, ok := want[fg]
+		if !ok {
+			t.Errorf("unexpected contrast check for %s", fg)
+			continue
+		}
+		for key, wantValue := range expected {
+			got, ok := check[key].(bool)
+			if !ok {
+				t.Errorf("%s: %s missing or not a boolean", fg, key)
+				continue
+			}
+			if got != wantValue {
+				t.Errorf("%s: %s = %v, want %v", fg, key, got, wantValue)
+			}
+		}
+	}
+
+	for key, wantValue := range map[string]bool{
+		"foreground_pass_aa":  true,
+		"foreground_pass_aaa": true,
+		"all_pass_aa_large":   true,
+		"all_pass_aa":         false,
+		"all_pass_aaa":        false,
+	} {
+		got, ok := decoded.Summary[key].(bool)
+		if !ok {
+			t.Errorf("summary.%s missing or not a boolean", key)
+			continue
+		}
+		if got != wantValue {
+			t.Errorf("summary.%s = %v, want %v", key, got, wantValue)
+		}
+	}
+}
+
+func TestAuditAccessibilityLowContrastForeground(t *testing.T) {
+	palette := auditTestPalette(map[Role]RGB{
+		RoleBackground: {R: 40, G: 40, B: 40},
+		RoleForeground: {R: 80, G: 80, B: 80},
+	})
+
+	audit := AuditAccessibility(palette)
+	if audit.Summary.ForegroundPassAA || audit.Summary.ForegroundPassAAA {
+		t.Errorf("low-contrast foreground should fail AA and AAA: %+v", audit.Summary)
+	}
+}
+
+func TestAuditAccessibilityCVD(t *testing.T) {
+	// Red and green of similar lightness collapse under deuteranopia.
+	redGreen := auditTestPalette(map[Role]RGB{
+		RoleDanger:  {R: 200, G: 80, B: 60},
+		RoleSuccess: {R: 110, G: 140, B: 60},
+	})
+	audit := AuditAccessibility(redGreen)
+	if audit.Summary.CVDSafe {
+		t.Error("similar-lightness red/green should not be CVD safe")
+	}
+	if len(audit.CVD) != len(CVDTypes) {
+		t.Fatalf("expected one check per deficiency, got %d", len(audit.CVD))
+	}
+	for _, check := range audit.CVD {
+		if check.Deficiency == CVDDeuteranopia && check.Distinguishable {
+			t.Errorf("danger/success should be indistinguishable under deuteranopia (delta E %.1f)", check.DeltaE)
+		}
+	}
+
+	// Orange against blue stays distinct for all deficiencies.
+	orangeBlue := auditTestPalette(map[Role]RGB{
+		RoleDanger:  {R: 230, G: 120, B: 30},
+		RoleSuccess: {R: 40, G: 90, B: 200},
+	})
+	if !AuditAccessibility(orangeBlue).Summary.CVDSafe {
+		t.Error("orange/blue should be CVD safe")
+	}
+}
+
+func TestContrastCheckRating(t *testing.T) {
+	black := CategorisedColour{Colour: RGBToColor(RGB{}), Hex: "#000000"}
+	tests := []struct {
+		grey uint8
+		want string
+	}{
+		{255, "AAA"},
+		{120, "AA"},
+		{90, "AA Large"},
+		{40, "Fail"},
+	}
+
+	for _, tt := range tests {
+		rgb := RGB{R: tt.grey, G: tt.grey, B: tt.grey}
+		fg := CategorisedColour{Colour: RGBToColor(rgb), Hex: rgb.Hex(), RGB: rgb}
+		check := CheckContrast(RoleForeground, RoleBackground, fg, black)
+		if got := check.Rating(); got != tt.want {
+			t.Errorf("Rating() of %s on black (%.2f:1) = %s, want %s", rgb.Hex(), check.Ratio, got, tt.want)
+		}
+	}
+}
+
+func TestSimulateCVDUnknown(t *testing.T) {
+	if _, err := SimulateCVD(RGB{R: 255}, "monochromacy"); err == nil {
+		t.Error("expected error for unknown deficiency")
+	}
+}
@@ -0,0 +1,65 @@
+// Package colour provides the canonical 16-colour ANSI mapping shared by terminal plugins.
+package colour
+
+// ansi16FallbackRoles are the roles used for each ANSI slot when a palette has too few
+// extracted colours to fill every hue by closest match.
+var ansi16FallbackRoles = [16]Role{
+	RoleBackground, RoleDanger, RoleSuccess, RoleWarning,
+	RoleAccent1, RoleAccent3, RoleAccent2, RoleForegroundMuted,
+	RoleBackgroundMuted, RoleDanger, RoleSuccess, RoleWarning,
+	RoleInfo, RoleAccent4, RoleAccent2, RoleForeground,
+}
+
+// ansi16LightRoles replace the black and white slots on light themes, so "black" text
+// stays readable on the light background and "white" stays light.
+var ansi16LightRoles = map[int]Role{
+	0:  RoleForeground,
+	7:  RoleBackgroundMuted,
+	8:  RoleForegroundMuted,
+	15: RoleBackground,
+}
+
+// ANSI16 returns the canonical colours for ANSI slots 0-15 (black, red, ..., bright white).
+// Every terminal output plugin reads these, so all terminals show identical ANSI colours.
+//
+// Each slot uses the palette colour closest to the standard ANSI colour. Palettes with
+// fewer than 16 extracted colours cannot fill every hue, so slots fall back to the
+// semantic and accent roles instead. On light themes black and white follow the
+// foreground and background.
+//
+// The mapping is computed once and cached; Set discards the cache.
+func (cp *CategorisedPalette) ANSI16() [16]ColorValue {
+	if cp.ansi16 != nil {
+		return *cp.ansi16
+	}
+
+	ph := NewPaletteHelper(cp)
+	fewColours := ph.ExtractedCount() < 16
+
+	var slots [16]ColorValue
+	for i := range slots {
+		if fewColours {
+			if cv, ok := ph.GetSafe(ansi16FallbackRoles[i]); ok {
+				slots[i] = cv
+				continue
+			}
+		}
+		slots[i], _ = ph.FindClosestANSIColor(ansiColors[i].Name)
+	}
+
+	if cp.ThemeType == ThemeLight {
+		for i, role := range ansi16LightRoles {
+			if cv, ok := ph.GetSafe(role); ok {
+				slots[i] = cv
+			}
+		}
+	}
+
+	cp.ansi16 = &slots
+	return slots
+}
+
+// ANSI16 returns the palette's canonical ANSI colours (see CategorisedPalette.ANSI16).
+func (ph *PaletteHelper) ANSI16() [16]ColorValue {
+	return ph.palette.ANSI16()
+}
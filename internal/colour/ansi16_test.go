@@ -0,0 +1,86 @@
+package colour
+
+import (
+	"image/color"
+	"testing"
+)
+
+// ansi16TestPalette returns a palette with count extracted colours: a dark background,
+// a light foreground and hues spread around the wheel.
+func ansi16TestPalette(count int) *Palette {
+	colors := []color.Color{
+		color.RGBA{R: 20, G: 22, B: 30, A: 255},
+		color.RGBA{R: 235, G: 235, B: 240, A: 255},
+	}
+	for i := range count - 2 {
+		hue := float64(i) * 360 / float64(count-2)
+		lightness := 0.45 + 0.2*float64(i%2)
+		rgb := HSLToRGB(hue, 0.7, lightness)
+		colors = append(colors, color.RGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: 255})
+	}
+	return &Palette{Colors: colors}
+}
+
+func TestANSI16ClosestMatch(t *testing.T) {
+	categorised := Categorise(ansi16TestPalette(20), DefaultCategorisationConfig())
+	ph := NewPaletteHelper(categorised)
+	if ph.ExtractedCount() < 16 {
+		t.Fatalf("test palette has %d extracted colours, want at least 16", ph.ExtractedCount())
+	}
+
+	slots := categorised.ANSI16()
+	for i, cv := range slots {
+		want, _ := ph.FindClosestANSIColor(ansiColors[i].Name)
+		if cv.Hex() != want.Hex() {
+			t.Errorf("slot %d = %s, want closest %s %s", i, cv.Hex(), ansiColors[i].Name, want.Hex())
+		}
+	}
+}
+
+func TestANSI16SmallPaletteFallsBackToRoles(t *testing.T) {
+	categorised := Categorise(ansi16TestPalette(8), DefaultCategorisationConfig())
+	slots := categorised.ANSI16()
+
+	for i, role := range ansi16FallbackRoles {
+		cc, ok := categorised.Get(role)
+		if !ok {
+			continue
+		}
+		if got, want := slots[i].Hex(), cc.Hex; got != want {
+			t.Errorf("slot %d = %s, want %s (%s)", i, got, want, role)
+		}
+	}
+}
+
+func TestANSI16LightTheme(t *testing.T) {
+	config := DefaultCategorisationConfig()
+	config.ThemeType = ThemeLight
+	categorised := Categorise(ansi16TestPalette(20), config)
+	slots := categorised.ANSI16()
+
+	for i, role := range ansi16LightRoles {
+		cc, ok := categorised.Get(role)
+		if !ok {
+			t.Fatalf("light palette missing %s", role)
+		}
+		if got, want := slots[i].Hex(), cc.Hex; got != want {
+			t.Errorf("slot %d = %s, want %s (%s)", i, got, want, role)
+		}
+	}
+}
+
+func TestANSI16CachedUntilSet(t *testing.T) {
+	categorised := Categorise(ansi16TestPalette(8), DefaultCategorisationConfig())
+	first := categorised.ANSI16()
+
+	// The cache is shared with helpers built from the same palette.
+	if NewPaletteHelper(categorised).ANSI16() != first {
+		t.Error("PaletteHelper.ANSI16() differs from CategorisedPalette.ANSI16()")
+	}
+
+	replacement := createCategorisedColour(color.RGBA{R: 255, G: 0, B: 0, A: 255}, 0)
+	categorised.Set(RoleDanger, replacement)
+	if got := categorised.ANSI16()[1].Hex(); got != replacement.Hex {
+		t.Errorf("slot 1 after Set = %s, want %s", got, replacement.Hex)
+	}
+}
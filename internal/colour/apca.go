@@ -0,0 +1,136 @@
+// Package colour provides APCA contrast and contrast model selection.
+package colour
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
+
+// APCA lightness contrast (Lc) thresholds, as absolute values.
+const (
+	APCALcContent = 60.0 // Minimum for content text, comparable to WCAG AA
+	APCALcBody    = 75.0 // Minimum for body text, comparable to WCAG AAA
+)
+
+// APCA-W3 0.0.98G constants.
+const (
+	apcaNormBG     = 0.56
+	apcaNormTxt    = 0.57
+	apcaRevTxt     = 0.62
+	apcaRevBG      = 0.65
+	apcaBlackClamp = 0.022
+	apcaClampExp   = 1.414
+	apcaScale      = 1.14
+	apcaOffset     = 0.027
+	apcaLoClip     = 0.1
+	apcaDeltaYMin  = 0.0005
+)
+
+// APCAContrast returns the APCA lightness contrast (Lc) of text on bg, the
+// contrast method proposed for WCAG 3. It is roughly -108 to 106: positive for
+// dark text on a light background, negative for light text on a dark one, and
+// 0 when the difference is too small to read. Unlike the WCAG ratio it accounts
+// for polarity and is more accurate for mid-tone colours.
+func APCAContrast(text, bg color.Color) float64 {
+	yText := apcaLuminance(text)
+	yBG := apcaLuminance(bg)
+	if math.Abs(yBG-yText) < apcaDeltaYMin {
+		return 0
+	}
+
+	if yBG > yText {
+		// Dark text on a light background.
+		sapc := (math.Pow(yBG, apcaNormBG) - math.Pow(yText, apcaNormTxt)) * apcaScale
+		if sapc < apcaLoClip {
+			return 0
+		}
+		return (sapc - apcaOffset) * 100
+	}
+
+	// Light text on a dark background.
+	sapc := (math.Pow(yBG, apcaRevBG) - math.Pow(yText, apcaRevTxt)) * apcaScale
+	if sapc > -apcaLoClip {
+		return 0
+	}
+	return (sapc + apcaOffset) * 100
+}
+
+// apcaLuminance returns the APCA screen luminance of c, with near-black soft clamped.
+func apcaLuminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	y := 0.2126729*math.Pow(float64(r>>8)/255, 2.4) +
+		0.7151522*math.Pow(float64(g>>8)/255, 2.4) +
+		0.0721750*math.Pow(float64(b>>8)/255, 2.4)
+	if y < apcaBlackClamp {
+		y += math.Pow(apcaBlackClamp-y, apcaClampExp)
+	}
+	return y
+}
+
+// ContrastModel selects how text contrast is measured during categorisation.
+type ContrastModel string
+
+const (
+	// ContrastWCAG uses the WCAG 2.x contrast ratio (default).
+	ContrastWCAG ContrastModel = "wcag"
+	// ContrastAPCA uses APCA lightness contrast (Lc).
+	ContrastAPCA ContrastModel = "apca"
+)
+
+// ParseContrastModel parses a --contrast-model value. An empty string is treated as wcag.
+func ParseContrastModel(s string) (ContrastModel, error) {
+	switch ContrastModel(s) {
+	case "", ContrastWCAG:
+		return ContrastWCAG, nil
+	case ContrastAPCA:
+		return ContrastAPCA, nil
+	default:
+		return "", fmt.Errorf("invalid contrast model %q (valid: wcag, apca)", s)
+	}
+}
+
+// Contrast returns the contrast of text on bg: the WCAG ratio (1-21), or the
+// absolute APCA Lc (0-108). Larger is always more readable.
+func (m ContrastModel) Contrast(text, bg color.Color) float64 {
+	if m == ContrastAPCA {
+		return math.Abs(APCAContrast(text, bg))
+	}
+	return ContrastRatio(text, bg)
+}
+
+// threshold returns the model's normal (WCAG AA, APCA Lc 60) or enhanced
+// (WCAG AAA, APCA Lc 75) minimum text contrast.
+func (m ContrastModel) threshold(enhanced bool) float64 {
+	switch {
+	case m == ContrastAPCA && enhanced:
+		return APCALcBody
+	case m == ContrastAPCA:
+		return APCALcContent
+	case enhanced:
+		return WCAGAAA
+	default:
+		return WCAGAA
+	}
+}
+
+// format formats a contrast value for explanations, e.g. "4.5:1" or "Lc 60".
+func (m ContrastModel) format(v float64) string {
+	if m == ContrastAPCA {
+		return fmt.Sprintf("Lc %.0f", v)
+	}
+	return fmt.Sprintf("%.1f:1", v)
+}
+
+// minTextContrast returns the minimum foreground contrast the config asks for,
+// in the units of its contrast model. MinContrastRatio applies to WCAG only.
+func (c CategorisationConfig) minTextContrast() float64 {
+	switch {
+	case c.ContrastModel == ContrastAPCA:
+		return ContrastAPCA.threshold(c.RequireAAA)
+	case c.RequireAAA:
+		return WCAGAAA
+	default:
+		return c.MinContrastRatio
+	}
+}
@@ -0,0 +1,96 @@
+package colour
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestAPCAContrast(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	black := color.RGBA{A: 255}
+	grey := color.RGBA{R: 0x88, G: 0x88, B: 0x88, A: 255}
+
+	// Reference values from the APCA-W3 0.0.98G README.
+	tests := []struct {
+		name     string
+		text, bg color.Color
+		want     float64
+	}{
+		{"black on white", black, white, 106.04},
+		{"white on black", white, black, -107.88},
+		{"grey on white", grey, white, 63.06},
+		{"white on grey", white, grey, -68.54},
+		{"same colour", grey, grey, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := APCAContrast(tt.text, tt.bg); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("APCAContrast() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseContrastModel(t *testing.T) {
+	for input, want := range map[string]ContrastModel{"": ContrastWCAG, "wcag": ContrastWCAG, "apca": ContrastAPCA} {
+		if got, err := ParseContrastModel(input); err != nil || got != want {
+			t.Errorf("ParseContrastModel(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseContrastModel("wcag3"); err == nil {
+		t.Error("ParseContrastModel(\"wcag3\") should fail")
+	}
+}
+
+func TestGenerateOnColorContrastModel(t *testing.T) {
+	// A mid-tone blue: the WCAG ratio favours black text, APCA favours white.
+	accent := RGB{R: 0x1e, G: 0x90, B: 0xff}
+
+	tests := []struct {
+		model ContrastModel
+		want  string
+	}{
+		{ContrastWCAG, "#000000"},
+		{ContrastAPCA, "#ffffff"},
+	}
+
+	for _, tt := range tests {
+		palette := NewCategorisedPalette(ThemeDark)
+		palette.Set(RoleAccent1, CategorisedColour{Colour: RGBToColor(accent), RGB: accent, Hex: accent.Hex()})
+		generateOnColor(palette, RoleAccent1, RoleOnAccent1, map[Role]bool{}, tt.model)
+
+		on, ok := palette.Get(RoleOnAccent1)
+		if !ok {
+			t.Fatalf("%s: no onAccent1 generated", tt.model)
+		}
+		if on.Hex != tt.want {
+			t.Errorf("%s: onAccent1 = %s, want %s", tt.model, on.Hex, tt.want)
+		}
+	}
+}
+
+func TestCategoriseAPCAForeground(t *testing.T) {
+	palette := NewPalette([]color.Color{
+		color.RGBA{R: 0x1a, G: 0x1b, B: 0x26, A: 0xff},
+		color.RGBA{R: 0xc0, G: 0xca, B: 0xf5, A: 0xff},
+		color.RGBA{R: 0x56, G: 0x5f, B: 0x89, A: 0xff},
+		color.RGBA{R: 0x7a, G: 0xa2, B: 0xf7, A: 0xff},
+	})
+
+	config := DefaultCategorisationConfig()
+	config.ThemeType = ThemeDark
+	config.ContrastModel = ContrastAPCA
+	result := Categorise(palette, config)
+
+	fg, _ := result.Get(RoleForeground)
+	bg, _ := result.Get(RoleBackground)
+	if lc := math.Abs(APCAContrast(fg.Colour, bg.Colour)); lc < APCALcContent {
+		t.Errorf("foreground %s on %s has Lc %.0f, want at least %.0f", fg.Hex, bg.Hex, lc, APCALcContent)
+	}
+	if reason := result.Reasons[RoleForeground]; !strings.Contains(reason, "Lc ") {
+		t.Errorf("foreground reason %q does not report APCA contrast", reason)
+	}
+}
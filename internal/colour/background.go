@@ -1,6 +1,70 @@
 // Package colour provides background color selection logic.
 package colour
 
+import "fmt"
+
+// BackgroundMode controls how the background colour is chosen from the extracted palette.
+type BackgroundMode string
+
+const (
+	// BackgroundAuto uses the weighted luminance heuristic in selectBackground.
+	BackgroundAuto BackgroundMode = "auto"
+	// BackgroundDarkest pins the background to the darkest extracted colour (dark theme).
+	BackgroundDarkest BackgroundMode = "darkest"
+	// BackgroundLightest pins the background to the lightest extracted colour (light theme).
+	BackgroundLightest BackgroundMode = "lightest"
+)
+
+// ValidBackgroundModes returns all supported background modes.
+func ValidBackgroundModes() []BackgroundMode {
+	return []BackgroundMode{BackgroundAuto, BackgroundDarkest, BackgroundLightest}
+}
+
+// ParseBackgroundMode parses a background mode string.
+// An empty string is treated as BackgroundAuto.
+func ParseBackgroundMode(s string) (BackgroundMode, error) {
+	if s == "" {
+		return BackgroundAuto, nil
+	}
+	for _, mode := range ValidBackgroundModes() {
+		if string(mode) == s {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("invalid background mode %q (valid: auto, darkest, lightest)", s)
+}
+
+// selectBackgroundForMode selects the background colour honouring the configured mode.
+// BackgroundDarkest and BackgroundLightest override the selectBackground heuristic and,
+// when the theme type is ThemeAuto, imply a dark or light theme respectively.
+func selectBackgroundForMode(extracted []CategorisedColour, themeType ThemeType, mode BackgroundMode) (CategorisedColour, ThemeType) {
+	if len(extracted) == 0 || (mode != BackgroundDarkest && mode != BackgroundLightest) {
+		return selectBackground(extracted, themeType)
+	}
+
+	idx := 0
+	for i, color := range extracted {
+		if mode == BackgroundDarkest && color.Luminance < extracted[idx].Luminance {
+			idx = i
+		}
+		if mode == BackgroundLightest && color.Luminance > extracted[idx].Luminance {
+			idx = i
+		}
+	}
+
+	if themeType == ThemeAuto {
+		if mode == BackgroundDarkest {
+			themeType = ThemeDark
+		} else {
+			themeType = ThemeLight
+		}
+	}
+
+	bg := extracted[idx]
+	bg.Role = RoleBackground
+	return bg, themeType
+}
+
 // selectBackground selects the background color based on theme type.
 //
 // Design Theory:.
@@ -0,0 +1,119 @@
+package colour
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestParseBackgroundMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    BackgroundMode
+		wantErr bool
+	}{
+		{"", BackgroundAuto, false},
+		{"auto", BackgroundAuto, false},
+		{"darkest", BackgroundDarkest, false},
+		{"lightest", BackgroundLightest, false},
+		{"brightest", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseBackgroundMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBackgroundMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBackgroundMode(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoriseBackgroundMode(t *testing.T) {
+	// The dominant colour is a mid-dark blue, so the auto heuristic would pick it
+	// rather than the darkest or lightest extreme.
+	darkest := color.RGBA{R: 10, G: 10, B: 12, A: 255}
+	lightest := color.RGBA{R: 245, G: 240, B: 235, A: 255}
+	palette := &Palette{
+		Colors: []color.Color{
+			color.RGBA{R: 40, G: 60, B: 110, A: 255},
+			darkest,
+			lightest,
+			color.RGBA{R: 200, G: 90, B: 60, A: 255},
+			color.RGBA{R: 90, G: 170, B: 100, A: 255},
+		},
+		Weights: []float64{0.6, 0.1, 0.1, 0.1, 0.1},
+	}
+
+	tests := []struct {
+		name      string
+		mode      BackgroundMode
+		themeType ThemeType
+		wantHex   string
+		wantTheme ThemeType
+	}{
+		{"auto uses dominant colour", BackgroundAuto, ThemeAuto, "#283c6e", ThemeDark},
+		{"darkest implies dark theme", BackgroundDarkest, ThemeAuto, ToRGB(darkest).Hex(), ThemeDark},
+		{"lightest implies light theme", BackgroundLightest, ThemeAuto, ToRGB(lightest).Hex(), ThemeLight},
+		{"darkest with explicit dark theme", BackgroundDarkest, ThemeDark, ToRGB(darkest).Hex(), ThemeDark},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultCategorisationConfig()
+			config.ThemeType = tt.themeType
+			config.BackgroundMode = tt.mode
+
+			categorised := Categorise(palette, config)
+
+			bg, ok := categorised.Get(RoleBackground)
+			if !ok {
+				t.Fatal("background role not assigned")
+			}
+			if bg.Hex != tt.wantHex {
+				t.Errorf("background = %s, want %s", bg.Hex, tt.wantHex)
+			}
+			if categorised.ThemeType != tt.wantTheme {
+				t.Errorf("theme = %s, want %s", categorised.ThemeType, tt.wantTheme)
+			}
+		})
+	}
+}
+
+func TestCategoriseBackgroundModeRespectsHints(t *testing.T) {
+	palette := &Palette{
+		Colors: []color.Color{
+			color.RGBA{R: 10, G: 10, B: 12, A: 255},
+			color.RGBA{R: 60, G: 60, B: 80, A: 255},
+			color.RGBA{R: 230, G: 230, B: 230, A: 255},
+		},
+		RoleHints: map[Role]int{RoleBackground: 1},
+	}
+
+	config := DefaultCategorisationConfig()
+	config.BackgroundMode = BackgroundDarkest
+
+	categorised := Categorise(palette, config)
+	bg, _ := categorised.Get(RoleBackground)
+	if bg.Hex != "#3c3c50" {
+		t.Errorf("explicit background hint should win, got %s", bg.Hex)
+	}
+}
+
+func TestCategoriseAutoThemeFromHintedBackground(t *testing.T) {
+	colors := []color.Color{
+		color.RGBA{R: 0x1e, G: 0x1e, B: 0x2e, A: 255},
+		color.RGBA{R: 0xef, G: 0xf1, B: 0xf5, A: 255},
+		color.RGBA{R: 0x89, G: 0xb4, B: 0xfa, A: 255},
+	}
+
+	for hint, want := range map[int]ThemeType{0: ThemeDark, 1: ThemeLight} {
+		palette := NewPaletteWithRoleHints(colors, map[Role]int{RoleBackground: hint})
+		categorised := Categorise(palette, DefaultCategorisationConfig())
+		if categorised.ThemeType != want {
+			t.Errorf("background hint %d: ThemeType = %v, want %v", hint, categorised.ThemeType, want)
+		}
+	}
+}
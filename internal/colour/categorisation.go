@@ -2,9 +2,12 @@
 package colour
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"image/color"
+	"maps"
+	"slices"
 	"strings"
 )
 
@@ -113,6 +116,12 @@ const (
 	RolePositionLeftTopOuter      Role = "positionLeftTopOuter"
 )
 
+// MonitorRole returns the per-monitor variant of a role (e.g. "monitor1.positionLeft").
+// Used for positional roles when several wallpapers each contribute ambient regions.
+func MonitorRole(monitor int, role Role) Role {
+	return Role(fmt.Sprintf("monitor%d.%s", monitor, role))
+}
+
 // CategorisedColour represents a colour with its assigned role and metadata.
 type CategorisedColour struct {
 	Colour      color.Color `json:"-"`
@@ -157,23 +166,37 @@ func (t ThemeType) String() string {
 
 // CategorisationConfig holds configuration for colour categorisation.
 type CategorisationConfig struct {
-	ThemeType             ThemeType
-	MinContrastRatio      float64 // Minimum contrast between foreground and background
-	RequireAAA            bool    // Require AAA contrast (7:1) instead of AA (4.5:1)
-	MutedLuminanceAdjust  float64 // How much to adjust luminance for muted variants (0.0-1.0)
-	EnhanceSemanticColors bool    // Boost saturation and adjust lightness for semantic colors
-	SemanticBoostAmount   float64 // How much to boost semantic saturation (0.0-1.0)
+	ThemeType                ThemeType
+	BackgroundMode           BackgroundMode      // How the background is chosen (auto, darkest, lightest)
+	MinContrastRatio         float64             // Minimum contrast between foreground and background
+	RequireAAA               bool                // Require AAA contrast (7:1) instead of AA (4.5:1)
+	ContrastModel            ContrastModel       // How text contrast is measured (wcag, apca)
+	MutedLuminanceAdjust     float64             // How much to adjust luminance for muted variants (0.0-1.0)
+	MutedSaturationReduction float64             // Fraction of saturation removed for muted variants (0.0-1.0)
+	EnhanceSemanticColors    bool                // Boost saturation and adjust lightness for semantic colors
+	SemanticBoostAmount      float64             // How much to boost semantic saturation (0.0-1.0)
+	AccentSaturationScale    float64             // Multiplier applied to accent saturation (1.0 = unchanged)
+	AccentCount              int                 // Accent slots to fill, generating accents as needed (0 = up to 4 extracted accents)
+	SemanticPalette          SemanticPalette     // Semantic hue anchors (standard, cvd-safe)
+	MaxOutputColors          int                 // Maximum colours kept in AllColours (0 = unlimited)
+	PreviousPalette          *CategorisedPalette // Previous palette for stable accent slots (nil = disabled)
+	UseOKLCH                 bool                // Keep hue in OKLCH when generating muted and surface colours
 }
 
 // DefaultCategorisationConfig returns the default categorisation configuration.
 func DefaultCategorisationConfig() CategorisationConfig {
 	return CategorisationConfig{
-		ThemeType:             ThemeAuto,
-		MinContrastRatio:      4.5, // WCAG AA standard
-		RequireAAA:            false,
-		MutedLuminanceAdjust:  0.15, // 15% adjustment for muted variants
-		EnhanceSemanticColors: true, // Enable semantic color enhancement by default
-		SemanticBoostAmount:   0.3,  // 30% saturation boost
+		ThemeType:                ThemeAuto,
+		BackgroundMode:           BackgroundAuto,
+		MinContrastRatio:         4.5, // WCAG AA standard
+		RequireAAA:               false,
+		ContrastModel:            ContrastWCAG,
+		MutedLuminanceAdjust:     0.15, // 15% adjustment for muted variants
+		MutedSaturationReduction: 0.5,  // Muted variants keep half the saturation
+		EnhanceSemanticColors:    true, // Enable semantic color enhancement by default
+		SemanticBoostAmount:      DefaultSemanticBoostAmount,
+		AccentSaturationScale:    1.0, // Accents keep their extracted saturation
+		SemanticPalette:          SemanticPaletteStandard,
 	}
 }
 
@@ -182,6 +205,13 @@ type CategorisedPalette struct {
 	Colours    map[Role]CategorisedColour `json:"colours"`
 	ThemeType  ThemeType                  `json:"theme_type"`
 	AllColours []CategorisedColour        `json:"all_colours,omitempty"`
+	Meta       map[string]string          `json:"meta,omitempty"` // Provenance copied from Palette.Meta
+
+	// Reasons records why each role was assigned its colour (see Explain).
+	Reasons map[Role]string `json:"-"`
+
+	// ansi16 caches the canonical ANSI mapping (see ANSI16).
+	ansi16 *[16]ColorValue
 }
 
 // NewCategorisedPalette creates a new categorised palette.
@@ -202,6 +232,7 @@ func (cp *CategorisedPalette) Get(role Role) (CategorisedColour, bool) {
 func (cp *CategorisedPalette) Set(role Role, colour CategorisedColour) {
 	colour.Role = role
 	cp.Colours[role] = colour
+	cp.ansi16 = nil
 }
 
 // Categorise assigns roles to colours in a palette based on luminance, contrast, and hue.
@@ -210,7 +241,7 @@ func (cp *CategorisedPalette) Set(role Role, colour CategorisedColour) {
 // - background.go: Selects background color (theme-aware)
 // - foreground.go: Selects foreground color (highest contrast for text)
 // - accents.go: Selects and sorts accent colors (analogous to background)
-// - muted.go: Creates muted variants (50% saturation reduction)
+// - muted.go: Creates muted variants (reduced saturation, 50% by default)
 // - semantic.go: Assigns semantic colors (danger, warning, success, etc.)
 //
 // Role hints always override automatic categorization.
@@ -227,13 +258,23 @@ func Categorise(palette *Palette, config CategorisationConfig) *CategorisedPalet
 	// Step 2: Select background and apply hints.
 	hintsApplied := make(map[Role]bool)
 	bg, bgIdx, themeType := selectBackgroundWithHints(extracted, allExtracted,
-		palette.RoleHints, config.ThemeType, hintsApplied)
+		palette.RoleHints, config.ThemeType, config.BackgroundMode, hintsApplied)
 
 	// Sort extracted colours by luminance for consistent ordering.
 	sortByLuminance(extracted, themeType)
 
 	result := NewCategorisedPalette(themeType)
 	result.Set(RoleBackground, bg)
+	if hintsApplied[RoleBackground] {
+		result.explain(RoleBackground, "role hint from the input plugin (extracted colour %d)", bgIdx)
+	} else {
+		result.explain(RoleBackground, "%s", backgroundReason(bg, config.ThemeType, themeType, config.BackgroundMode))
+	}
+
+	// Keep the background index pointing at the background in the sorted colours.
+	if bgIdx >= 0 {
+		bgIdx = findColourIndex(extracted, bg.Hex)
+	}
 
 	// Step 3: Apply other role hints.
 	applyRoleHints(result, extracted, allExtracted, palette.RoleHints, hintsApplied)
@@ -250,25 +291,35 @@ func Categorise(palette *Palette, config CategorisationConfig) *CategorisedPalet
 	sortAccentsForTheme(accents, bg, fg, themeType)
 
 	// Generate synthetic accents if needed.
+	accentCount := max(config.AccentCount, DefaultAccentCount)
 	if needsSyntheticAccents(accents, bg) {
-		accents = generateSyntheticAccents(bg, themeType, 4)
+		accents = generateSyntheticAccents(bg, themeType, accentCount)
+	} else if len(accents) < config.AccentCount {
+		// Fill the requested slots the extracted colours cannot.
+		synthetic := generateSyntheticAccents(bg, themeType, config.AccentCount)
+		accents = append(accents, synthetic[len(accents):]...)
 	}
 
+	// Keep accents in the slots nearest their previous hue if requested.
+	stabiliseAccentSlots(accents, config.PreviousPalette)
+
 	// Step 7: Assign accent roles and their muted variants.
-	assignAccentRoles(result, accents, themeType, config, palette.RoleHints)
+	assignAccentRoles(result, accents, accentCount, themeType, config, palette.RoleHints)
 
 	// Step 8: Assign semantic roles.
 	usedForSemantic := make(map[string]bool)
-	assignSemanticRolesWithHints(result, accents, usedForSemantic, hintsApplied)
+	assignSemanticRolesWithHints(result, accents, usedForSemantic, hintsApplied, config.SemanticPalette, config.SemanticBoostAmount)
 
 	// Step 9: Generate surface and container colors.
-	generateSurfaceColors(result, bg, fg, themeType, hintsApplied)
+	generateSurfaceColors(result, bg, fg, themeType, hintsApplied, config)
 
 	// Step 10: Collect unassigned colors.
 	additionalColors := collectUnassignedColors(allExtracted, result)
 
 	// Step 11: Build final AllColours array.
 	result.AllColours = buildSortedAllColours(result, themeType, additionalColors)
+	result.AllColours = truncateAllColours(result.AllColours, themeType, config.MaxOutputColors)
+	result.Meta = maps.Clone(palette.Meta)
 
 	return result
 }
@@ -293,21 +344,29 @@ func collectUnassignedColors(allExtracted []CategorisedColour, result *Categoris
 }
 
 // buildSortedAllColours creates the final sorted array of all colours.
-// All colours are sorted by luminance (dark→light for dark themes, light→dark for light themes).
-// Indices are assigned sequentially based on sort order (0, 1, 2, ...).
+//
+// Ordering guarantee: colours are sorted by luminance (dark→light for dark themes,
+// light→dark for light themes). Colours with equal luminance keep a stable order:
+// role colours first, ordered by role name, followed by unassigned extracted colours
+// in extraction order. Indices are assigned sequentially based on sort order (0, 1, 2, ...).
 // The index field is purely positional metadata with no semantic meaning.
 func buildSortedAllColours(palette *CategorisedPalette, themeType ThemeType, additionalColors []CategorisedColour) []CategorisedColour {
-	// Collect all colours from the palette.
-	allColours := make([]CategorisedColour, 0, len(palette.Colours)+len(additionalColors))
+	// Collect all colours from the palette in role name order so ties sort deterministically.
+	roles := make([]Role, 0, len(palette.Colours))
+	for role := range palette.Colours {
+		roles = append(roles, role)
+	}
+	slices.Sort(roles)
 
-	for _, cc := range palette.Colours {
-		allColours = append(allColours, cc)
+	allColours := make([]CategorisedColour, 0, len(palette.Colours)+len(additionalColors))
+	for _, role := range roles {
+		allColours = append(allColours, palette.Colours[role])
 	}
 
 	// Add any extra colors that weren't assigned to semantic roles.
 	allColours = append(allColours, additionalColors...)
 
-	// Sort all colours by luminance (theme-aware).
+	// Sort all colours by luminance (theme-aware, stable).
 	sortByLuminance(allColours, themeType)
 
 	// Assign sequential indices based on sorted position.
@@ -318,6 +377,44 @@ func buildSortedAllColours(palette *CategorisedPalette, themeType ThemeType, add
 	return allColours
 }
 
+// truncateAllColours limits colours to the max most significant entries.
+// Significance is weight (descending), then visibility against the background:
+// lighter first for dark themes, darker first for light themes. Generated colours have
+// zero weight, so extracted colours are always kept ahead of them. The result keeps the
+// ordering guarantee of buildSortedAllColours and is re-indexed.
+func truncateAllColours(colours []CategorisedColour, themeType ThemeType, maxColours int) []CategorisedColour {
+	if maxColours <= 0 || len(colours) <= maxColours {
+		return colours
+	}
+
+	ranked := make([]CategorisedColour, len(colours))
+	copy(ranked, colours)
+	slices.SortStableFunc(ranked, func(a, b CategorisedColour) int {
+		if a.Weight != b.Weight {
+			return cmp.Compare(b.Weight, a.Weight)
+		}
+		if themeType == ThemeLight {
+			return cmp.Compare(a.Luminance, b.Luminance)
+		}
+		return cmp.Compare(b.Luminance, a.Luminance)
+	})
+
+	kept := make(map[int]bool, maxColours)
+	for _, cc := range ranked[:maxColours] {
+		kept[cc.Index] = true
+	}
+
+	truncated := make([]CategorisedColour, 0, maxColours)
+	for _, cc := range colours {
+		if kept[cc.Index] {
+			cc.Index = len(truncated)
+			truncated = append(truncated, cc)
+		}
+	}
+
+	return truncated
+}
+
 // ToJSON converts the categorised palette to JSON format.
 func (cp *CategorisedPalette) ToJSON() ([]byte, error) {
 	return json.MarshalIndent(cp, "", "  ")
@@ -28,10 +28,14 @@ func normalizeWeights(weights []float64, count int) []float64 {
 }
 
 // createCategorisedColour creates a single categorised colour with metadata.
+// Alpha is kept in RGBA only; roles are chosen from the colour at full opacity.
 func createCategorisedColour(c color.Color, weight float64) CategorisedColour {
-	lum := Luminance(c)
-	rgb := ToRGB(c)
 	rgba := ToRGBA(c)
+	rgb := rgba.ToRGB()
+	if rgba.A != 255 {
+		c = RGBToColor(rgb)
+	}
+	lum := Luminance(c)
 	h, s, _ := rgbToHSL(rgb)
 
 	return CategorisedColour{
@@ -50,7 +54,7 @@ func createCategorisedColour(c color.Color, weight float64) CategorisedColour {
 
 // selectBackgroundWithHints selects background color, applying hints if available.
 func selectBackgroundWithHints(extracted, allExtracted []CategorisedColour,
-	hints map[Role]int, themeType ThemeType, hintsApplied map[Role]bool) (CategorisedColour, int, ThemeType) {
+	hints map[Role]int, themeType ThemeType, mode BackgroundMode, hintsApplied map[Role]bool) (CategorisedColour, int, ThemeType) {
 
 	// Check for hinted background.
 	if bgIdx, hasHint := hints[RoleBackground]; hasHint {
@@ -58,12 +62,19 @@ func selectBackgroundWithHints(extracted, allExtracted []CategorisedColour,
 			bg := allExtracted[bgIdx]
 			bg.Role = RoleBackground
 			hintsApplied[RoleBackground] = true
+			// Detect an auto theme from the hinted background, as for a dominant one.
+			if themeType == ThemeAuto {
+				themeType = ThemeDark
+				if bg.Luminance >= 0.5 {
+					themeType = ThemeLight
+				}
+			}
 			return bg, bgIdx, themeType
 		}
 	}
 
 	// No hint, select background normally.
-	bg, finalTheme := selectBackground(extracted, themeType)
+	bg, finalTheme := selectBackgroundForMode(extracted, themeType, mode)
 
 	// Find index.
 	bgIdx := findColourIndex(extracted, bg.Hex)
@@ -91,6 +102,7 @@ func applyRoleHints(result *CategorisedPalette, extracted, allExtracted []Catego
 				if cc.Hex == hintedColor.Hex {
 					cc.Role = role
 					result.Set(role, cc)
+					result.explain(role, "role hint from the input plugin (extracted colour %d)", originalIndex)
 					hintsApplied[role] = true
 					break
 				}
@@ -113,10 +125,17 @@ func selectForegroundWithHints(result *CategorisedPalette, extracted []Categoris
 
 	// Select foreground.
 	fgIdx = selectForeground(extracted, bg, config, bgIdx)
+	model := config.ContrastModel
+	minContrast := config.minTextContrast()
 	if fgIdx >= 0 {
 		fg = extracted[fgIdx]
 		fg.Role = RoleForeground
 		result.Set(RoleForeground, fg)
+		if contrast := model.Contrast(fg.Colour, bg.Colour); contrast >= minContrast {
+			result.explain(RoleForeground, "highest contrast with the background (%s, minimum %s)", model.format(contrast), model.format(minContrast))
+		} else {
+			result.explain(RoleForeground, "highest available contrast with the background (%s, below the %s minimum)", model.format(contrast), model.format(minContrast))
+		}
 		return fg, fgIdx
 	}
 
@@ -124,6 +143,8 @@ func selectForegroundWithHints(result *CategorisedPalette, extracted []Categoris
 	fg = generateSyntheticForeground(bg, themeType, config)
 	fg.Role = RoleForeground
 	result.Set(RoleForeground, fg)
+	result.explain(RoleForeground, "generated: no other extracted colour; background hue adjusted to %s contrast",
+		model.format(model.Contrast(fg.Colour, bg.Colour)))
 	return fg, -1
 }
 
@@ -133,19 +154,21 @@ func addMutedVariants(result *CategorisedPalette, bg, fg CategorisedColour,
 
 	// Background muted.
 	if _, hasHint := hints[RoleBackgroundMuted]; !hasHint {
-		bgMuted := createMutedVariant(bg, config.MutedLuminanceAdjust, themeType, true)
+		bgMuted := createMutedVariant(bg, config.MutedLuminanceAdjust, config.MutedSaturationReduction, themeType, true, config.UseOKLCH)
 		bgMuted.Role = RoleBackgroundMuted
 		bgMuted.IsGenerated = true
 		result.Set(RoleBackgroundMuted, bgMuted)
+		result.explain(RoleBackgroundMuted, "generated: muted variant of background")
 	}
 
 	// Foreground muted (if foreground exists).
 	if _, hasFg := result.Get(RoleForeground); hasFg {
 		if _, hasHint := hints[RoleForegroundMuted]; !hasHint {
-			fgMuted := createMutedVariant(fg, config.MutedLuminanceAdjust, themeType, false)
+			fgMuted := createMutedVariant(fg, config.MutedLuminanceAdjust, config.MutedSaturationReduction, themeType, false, config.UseOKLCH)
 			fgMuted.Role = RoleForegroundMuted
 			fgMuted.IsGenerated = true
 			result.Set(RoleForegroundMuted, fgMuted)
+			result.explain(RoleForegroundMuted, "generated: muted variant of foreground")
 		}
 	}
 }
@@ -184,26 +207,18 @@ func buildUsedIndicesSet(hints map[Role]int, bgIdx, fgIdx int) map[int]bool {
 	return used
 }
 
-// assignAccentRoles assigns accent colors to primary and muted roles.
-func assignAccentRoles(result *CategorisedPalette, accents []CategorisedColour,
+// assignAccentRoles assigns accent colors to the primary and muted roles of the
+// first count accent slots.
+func assignAccentRoles(result *CategorisedPalette, accents []CategorisedColour, count int,
 	themeType ThemeType, config CategorisationConfig, hints map[Role]int) {
 
-	accentRoles := []struct {
-		primary Role
-		muted   Role
-	}{
-		{RoleAccent1, RoleAccent1Muted},
-		{RoleAccent2, RoleAccent2Muted},
-		{RoleAccent3, RoleAccent3Muted},
-		{RoleAccent4, RoleAccent4Muted},
-	}
-
 	accentIndex := 0
-	for _, roles := range accentRoles {
+	for n := 1; n <= count; n++ {
 		if accentIndex >= len(accents) {
 			break
 		}
 
+		roles := struct{ primary, muted Role }{AccentRole(n), AccentMutedRole(n)}
 		assignAccentPair(result, accents, &accentIndex, roles, themeType, config, hints)
 	}
 }
@@ -222,16 +237,18 @@ func assignAccentPair(result *CategorisedPalette, accents []CategorisedColour,
 		return
 	}
 
-	accent := accents[*accentIndex]
+	accent := scaleSaturation(accents[*accentIndex], config.AccentSaturationScale)
 	accent.Role = roles.primary
 	result.Set(roles.primary, accent)
+	result.explain(roles.primary, "%s", accentReason(accent, *accentIndex, len(accents), themeType, config.PreviousPalette != nil))
 
 	// Create muted variant if not hinted.
 	if _, hasHint := hints[roles.muted]; !hasHint {
-		muted := createMutedVariant(accent, config.MutedLuminanceAdjust, themeType, false)
+		muted := createMutedVariant(accent, config.MutedLuminanceAdjust, config.MutedSaturationReduction, themeType, false, config.UseOKLCH)
 		muted.Role = roles.muted
 		muted.IsGenerated = true
 		result.Set(roles.muted, muted)
+		result.explain(roles.muted, "generated: muted variant of %s", roles.primary)
 	}
 
 	*accentIndex++
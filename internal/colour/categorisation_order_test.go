@@ -0,0 +1,116 @@
+package colour
+
+import (
+	"image/color"
+	"testing"
+)
+
+func weightedTestPalette() *Palette {
+	return &Palette{
+		Colors: []color.Color{
+			color.RGBA{R: 18, G: 20, B: 30, A: 255},    // 0: dominant dark
+			color.RGBA{R: 210, G: 215, B: 230, A: 255}, // 1: light
+			color.RGBA{R: 200, G: 70, B: 60, A: 255},   // 2: red
+			color.RGBA{R: 70, G: 160, B: 90, A: 255},   // 3: green
+			color.RGBA{R: 80, G: 110, B: 200, A: 255},  // 4: blue
+			color.RGBA{R: 190, G: 150, B: 60, A: 255},  // 5: gold
+		},
+		Weights: []float64{0.40, 0.25, 0.15, 0.10, 0.06, 0.04},
+	}
+}
+
+func TestAllColoursOrderingIsStable(t *testing.T) {
+	config := DefaultCategorisationConfig()
+	config.ThemeType = ThemeDark
+
+	first := Categorise(weightedTestPalette(), config)
+	for range 20 {
+		again := Categorise(weightedTestPalette(), config)
+		if len(again.AllColours) != len(first.AllColours) {
+			t.Fatalf("AllColours length changed: %d vs %d", len(again.AllColours), len(first.AllColours))
+		}
+		for i := range first.AllColours {
+			if again.AllColours[i].Role != first.AllColours[i].Role || again.AllColours[i].Hex != first.AllColours[i].Hex {
+				t.Fatalf("AllColours order differs at %d: %s/%s vs %s/%s", i,
+					again.AllColours[i].Role, again.AllColours[i].Hex,
+					first.AllColours[i].Role, first.AllColours[i].Hex)
+			}
+		}
+	}
+
+	for i := 1; i < len(first.AllColours); i++ {
+		if first.AllColours[i].Luminance < first.AllColours[i-1].Luminance {
+			t.Errorf("dark theme AllColours not ascending by luminance at %d", i)
+		}
+		if first.AllColours[i].Index != i {
+			t.Errorf("AllColours[%d].Index = %d", i, first.AllColours[i].Index)
+		}
+	}
+}
+
+func TestMaxOutputColorsKeepsHighestWeight(t *testing.T) {
+	palette := weightedTestPalette()
+
+	config := DefaultCategorisationConfig()
+	config.ThemeType = ThemeDark
+	full := Categorise(palette, config)
+
+	const maxColours = 4
+	config.MaxOutputColors = maxColours
+	truncated := Categorise(palette, config)
+
+	if len(full.AllColours) <= maxColours {
+		t.Fatalf("test palette should produce more than %d colours, got %d", maxColours, len(full.AllColours))
+	}
+	if len(truncated.AllColours) != maxColours {
+		t.Fatalf("expected %d colours, got %d", maxColours, len(truncated.AllColours))
+	}
+
+	// The top-weighted extracted colours must survive truncation.
+	kept := make(map[string]bool)
+	for _, cc := range truncated.AllColours {
+		kept[cc.Hex] = true
+	}
+	for _, idx := range []int{0, 1} {
+		hex := ToRGB(palette.Colors[idx]).Hex()
+		if !kept[hex] {
+			t.Errorf("highest-weight colour %s (weight %.2f) was dropped", hex, palette.Weights[idx])
+		}
+	}
+
+	// Every kept colour must weigh at least as much as every dropped colour.
+	minKept := 1.0
+	for _, cc := range truncated.AllColours {
+		minKept = min(minKept, cc.Weight)
+	}
+	for _, cc := range full.AllColours {
+		if !kept[cc.Hex] && cc.Weight > minKept {
+			t.Errorf("dropped %s (weight %.2f) outweighs kept minimum %.2f", cc.Hex, cc.Weight, minKept)
+		}
+	}
+
+	// Ordering guarantee and indices are preserved after truncation.
+	for i, cc := range truncated.AllColours {
+		if cc.Index != i {
+			t.Errorf("AllColours[%d].Index = %d", i, cc.Index)
+		}
+		if i > 0 && cc.Luminance < truncated.AllColours[i-1].Luminance {
+			t.Errorf("truncated AllColours not sorted by luminance at %d", i)
+		}
+	}
+
+	// Role assignments are unaffected by truncation.
+	if len(truncated.Colours) != len(full.Colours) {
+		t.Errorf("role count changed: %d vs %d", len(truncated.Colours), len(full.Colours))
+	}
+}
+
+func TestTruncateAllColoursNoLimit(t *testing.T) {
+	colours := []CategorisedColour{{Index: 0}, {Index: 1}}
+	if got := truncateAllColours(colours, ThemeDark, 0); len(got) != 2 {
+		t.Errorf("limit 0 should not truncate, got %d", len(got))
+	}
+	if got := truncateAllColours(colours, ThemeDark, 5); len(got) != 2 {
+		t.Errorf("limit above length should not truncate, got %d", len(got))
+	}
+}
@@ -78,7 +78,7 @@ func TestSemanticColourEnhancement(t *testing.T) {
 			}
 
 			// Enhance the color.
-			enhanced := enhanceSemanticColour(inputCategorised, tt.role, tt.themeType, true, bgCategorised)
+			enhanced := enhanceSemanticColour(inputCategorised, tt.role, tt.themeType, true, bgCategorised, DefaultSemanticBoostAmount)
 
 			// Check saturation is boosted.
 			if enhanced.Saturation < tt.wantMinSat {
@@ -171,7 +171,7 @@ func TestGenerateFallbackSemanticColours(t *testing.T) {
 			}
 
 			// Generate fallback color.
-			fallback := generateFallbackSemanticColour(tt.role, tt.themeType, true, bgCategorised)
+			fallback := generateFallbackSemanticColour(tt.role, tt.themeType, true, bgCategorised, DefaultSemanticBoostAmount)
 
 			// Check hue is correct.
 			hueDiff := math.Abs(fallback.Hue - tt.wantHue)
@@ -434,3 +434,27 @@ func TestThemeAwareLightness(t *testing.T) {
 		})
 	}
 }
+
+func TestCategoriseForegroundAfterLuminanceSort(t *testing.T) {
+	// The background is extracted last but sorts first, so its extracted index
+	// is where the lightest colour, the best foreground, ends up after sorting.
+	palette := &Palette{Colors: []color.Color{
+		color.RGBA{R: 0xe0, G: 0xe0, B: 0xe8, A: 255},
+		color.RGBA{R: 0xc0, G: 0x50, B: 0x50, A: 255},
+		color.RGBA{R: 0xd0, G: 0xb0, B: 0x40, A: 255},
+		color.RGBA{R: 0x50, G: 0x70, B: 0xd0, A: 255},
+		color.RGBA{R: 0x18, G: 0x18, B: 0x24, A: 255},
+	}}
+
+	config := DefaultCategorisationConfig()
+	config.ThemeType = ThemeDark
+	config.BackgroundMode = BackgroundDarkest
+	categorised := Categorise(palette, config)
+
+	if bg, _ := categorised.Get(RoleBackground); bg.Hex != "#181824" {
+		t.Fatalf("background = %s, want #181824", bg.Hex)
+	}
+	if fg, _ := categorised.Get(RoleForeground); fg.Hex != "#e0e0e8" {
+		t.Errorf("foreground = %s, want the highest contrast colour #e0e0e8", fg.Hex)
+	}
+}
@@ -0,0 +1,100 @@
+// Package colour provides colour space conversion for HDR and wide-gamut aware outputs.
+package colour
+
+import (
+	"fmt"
+	"math"
+)
+
+// ColorSpace is the colour space output plugins emit colours in, where supported.
+type ColorSpace string
+
+const (
+	// ColorSpaceSRGB emits colours as sRGB (default).
+	ColorSpaceSRGB ColorSpace = "srgb"
+	// ColorSpaceDisplayP3 emits colours converted to Display-P3.
+	ColorSpaceDisplayP3 ColorSpace = "display-p3"
+	// ColorSpaceLinear emits colours as linear-light sRGB.
+	ColorSpaceLinear ColorSpace = "linear"
+)
+
+// ParseColorSpace parses a --color-space value. An empty string is treated as sRGB.
+func ParseColorSpace(s string) (ColorSpace, error) {
+	switch ColorSpace(s) {
+	case "", ColorSpaceSRGB:
+		return ColorSpaceSRGB, nil
+	case ColorSpaceDisplayP3, ColorSpaceLinear:
+		return ColorSpace(s), nil
+	default:
+		return "", fmt.Errorf("invalid colour space %q (valid: srgb, display-p3, linear)", s)
+	}
+}
+
+// linearSRGBToP3 converts linear sRGB to linear Display-P3 (both D65), per CSS Color 4.
+var linearSRGBToP3 = [3][3]float64{
+	{0.8224619687143623, 0.17753803128563775, 0},
+	{0.033194199363165, 0.9668058006368351, 0},
+	{0.017082630607604, 0.0723974405270917, 0.9105199288653042},
+}
+
+// LinearRGB returns the colour's linear-light sRGB channels (0-1).
+func (rgb RGB) LinearRGB() (r, g, b float64) {
+	return SRGBToLinear(float64(rgb.R) / 255), SRGBToLinear(float64(rgb.G) / 255), SRGBToLinear(float64(rgb.B) / 255)
+}
+
+// DisplayP3 returns the colour's Display-P3 channels (0-1, gamma encoded).
+// Every sRGB colour lies within the P3 gamut, so no clipping is needed.
+func (rgb RGB) DisplayP3() (r, g, b float64) {
+	lr, lg, lb := rgb.LinearRGB()
+	m := linearSRGBToP3
+	// Display-P3 shares the sRGB transfer function.
+	return LinearToSRGB(m[0][0]*lr + m[0][1]*lg + m[0][2]*lb),
+		LinearToSRGB(m[1][0]*lr + m[1][1]*lg + m[1][2]*lb),
+		LinearToSRGB(m[2][0]*lr + m[2][1]*lg + m[2][2]*lb)
+}
+
+// SRGBToLinear converts a gamma-encoded sRGB channel value (0-1) to linear light.
+func SRGBToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// LinearToSRGB converts a linear light channel value to gamma-encoded sRGB (0-1),
+// clamping out-of-range values first.
+func LinearToSRGB(v float64) float64 {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1.0/2.4) - 0.055
+}
+
+// CSSColor returns the colour as a CSS Color 4 value in space.
+// sRGB uses #rrggbb (#rrggbbaa when translucent); Display-P3 and linear use
+// color(display-p3 ...) and color(srgb-linear ...), with "/ alpha" when translucent.
+func (cv ColorValue) CSSColor(space ColorSpace) string {
+	rgb := cv.rgba.ToRGB()
+
+	var name string
+	var r, g, b float64
+	switch space {
+	case ColorSpaceDisplayP3:
+		name = "display-p3"
+		r, g, b = rgb.DisplayP3()
+	case ColorSpaceLinear:
+		name = "srgb-linear"
+		r, g, b = rgb.LinearRGB()
+	default:
+		if cv.rgba.A < 255 {
+			return cv.HexAlpha()
+		}
+		return cv.Hex()
+	}
+
+	if cv.rgba.A < 255 {
+		return fmt.Sprintf("color(%s %.4f %.4f %.4f / %.2f)", name, r, g, b, cv.rgba.AlphaFloat())
+	}
+	return fmt.Sprintf("color(%s %.4f %.4f %.4f)", name, r, g, b)
+}
@@ -0,0 +1,101 @@
+package colour
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSRGBLinearRoundTrip(t *testing.T) {
+	for i := 0; i <= 255; i++ {
+		v := float64(i) / 255.0
+		got := LinearToSRGB(SRGBToLinear(v))
+		if math.Abs(got-v) > 1e-9 {
+			t.Errorf("round trip of %d: got %f, want %f", i, got, v)
+		}
+	}
+	if got := LinearToSRGB(-0.5); got != 0 {
+		t.Errorf("LinearToSRGB(-0.5) = %f, want 0", got)
+	}
+	if got := LinearToSRGB(1.5); math.Abs(got-1) > 1e-9 {
+		t.Errorf("LinearToSRGB(1.5) = %f, want 1", got)
+	}
+}
+
+func TestDisplayP3(t *testing.T) {
+	// Reference values from the CSS Color 4 sRGB to Display-P3 conversion.
+	tests := []struct {
+		name    string
+		rgb     RGB
+		r, g, b float64
+	}{
+		{"red", RGB{R: 255}, 0.9175, 0.2003, 0.1386},
+		{"green", RGB{G: 255}, 0.4584, 0.9853, 0.2983},
+		{"blue", RGB{B: 255}, 0, 0, 0.9596},
+		{"white", RGB{R: 255, G: 255, B: 255}, 1, 1, 1},
+		{"black", RGB{}, 0, 0, 0},
+		{"grey", RGB{R: 128, G: 128, B: 128}, 0.5020, 0.5020, 0.5020},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, g, b := tt.rgb.DisplayP3()
+			if math.Abs(r-tt.r) > 1e-4 || math.Abs(g-tt.g) > 1e-4 || math.Abs(b-tt.b) > 1e-4 {
+				t.Errorf("DisplayP3() = (%.4f, %.4f, %.4f), want (%.4f, %.4f, %.4f)", r, g, b, tt.r, tt.g, tt.b)
+			}
+		})
+	}
+}
+
+func TestLinearRGB(t *testing.T) {
+	r, g, b := RGB{R: 128, G: 255, B: 0}.LinearRGB()
+	if math.Abs(r-0.2159) > 1e-4 || g != 1 || b != 0 {
+		t.Errorf("LinearRGB() = (%.4f, %.4f, %.4f), want (0.2159, 1, 0)", r, g, b)
+	}
+}
+
+func TestCSSColor(t *testing.T) {
+	red := NewColorValue(RGBA{R: 255, A: 255}, "", -1)
+
+	tests := []struct {
+		space ColorSpace
+		cv    ColorValue
+		want  string
+	}{
+		{ColorSpaceSRGB, red, "#ff0000"},
+		{ColorSpaceDisplayP3, red, "color(display-p3 0.9175 0.2003 0.1386)"},
+		{ColorSpaceLinear, red, "color(srgb-linear 1.0000 0.0000 0.0000)"},
+		{ColorSpaceSRGB, red.WithAlpha(0.5), "#ff00007f"},
+		{ColorSpaceDisplayP3, red.WithAlpha(0.5), "color(display-p3 0.9175 0.2003 0.1386 / 0.50)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.cv.CSSColor(tt.space); got != tt.want {
+			t.Errorf("CSSColor(%s) = %q, want %q", tt.space, got, tt.want)
+		}
+	}
+}
+
+func TestParseColorSpace(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ColorSpace
+		wantErr bool
+	}{
+		{"", ColorSpaceSRGB, false},
+		{"srgb", ColorSpaceSRGB, false},
+		{"display-p3", ColorSpaceDisplayP3, false},
+		{"linear", ColorSpaceLinear, false},
+		{"rec2020", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseColorSpace(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseColorSpace(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseColorSpace(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Package colour provides categorisation explanations for debugging role assignments.
+package colour
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// explain records why role was assigned its colour.
+func (cp *CategorisedPalette) explain(role Role, format string, args ...any) {
+	if cp.Reasons == nil {
+		cp.Reasons = make(map[Role]string)
+	}
+	cp.Reasons[role] = fmt.Sprintf(format, args...)
+}
+
+// Reason returns the recorded reason for a role's colour, if one was recorded.
+func (cp *CategorisedPalette) Reason(role Role) (string, bool) {
+	reason, ok := cp.Reasons[role]
+	return reason, ok
+}
+
+// Explain returns one line per role describing why its colour was chosen, in the
+// standard role order followed by any other recorded roles (such as positions).
+// Roles generated without a recorded reason are described as derived.
+func (cp *CategorisedPalette) Explain() string {
+	roles := NewPaletteHelper(cp).AllRoles()
+
+	extra := make([]Role, 0)
+	for role := range cp.Reasons {
+		if !slices.Contains(roles, role) {
+			extra = append(extra, role)
+		}
+	}
+	slices.Sort(extra)
+	roles = append(roles, extra...)
+
+	width := 0
+	for _, role := range roles {
+		width = max(width, len(role))
+	}
+
+	var b strings.Builder
+	for _, role := range roles {
+		cc, ok := cp.Get(role)
+		if !ok {
+			continue
+		}
+		reason, ok := cp.Reason(role)
+		if !ok {
+			reason = "generated: derived from the background, foreground and accents"
+		}
+		fmt.Fprintf(&b, "%-*s  %s  %s\n", width, role, cc.Hex, reason)
+	}
+	return b.String()
+}
+
+// backgroundReason describes how bg was chosen by selectBackgroundForMode.
+// requested is the configured theme type and themeType the resulting one.
+func backgroundReason(bg CategorisedColour, requested, themeType ThemeType, mode BackgroundMode) string {
+	switch {
+	case mode == BackgroundDarkest:
+		return "darkest extracted colour (background mode darkest)"
+	case mode == BackgroundLightest:
+		return "lightest extracted colour (background mode lightest)"
+	case requested == ThemeAuto:
+		return fmt.Sprintf("most dominant colour (weight %s); luminance %.2f selects a %s theme",
+			formatWeight(bg.Weight), bg.Luminance, themeType)
+	case requested == ThemeDark && bg.Luminance < 0.5:
+		return fmt.Sprintf("most dominant dark colour (weight %s, luminance %.2f)", formatWeight(bg.Weight), bg.Luminance)
+	case requested == ThemeDark:
+		return "darkest colour; no extracted colour is dark (luminance < 0.5)"
+	case bg.Luminance >= 0.5:
+		return fmt.Sprintf("most dominant light colour (weight %s, luminance %.2f)", formatWeight(bg.Weight), bg.Luminance)
+	default:
+		return "lightest colour; no extracted colour is light (luminance >= 0.5)"
+	}
+}
+
+// accentReason describes how an accent in position idx of n candidates was chosen.
+func accentReason(accent CategorisedColour, idx, n int, themeType ThemeType, stabilised bool) string {
+	if accent.IsGenerated {
+		return fmt.Sprintf("generated: extracted accents are too few or too similar to the background; hue %.0f°", accent.Hue)
+	}
+
+	order := "lightest first for a dark theme"
+	if themeType == ThemeLight {
+		order = "darkest first for a light theme"
+	}
+	reason := fmt.Sprintf("accent candidate %d of %d, ranked by harmony with the background (hue, saturation, contrast) then ordered %s; hue %.0f°, weight %s",
+		idx+1, n, order, accent.Hue, formatWeight(accent.Weight))
+	if stabilised {
+		reason += "; slot kept close to the previous palette's hue"
+	}
+	return reason
+}
+
+// formatWeight formats a 0-1 weight as a percentage.
+func formatWeight(weight float64) string {
+	return fmt.Sprintf("%.0f%%", weight*100)
+}
@@ -0,0 +1,132 @@
+package colour
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// explainTestPalette returns a dark palette with a dominant background and distinct accents.
+func explainTestPalette() *Palette {
+	return &Palette{
+		Colors: []color.Color{
+			color.RGBA{R: 20, G: 22, B: 30, A: 255},    // background
+			color.RGBA{R: 235, G: 235, B: 240, A: 255}, // foreground
+			color.RGBA{R: 220, G: 80, B: 80, A: 255},
+			color.RGBA{R: 90, G: 200, B: 110, A: 255},
+			color.RGBA{R: 90, G: 140, B: 230, A: 255},
+			color.RGBA{R: 230, G: 190, B: 70, A: 255},
+			color.RGBA{R: 180, G: 110, B: 220, A: 255},
+		},
+		Weights: []float64{0.4, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1},
+	}
+}
+
+func TestExplainCoversCoreRoles(t *testing.T) {
+	categorised := Categorise(explainTestPalette(), DefaultCategorisationConfig())
+	explanation := categorised.Explain()
+
+	lines := make(map[Role]string)
+	for _, line := range strings.Split(strings.TrimSpace(explanation), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			t.Fatalf("malformed explanation line %q", line)
+		}
+		lines[Role(fields[0])] = line
+	}
+
+	tests := []struct {
+		role Role
+		want string
+	}{
+		{RoleBackground, "most dominant colour (weight 40%)"},
+		{RoleForeground, "highest contrast with the background"},
+		{RoleBackgroundMuted, "muted variant of background"},
+		{RoleAccent1, "accent candidate 1 of"},
+		{RoleAccent2, "accent candidate 2 of"},
+		{RoleAccent3, "accent candidate 3 of"},
+		{RoleAccent4, "accent candidate 4 of"},
+		{RoleAccent1Muted, "muted variant of accent1"},
+		{RoleDanger, "most saturated red extracted colour"},
+	}
+	for _, tt := range tests {
+		line, ok := lines[tt.role]
+		if !ok {
+			t.Errorf("explanation has no entry for %s:\n%s", tt.role, explanation)
+			continue
+		}
+		if !strings.Contains(line, tt.want) {
+			t.Errorf("%s explanation = %q, want it to mention %q", tt.role, line, tt.want)
+		}
+		cc, _ := categorised.Get(tt.role)
+		if !strings.Contains(line, cc.Hex) {
+			t.Errorf("%s explanation = %q, want it to include %s", tt.role, line, cc.Hex)
+		}
+	}
+
+	// Every assigned role appears exactly once.
+	if got, want := len(lines), len(categorised.Colours); got != want {
+		t.Errorf("explanation has %d roles, palette has %d", got, want)
+	}
+}
+
+func TestExplainHintsAndGeneratedColours(t *testing.T) {
+	palette := &Palette{
+		Colors: []color.Color{
+			color.RGBA{R: 10, G: 10, B: 12, A: 255},
+			color.RGBA{R: 60, G: 60, B: 80, A: 255},
+		},
+		RoleHints: map[Role]int{RoleBackground: 1},
+	}
+
+	categorised := Categorise(palette, DefaultCategorisationConfig())
+
+	tests := []struct {
+		role Role
+		want string
+	}{
+		{RoleBackground, "role hint from the input plugin (extracted colour 1)"},
+		{RoleAccent1, "generated: extracted accents are too few"},
+		{RoleInfo, "generated: no saturated blue colour"},
+		{RoleSuccess, "most saturated green generated accent"},
+		{RoleSurface, "generated: derived from"},
+	}
+	explanation := categorised.Explain()
+	for _, tt := range tests {
+		if reason, ok := categorised.Reason(tt.role); tt.role != RoleSurface && (!ok || !strings.Contains(reason, tt.want)) {
+			t.Errorf("Reason(%s) = %q, want it to mention %q", tt.role, reason, tt.want)
+		}
+		if !strings.Contains(explanation, string(tt.role)) || !strings.Contains(explanation, tt.want) {
+			t.Errorf("Explain() missing %s entry mentioning %q:\n%s", tt.role, tt.want, explanation)
+		}
+	}
+}
+
+func TestBackgroundReason(t *testing.T) {
+	dark := CategorisedColour{Luminance: 0.05, Weight: 0.34}
+	light := CategorisedColour{Luminance: 0.9, Weight: 0.2}
+
+	tests := []struct {
+		name      string
+		bg        CategorisedColour
+		requested ThemeType
+		themeType ThemeType
+		mode      BackgroundMode
+		want      string
+	}{
+		{"auto", dark, ThemeAuto, ThemeDark, BackgroundAuto, "most dominant colour (weight 34%); luminance 0.05 selects a dark theme"},
+		{"darkest mode", dark, ThemeAuto, ThemeDark, BackgroundDarkest, "darkest extracted colour"},
+		{"lightest mode", light, ThemeAuto, ThemeLight, BackgroundLightest, "lightest extracted colour"},
+		{"explicit dark", dark, ThemeDark, ThemeDark, BackgroundAuto, "most dominant dark colour (weight 34%"},
+		{"dark fallback", light, ThemeDark, ThemeDark, BackgroundAuto, "no extracted colour is dark"},
+		{"explicit light", light, ThemeLight, ThemeLight, BackgroundAuto, "most dominant light colour (weight 20%"},
+		{"light fallback", dark, ThemeLight, ThemeLight, BackgroundAuto, "no extracted colour is light"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backgroundReason(tt.bg, tt.requested, tt.themeType, tt.mode); !strings.Contains(got, tt.want) {
+				t.Errorf("backgroundReason() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,10 +21,19 @@ const (
 	// AlgorithmKMeans uses k-means clustering for color extraction.
 	AlgorithmKMeans Algorithm = "kmeans"
 
-	// AlgorithmMedianCut uses median cut algorithm for color extraction.
-	// Not yet implemented - placeholder for future.
+	// AlgorithmMedianCut uses median cut quantisation for color extraction.
+	// It is faster than k-means and deterministic without a seed.
 	AlgorithmMedianCut Algorithm = "mediancut"
 
+	// AlgorithmOctree uses octree quantisation for color extraction.
+	// It uses bounded memory and is deterministic without a seed.
+	AlgorithmOctree Algorithm = "octree"
+
+	// AlgorithmWu uses Xiaolin Wu's minimum variance quantisation for color extraction.
+	// It gives higher quality palettes than median cut at similar speed and is
+	// deterministic without a seed.
+	AlgorithmWu Algorithm = "wu"
+
 	// AlgorithmDominant extracts the most dominant (frequent) colors.
 	// Not yet implemented - placeholder for future.
 	AlgorithmDominant Algorithm = "dominant"
@@ -34,6 +43,9 @@ const (
 func ValidAlgorithms() []Algorithm {
 	return []Algorithm{
 		AlgorithmKMeans,
+		AlgorithmMedianCut,
+		AlgorithmOctree,
+		AlgorithmWu,
 		// Future algorithms will be added here.
 	}
 }
@@ -47,21 +59,45 @@ func IsValidAlgorithm(alg Algorithm) bool {
 type ExtractorOptions struct {
 	// Seed is an optional random seed for deterministic k-means clustering.
 	// Only applicable to k-means algorithm. nil means non-deterministic.
+	// Median cut, octree and Wu are always deterministic and ignore it.
 	Seed *int64
+
+	// KeepAlpha keeps the alpha of source pixels in the extracted colors instead of
+	// compositing translucent pixels over black.
+	KeepAlpha bool
+
+	// DistanceMetric is the colour distance k-means uses to assign pixels to
+	// clusters. Empty means DistanceRGB. Other algorithms ignore it.
+	DistanceMetric DistanceMetric
 }
 
 // NewExtractor creates a new Extractor based on the specified algorithm with custom options.
-// Returns an error if the algorithm is not recognized or not yet implemented.
+// Returns an error if the algorithm is not recognized or not yet implemented, or
+// the distance metric is not recognized.
 func NewExtractor(alg Algorithm, opts ExtractorOptions) (Extractor, error) {
+	metric, err := ParseDistanceMetric(string(opts.DistanceMetric))
+	if err != nil {
+		return nil, err
+	}
+
 	switch alg {
 	case AlgorithmKMeans:
 		extractor := NewKMeansExtractor()
 		if opts.Seed != nil {
 			extractor.WithSeed(*opts.Seed)
 		}
+		extractor.WithKeepAlpha(opts.KeepAlpha)
+		extractor.WithDistanceMetric(metric)
 		return extractor, nil
 	case AlgorithmMedianCut:
-		return nil, fmt.Errorf("median cut algorithm not yet implemented")
+		// Median cut is deterministic, so any seed is ignored.
+		return NewMedianCutExtractor().WithKeepAlpha(opts.KeepAlpha), nil
+	case AlgorithmOctree:
+		// Octree quantisation is deterministic, so any seed is ignored.
+		return NewOctreeExtractor().WithKeepAlpha(opts.KeepAlpha), nil
+	case AlgorithmWu:
+		// Wu's quantiser is deterministic, so any seed is ignored.
+		return NewWuExtractor().WithKeepAlpha(opts.KeepAlpha), nil
 	case AlgorithmDominant:
 		return nil, fmt.Errorf("dominant color algorithm not yet implemented")
 	default:
@@ -10,22 +10,20 @@ package colour
 // - AAA standard requires 7:1 contrast for normal text.
 // - Selects the color with HIGHEST contrast against background.
 // - Hue is NOT considered - only contrast matters for readability.
+// - With the APCA contrast model, contrast is APCA Lc (minimum 60, or 75 for AAA).
 //
 // Returns the index of the selected foreground color, or -1 if none found.
 func selectForeground(extracted []CategorisedColour, bg CategorisedColour, config CategorisationConfig, bgIdx int) int {
 	fgIdx := -1
 	maxContrast := 0.0
-	minContrast := config.MinContrastRatio
-	if config.RequireAAA {
-		minContrast = 7.0 // WCAG AAA standard
-	}
+	minContrast := config.minTextContrast()
 
 	// Find color with highest contrast that meets minimum threshold.
 	for i, cc := range extracted {
 		if i == bgIdx {
 			continue // Skip background itself
 		}
-		contrast := ContrastRatio(cc.Colour, bg.Colour)
+		contrast := config.ContrastModel.Contrast(cc.Colour, bg.Colour)
 		if contrast > maxContrast && contrast >= minContrast {
 			maxContrast = contrast
 			fgIdx = i
@@ -40,7 +38,7 @@ func selectForeground(extracted []CategorisedColour, bg CategorisedColour, confi
 			if i == bgIdx {
 				continue
 			}
-			contrast := ContrastRatio(cc.Colour, bg.Colour)
+			contrast := config.ContrastModel.Contrast(cc.Colour, bg.Colour)
 			if contrast > maxContrast {
 				maxContrast = contrast
 				fgIdx = i
@@ -77,13 +75,9 @@ func generateSyntheticForeground(bg CategorisedColour, theme ThemeType, config C
 	targetSat := s * 0.7
 
 	// Adjust luminance iteratively until we hit minimum contrast.
-	minContrast := config.MinContrastRatio
-	if config.RequireAAA {
-		minContrast = 7.0
-	}
-
 	var fgRGB RGB
-	targetLum, fgRGB = adjustLuminanceForContrast(h, targetSat, targetLum, bg.Colour, minContrast, theme, 20)
+	targetLum, fgRGB = adjustLuminanceForContrastModel(h, targetSat, targetLum, bg.Colour,
+		config.ContrastModel, config.minTextContrast(), theme, 20)
 
 	return CategorisedColour{
 		Colour:      RGBToColor(fgRGB),
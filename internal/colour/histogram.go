@@ -0,0 +1,100 @@
+// Package colour provides hue and luminance histograms of images.
+package colour
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// achromaticSaturation is the HSL saturation below which a pixel is treated as grey,
+// since its hue is too unstable to be meaningful.
+const achromaticSaturation = 0.1
+
+// MaxHistogramBuckets is the largest supported bucket count (one per degree of hue).
+const MaxHistogramBuckets = 360
+
+// HistogramBucket is one bin of a histogram.
+type HistogramBucket struct {
+	Start float64 `json:"start"` // Inclusive lower bound (degrees for hue, 0-1 for luminance)
+	End   float64 `json:"end"`   // Exclusive upper bound
+	Count int     `json:"count"` // Pixels in this bin
+	Share float64 `json:"share"` // Fraction of the binned pixels (0-1)
+}
+
+// Histogram describes how an image's colours are distributed.
+type Histogram struct {
+	Pixels     int               `json:"pixels"`              // Opaque pixels counted
+	Achromatic int               `json:"achromatic"`          // Pixels too grey to have a hue (excluded from Hue)
+	Hue        []HistogramBucket `json:"hue"`                 // Hue bins from 0 to 360 degrees
+	Luminance  []HistogramBucket `json:"luminance,omitempty"` // WCAG relative luminance bins from 0 to 1
+}
+
+// ComputeHistogram bins the opaque pixels of img by HSL hue and by relative
+// luminance into the given number of equal-width buckets.
+func ComputeHistogram(img image.Image, buckets int) (*Histogram, error) {
+	if img == nil {
+		return nil, fmt.Errorf("image cannot be nil")
+	}
+	if buckets < 1 || buckets > MaxHistogramBuckets {
+		return nil, fmt.Errorf("bucket count must be between 1 and %d, got %d", MaxHistogramBuckets, buckets)
+	}
+
+	hist := &Histogram{
+		Hue:       newBuckets(buckets, 360),
+		Luminance: newBuckets(buckets, 1),
+	}
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A == 0 {
+				continue
+			}
+			hist.Pixels++
+
+			c.A = 255
+			hist.Luminance[bucketIndex(Luminance(c), 1, buckets)].Count++
+
+			h, s, _ := rgbToHSL(RGB{R: c.R, G: c.G, B: c.B})
+			if s < achromaticSaturation {
+				hist.Achromatic++
+				continue
+			}
+			hist.Hue[bucketIndex(h, 360, buckets)].Count++
+		}
+	}
+
+	setShares(hist.Hue, hist.Pixels-hist.Achromatic)
+	setShares(hist.Luminance, hist.Pixels)
+
+	return hist, nil
+}
+
+// newBuckets returns n empty buckets evenly covering [0, upper).
+func newBuckets(n int, upper float64) []HistogramBucket {
+	width := upper / float64(n)
+	buckets := make([]HistogramBucket, n)
+	for i := range buckets {
+		buckets[i].Start = float64(i) * width
+		buckets[i].End = float64(i+1) * width
+	}
+	return buckets
+}
+
+// bucketIndex returns the bucket holding v, where n buckets evenly cover [0, upper).
+func bucketIndex(v, upper float64, n int) int {
+	i := int(v / upper * float64(n))
+	return max(0, min(n-1, i))
+}
+
+// setShares fills in each bucket's share of total.
+func setShares(buckets []HistogramBucket, total int) {
+	if total == 0 {
+		return
+	}
+	for i := range buckets {
+		buckets[i].Share = float64(buckets[i].Count) / float64(total)
+	}
+}
@@ -0,0 +1,86 @@
+package colour
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// stripeImage returns an image with one row per colour, each width pixels wide.
+func stripeImage(width int, colors ...color.Color) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, len(colors)))
+	for y, c := range colors {
+		for x := range width {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestComputeHistogramHues(t *testing.T) {
+	img := stripeImage(10,
+		color.NRGBA{R: 255, A: 255},                 // 0° red
+		color.NRGBA{R: 255, G: 128, A: 255},         // ~30° orange
+		color.NRGBA{G: 255, A: 255},                 // 120° green
+		color.NRGBA{G: 255, A: 255},                 // 120° green
+		color.NRGBA{B: 255, A: 255},                 // 240° blue
+		color.NRGBA{R: 128, G: 128, B: 128, A: 255}, // grey
+		color.NRGBA{R: 255, A: 0},                   // transparent, ignored
+	)
+
+	hist, err := ComputeHistogram(img, 12)
+	if err != nil {
+		t.Fatalf("ComputeHistogram() error = %v", err)
+	}
+
+	if hist.Pixels != 60 {
+		t.Errorf("Pixels = %d, want 60", hist.Pixels)
+	}
+	if hist.Achromatic != 10 {
+		t.Errorf("Achromatic = %d, want 10", hist.Achromatic)
+	}
+
+	want := map[int]int{0: 10, 1: 10, 4: 20, 8: 10}
+	for i, bucket := range hist.Hue {
+		if bucket.Count != want[i] {
+			t.Errorf("hue bucket %d (%g-%g°) = %d, want %d", i, bucket.Start, bucket.End, bucket.Count, want[i])
+		}
+	}
+	if got := hist.Hue[4].Share; got != 0.4 {
+		t.Errorf("green share = %g, want 0.4", got)
+	}
+	if hist.Hue[11].End != 360 {
+		t.Errorf("last hue bucket ends at %g, want 360", hist.Hue[11].End)
+	}
+}
+
+func TestComputeHistogramLuminance(t *testing.T) {
+	img := stripeImage(4,
+		color.NRGBA{A: 255},                         // black
+		color.NRGBA{R: 255, G: 255, B: 255, A: 255}, // white
+	)
+
+	hist, err := ComputeHistogram(img, 4)
+	if err != nil {
+		t.Fatalf("ComputeHistogram() error = %v", err)
+	}
+
+	counts := []int{4, 0, 0, 4}
+	for i, bucket := range hist.Luminance {
+		if bucket.Count != counts[i] {
+			t.Errorf("luminance bucket %d = %d, want %d", i, bucket.Count, counts[i])
+		}
+	}
+	if hist.Achromatic != 8 {
+		t.Errorf("Achromatic = %d, want 8", hist.Achromatic)
+	}
+}
+
+func TestComputeHistogramInvalidBuckets(t *testing.T) {
+	img := stripeImage(1, color.NRGBA{A: 255})
+	for _, buckets := range []int{0, -1, MaxHistogramBuckets + 1} {
+		if _, err := ComputeHistogram(img, buckets); err == nil {
+			t.Errorf("ComputeHistogram(%d buckets) should fail", buckets)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Package colour provides OKLCH interpolation between colours and palettes.
+package colour
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// achromaticChroma is the OKLCH chroma below which a colour's hue is meaningless.
+const achromaticChroma = 0.02
+
+// InterpolateOKLCH returns the colour t (0-1) of the way from a to b, interpolated
+// in OKLCH: lightness and chroma linearly and hue the short way round. When one
+// end is grey, the other end's hue is used so greys do not sweep through
+// unrelated hues. t = 0 and t = 1 return a and b exactly. Unlike ColorValue.Mix,
+// which blends in RGB for template shades, this keeps lightness and chroma even
+// across a palette transition.
+func InterpolateOKLCH(a, b RGB, t float64) RGB {
+	switch {
+	case t <= 0:
+		return a
+	case t >= 1:
+		return b
+	}
+
+	l1, c1, h1 := RGBToOKLCH(a)
+	l2, c2, h2 := RGBToOKLCH(b)
+	if c1 < achromaticChroma {
+		h1 = h2
+	}
+	if c2 < achromaticChroma {
+		h2 = h1
+	}
+
+	dh := math.Mod(h2-h1+540, 360) - 180
+	h := math.Mod(h1+dh*t+360, 360)
+	return OKLCHToRGB(l1+(l2-l1)*t, c1+(c2-c1)*t, h)
+}
+
+// InterpolatePalettes returns the palette t (0-1) of the way from one categorised
+// palette to another, interpolating each role present in both with InterpolateOKLCH.
+// Roles in only one palette are dropped. The theme type switches at the midpoint.
+//
+// On-colour roles (onAccent1, onSurface, ...) are not interpolated: blending black
+// and white text passes through mid-grey, which is unreadable on a mid-tone base.
+// Between the endpoints each on-role is re-derived against its interpolated base
+// as black or white, whichever has the higher WCAG contrast.
+func InterpolatePalettes(from, to *CategorisedPalette, t float64) *CategorisedPalette {
+	themeType := from.ThemeType
+	if t >= 0.5 {
+		themeType = to.ThemeType
+	}
+
+	result := NewCategorisedPalette(themeType)
+	var onRoles []Role
+	for role, a := range from.Colours {
+		b, ok := to.Colours[role]
+		if !ok {
+			continue
+		}
+		if _, isOn := onRoleBase(role); isOn && t > 0 && t < 1 {
+			onRoles = append(onRoles, role)
+			continue
+		}
+		rgb := InterpolateOKLCH(a.RGB, b.RGB, t)
+		result.Set(role, categorisedFromRGB(rgb))
+	}
+
+	for _, role := range onRoles {
+		base, _ := onRoleBase(role)
+		generateOnColor(result, base, role, nil, ContrastWCAG)
+	}
+	return result
+}
+
+// onRoleBase returns the background role that an on-colour role is drawn on,
+// e.g. accent1 for onAccent1 and inverseSurface for inverseOnSurface.
+func onRoleBase(role Role) (Role, bool) {
+	name := string(role)
+	prefix := ""
+	if rest, ok := strings.CutPrefix(name, "inverse"); ok {
+		prefix, name = "inverse", rest
+	}
+
+	rest, ok := strings.CutPrefix(name, "On")
+	if !ok && prefix == "" {
+		rest, ok = strings.CutPrefix(name, "on")
+	}
+	if !ok || rest == "" || !unicode.IsUpper(rune(rest[0])) {
+		return "", false
+	}
+
+	if prefix == "" {
+		rest = strings.ToLower(rest[:1]) + rest[1:]
+	}
+	return Role(prefix + rest), true
+}
+
+// Transition returns steps palettes moving from one categorised palette to another,
+// evenly spaced in t. The first and last are the endpoints' shared roles unchanged.
+// steps must be at least 2.
+func Transition(from, to *CategorisedPalette, steps int) []*CategorisedPalette {
+	steps = max(steps, 2)
+	palettes := make([]*CategorisedPalette, steps)
+	for i := range palettes {
+		palettes[i] = InterpolatePalettes(from, to, float64(i)/float64(steps-1))
+	}
+	return palettes
+}
+
+// categorisedFromRGB returns an opaque categorised colour for rgb with its
+// luminance, hue and saturation filled in.
+func categorisedFromRGB(rgb RGB) CategorisedColour {
+	h, s, _ := rgbToHSL(rgb)
+	lum := Luminance(RGBToColor(rgb))
+	return CategorisedColour{
+		Colour:     RGBToColor(rgb),
+		Hex:        rgb.Hex(),
+		RGB:        rgb,
+		RGBA:       RGBToRGBA(rgb),
+		Luminance:  lum,
+		IsLight:    lum > 0.5,
+		Hue:        h,
+		Saturation: s,
+	}
+}
@@ -0,0 +1,178 @@
+package colour
+
+import (
+	"math"
+	"testing"
+)
+
+// transitionPalette returns a categorised palette with the given role colours.
+func transitionPalette(theme ThemeType, hexes map[Role]string) *CategorisedPalette {
+	palette := NewCategorisedPalette(theme)
+	for role, hex := range hexes {
+		rgb, _ := ParseHex(hex)
+		palette.Set(role, categorisedFromRGB(rgb))
+	}
+	return palette
+}
+
+func TestInterpolateOKLCHEndpointsAndHue(t *testing.T) {
+	red := RGB{R: 0xe0, G: 0x40, B: 0x40}
+	blue := RGB{R: 0x40, G: 0x60, B: 0xe0}
+
+	if got := InterpolateOKLCH(red, blue, 0); got != red {
+		t.Errorf("t=0 = %s, want %s", got.Hex(), red.Hex())
+	}
+	if got := InterpolateOKLCH(red, blue, 1); got != blue {
+		t.Errorf("t=1 = %s, want %s", got.Hex(), blue.Hex())
+	}
+
+	// Red (hue ~25) to blue (hue ~265) goes the short way, through magenta, not green.
+	_, _, h := RGBToOKLCH(InterpolateOKLCH(red, blue, 0.5))
+	if h > 25 && h < 265 {
+		t.Errorf("midpoint hue = %.0f, want the short way round through magenta", h)
+	}
+
+	// A grey end takes the other end's hue rather than sweeping from hue 0.
+	grey := RGB{R: 0x80, G: 0x80, B: 0x80}
+	_, _, wantH := RGBToOKLCH(blue)
+	if _, _, h := RGBToOKLCH(InterpolateOKLCH(grey, blue, 0.5)); math.Abs(h-wantH) > 2 {
+		t.Errorf("grey to blue midpoint hue = %.0f, want %.0f", h, wantH)
+	}
+}
+
+func TestTransition(t *testing.T) {
+	from := transitionPalette(ThemeDark, map[Role]string{
+		RoleBackground: "#1a1b26",
+		RoleForeground: "#c0caf5",
+		RoleAccent1:    "#7aa2f7",
+		RoleDanger:     "#f7768e",
+	})
+	to := transitionPalette(ThemeLight, map[Role]string{
+		RoleBackground: "#fdf6e3",
+		RoleForeground: "#657b83",
+		RoleAccent1:    "#859900",
+		RoleDanger:     "#dc322f",
+		RoleWarning:    "#b58900",
+	})
+
+	const steps = 30
+	palettes := Transition(from, to, steps)
+	if len(palettes) != steps {
+		t.Fatalf("Transition() returned %d palettes, want %d", len(palettes), steps)
+	}
+
+	first, last := palettes[0], palettes[steps-1]
+	for role, want := range from.Colours {
+		if got, _ := first.Get(role); got.Hex != want.Hex {
+			t.Errorf("first step %s = %s, want %s", role, got.Hex, want.Hex)
+		}
+		if got, want := last.Colours[role], to.Colours[role]; got.Hex != want.Hex {
+			t.Errorf("last step %s = %s, want %s", role, got.Hex, want.Hex)
+		}
+	}
+	if _, ok := last.Get(RoleWarning); ok {
+		t.Error("role only in the target palette should be dropped")
+	}
+	if first.ThemeType != ThemeDark || last.ThemeType != ThemeLight {
+		t.Errorf("theme types = %v, %v, want dark then light", first.ThemeType, last.ThemeType)
+	}
+
+	// Each OKLCH channel moves monotonically from one end to the other, allowing
+	// for 8-bit rounding. Hue is measured as progress along the short arc.
+	const tolerance = 0.004
+	for role, a := range from.Colours {
+		l1, _, h1 := RGBToOKLCH(a.RGB)
+		l2, _, h2 := RGBToOKLCH(to.Colours[role].RGB)
+		dh := math.Mod(h2-h1+540, 360) - 180
+
+		prevL, prevArc := l1, 0.0
+		for i, palette := range palettes[1:] {
+			l, c, h := RGBToOKLCH(palette.Colours[role].RGB)
+			if (l-prevL)*(l2-l1) < -tolerance {
+				t.Errorf("%s step %d: lightness %.3f moved away from %.3f (previous %.3f)", role, i+1, l, l2, prevL)
+			}
+			prevL = l
+
+			if c < achromaticChroma || math.Abs(dh) < 1 {
+				continue
+			}
+			arc := (math.Mod(h-h1+540, 360) - 180) / dh
+			if arc < prevArc-0.02 {
+				t.Errorf("%s step %d: hue %.1f went back along the arc (%.2f < %.2f)", role, i+1, h, arc, prevArc)
+			}
+			prevArc = arc
+		}
+	}
+}
+
+func TestTransitionOnRolesKeepContrast(t *testing.T) {
+	from := transitionPalette(ThemeDark, map[Role]string{
+		RoleSurface:          "#1a1b26",
+		RoleOnSurface:        "#c0caf5",
+		RoleAccent1:          "#2b2f77",
+		RoleOnAccent1:        "#ffffff",
+		RoleDanger:           "#f7768e",
+		RoleOnDanger:         "#000000",
+		RoleInverseSurface:   "#e0e0e0",
+		RoleInverseOnSurface: "#000000",
+	})
+	to := transitionPalette(ThemeLight, map[Role]string{
+		RoleSurface:          "#fdf6e3",
+		RoleOnSurface:        "#073642",
+		RoleAccent1:          "#f0e68c",
+		RoleOnAccent1:        "#000000",
+		RoleDanger:           "#5c0f0f",
+		RoleOnDanger:         "#ffffff",
+		RoleInverseSurface:   "#202020",
+		RoleInverseOnSurface: "#ffffff",
+	})
+
+	pairs := map[Role]Role{
+		RoleOnSurface:        RoleSurface,
+		RoleOnAccent1:        RoleAccent1,
+		RoleOnDanger:         RoleDanger,
+		RoleInverseOnSurface: RoleInverseSurface,
+	}
+
+	palettes := Transition(from, to, 9)
+	for i, palette := range palettes[1 : len(palettes)-1] {
+		for onRole, baseRole := range pairs {
+			on, ok := palette.Get(onRole)
+			if !ok {
+				t.Fatalf("step %d: %s missing", i+1, onRole)
+			}
+			base, _ := palette.Get(baseRole)
+			if ratio := ContrastRatio(on.Colour, base.Colour); ratio < 4.5 {
+				t.Errorf("step %d: %s %s on %s %s = %.2f:1, want at least 4.5:1",
+					i+1, onRole, on.Hex, baseRole, base.Hex, ratio)
+			}
+		}
+	}
+
+	// The endpoints keep their own on-colours rather than re-derived ones.
+	if got, _ := palettes[0].Get(RoleOnSurface); got.Hex != "#c0caf5" {
+		t.Errorf("first step onSurface = %s, want #c0caf5", got.Hex)
+	}
+}
+
+func TestOnRoleBase(t *testing.T) {
+	tests := []struct {
+		role   Role
+		want   Role
+		wantOK bool
+	}{
+		{RoleOnAccent1, RoleAccent1, true},
+		{OnAccentRole(6), AccentRole(6), true},
+		{RoleOnSurfaceVariant, RoleSurfaceVariant, true},
+		{RoleInverseOnSurface, RoleInverseSurface, true},
+		{RoleAccent1, "", false},
+		{RoleInverseSurface, "", false},
+		{Role("one"), "", false},
+	}
+	for _, tt := range tests {
+		got, ok := onRoleBase(tt.role)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("onRoleBase(%s) = %s, %v, want %s, %v", tt.role, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
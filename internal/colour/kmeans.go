@@ -7,6 +7,8 @@ import (
 	"image/color"
 	"math"
 	"math/rand"
+
+	"github.com/jmylchreest/tinct/internal/security"
 )
 
 // KMeansExtractor implements color extraction using k-means clustering.
@@ -16,6 +18,8 @@ type KMeansExtractor struct {
 	maxSamples    int
 	seed          *int64 // Random seed for k-means initialization (nil = use default random)
 	rng           *rand.Rand
+	keepAlpha     bool           // Keep source alpha instead of compositing translucent pixels over black
+	distance      DistanceMetric // Colour distance used to assign pixels to clusters
 }
 
 // NewKMeansExtractor creates a new KMeansExtractor with default settings.
@@ -26,6 +30,8 @@ func NewKMeansExtractor() *KMeansExtractor {
 		maxSamples:    5000, // Limit total samples for performance
 		seed:          nil,  // No seed by default (non-deterministic)
 		rng:           nil,
+		keepAlpha:     false,
+		distance:      DistanceRGB,
 	}
 }
 
@@ -36,6 +42,23 @@ func (e *KMeansExtractor) WithSeed(seed int64) *KMeansExtractor {
 	return e
 }
 
+// WithKeepAlpha makes extracted colors keep the alpha of their source pixels.
+// Each color's alpha is the mean alpha of its cluster. By default translucent
+// pixels are composited over black. Fully transparent pixels, such as areas
+// excluded by a mask, are ignored in both modes.
+func (e *KMeansExtractor) WithKeepAlpha(keep bool) *KMeansExtractor {
+	e.keepAlpha = keep
+	return e
+}
+
+// WithDistanceMetric sets the colour distance used to assign pixels to their nearest
+// centroid. CIELAB metrics give more perceptually even clusters on photographs at
+// some cost in speed. Centroids are still averaged in RGB.
+func (e *KMeansExtractor) WithDistanceMetric(metric DistanceMetric) *KMeansExtractor {
+	e.distance = metric
+	return e
+}
+
 // Extract extracts colors from an image using k-means clustering.
 // Returns colors with their relative weights (cluster sizes).
 func (e *KMeansExtractor) Extract(img image.Image, count int) (*Palette, error) {
@@ -49,34 +72,35 @@ func (e *KMeansExtractor) Extract(img image.Image, count int) (*Palette, error)
 		return nil, fmt.Errorf("color count too large: %d (maximum: 256)", count)
 	}
 
-	// Sample pixels from the image.
-	pixels := samplePixels(img)
-	if len(pixels) == 0 {
-		return nil, fmt.Errorf("no pixels found in image")
+	// Sample pixels from the image, skipping fully transparent (e.g. masked) ones.
+	pixels := visiblePixels(samplePixels(img))
+	if !e.keepAlpha {
+		pixels = flattenPixels(pixels)
 	}
-
-	// Get unique colors first.
-	uniqueColors := make([]color.Color, 0, len(pixels))
-	seen := make(map[RGB]bool)
-	for _, p := range pixels {
-		rgb := ToRGB(p)
-		if !seen[rgb] {
-			uniqueColors = append(uniqueColors, p)
-			seen[rgb] = true
-		}
+	if len(pixels) == 0 {
+		return nil, fmt.Errorf("no visible pixels found in image")
 	}
 
 	// If we want more colors than unique colors exist, return all unique colors.
-	if count >= len(uniqueColors) {
-		return NewPalette(uniqueColors), nil
+	if unique := uniquePixels(pixels); count >= len(unique) {
+		return NewPaletteWithWeights(unique, pixelCounts(pixels, unique)), nil
 	}
 
 	// Run k-means clustering and get cluster weights.
-	centroids, weights := e.kmeans(pixels, count)
+	centroids, weights, alphas := e.kmeans(pixels, count)
 
 	// Convert centroids to colors.
 	colors := make([]color.Color, len(centroids))
 	for i, c := range centroids {
+		if e.keepAlpha {
+			colors[i] = color.NRGBA{
+				R: uint8(c.R),
+				G: uint8(c.G),
+				B: uint8(c.B),
+				A: uint8(math.Round(alphas[i])),
+			}
+			continue
+		}
 		colors[i] = color.RGBA{
 			R: uint8(c.R),
 			G: uint8(c.G),
@@ -140,18 +164,48 @@ func samplePixels(img image.Image) []color.Color {
 	return pixels
 }
 
+// flattenPixels composites translucent pixels over black, discarding their alpha.
+func flattenPixels(pixels []color.Color) []color.Color {
+	flat := make([]color.Color, len(pixels))
+	for i, c := range pixels {
+		// RGBA returns alpha-premultiplied values, i.e. the colour over black.
+		r, g, b, _ := c.RGBA()
+		flat[i] = color.RGBA{
+			R: security.SafeUint8FromUint32(r >> 8),
+			G: security.SafeUint8FromUint32(g >> 8),
+			B: security.SafeUint8FromUint32(b >> 8),
+			A: 255,
+		}
+	}
+	return flat
+}
+
+// visiblePixels returns the pixels that are not fully transparent, with straight alpha.
+func visiblePixels(pixels []color.Color) []color.Color {
+	visible := make([]color.Color, 0, len(pixels))
+	for _, c := range pixels {
+		if rgba := ToRGBA(c); rgba.A > 0 {
+			visible = append(visible, color.NRGBA{R: rgba.R, G: rgba.G, B: rgba.B, A: rgba.A})
+		}
+	}
+	return visible
+}
+
 // kmeans performs k-means clustering on the pixel data.
-// Returns centroids and their weights (relative cluster sizes).
-func (e *KMeansExtractor) kmeans(pixels []color.Color, k int) (centroids []point3D, weights []float64) {
+// Returns centroids, their weights (relative cluster sizes) and the mean alpha (0-255)
+// of the pixels in each cluster. Clustering uses the colour only, not the alpha.
+func (e *KMeansExtractor) kmeans(pixels []color.Color, k int) (centroids []point3D, weights, alphas []float64) {
 	// Convert colors to 3D points.
 	points := make([]point3D, len(pixels))
+	pointAlphas := make([]float64, len(pixels))
 	for i, c := range pixels {
-		rgb := ToRGB(c)
+		rgba := ToRGBA(c)
 		points[i] = point3D{
-			R: float64(rgb.R),
-			G: float64(rgb.G),
-			B: float64(rgb.B),
+			R: float64(rgba.R),
+			G: float64(rgba.G),
+			B: float64(rgba.B),
 		}
+		pointAlphas[i] = float64(rgba.A)
 	}
 
 	// Initialise centroids using k-means++ algorithm.
@@ -160,12 +214,28 @@ func (e *KMeansExtractor) kmeans(pixels []color.Color, k int) (centroids []point
 	// Track cluster assignments.
 	assignments := make([]int, len(points))
 
+	// CIELAB metrics convert the points once and the centroids every iteration.
+	var pointLabs []Lab
+	if e.distance == DistanceCIE76 || e.distance == DistanceCIE2000 {
+		pointLabs = toLab(points)
+	}
+
 	// Iterate until convergence or max iterations.
 	for iter := 0; iter < e.maxIterations; iter++ {
+		var centroidLabs []Lab
+		if pointLabs != nil {
+			centroidLabs = toLab(centroids)
+		}
+
 		// Assign each point to nearest centroid.
 		changed := 0
 		for i, point := range points {
-			nearest := e.findNearestCentroid(point, centroids)
+			var nearest int
+			if pointLabs != nil {
+				nearest = e.findNearestCentroidLab(pointLabs[i], centroidLabs)
+			} else {
+				nearest = e.findNearestCentroid(point, centroids)
+			}
 			if assignments[i] != nearest {
 				assignments[i] = nearest
 				changed++
@@ -195,10 +265,19 @@ func (e *KMeansExtractor) kmeans(pixels []color.Color, k int) (centroids []point
 		}
 	}
 
-	// Calculate cluster weights (relative sizes).
+	// Calculate cluster weights (relative sizes) and mean alphas.
 	weights = make([]float64, k)
-	for _, assignment := range assignments {
+	alphas = make([]float64, k)
+	for i, assignment := range assignments {
 		weights[assignment]++
+		alphas[assignment] += pointAlphas[i]
+	}
+	for i := range alphas {
+		if weights[i] > 0 {
+			alphas[i] /= weights[i]
+		} else {
+			alphas[i] = 255
+		}
 	}
 
 	// Normalize weights to sum to 1.0.
@@ -207,7 +286,7 @@ func (e *KMeansExtractor) kmeans(pixels []color.Color, k int) (centroids []point
 		weights[i] /= totalPixels
 	}
 
-	return centroids, weights
+	return centroids, weights, alphas
 }
 
 // initializeCentroidsKMeansPlusPlus initialises centroids using k-means++ algorithm.
@@ -290,6 +369,37 @@ func (e *KMeansExtractor) findNearestCentroid(point point3D, centroids []point3D
 	return nearest
 }
 
+// findNearestCentroidLab finds the index of the nearest centroid to a point in
+// CIELAB, using CIE76 or CIEDE2000 distance.
+func (e *KMeansExtractor) findNearestCentroidLab(point Lab, centroids []Lab) int {
+	minDist := math.MaxFloat64
+	nearest := 0
+
+	for i, centroid := range centroids {
+		var dist float64
+		if e.distance == DistanceCIE2000 {
+			dist = point.DeltaE2000(centroid)
+		} else {
+			dist = point.DeltaE76(centroid)
+		}
+		if dist < minDist {
+			minDist = dist
+			nearest = i
+		}
+	}
+
+	return nearest
+}
+
+// toLab converts RGB points to CIELAB.
+func toLab(points []point3D) []Lab {
+	labs := make([]Lab, len(points))
+	for i, p := range points {
+		labs[i] = labFromRGB(p.R, p.G, p.B)
+	}
+	return labs
+}
+
 // recalculateCentroids recalculates centroid positions based on assigned points.
 func (e *KMeansExtractor) recalculateCentroids(points []point3D, assignments []int, k int) []point3D {
 	// Sum up all points assigned to each cluster.
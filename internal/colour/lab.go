@@ -0,0 +1,156 @@
+// Package colour provides CIELAB colour distance metrics.
+package colour
+
+import (
+	"fmt"
+	"math"
+)
+
+// DistanceMetric is the colour distance k-means uses to assign pixels to clusters.
+type DistanceMetric string
+
+const (
+	// DistanceRGB is Euclidean distance in sRGB (default). It is the fastest.
+	DistanceRGB DistanceMetric = "rgb"
+	// DistanceCIE76 is Euclidean distance in CIELAB, which is roughly perceptual.
+	DistanceCIE76 DistanceMetric = "cie76"
+	// DistanceCIE2000 is the CIEDE2000 colour difference, the most perceptually
+	// accurate and the slowest.
+	DistanceCIE2000 DistanceMetric = "cie2000"
+)
+
+// ParseDistanceMetric parses a distance metric name. An empty string is treated as rgb.
+func ParseDistanceMetric(s string) (DistanceMetric, error) {
+	switch DistanceMetric(s) {
+	case "", DistanceRGB:
+		return DistanceRGB, nil
+	case DistanceCIE76, DistanceCIE2000:
+		return DistanceMetric(s), nil
+	default:
+		return "", fmt.Errorf("invalid distance metric %q (valid: rgb, cie76, cie2000)", s)
+	}
+}
+
+// Lab is a colour in CIELAB (D65): lightness 0-100 and the a (green-red) and
+// b (blue-yellow) axes.
+type Lab struct {
+	L, A, B float64
+}
+
+// RGBToLab converts sRGB to CIELAB using the D65 white point.
+func RGBToLab(rgb RGB) Lab {
+	return labFromRGB(float64(rgb.R), float64(rgb.G), float64(rgb.B))
+}
+
+// labFromRGB converts sRGB channels (0-255, not necessarily whole) to CIELAB.
+func labFromRGB(r, g, b float64) Lab {
+	lr := SRGBToLinear(r / 255)
+	lg := SRGBToLinear(g / 255)
+	lb := SRGBToLinear(b / 255)
+
+	x := (0.4124564*lr + 0.3575761*lg + 0.1804375*lb) / 0.95047
+	y := 0.2126729*lr + 0.7151522*lg + 0.0721750*lb
+	z := (0.0193339*lr + 0.1191920*lg + 0.9503041*lb) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 0.008856 {
+			return math.Cbrt(t)
+		}
+		return 7.787*t + 16.0/116.0
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+
+	return Lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// DeltaE76 returns the CIE76 colour difference, the Euclidean distance in CIELAB.
+func (c Lab) DeltaE76(o Lab) float64 {
+	dl, da, db := c.L-o.L, c.A-o.A, c.B-o.B
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
+
+// DeltaE2000 returns the CIEDE2000 colour difference (Sharma, Wu and Dalal 2005)
+// with unit weighting factors. A difference around 1 is just noticeable.
+func (c Lab) DeltaE2000(o Lab) float64 {
+	const pow25to7 = 6103515625.0 // 25^7
+
+	// Adjust a* so neutral colours have the same chroma weighting as in CIE94.
+	c1 := math.Hypot(c.A, c.B)
+	c2 := math.Hypot(o.A, o.B)
+	meanC7 := math.Pow((c1+c2)/2, 7)
+	g := 0.5 * (1 - math.Sqrt(meanC7/(meanC7+pow25to7)))
+	a1, a2 := c.A*(1+g), o.A*(1+g)
+
+	c1p, c2p := math.Hypot(a1, c.B), math.Hypot(a2, o.B)
+	h1p, h2p := labHue(a1, c.B), labHue(a2, o.B)
+
+	// Differences in lightness, chroma and hue.
+	dLp := o.L - c.L
+	dCp := c2p - c1p
+	var dhp float64
+	if c1p*c2p != 0 {
+		dhp = h2p - h1p
+		switch {
+		case dhp > 180:
+			dhp -= 360
+		case dhp < -180:
+			dhp += 360
+		}
+	}
+	dHp := 2 * math.Sqrt(c1p*c2p) * math.Sin(degToRad(dhp/2))
+
+	// Means, with the hue mean taken the short way round the circle.
+	meanLp := (c.L + o.L) / 2
+	meanCp := (c1p + c2p) / 2
+	meanHp := h1p + h2p
+	if c1p*c2p != 0 {
+		switch {
+		case math.Abs(h1p-h2p) <= 180:
+			meanHp /= 2
+		case meanHp < 360:
+			meanHp = (meanHp + 360) / 2
+		default:
+			meanHp = (meanHp - 360) / 2
+		}
+	}
+
+	t := 1 -
+		0.17*math.Cos(degToRad(meanHp-30)) +
+		0.24*math.Cos(degToRad(2*meanHp)) +
+		0.32*math.Cos(degToRad(3*meanHp+6)) -
+		0.20*math.Cos(degToRad(4*meanHp-63))
+
+	l50 := (meanLp - 50) * (meanLp - 50)
+	sl := 1 + 0.015*l50/math.Sqrt(20+l50)
+	sc := 1 + 0.045*meanCp
+	sh := 1 + 0.015*meanCp*t
+
+	meanCp7 := math.Pow(meanCp, 7)
+	dTheta := 30 * math.Exp(-((meanHp-275)/25)*((meanHp-275)/25))
+	rt := -2 * math.Sqrt(meanCp7/(meanCp7+pow25to7)) * math.Sin(degToRad(2*dTheta))
+
+	l, cc, h := dLp/sl, dCp/sc, dHp/sh
+	return math.Sqrt(l*l + cc*cc + h*h + rt*cc*h)
+}
+
+// DeltaE2000 returns the CIEDE2000 colour difference between two colours.
+func DeltaE2000(a, b RGB) float64 {
+	return RGBToLab(a).DeltaE2000(RGBToLab(b))
+}
+
+// labHue returns the hue angle (0-360) of the a and b axes.
+func labHue(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// degToRad converts degrees to radians.
+func degToRad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
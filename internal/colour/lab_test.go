@@ -0,0 +1,127 @@
+package colour
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestRGBToLab(t *testing.T) {
+	tests := []struct {
+		rgb  RGB
+		want Lab
+	}{
+		{RGB{R: 255, G: 255, B: 255}, Lab{L: 100, A: 0, B: 0}},
+		{RGB{R: 0, G: 0, B: 0}, Lab{L: 0, A: 0, B: 0}},
+		{RGB{R: 255, G: 0, B: 0}, Lab{L: 53.24, A: 80.09, B: 67.20}},
+		{RGB{R: 0, G: 0, B: 255}, Lab{L: 32.30, A: 79.19, B: -107.86}},
+	}
+
+	for _, tt := range tests {
+		got := RGBToLab(tt.rgb)
+		if got.DeltaE76(tt.want) > 0.1 {
+			t.Errorf("RGBToLab(%s) = %+v, want %+v", tt.rgb.Hex(), got, tt.want)
+		}
+	}
+}
+
+func TestDeltaE2000(t *testing.T) {
+	// Test pairs from Sharma, Wu and Dalal (2005), Table 1.
+	tests := []struct {
+		a, b Lab
+		want float64
+	}{
+		{Lab{50, 2.6772, -79.7751}, Lab{50, 0, -82.7485}, 2.0425},
+		{Lab{50, 3.1571, -77.2803}, Lab{50, 0, -82.7485}, 2.8615},
+		{Lab{50, -1.3802, -84.2814}, Lab{50, 0, -82.7485}, 1.0000},
+		{Lab{50, 0, 0}, Lab{50, -1, 2}, 2.3669},
+		{Lab{50, 2.49, -0.001}, Lab{50, -2.49, 0.0009}, 7.1792},
+		{Lab{50, 2.5, 0}, Lab{56, -27, -3}, 31.9030},
+		{Lab{50, 2.5, 0}, Lab{73, 25, -18}, 27.1492},
+		{Lab{60.2574, -34.0099, 36.2677}, Lab{60.4626, -34.1751, 39.4387}, 1.2644},
+		{Lab{22.7233, 20.0904, -46.694}, Lab{23.0331, 14.973, -42.5619}, 2.0373},
+		{Lab{90.8027, -2.0831, 1.441}, Lab{91.1528, -1.6435, 0.0447}, 1.4441},
+		{Lab{2.0776, 0.0795, -1.135}, Lab{0.9033, -0.0636, -0.5514}, 0.9082},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.DeltaE2000(tt.b); math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("DeltaE2000(%+v, %+v) = %.4f, want %.4f", tt.a, tt.b, got, tt.want)
+		}
+		if got := tt.b.DeltaE2000(tt.a); math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("DeltaE2000 is not symmetric for %+v, %+v: %.4f", tt.a, tt.b, got)
+		}
+	}
+
+	if got := DeltaE2000(RGB{R: 0x33, G: 0x66, B: 0xcc}, RGB{R: 0x33, G: 0x66, B: 0xcc}); got != 0 {
+		t.Errorf("DeltaE2000 of identical colours = %f, want 0", got)
+	}
+}
+
+func TestParseDistanceMetric(t *testing.T) {
+	for input, want := range map[string]DistanceMetric{
+		"":        DistanceRGB,
+		"rgb":     DistanceRGB,
+		"cie76":   DistanceCIE76,
+		"cie2000": DistanceCIE2000,
+	} {
+		if got, err := ParseDistanceMetric(input); err != nil || got != want {
+			t.Errorf("ParseDistanceMetric(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseDistanceMetric("lab"); err == nil {
+		t.Error("ParseDistanceMetric(\"lab\") should fail")
+	}
+	if _, err := NewExtractor(AlgorithmKMeans, ExtractorOptions{DistanceMetric: "lab"}); err == nil {
+		t.Error("NewExtractor() with an invalid distance metric should fail")
+	}
+}
+
+func TestKMeansDistanceMetrics(t *testing.T) {
+	// Two dark blues and two bright yellows: every metric should keep them apart.
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	for y := range 40 {
+		for x := range 40 {
+			var c color.RGBA
+			switch {
+			case x < 20 && y < 20:
+				c = color.RGBA{R: 0x10, G: 0x20, B: 0x60, A: 0xff}
+			case x < 20:
+				c = color.RGBA{R: 0x14, G: 0x24, B: 0x68, A: 0xff}
+			case y < 20:
+				c = color.RGBA{R: 0xf0, G: 0xe0, B: 0x30, A: 0xff}
+			default:
+				c = color.RGBA{R: 0xe8, G: 0xd8, B: 0x38, A: 0xff}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	for _, metric := range []DistanceMetric{DistanceRGB, DistanceCIE76, DistanceCIE2000} {
+		t.Run(string(metric), func(t *testing.T) {
+			seed := int64(1)
+			extractor, err := NewExtractor(AlgorithmKMeans, ExtractorOptions{Seed: &seed, DistanceMetric: metric})
+			if err != nil {
+				t.Fatalf("NewExtractor() error = %v", err)
+			}
+			palette, err := extractor.Extract(img, 2)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if palette.Len() != 2 {
+				t.Fatalf("got %d colours, want 2", palette.Len())
+			}
+
+			lums := []float64{Luminance(palette.Colors[0]), Luminance(palette.Colors[1])}
+			if math.Abs(lums[0]-lums[1]) < 0.3 {
+				t.Errorf("colours %v do not separate blue from yellow", palette.ToHex())
+			}
+			for i, w := range palette.Weights {
+				if math.Abs(w-0.5) > 1e-9 {
+					t.Errorf("weight %d = %.3f, want 0.5", i, w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Package color provides color extraction and palette generation functionality.
+package colour
+
+import (
+	"cmp"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+)
+
+// MedianCutExtractor implements color extraction using median cut quantisation.
+// It is deterministic and does not need a seed.
+type MedianCutExtractor struct {
+	keepAlpha bool // Keep source alpha instead of compositing translucent pixels over black
+}
+
+// NewMedianCutExtractor creates a new MedianCutExtractor with default settings.
+func NewMedianCutExtractor() *MedianCutExtractor {
+	return &MedianCutExtractor{
+		keepAlpha: false,
+	}
+}
+
+// WithKeepAlpha makes extracted colors keep the alpha of their source pixels.
+// Each color's alpha is the mean alpha of its box. By default translucent
+// pixels are composited over black. Fully transparent pixels are ignored in both modes.
+func (e *MedianCutExtractor) WithKeepAlpha(keep bool) *MedianCutExtractor {
+	e.keepAlpha = keep
+	return e
+}
+
+// colourBox is a set of pixels that median cut splits until there is one box per colour.
+type colourBox []RGBA
+
+// rgbChannel returns the value of channel c (0 red, 1 green, 2 blue) of a pixel.
+func rgbChannel(p RGBA, c int) uint8 {
+	switch c {
+	case 0:
+		return p.R
+	case 1:
+		return p.G
+	default:
+		return p.B
+	}
+}
+
+// widestChannel returns the channel with the largest range in the box, and that range.
+func (b colourBox) widestChannel() (widest int, spread uint8) {
+	for c := range 3 {
+		lo, hi := uint8(255), uint8(0)
+		for _, p := range b {
+			v := rgbChannel(p, c)
+			lo = min(lo, v)
+			hi = max(hi, v)
+		}
+		if hi-lo > spread {
+			widest, spread = c, hi-lo
+		}
+	}
+	return widest, spread
+}
+
+// split sorts the box along its widest channel and cuts it at the median pixel.
+func (b colourBox) split() (lower, upper colourBox) {
+	c, _ := b.widestChannel()
+	slices.SortStableFunc(b, func(x, y RGBA) int {
+		return cmp.Compare(rgbChannel(x, c), rgbChannel(y, c))
+	})
+	mid := len(b) / 2
+	return b[:mid], b[mid:]
+}
+
+// average returns the mean colour of the box and its mean alpha (0-255).
+func (b colourBox) average() (RGB, float64) {
+	var r, g, bl, a float64
+	for _, p := range b {
+		r += float64(p.R)
+		g += float64(p.G)
+		bl += float64(p.B)
+		a += float64(p.A)
+	}
+	n := float64(len(b))
+	return RGB{
+		R: uint8(math.Round(r / n)),
+		G: uint8(math.Round(g / n)),
+		B: uint8(math.Round(bl / n)),
+	}, a / n
+}
+
+// Extract extracts colors from an image using median cut.
+// The colour space is recursively split along the channel with the largest range
+// until there are count boxes. Returns the box averages weighted by box size,
+// largest first.
+func (e *MedianCutExtractor) Extract(img image.Image, count int) (*Palette, error) {
+	if img == nil {
+		return nil, fmt.Errorf("image cannot be nil")
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("color count must be at least 1, got %d", count)
+	}
+	if count > 256 {
+		return nil, fmt.Errorf("color count too large: %d (maximum: 256)", count)
+	}
+
+	// Sample pixels from the image, skipping fully transparent (e.g. masked) ones.
+	pixels := visiblePixels(samplePixels(img))
+	if !e.keepAlpha {
+		pixels = flattenPixels(pixels)
+	}
+	if len(pixels) == 0 {
+		return nil, fmt.Errorf("no visible pixels found in image")
+	}
+
+	// If we want more colors than unique colors exist, return all unique colors.
+	if unique := uniquePixels(pixels); count >= len(unique) {
+		return NewPaletteWithWeights(unique, pixelCounts(pixels, unique)), nil
+	}
+
+	box := make(colourBox, len(pixels))
+	for i, p := range pixels {
+		box[i] = ToRGBA(p)
+	}
+
+	boxes := e.medianCut(box, count)
+
+	colors := make([]color.Color, len(boxes))
+	weights := make([]float64, len(boxes))
+	for i, b := range boxes {
+		rgb, alpha := b.average()
+		if e.keepAlpha {
+			colors[i] = color.NRGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: uint8(math.Round(alpha))}
+		} else {
+			colors[i] = color.RGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: 255}
+		}
+		weights[i] = float64(len(b)) / float64(len(pixels))
+	}
+
+	return NewPaletteWithWeights(colors, weights), nil
+}
+
+// medianCut splits box into up to count boxes, always splitting the box with the
+// widest channel range next, and returns them largest first.
+func (e *MedianCutExtractor) medianCut(box colourBox, count int) []colourBox {
+	boxes := []colourBox{box}
+	for len(boxes) < count {
+		next, widest := -1, uint8(0)
+		for i, b := range boxes {
+			if len(b) < 2 {
+				continue
+			}
+			if _, spread := b.widestChannel(); spread > widest {
+				next, widest = i, spread
+			}
+		}
+		if next < 0 {
+			break // Every box holds a single colour.
+		}
+
+		lower, upper := boxes[next].split()
+		boxes[next] = lower
+		boxes = append(boxes, upper)
+	}
+
+	slices.SortStableFunc(boxes, func(a, b colourBox) int {
+		return cmp.Compare(len(b), len(a))
+	})
+	return boxes
+}
@@ -0,0 +1,192 @@
+package colour
+
+import (
+	"cmp"
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+)
+
+// quadrantImage returns an image split into four solid quadrants.
+func quadrantImage(colours [4]color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 20, 20))
+	for y := range 20 {
+		for x := range 20 {
+			img.Set(x, y, colours[y/10*2+x/10])
+		}
+	}
+	return img
+}
+
+// paletteHexes returns the hex of every colour in a palette.
+func paletteHexes(p *Palette) []string {
+	hexes := make([]string, len(p.Colors))
+	for i, c := range p.Colors {
+		hexes[i] = ToRGBA(c).HexAlpha()
+	}
+	return hexes
+}
+
+func TestMedianCutExtractor_Quadrants(t *testing.T) {
+	img := quadrantImage([4]color.Color{
+		color.NRGBA{R: 200, G: 30, B: 30, A: 255},
+		color.NRGBA{R: 30, G: 200, B: 30, A: 255},
+		color.NRGBA{R: 30, G: 30, B: 200, A: 255},
+		color.NRGBA{R: 240, G: 240, B: 240, A: 255},
+	})
+
+	palette, err := NewMedianCutExtractor().Extract(img, 2)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(palette.Colors) != 2 {
+		t.Fatalf("got %d colours, want 2", len(palette.Colors))
+	}
+	for i, w := range palette.Weights {
+		if w != 0.5 {
+			t.Errorf("weight[%d] = %v, want 0.5", i, w)
+		}
+	}
+
+	// Every colour is a box average of two quadrants, so no quadrant colour survives.
+	for _, hex := range paletteHexes(palette) {
+		if slices.Contains([]string{"#c81e1eff", "#1ec81eff", "#1e1ec8ff", "#f0f0f0ff"}, hex) {
+			t.Errorf("colour %s should be a box average, not a single quadrant", hex)
+		}
+	}
+}
+
+func TestMedianCutExtractor_SplitsWidestChannel(t *testing.T) {
+	// A red gradient: only the red channel varies, so every split is along red.
+	img := image.NewNRGBA(image.Rect(0, 0, 256, 1))
+	for x := range 256 {
+		img.Set(x, 0, color.NRGBA{R: uint8(x), G: 100, B: 50, A: 255})
+	}
+
+	palette, err := NewMedianCutExtractor().Extract(img, 4)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(palette.Colors) != 4 {
+		t.Fatalf("got %d colours, want 4", len(palette.Colors))
+	}
+
+	reds := make([]uint8, 0, len(palette.Colors))
+	for _, c := range palette.Colors {
+		rgba := ToRGBA(c)
+		if rgba.G != 100 || rgba.B != 50 {
+			t.Errorf("colour %s changed green or blue", rgba.Hex())
+		}
+		reds = append(reds, rgba.R)
+	}
+	slices.Sort(reds)
+	// Four equal boxes of 64 reds each average to 31.5, 95.5, 159.5 and 223.5.
+	if want := []uint8{32, 96, 160, 224}; !slices.Equal(reds, want) {
+		t.Errorf("reds = %v, want %v", reds, want)
+	}
+}
+
+func TestMedianCutExtractor_Deterministic(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	for y := range 64 {
+		for x := range 64 {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 4), G: uint8(y * 4), B: uint8((x + y) * 2), A: 255})
+		}
+	}
+
+	// The seed is ignored: median cut gives the same palette with or without one.
+	seed := int64(42)
+	withSeed, err := NewExtractor(AlgorithmMedianCut, ExtractorOptions{Seed: &seed})
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+	withoutSeed, err := NewExtractor(AlgorithmMedianCut, ExtractorOptions{})
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+
+	first, err := withSeed.Extract(img, 8)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	for range 3 {
+		again, err := withoutSeed.Extract(img, 8)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if !slices.Equal(paletteHexes(first), paletteHexes(again)) || !slices.Equal(first.Weights, again.Weights) {
+			t.Fatalf("palettes differ between runs: %v and %v", paletteHexes(first), paletteHexes(again))
+		}
+	}
+
+	// Boxes are returned largest first.
+	if !slices.IsSortedFunc(first.Weights, func(a, b float64) int { return cmp.Compare(b, a) }) {
+		t.Errorf("weights %v are not in descending order", first.Weights)
+	}
+}
+
+func TestMedianCutExtractor_KeepAlpha(t *testing.T) {
+	img := quadrantImage([4]color.Color{
+		color.NRGBA{R: 200, G: 30, B: 30, A: 128},
+		color.NRGBA{R: 200, G: 30, B: 30, A: 128},
+		color.NRGBA{R: 30, G: 30, B: 200, A: 255},
+		color.NRGBA{},
+	})
+
+	palette, err := NewMedianCutExtractor().WithKeepAlpha(true).Extract(img, 1)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	// The transparent quadrant is ignored; the rest average to a translucent purple.
+	if got := ToRGBA(palette.Colors[0]); got.A != 170 || got.R != 143 || got.B != 87 {
+		t.Errorf("colour = %v, want the average of the visible quadrants with alpha 170", got)
+	}
+}
+
+func TestMedianCutExtractor_FewUniqueColoursWeighted(t *testing.T) {
+	blue := color.NRGBA{B: 255, A: 255}
+	red := color.NRGBA{R: 255, A: 255}
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for y := range 10 {
+		for x := range 10 {
+			img.Set(x, y, blue)
+		}
+	}
+	img.Set(0, 0, red)
+
+	palette, err := NewMedianCutExtractor().Extract(img, 8)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(palette.Weights) != 2 {
+		t.Fatalf("Extract() returned weights %v, want one per unique colour", palette.Weights)
+	}
+	dominant, err := palette.Dominant()
+	if err != nil {
+		t.Fatalf("Dominant() error = %v", err)
+	}
+	if got := ToRGBA(dominant).Hex(); got != "#0000ff" {
+		t.Errorf("Dominant() = %s, want #0000ff", got)
+	}
+}
+
+func TestMedianCutExtractor_Errors(t *testing.T) {
+	e := NewMedianCutExtractor()
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+
+	if _, err := e.Extract(nil, 4); err == nil {
+		t.Error("Extract(nil) should fail")
+	}
+	for _, count := range []int{0, 257} {
+		if _, err := e.Extract(img, count); err == nil {
+			t.Errorf("Extract(count %d) should fail", count)
+		}
+	}
+	if _, err := e.Extract(img, 4); err == nil {
+		t.Error("Extract() of a fully transparent image should fail")
+	}
+	if !IsValidAlgorithm(AlgorithmMedianCut) {
+		t.Error("median cut should be a valid algorithm")
+	}
+}
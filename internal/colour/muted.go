@@ -9,7 +9,7 @@ import (
 //
 // Design Theory (Industry Standards):.
 // - Muted colors are used for INACTIVE or DISABLED UI elements.
-// - Created by reducing saturation by ~50% (to 50% of original)
+// - Created by reducing saturation (by 50% by default, see MutedSaturationReduction)
 // - Luminance adjusted by ±10-15% to maintain perceptual consistency.
 // - Creates visual hierarchy: active vs inactive states.
 // - Common in design systems (Material Design, Atlassian, etc.)
@@ -17,9 +17,11 @@ import (
 // Parameters:.
 // - cc: The base color to create a muted variant from.
 // - adjustment: Luminance adjustment amount (typically 0.15 = 15%)
+// - saturationReduction: Fraction of saturation removed (typically 0.5 = 50%), clamped to 0.0-1.0.
 // - themeType: Dark or light theme affects luminance adjustment direction.
 // - isBackground: Background vs foreground affects adjustment direction.
-func createMutedVariant(cc CategorisedColour, adjustment float64, themeType ThemeType, isBackground bool) CategorisedColour {
+// - useOKLCH: Keep the OKLCH hue of the base colour (see CategorisationConfig.UseOKLCH).
+func createMutedVariant(cc CategorisedColour, adjustment, saturationReduction float64, themeType ThemeType, isBackground, useOKLCH bool) CategorisedColour {
 	h, s, l := rgbToHSL(cc.RGB)
 
 	// Luminance adjustment based on theme and role.
@@ -39,13 +41,12 @@ func createMutedVariant(cc CategorisedColour, adjustment float64, themeType Them
 		newLum = math.Min(1.0, l+adjustment)
 	}
 
-	// Saturation reduction: ~50% (reduce to half of original).
-	// This is the key change from the previous 80% (0.8 factor).
+	// Saturation reduction: ~50% by default (reduce to half of original).
 	// Industry standard for muted/inactive states.
-	newSat := s * 0.5
+	newSat := s * (1 - math.Max(0.0, math.Min(1.0, saturationReduction)))
 
 	// Convert back to RGB.
-	newRGB := HSLToRGB(h, newSat, newLum)
+	newRGB := adjustHSL(cc.RGB, h, s, newSat, newLum, useOKLCH)
 	newColor := RGBToColor(newRGB)
 
 	// Calculate actual relative luminance (WCAG standard) from the RGB color.
@@ -0,0 +1,94 @@
+package colour
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestCreateMutedVariantSaturation(t *testing.T) {
+	// A saturated blue at mid lightness.
+	rgb := RGB{R: 0x33, G: 0x66, B: 0xcc}
+	base := CategorisedColour{RGB: rgb}
+	_, original, _ := rgbToHSL(rgb)
+
+	tests := []struct {
+		name      string
+		reduction float64
+		want      float64
+	}{
+		{"default", 0.5, original * 0.5},
+		{"none", 0, original},
+		{"slight", 0.2, original * 0.8},
+		{"full", 1, 0},
+		{"clamped above", 1.5, 0},
+		{"clamped below", -0.5, original},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			muted := createMutedVariant(base, 0.15, tt.reduction, ThemeDark, false, false)
+			if math.Abs(muted.Saturation-tt.want) > 1e-9 {
+				t.Errorf("Saturation = %.4f, want %.4f", muted.Saturation, tt.want)
+			}
+
+			// The RGB value must carry the reduced saturation, within 8-bit rounding.
+			if _, got, _ := rgbToHSL(muted.RGB); math.Abs(got-tt.want) > 0.02 {
+				t.Errorf("saturation of %s = %.4f, want %.4f", muted.Hex, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoriseMutedSaturationReduction(t *testing.T) {
+	palette := NewPalette([]color.Color{
+		color.RGBA{R: 0x1a, G: 0x1b, B: 0x26, A: 0xff},
+		color.RGBA{R: 0xc0, G: 0xca, B: 0xf5, A: 0xff},
+		color.RGBA{R: 0x33, G: 0x66, B: 0xcc, A: 0xff},
+		color.RGBA{R: 0xcc, G: 0x66, B: 0x33, A: 0xff},
+	})
+
+	for _, reduction := range []float64{0, 0.5, 1} {
+		config := DefaultCategorisationConfig()
+		config.ThemeType = ThemeDark
+		config.MutedSaturationReduction = reduction
+		result := Categorise(palette, config)
+
+		accent, ok := result.Get(RoleAccent1)
+		if !ok {
+			t.Fatal("palette has no accent1")
+		}
+		muted, ok := result.Get(RoleAccent1Muted)
+		if !ok {
+			t.Fatal("palette has no accent1Muted")
+		}
+		if want := accent.Saturation * (1 - reduction); math.Abs(muted.Saturation-want) > 0.01 {
+			t.Errorf("reduction %.1f: accent1Muted saturation = %.4f, want %.4f", reduction, muted.Saturation, want)
+		}
+	}
+}
+
+func TestCreateMutedVariantOKLCHKeepsHue(t *testing.T) {
+	// Saturated blue, whose HSL hue drifts towards purple as it lightens.
+	base := CategorisedColour{RGB: RGB{R: 0x00, G: 0x33, B: 0xff}}
+	_, _, wantHue := RGBToOKLCH(base.RGB)
+
+	hsl := createMutedVariant(base, 0.15, 0.5, ThemeLight, false, false)
+	oklch := createMutedVariant(base, 0.15, 0.5, ThemeLight, false, true)
+
+	_, _, hslHue := RGBToOKLCH(hsl.RGB)
+	_, _, oklchHue := RGBToOKLCH(oklch.RGB)
+	if math.Abs(oklchHue-wantHue) > 2 {
+		t.Errorf("OKLCH muted hue = %.1f, want %.1f", oklchHue, wantHue)
+	}
+	if math.Abs(oklchHue-wantHue) >= math.Abs(hslHue-wantHue) {
+		t.Errorf("OKLCH hue drift %.1f not smaller than HSL drift %.1f", math.Abs(oklchHue-wantHue), math.Abs(hslHue-wantHue))
+	}
+
+	// Lightness follows the HSL target.
+	hslL, _, _ := RGBToOKLCH(hsl.RGB)
+	oklchL, _, _ := RGBToOKLCH(oklch.RGB)
+	if math.Abs(hslL-oklchL) > 0.01 {
+		t.Errorf("OKLCH muted lightness = %.3f, want %.3f", oklchL, hslL)
+	}
+}
@@ -0,0 +1,244 @@
+// Package color provides color extraction and palette generation functionality.
+package colour
+
+import (
+	"cmp"
+	"fmt"
+	"image"
+	"image/color"
+	"slices"
+
+	"github.com/jmylchreest/tinct/internal/security"
+)
+
+const (
+	// octreeDepth is the number of levels below the root, one per bit of each channel.
+	octreeDepth = 8
+
+	// octreeMaxLeaves bounds the leaves kept while pixels are inserted. The tree is
+	// reduced whenever it grows past this, so memory does not depend on the image.
+	octreeMaxLeaves = 4096
+)
+
+// OctreeExtractor implements color extraction using octree quantisation.
+// It is deterministic and does not need a seed.
+type OctreeExtractor struct {
+	keepAlpha bool // Keep source alpha instead of compositing translucent pixels over black
+}
+
+// NewOctreeExtractor creates a new OctreeExtractor with default settings.
+func NewOctreeExtractor() *OctreeExtractor {
+	return &OctreeExtractor{
+		keepAlpha: false,
+	}
+}
+
+// WithKeepAlpha makes extracted colors keep the alpha of their source pixels.
+// Each color's alpha is the mean alpha of its leaf. By default translucent
+// pixels are composited over black. Fully transparent pixels are ignored in both modes.
+func (e *OctreeExtractor) WithKeepAlpha(keep bool) *OctreeExtractor {
+	e.keepAlpha = keep
+	return e
+}
+
+// octreeNode is a node of the colour octree. Every node holds the channel sums and
+// pixel count of all pixels inserted below it, so a reduced node is its own average.
+type octreeNode struct {
+	children   [8]*octreeNode
+	leaf       bool
+	r, g, b, a uint64
+	pixels     uint64
+}
+
+// add accumulates a pixel into the node.
+func (n *octreeNode) add(p RGBA) {
+	n.r += uint64(p.R)
+	n.g += uint64(p.G)
+	n.b += uint64(p.B)
+	n.a += uint64(p.A)
+	n.pixels++
+}
+
+// merge accumulates another node's pixels into the node.
+func (n *octreeNode) merge(o *octreeNode) {
+	n.r += o.r
+	n.g += o.g
+	n.b += o.b
+	n.a += o.a
+	n.pixels += o.pixels
+}
+
+// average returns the mean colour of the node and its mean alpha (0-255).
+func (n *octreeNode) average() (RGB, uint8) {
+	half := n.pixels / 2 // Round to nearest.
+	return RGB{
+		R: security.SafeUint8FromUint64((n.r + half) / n.pixels),
+		G: security.SafeUint8FromUint64((n.g + half) / n.pixels),
+		B: security.SafeUint8FromUint64((n.b + half) / n.pixels),
+	}, security.SafeUint8FromUint64((n.a + half) / n.pixels)
+}
+
+// octreeChild returns which child of a node at level holds the pixel, taking
+// one bit of each channel from the most significant bit down.
+func octreeChild(p RGBA, level int) int {
+	shift := 7 - level
+	return int(p.R>>shift&1)<<2 | int(p.G>>shift&1)<<1 | int(p.B>>shift&1)
+}
+
+// octree is a colour octree with the non-leaf nodes of every level kept, in
+// creation order, as candidates for reduction.
+type octree struct {
+	root      *octreeNode
+	reducible [octreeDepth][]*octreeNode
+	leafCount int
+}
+
+// newOctree returns an empty octree.
+func newOctree() *octree {
+	t := &octree{root: &octreeNode{}}
+	t.reducible[0] = append(t.reducible[0], t.root)
+	return t
+}
+
+// insert adds a pixel, creating nodes down to the deepest level or the first leaf.
+func (t *octree) insert(p RGBA) {
+	node := t.root
+	node.add(p)
+	for level := 0; !node.leaf; level++ {
+		i := octreeChild(p, level)
+		child := node.children[i]
+		if child == nil {
+			child = &octreeNode{leaf: level+1 == octreeDepth}
+			if child.leaf {
+				t.leafCount++
+			} else {
+				t.reducible[level+1] = append(t.reducible[level+1], child)
+			}
+			node.children[i] = child
+		}
+		child.add(p)
+		node = child
+	}
+}
+
+// reduce merges leaves until at most count remain. It always reduces the node
+// with the fewest pixels on the deepest level that still has children, so the
+// most common colours keep the most detail.
+func (t *octree) reduce(count int) {
+	for t.leafCount > count {
+		level := octreeDepth - 1
+		for len(t.reducible[level]) == 0 {
+			level--
+		}
+		nodes := t.reducible[level]
+		next := 0
+		for i, n := range nodes {
+			if n.pixels < nodes[next].pixels {
+				next = i
+			}
+		}
+		node := nodes[next]
+
+		// Children of the deepest reducible level are all leaves.
+		children := make([]int, 0, len(node.children))
+		for i, c := range node.children {
+			if c != nil {
+				children = append(children, i)
+			}
+		}
+
+		excess := t.leafCount - count
+		if len(children)-1 <= excess {
+			// Collapse the node into a single leaf.
+			node.children = [8]*octreeNode{}
+			node.leaf = true
+			t.leafCount -= len(children) - 1
+			t.reducible[level] = slices.Delete(nodes, next, next+1)
+			continue
+		}
+
+		// Collapsing every child would leave fewer than count colours, so only
+		// merge the smallest children together into the first of them.
+		slices.SortStableFunc(children, func(a, b int) int {
+			return cmp.Compare(node.children[a].pixels, node.children[b].pixels)
+		})
+		into := node.children[children[0]]
+		for _, i := range children[1 : excess+1] {
+			into.merge(node.children[i])
+			node.children[i] = nil
+		}
+		t.leafCount -= excess
+	}
+}
+
+// leaves returns the leaves of the tree in depth-first child order.
+func (t *octree) leaves() []*octreeNode {
+	var leaves []*octreeNode
+	var walk func(n *octreeNode)
+	walk = func(n *octreeNode) {
+		if n.leaf {
+			leaves = append(leaves, n)
+			return
+		}
+		for _, c := range n.children {
+			if c != nil {
+				walk(c)
+			}
+		}
+	}
+	walk(t.root)
+	return leaves
+}
+
+// Extract extracts colors from an image using octree quantisation.
+// Pixels are inserted into an 8-level octree, one level per bit of each channel,
+// and leaves are merged until count remain. Returns the leaf averages weighted by
+// pixel count, largest first.
+func (e *OctreeExtractor) Extract(img image.Image, count int) (*Palette, error) {
+	if img == nil {
+		return nil, fmt.Errorf("image cannot be nil")
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("color count must be at least 1, got %d", count)
+	}
+	if count > 256 {
+		return nil, fmt.Errorf("color count too large: %d (maximum: 256)", count)
+	}
+
+	// Sample pixels from the image, skipping fully transparent (e.g. masked) ones.
+	pixels := visiblePixels(samplePixels(img))
+	if !e.keepAlpha {
+		pixels = flattenPixels(pixels)
+	}
+	if len(pixels) == 0 {
+		return nil, fmt.Errorf("no visible pixels found in image")
+	}
+
+	tree := newOctree()
+	for _, p := range pixels {
+		tree.insert(ToRGBA(p))
+		if tree.leafCount > octreeMaxLeaves {
+			tree.reduce(max(count, octreeMaxLeaves/2))
+		}
+	}
+	tree.reduce(count)
+
+	leaves := tree.leaves()
+	slices.SortStableFunc(leaves, func(a, b *octreeNode) int {
+		return cmp.Compare(b.pixels, a.pixels)
+	})
+
+	colors := make([]color.Color, len(leaves))
+	weights := make([]float64, len(leaves))
+	for i, leaf := range leaves {
+		rgb, alpha := leaf.average()
+		if e.keepAlpha {
+			colors[i] = color.NRGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: alpha}
+		} else {
+			colors[i] = color.RGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: 255}
+		}
+		weights[i] = float64(leaf.pixels)
+	}
+
+	return NewPaletteWithWeights(colors, weights), nil
+}
@@ -0,0 +1,184 @@
+package colour
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+)
+
+// gradientImage returns an image whose pixels are almost all distinct colours.
+func gradientImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	for y := range 64 {
+		for x := range 64 {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 4), G: uint8(y * 4), B: uint8((x + y) * 2), A: 255})
+		}
+	}
+	return img
+}
+
+func TestOctreeExtractor_Count(t *testing.T) {
+	img := gradientImage()
+
+	for _, count := range []int{1, 2, 5, 8, 16, 33, 256} {
+		palette, err := NewOctreeExtractor().Extract(img, count)
+		if err != nil {
+			t.Fatalf("Extract(%d) error = %v", count, err)
+		}
+		if len(palette.Colors) != count {
+			t.Errorf("Extract(%d) returned %d colours", count, len(palette.Colors))
+		}
+		if len(palette.Weights) != len(palette.Colors) {
+			t.Errorf("Extract(%d) returned %d weights for %d colours", count, len(palette.Weights), len(palette.Colors))
+		}
+	}
+}
+
+func TestOctreeExtractor_Quadrants(t *testing.T) {
+	quadrants := []string{"#c81e1eff", "#1ec81eff", "#1e1ec8ff", "#f0f0f0ff"}
+	img := quadrantImage([4]color.Color{
+		color.NRGBA{R: 200, G: 30, B: 30, A: 255},
+		color.NRGBA{R: 30, G: 200, B: 30, A: 255},
+		color.NRGBA{R: 30, G: 30, B: 200, A: 255},
+		color.NRGBA{R: 240, G: 240, B: 240, A: 255},
+	})
+
+	// Asking for more colours than the image has returns each colour once.
+	palette, err := NewOctreeExtractor().Extract(img, 8)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	got := paletteHexes(palette)
+	slices.Sort(got)
+	want := slices.Clone(quadrants)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("colours = %v, want %v", got, want)
+	}
+	for i, w := range palette.Weights {
+		if w != 0.25 {
+			t.Errorf("weight[%d] = %v, want 0.25", i, w)
+		}
+	}
+
+	// Reducing to two colours averages pairs of quadrants.
+	palette, err = NewOctreeExtractor().Extract(img, 2)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(palette.Colors) != 2 {
+		t.Fatalf("got %d colours, want 2", len(palette.Colors))
+	}
+	weights := slices.Clone(palette.Weights)
+	slices.Sort(weights)
+	if weights[0]+weights[1] != 1 || weights[0] < 0.25 {
+		t.Errorf("weights = %v, want pixel shares of the merged quadrants", palette.Weights)
+	}
+}
+
+func TestOctreeExtractor_WeightsFollowPixelCounts(t *testing.T) {
+	// Three quarters red, one quarter blue.
+	red := color.NRGBA{R: 220, G: 20, B: 20, A: 255}
+	blue := color.NRGBA{R: 20, G: 20, B: 220, A: 255}
+	img := quadrantImage([4]color.Color{red, red, red, blue})
+
+	palette, err := NewOctreeExtractor().Extract(img, 2)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if got := paletteHexes(palette); !slices.Equal(got, []string{"#dc1414ff", "#1414dcff"}) {
+		t.Errorf("colours = %v, want red then blue", got)
+	}
+	if !slices.Equal(palette.Weights, []float64{0.75, 0.25}) {
+		t.Errorf("weights = %v, want [0.75 0.25]", palette.Weights)
+	}
+}
+
+func TestOctreeExtractor_Deterministic(t *testing.T) {
+	img := gradientImage()
+
+	// The seed is ignored: octree gives the same palette with or without one.
+	seed := int64(42)
+	withSeed, err := NewExtractor(AlgorithmOctree, ExtractorOptions{Seed: &seed})
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+	withoutSeed, err := NewExtractor(AlgorithmOctree, ExtractorOptions{})
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+
+	first, err := withSeed.Extract(img, 8)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	for range 3 {
+		again, err := withoutSeed.Extract(img, 8)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if !slices.Equal(paletteHexes(first), paletteHexes(again)) || !slices.Equal(first.Weights, again.Weights) {
+			t.Fatalf("palettes differ between runs: %v and %v", paletteHexes(first), paletteHexes(again))
+		}
+	}
+}
+
+func TestOctree_BoundedLeaves(t *testing.T) {
+	tree := newOctree()
+	for i := range 1 << 16 {
+		tree.insert(RGBA{R: uint8(i), G: uint8(i >> 8), B: uint8(i * 7), A: 255})
+		if tree.leafCount > octreeMaxLeaves {
+			tree.reduce(octreeMaxLeaves / 2)
+		}
+	}
+	if tree.leafCount > octreeMaxLeaves {
+		t.Errorf("tree has %d leaves, want at most %d", tree.leafCount, octreeMaxLeaves)
+	}
+
+	tree.reduce(10)
+	leaves := tree.leaves()
+	if len(leaves) != 10 || tree.leafCount != 10 {
+		t.Fatalf("got %d leaves (counted %d), want 10", len(leaves), tree.leafCount)
+	}
+	var pixels uint64
+	for _, leaf := range leaves {
+		pixels += leaf.pixels
+	}
+	if pixels != 1<<16 {
+		t.Errorf("leaves hold %d pixels, want %d", pixels, 1<<16)
+	}
+}
+
+func TestOctreeExtractor_KeepAlpha(t *testing.T) {
+	img := quadrantImage([4]color.Color{
+		color.NRGBA{R: 200, G: 30, B: 30, A: 128},
+		color.NRGBA{R: 200, G: 30, B: 30, A: 128},
+		color.NRGBA{R: 30, G: 30, B: 200, A: 0},
+		color.NRGBA{R: 30, G: 30, B: 200, A: 0},
+	})
+
+	palette, err := NewOctreeExtractor().WithKeepAlpha(true).Extract(img, 4)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if got := paletteHexes(palette); !slices.Equal(got, []string{"#c81e1e80"}) {
+		t.Errorf("colours = %v, want only the translucent red (transparent pixels ignored)", got)
+	}
+}
+
+func TestOctreeExtractor_Errors(t *testing.T) {
+	img := gradientImage()
+	if _, err := NewOctreeExtractor().Extract(nil, 4); err == nil {
+		t.Error("Extract(nil) should fail")
+	}
+	if _, err := NewOctreeExtractor().Extract(img, 0); err == nil {
+		t.Error("Extract() with count 0 should fail")
+	}
+	if _, err := NewOctreeExtractor().Extract(img, 257); err == nil {
+		t.Error("Extract() with count 257 should fail")
+	}
+	if _, err := NewOctreeExtractor().Extract(image.NewNRGBA(image.Rect(0, 0, 4, 4)), 4); err == nil {
+		t.Error("Extract() of a fully transparent image should fail")
+	}
+}
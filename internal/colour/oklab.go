@@ -0,0 +1,115 @@
+// Package colour provides OKLab and OKLCH colour space conversion.
+package colour
+
+import "math"
+
+// oklchGamutSteps is the number of bisection steps used to find the largest
+// in-gamut chroma when converting OKLCH to sRGB.
+const oklchGamutSteps = 24
+
+// RGBToOKLab converts sRGB to OKLab. L is 0-1 and a, b are roughly -0.4 to 0.4.
+func RGBToOKLab(rgb RGB) (l, a, b float64) {
+	r, g, bl := rgb.LinearRGB()
+
+	lms1 := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*bl)
+	lms2 := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*bl)
+	lms3 := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*bl)
+
+	return 0.2104542553*lms1 + 0.7936177850*lms2 - 0.0040720468*lms3,
+		1.9779984951*lms1 - 2.4285922050*lms2 + 0.4505937099*lms3,
+		0.0259040371*lms1 + 0.7827717662*lms2 - 0.8086757660*lms3
+}
+
+// OKLabToRGB converts OKLab to sRGB. Colours outside the sRGB gamut are clipped
+// per channel; use OKLCHToRGB to keep the hue of out-of-gamut colours.
+func OKLabToRGB(l, a, b float64) RGB {
+	r, g, bl := okLabToLinear(l, a, b)
+	return RGB{R: linearToSRGB8(r), G: linearToSRGB8(g), B: linearToSRGB8(bl)}
+}
+
+// RGBToOKLCH converts sRGB to OKLCH: lightness (0-1), chroma (0 to about 0.37)
+// and hue (0-360). The hue of a grey is 0.
+func RGBToOKLCH(rgb RGB) (l, c, h float64) {
+	l, a, b := RGBToOKLab(rgb)
+	c = math.Hypot(a, b)
+	if c < 1e-6 {
+		return l, 0, 0
+	}
+	h = math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return l, c, h
+}
+
+// OKLCHToRGB converts OKLCH to sRGB. Lightness is clamped to 0-1. If the colour
+// is outside the sRGB gamut, chroma is reduced until it fits, so lightness and
+// hue are kept rather than clipping each channel.
+func OKLCHToRGB(l, c, h float64) RGB {
+	l = math.Max(0, math.Min(1, l))
+	c = math.Max(0, c)
+
+	if !oklchInGamut(l, c, h) {
+		lo, hi := 0.0, c
+		for range oklchGamutSteps {
+			mid := (lo + hi) / 2
+			if oklchInGamut(l, mid, h) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		c = lo
+	}
+
+	a, b := oklchToOKLab(c, h)
+	return OKLabToRGB(l, a, b)
+}
+
+// oklchToOKLab converts OKLCH chroma and hue to OKLab a and b.
+func oklchToOKLab(c, h float64) (a, b float64) {
+	rad := h * math.Pi / 180
+	return c * math.Cos(rad), c * math.Sin(rad)
+}
+
+// oklchInGamut reports whether an OKLCH colour lies within the sRGB gamut.
+func oklchInGamut(l, c, h float64) bool {
+	const eps = 1e-6
+	a, b := oklchToOKLab(c, h)
+	r, g, bl := okLabToLinear(l, a, b)
+	return r >= -eps && r <= 1+eps && g >= -eps && g <= 1+eps && bl >= -eps && bl <= 1+eps
+}
+
+// okLabToLinear converts OKLab to linear-light sRGB channels, which may fall outside 0-1.
+func okLabToLinear(l, a, b float64) (r, g, bl float64) {
+	lms1 := l + 0.3963377774*a + 0.2158037573*b
+	lms2 := l - 0.1055613458*a - 0.0638541728*b
+	lms3 := l - 0.0894841775*a - 1.2914855480*b
+
+	lms1, lms2, lms3 = lms1*lms1*lms1, lms2*lms2*lms2, lms3*lms3*lms3
+
+	return 4.0767416621*lms1 - 3.3077115913*lms2 + 0.2309699292*lms3,
+		-1.2684380046*lms1 + 2.6097574011*lms2 - 0.3413193965*lms3,
+		-0.0041960863*lms1 - 0.7034186147*lms2 + 1.7076147010*lms3
+}
+
+// adjustHSL returns rgb moved to the HSL saturation and lightness targets newS and
+// newL, where h and s are the HSL hue and saturation of rgb. With useOKLCH the
+// result takes its OKLCH lightness from the HSL target but keeps the OKLCH hue
+// of rgb, with chroma scaled by the same factor as saturation. HSL hue drifts
+// perceptually as lightness changes, most visibly on saturated blues and yellows.
+func adjustHSL(rgb RGB, h, s, newS, newL float64, useOKLCH bool) RGB {
+	target := HSLToRGB(h, newS, newL)
+	if !useOKLCH {
+		return target
+	}
+
+	l, _, _ := RGBToOKLCH(target)
+	_, c, hue := RGBToOKLCH(rgb)
+	if s > 0 {
+		c *= newS / s
+	} else {
+		c = 0
+	}
+	return OKLCHToRGB(l, c, hue)
+}
@@ -0,0 +1,70 @@
+package colour
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRGBToOKLab(t *testing.T) {
+	// Reference values from Björn Ottosson's OKLab definition.
+	tests := []struct {
+		rgb     RGB
+		l, a, b float64
+	}{
+		{RGB{R: 255, G: 255, B: 255}, 1, 0, 0},
+		{RGB{R: 0, G: 0, B: 0}, 0, 0, 0},
+		{RGB{R: 255, G: 0, B: 0}, 0.6279, 0.2249, 0.1258},
+		{RGB{R: 0, G: 255, B: 0}, 0.8664, -0.2339, 0.1795},
+		{RGB{R: 0, G: 0, B: 255}, 0.4520, -0.0325, -0.3115},
+	}
+
+	for _, tt := range tests {
+		l, a, b := RGBToOKLab(tt.rgb)
+		if math.Abs(l-tt.l) > 1e-3 || math.Abs(a-tt.a) > 1e-3 || math.Abs(b-tt.b) > 1e-3 {
+			t.Errorf("RGBToOKLab(%s) = (%.4f, %.4f, %.4f), want (%.4f, %.4f, %.4f)",
+				tt.rgb.Hex(), l, a, b, tt.l, tt.a, tt.b)
+		}
+	}
+}
+
+func TestOKLabRoundTrip(t *testing.T) {
+	for _, rgb := range []RGB{
+		{R: 0, G: 0, B: 0},
+		{R: 255, G: 255, B: 255},
+		{R: 0x1a, G: 0x1b, B: 0x26},
+		{R: 0x33, G: 0x66, B: 0xcc},
+		{R: 0xff, G: 0xcc, B: 0x00},
+		{R: 0x80, G: 0x80, B: 0x80},
+	} {
+		if got := OKLabToRGB(RGBToOKLab(rgb)); got != rgb {
+			t.Errorf("OKLab round trip of %s = %s", rgb.Hex(), got.Hex())
+		}
+		if got := OKLCHToRGB(RGBToOKLCH(rgb)); got != rgb {
+			t.Errorf("OKLCH round trip of %s = %s", rgb.Hex(), got.Hex())
+		}
+	}
+}
+
+func TestRGBToOKLCHGrey(t *testing.T) {
+	l, c, h := RGBToOKLCH(RGB{R: 0x80, G: 0x80, B: 0x80})
+	if c != 0 || h != 0 {
+		t.Errorf("RGBToOKLCH(grey) = (%.4f, %.4f, %.1f), want zero chroma and hue", l, c, h)
+	}
+}
+
+func TestOKLCHToRGBGamutMapping(t *testing.T) {
+	// Chroma 0.4 at this lightness is well outside sRGB; hue and lightness must survive.
+	wantL, wantH := 0.7, 250.0
+	got := OKLCHToRGB(wantL, 0.4, wantH)
+
+	l, c, h := RGBToOKLCH(got)
+	if math.Abs(l-wantL) > 0.01 {
+		t.Errorf("lightness = %.3f, want %.3f", l, wantL)
+	}
+	if math.Abs(h-wantH) > 2 {
+		t.Errorf("hue = %.1f, want %.1f", h, wantH)
+	}
+	if c >= 0.4 || c < 0.05 {
+		t.Errorf("chroma = %.3f, want reduced into gamut", c)
+	}
+}
@@ -4,19 +4,33 @@ package colour
 import (
 	"encoding/json"
 	"fmt"
+	"image"
 	"image/color"
+	"image/draw"
+	"math"
+	"strconv"
 	"strings"
-
-	"github.com/jmylchreest/tinct/internal/security"
 )
 
 // Palette represents a collection of colors extracted from an image.
 type Palette struct {
 	Colors    []color.Color
-	Weights   []float64    // Optional: relative frequency/volume of each color (0.0-1.0)
-	RoleHints map[Role]int // Optional: explicit role assignments (role -> color index)
+	Weights   []float64         // Optional: relative frequency/volume of each color (0.0-1.0)
+	RoleHints map[Role]int      // Optional: explicit role assignments (role -> color index)
+	Meta      map[string]string // Optional: provenance such as the AI prompt and model (see Meta* keys)
 }
 
+// Palette metadata keys recorded by input plugins.
+const (
+	MetaPrompt         = "prompt"
+	MetaNegativePrompt = "negative_prompt"
+	MetaModel          = "model"
+	MetaBackend        = "backend"
+	MetaAspectRatio    = "aspect_ratio"
+	MetaSeedMode       = "seed_mode"
+	MetaSeed           = "seed"
+)
+
 // NewPalette creates a new Palette with the given colors.
 func NewPalette(colors []color.Color) *Palette {
 	return &Palette{
@@ -72,11 +86,77 @@ func NewPaletteWithRoleHints(colors []color.Color, roleHints map[Role]int) *Pale
 	}
 }
 
+// SetMeta records a metadata value, allocating Meta on first use.
+func (p *Palette) SetMeta(key, value string) {
+	if p.Meta == nil {
+		p.Meta = make(map[string]string)
+	}
+	p.Meta[key] = value
+}
+
 // Len returns the number of colors in the palette.
 func (p *Palette) Len() int {
 	return len(p.Colors)
 }
 
+// Dominant returns the most prevalent colour: the one with the highest weight.
+// Ties go to the earlier colour. Without weights there is no way to tell which
+// colour is most prevalent, so an unweighted palette is an error.
+func (p *Palette) Dominant() (color.Color, error) {
+	if len(p.Colors) == 0 {
+		return nil, fmt.Errorf("palette is empty")
+	}
+	if len(p.Weights) != len(p.Colors) {
+		return nil, fmt.Errorf("palette has no weights")
+	}
+
+	best := 0
+	for i, w := range p.Weights {
+		if w > p.Weights[best] {
+			best = i
+		}
+	}
+	return p.Colors[best], nil
+}
+
+// ToImage renders the palette as a width x height strip of vertical bands, one per
+// colour in palette order. Each band's width is proportional to the colour's weight,
+// or equal when the palette has no weights. Band edges are rounded so the bands
+// always fill the strip exactly; a colour with a tiny weight may get no pixels.
+// An empty palette renders as a transparent image.
+func (p *Palette) ToImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, max(width, 0), max(height, 0)))
+	if len(p.Colors) == 0 || width <= 0 || height <= 0 {
+		return img
+	}
+
+	weights := p.Weights
+	total := 0.0
+	for _, w := range weights {
+		total += max(w, 0)
+	}
+	if len(weights) != len(p.Colors) || total <= 0 {
+		weights = make([]float64, len(p.Colors))
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = float64(len(weights))
+	}
+
+	cumulative := 0.0
+	left := 0
+	for i, c := range p.Colors {
+		cumulative += max(weights[i], 0)
+		right := int(math.Round(cumulative / total * float64(width)))
+		if i == len(p.Colors)-1 {
+			right = width
+		}
+		draw.Draw(img, image.Rect(left, 0, right, height), image.NewUniform(c), image.Point{}, draw.Src)
+		left = right
+	}
+	return img
+}
+
 // RGB represents a color in RGB format (without alpha).
 type RGB struct {
 	R uint8 `json:"r"`
@@ -94,6 +174,70 @@ func (rgb RGB) Hex() string {
 	return fmt.Sprintf("#%02x%02x%02x", rgb.R, rgb.G, rgb.B)
 }
 
+// ToColor returns the RGB color as an opaque color.Color.
+func (rgb RGB) ToColor() color.Color {
+	return color.RGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: 255}
+}
+
+// ParseHex parses a hex color string into an RGB struct.
+// Supports formats: #RRGGBB, RRGGBB, #RGB, RGB. Surrounding whitespace is ignored.
+func ParseHex(hex string) (RGB, error) {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+
+	// Expand shorthand format (RGB -> RRGGBB).
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	if len(hex) != 6 {
+		return RGB{}, fmt.Errorf("invalid hex colour length: expected 6 characters, got %d", len(hex))
+	}
+
+	r, err := strconv.ParseUint(hex[0:2], 16, 8)
+	if err != nil {
+		return RGB{}, fmt.Errorf("invalid red component: %w", err)
+	}
+
+	g, err := strconv.ParseUint(hex[2:4], 16, 8)
+	if err != nil {
+		return RGB{}, fmt.Errorf("invalid green component: %w", err)
+	}
+
+	b, err := strconv.ParseUint(hex[4:6], 16, 8)
+	if err != nil {
+		return RGB{}, fmt.Errorf("invalid blue component: %w", err)
+	}
+
+	return RGB{R: uint8(r), G: uint8(g), B: uint8(b)}, nil
+}
+
+// HexOptions controls hex string formatting for HexWith.
+// The zero value produces the same output as Hex().
+type HexOptions struct {
+	Uppercase bool // Use uppercase digits (e.g., "#1A2B3C")
+	Shorthand bool // Collapse to 3-digit form when possible (e.g., "#ffffff" -> "#fff")
+	NoHash    bool // Omit the leading "#"
+}
+
+// HexWith returns the RGB color as a hex string formatted according to opts.
+// Shorthand is only used when every channel has identical nibbles (e.g., "#ff0056" stays 6-digit).
+func (rgb RGB) HexWith(opts HexOptions) string {
+	var hex string
+	if opts.Shorthand && rgb.R%17 == 0 && rgb.G%17 == 0 && rgb.B%17 == 0 {
+		hex = fmt.Sprintf("%x%x%x", rgb.R/17, rgb.G/17, rgb.B/17)
+	} else {
+		hex = fmt.Sprintf("%02x%02x%02x", rgb.R, rgb.G, rgb.B)
+	}
+
+	if opts.Uppercase {
+		hex = strings.ToUpper(hex)
+	}
+	if opts.NoHash {
+		return hex
+	}
+	return "#" + hex
+}
+
 // RGBA represents a color with alpha channel.
 type RGBA struct {
 	R uint8 `json:"r"`
@@ -118,12 +262,23 @@ func (rgba RGBA) Hex() string {
 	return fmt.Sprintf("#%02x%02x%02x", rgba.R, rgba.G, rgba.B)
 }
 
+// HexWith returns the RGB color (without alpha) as a hex string formatted according to opts.
+func (rgba RGBA) HexWith(opts HexOptions) string {
+	return rgba.ToRGB().HexWith(opts)
+}
+
 // HexAlpha returns the RGBA color as a hex string with alpha (e.g., "#1a2b3cff").
 // This format is used by some applications like Dunst.
 func (rgba RGBA) HexAlpha() string {
 	return fmt.Sprintf("#%02x%02x%02x%02x", rgba.R, rgba.G, rgba.B, rgba.A)
 }
 
+// HexAlphaFirst returns the RGBA color as a hex string with alpha first (e.g., "#ff1a2b3c").
+// This format is used by Polybar.
+func (rgba RGBA) HexAlphaFirst() string {
+	return fmt.Sprintf("#%02x%02x%02x%02x", rgba.A, rgba.R, rgba.G, rgba.B)
+}
+
 // CSSRgb returns the color in CSS rgb() format (e.g., "rgb(26, 43, 60)").
 func (rgba RGBA) CSSRgb() string {
 	return fmt.Sprintf("rgb(%d, %d, %d)", rgba.R, rgba.G, rgba.B)
@@ -141,27 +296,17 @@ func (rgba RGBA) ToRGB() RGB {
 }
 
 // ToRGB converts a color.Color to RGB.
+// Translucent colours are un-premultiplied, so the result is the colour itself
+// rather than the colour composited over black.
 func ToRGB(c color.Color) RGB {
-	r, g, b, _ := c.RGBA()
-	// RGBA returns values in the range [0, 65535], convert to [0, 255].
-	return RGB{
-		R: security.SafeUint8FromUint32(r >> 8),
-		G: security.SafeUint8FromUint32(g >> 8),
-		B: security.SafeUint8FromUint32(b >> 8),
-	}
+	return ToRGBA(c).ToRGB()
 }
 
-// ToRGBA converts a color.Color to RGBA.
+// ToRGBA converts a color.Color to RGBA with straight (non-premultiplied) alpha.
 // If the color doesn't have an alpha channel, it defaults to 255 (fully opaque).
 func ToRGBA(c color.Color) RGBA {
-	r, g, b, a := c.RGBA()
-	// RGBA returns values in the range [0, 65535], convert to [0, 255].
-	return RGBA{
-		R: security.SafeUint8FromUint32(r >> 8),
-		G: security.SafeUint8FromUint32(g >> 8),
-		B: security.SafeUint8FromUint32(b >> 8),
-		A: security.SafeUint8FromUint32(a >> 8),
-	}
+	n, _ := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return RGBA{R: n.R, G: n.G, B: n.B, A: n.A}
 }
 
 // ToHex converts the palette colors to hex strings.
@@ -192,8 +337,9 @@ type ColorJSON struct {
 
 // PaletteJSON represents the palette in JSON format.
 type PaletteJSON struct {
-	Count  int         `json:"count"`
-	Colors []ColorJSON `json:"colors"`
+	Count  int               `json:"count"`
+	Colors []ColorJSON       `json:"colors"`
+	Meta   map[string]string `json:"meta,omitempty"`
 }
 
 // ToJSON converts the palette to JSON format.
@@ -210,6 +356,7 @@ func (p *Palette) ToJSON() ([]byte, error) {
 	paletteJSON := PaletteJSON{
 		Count:  len(p.Colors),
 		Colors: colors,
+		Meta:   p.Meta,
 	}
 
 	return json.MarshalIndent(paletteJSON, "", "  ")
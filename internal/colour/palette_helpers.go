@@ -3,6 +3,7 @@ package colour
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -10,13 +11,14 @@ import (
 type ColorFormat int
 
 const (
-	FormatHex         ColorFormat = iota // #RRGGBB
-	FormatHexAlpha                       // #RRGGBBAA
-	FormatRGB                            // rgb(r,g,b)
-	FormatRGBA                           // rgba(r,g,b,a)
-	FormatHexNoHash                      // RRGGBB (for Hyprland)
-	FormatRGBDecimal                     // "r,g,b" (for Hyprland)
-	FormatRGBADecimal                    // "r,g,b,a" decimal format
+	FormatHex           ColorFormat = iota // #RRGGBB
+	FormatHexAlpha                         // #RRGGBBAA
+	FormatRGB                              // rgb(r,g,b)
+	FormatRGBA                             // rgba(r,g,b,a)
+	FormatHexNoHash                        // RRGGBB (for Hyprland)
+	FormatRGBDecimal                       // "r,g,b" (for Hyprland)
+	FormatRGBADecimal                      // "r,g,b,a" decimal format
+	FormatHexAlphaFirst                    // #AARRGGBB (for Polybar)
 )
 
 // ColorValue provides multiple format accessors for a single color.
@@ -49,6 +51,50 @@ func (cv ColorValue) WithAlpha(alpha float64) ColorValue {
 	return newCV
 }
 
+// Mix returns the colour t (0.0-1.0) of the way from cv to other, interpolated in RGB space.
+// The result is opaque and carries no role or index. Mix stays in RGB because output
+// plugins use it to derive shades the way the target applications do (e.g. Qt's
+// light/dark button colours); InterpolateOKLCH is for perceptual transitions between
+// whole palettes.
+func (cv ColorValue) Mix(other ColorValue, t float64) ColorValue {
+	t = math.Max(0, math.Min(1, t))
+	lerp := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*t))
+	}
+	rgba := RGBA{R: lerp(cv.rgba.R, other.rgba.R), G: lerp(cv.rgba.G, other.rgba.G), B: lerp(cv.rgba.B, other.rgba.B), A: 255}
+	return NewColorValue(rgba, "", -1)
+}
+
+// HSL returns the colour's hue (0-360), saturation (0-1) and lightness (0-1).
+func (cv ColorValue) HSL() (h, s, l float64) {
+	return rgbToHSL(RGB{R: cv.rgba.R, G: cv.rgba.G, B: cv.rgba.B})
+}
+
+// WithLightness returns the colour with its HSL lightness set to l (clamped to 0.0-1.0),
+// keeping hue and saturation. The result is opaque and carries no role or index.
+func (cv ColorValue) WithLightness(l float64) ColorValue {
+	h, s, _ := cv.HSL()
+	rgb := HSLToRGB(h, s, math.Max(0, math.Min(1, l)))
+	return NewColorValue(RGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: 255}, "", -1)
+}
+
+// Gradient returns n evenly spaced colours from from to to, inclusive of both ends,
+// blended in RGB with Mix.
+// A count of 1 returns just from; a count below 1 returns nil.
+func Gradient(from, to ColorValue, n int) []ColorValue {
+	if n < 1 {
+		return nil
+	}
+	if n == 1 {
+		return []ColorValue{from.Mix(from, 0)}
+	}
+	stops := make([]ColorValue, n)
+	for i := range stops {
+		stops[i] = from.Mix(to, float64(i)/float64(n-1))
+	}
+	return stops
+}
+
 // Format returns the color in the specified format.
 func (cv ColorValue) Format(format ColorFormat) string {
 	switch format {
@@ -66,18 +112,24 @@ func (cv ColorValue) Format(format ColorFormat) string {
 		return fmt.Sprintf("%d,%d,%d", cv.rgba.R, cv.rgba.G, cv.rgba.B)
 	case FormatRGBADecimal:
 		return fmt.Sprintf("%d,%d,%d,%.2f", cv.rgba.R, cv.rgba.G, cv.rgba.B, cv.rgba.AlphaFloat())
+	case FormatHexAlphaFirst:
+		return cv.rgba.HexAlphaFirst()
 	default:
 		return cv.rgba.Hex()
 	}
 }
 
 // Convenience accessors for common formats.
-func (cv ColorValue) Hex() string        { return cv.Format(FormatHex) }
-func (cv ColorValue) HexAlpha() string   { return cv.Format(FormatHexAlpha) }
-func (cv ColorValue) RGB() string        { return cv.Format(FormatRGB) }
-func (cv ColorValue) RGBA() string       { return cv.Format(FormatRGBA) }
-func (cv ColorValue) HexNoHash() string  { return cv.Format(FormatHexNoHash) }
-func (cv ColorValue) RGBDecimal() string { return cv.Format(FormatRGBDecimal) }
+func (cv ColorValue) Hex() string           { return cv.Format(FormatHex) }
+func (cv ColorValue) HexAlpha() string      { return cv.Format(FormatHexAlpha) }
+func (cv ColorValue) RGB() string           { return cv.Format(FormatRGB) }
+func (cv ColorValue) RGBA() string          { return cv.Format(FormatRGBA) }
+func (cv ColorValue) HexNoHash() string     { return cv.Format(FormatHexNoHash) }
+func (cv ColorValue) RGBDecimal() string    { return cv.Format(FormatRGBDecimal) }
+func (cv ColorValue) HexAlphaFirst() string { return cv.Format(FormatHexAlphaFirst) }
+
+// HexWith returns the color (without alpha) as a hex string formatted according to opts.
+func (cv ColorValue) HexWith(opts HexOptions) string { return cv.rgba.HexWith(opts) }
 
 // Metadata accessors.
 func (cv ColorValue) Role() Role { return cv.role }
@@ -153,8 +205,8 @@ func (ph *PaletteHelper) GetWithFallback(role Role, fallbackHex string) ColorVal
 	if cv, ok := ph.colors[role]; ok {
 		return cv
 	}
-	// Parse fallback hex and create ColorValue.
-	rgb := parseHex(fallbackHex)
+	// Parse fallback hex and create ColorValue; an invalid hex falls back to black.
+	rgb, _ := ParseHex(fallbackHex)
 	return ColorValue{
 		role:  role,
 		rgba:  RGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: 255},
@@ -171,49 +223,77 @@ func (ph *PaletteHelper) GetByIndex(index int) (ColorValue, bool) {
 	return ph.indexed[index], true
 }
 
-// AllRoles returns all roles in deterministic order (core → accents → semantic → surface → variants).
-func (ph *PaletteHelper) AllRoles() []Role {
-	// Define priority order for consistency across all plugins.
-	order := []Role{
-		// Core colors.
-		RoleBackground, RoleBackgroundMuted,
-		RoleForeground, RoleForegroundMuted,
+// canonicalRoleOrder is the fixed role order shared by AllRoles and OrderedRoles
+// (core → accents → semantic → surface → variants), for consistency across all plugins.
+var canonicalRoleOrder = []Role{
+	// Core colors.
+	RoleBackground, RoleBackgroundMuted,
+	RoleForeground, RoleForegroundMuted,
+
+	// Accents.
+	RoleAccent1, RoleAccent1Muted,
+	RoleAccent2, RoleAccent2Muted,
+	RoleAccent3, RoleAccent3Muted,
+	RoleAccent4, RoleAccent4Muted,
+
+	// Semantic.
+	RoleDanger, RoleWarning, RoleSuccess, RoleInfo, RoleNotification,
 
-		// Accents.
-		RoleAccent1, RoleAccent1Muted,
-		RoleAccent2, RoleAccent2Muted,
-		RoleAccent3, RoleAccent3Muted,
-		RoleAccent4, RoleAccent4Muted,
+	// Surface & Container (Priority 1).
+	RoleSurface, RoleOnSurface, RoleOutline, RoleBorder,
 
-		// Semantic.
-		RoleDanger, RoleWarning, RoleSuccess, RoleInfo, RoleNotification,
+	// Surface & Border Variants (Priority 2).
+	RoleSurfaceVariant, RoleOnSurfaceVariant,
+	RoleBorderMuted, RoleOutlineVariant,
 
-		// Surface & Container (Priority 1).
-		RoleSurface, RoleOnSurface, RoleOutline, RoleBorder,
+	// On-colors for Accents (Priority 2).
+	RoleOnAccent1, RoleOnAccent2, RoleOnAccent3, RoleOnAccent4,
 
-		// Surface & Border Variants (Priority 2).
-		RoleSurfaceVariant, RoleOnSurfaceVariant,
-		RoleBorderMuted, RoleOutlineVariant,
+	// On-colors for Semantic (Priority 2).
+	RoleOnDanger, RoleOnWarning, RoleOnSuccess, RoleOnInfo,
 
-		// On-colors for Accents (Priority 2).
-		RoleOnAccent1, RoleOnAccent2, RoleOnAccent3, RoleOnAccent4,
+	// Inverse Colors (Priority 3).
+	RoleInverseSurface, RoleInverseOnSurface, RoleInversePrimary,
 
-		// On-colors for Semantic (Priority 2).
-		RoleOnDanger, RoleOnWarning, RoleOnSuccess, RoleOnInfo,
+	// Scrim & Shadow (Priority 3).
+	RoleScrim, RoleShadow,
 
-		// Inverse Colors (Priority 3).
-		RoleInverseSurface, RoleInverseOnSurface, RoleInversePrimary,
+	// Container Elevation Variants (Priority 3).
+	RoleSurfaceContainerLowest, RoleSurfaceContainerLow, RoleSurfaceContainer,
+	RoleSurfaceContainerHigh, RoleSurfaceContainerHighest,
+}
 
-		// Scrim & Shadow (Priority 3).
-		RoleScrim, RoleShadow,
+// roleOrder returns canonicalRoleOrder with any accent slots beyond the fourth
+// (see CategorisationConfig.AccentCount) inserted in slot order after accent4Muted
+// and onAccent4.
+func roleOrder() []Role {
+	extraAccents := make([]Role, 0, 2*(MaxAccentCount-DefaultAccentCount))
+	extraOnAccents := make([]Role, 0, MaxAccentCount-DefaultAccentCount)
+	for n := DefaultAccentCount + 1; n <= MaxAccentCount; n++ {
+		extraAccents = append(extraAccents, AccentRole(n), AccentMutedRole(n))
+		extraOnAccents = append(extraOnAccents, OnAccentRole(n))
+	}
 
-		// Container Elevation Variants (Priority 3).
-		RoleSurfaceContainerLowest, RoleSurfaceContainerLow, RoleSurfaceContainer,
-		RoleSurfaceContainerHigh, RoleSurfaceContainerHighest,
+	order := make([]Role, 0, len(canonicalRoleOrder)+len(extraAccents)+len(extraOnAccents))
+	for _, role := range canonicalRoleOrder {
+		order = append(order, role)
+		switch role {
+		case RoleAccent4Muted:
+			order = append(order, extraAccents...)
+		case RoleOnAccent4:
+			order = append(order, extraOnAccents...)
+		}
 	}
+	return order
+}
+
+// orderedRoles is roleOrder, computed once.
+var orderedRoles = roleOrder()
 
+// AllRoles returns all roles in deterministic order (core → accents → semantic → surface → variants).
+func (ph *PaletteHelper) AllRoles() []Role {
 	var result []Role
-	for _, role := range order {
+	for _, role := range orderedRoles {
 		if ph.Has(role) {
 			result = append(result, role)
 		}
@@ -231,6 +311,18 @@ func (ph *PaletteHelper) Count() int {
 	return len(ph.indexed)
 }
 
+// ExtractedCount returns the number of colours extracted from the source,
+// excluding colours generated to fill roles.
+func (ph *PaletteHelper) ExtractedCount() int {
+	n := 0
+	for _, cc := range ph.palette.AllColours {
+		if !cc.IsGenerated {
+			n++
+		}
+	}
+	return n
+}
+
 // ThemeType returns the detected or specified theme type.
 func (ph *PaletteHelper) ThemeType() ThemeType {
 	return ph.palette.ThemeType
@@ -246,19 +338,3 @@ func (ph *PaletteHelper) ThemeTypeString() string {
 func (ph *PaletteHelper) Palette() *CategorisedPalette {
 	return ph.palette
 }
-
-// parseHex parses a hex color string (#RRGGBB or RRGGBB) to RGB.
-// Returns black if parsing fails.
-func parseHex(hex string) RGB {
-	hex = strings.TrimPrefix(hex, "#")
-	if len(hex) != 6 {
-		return RGB{R: 0, G: 0, B: 0}
-	}
-
-	var r, g, b uint8
-	if _, err := fmt.Sscanf(hex, "%02x%02x%02x", &r, &g, &b); err != nil {
-		return RGB{R: 0, G: 0, B: 0}
-	}
-
-	return RGB{R: r, G: g, B: b}
-}
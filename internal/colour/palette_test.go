@@ -2,7 +2,9 @@
 package colour
 
 import (
+	"image"
 	"image/color"
+	"math"
 	"testing"
 )
 
@@ -63,6 +65,130 @@ func TestPaletteLen(t *testing.T) {
 	}
 }
 
+func TestPaletteDominant(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	green := color.RGBA{G: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+
+	tests := []struct {
+		name    string
+		palette *Palette
+		want    color.Color
+	}{
+		{
+			name:    "highest weight",
+			palette: NewPaletteWithWeights([]color.Color{red, green, blue}, []float64{0.2, 0.5, 0.3}),
+			want:    green,
+		},
+		{
+			name:    "tie goes to first",
+			palette: NewPaletteWithWeights([]color.Color{red, green, blue}, []float64{0.2, 0.4, 0.4}),
+			want:    green,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.palette.Dominant()
+			if err != nil {
+				t.Fatalf("Dominant() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Dominant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewPalette(nil).Dominant(); err == nil {
+		t.Error("Dominant() of an empty palette should fail")
+	}
+	if _, err := NewPalette([]color.Color{blue, red}).Dominant(); err == nil {
+		t.Error("Dominant() of an unweighted palette should fail")
+	}
+}
+
+// bandWidths returns the colour and width of each run of identical pixels along
+// the top row of img.
+func bandWidths(img image.Image) (colours []color.Color, widths []int) {
+	b := img.Bounds()
+	for x := b.Min.X; x < b.Max.X; x++ {
+		c := color.RGBAModel.Convert(img.At(x, b.Min.Y))
+		if n := len(colours); n > 0 && colours[n-1] == c {
+			widths[n-1]++
+			continue
+		}
+		colours = append(colours, c)
+		widths = append(widths, 1)
+	}
+	return colours, widths
+}
+
+func TestPaletteToImage(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	green := color.RGBA{G: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+
+	tests := []struct {
+		name       string
+		palette    *Palette
+		width      int
+		wantColors []color.Color
+		wantWidths []int
+	}{
+		{
+			name:       "weighted",
+			palette:    NewPaletteWithWeights([]color.Color{red, green, blue}, []float64{0.5, 0.3, 0.2}),
+			width:      100,
+			wantColors: []color.Color{red, green, blue},
+			wantWidths: []int{50, 30, 20},
+		},
+		{
+			name:       "no weights",
+			palette:    NewPalette([]color.Color{red, green, blue, red}),
+			width:      40,
+			wantColors: []color.Color{red, green, blue, red},
+			wantWidths: []int{10, 10, 10, 10},
+		},
+		{
+			name:       "rounded edges fill the strip",
+			palette:    NewPalette([]color.Color{red, green, blue}),
+			width:      10,
+			wantColors: []color.Color{red, green, blue},
+			wantWidths: []int{3, 4, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := tt.palette.ToImage(tt.width, 5)
+			if b := img.Bounds(); b.Dx() != tt.width || b.Dy() != 5 {
+				t.Fatalf("ToImage() size = %dx%d, want %dx5", b.Dx(), b.Dy(), tt.width)
+			}
+
+			colours, widths := bandWidths(img)
+			if len(colours) != len(tt.wantColors) {
+				t.Fatalf("ToImage() has %d bands, want %d", len(colours), len(tt.wantColors))
+			}
+			for i := range colours {
+				if colours[i] != tt.wantColors[i] || widths[i] != tt.wantWidths[i] {
+					t.Errorf("band %d = %v x %d, want %v x %d", i, colours[i], widths[i], tt.wantColors[i], tt.wantWidths[i])
+				}
+			}
+
+			// Every row matches the first.
+			for x := range tt.width {
+				if img.At(x, 4) != img.At(x, 0) {
+					t.Fatalf("pixel (%d, 4) = %v, want %v", x, img.At(x, 4), img.At(x, 0))
+				}
+			}
+		})
+	}
+
+	if img := NewPalette(nil).ToImage(10, 10); img.At(5, 5) != (color.RGBA{}) {
+		t.Errorf("ToImage() of an empty palette = %v at (5, 5), want transparent", img.At(5, 5))
+	}
+}
+
 func TestToRGB(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -94,6 +220,11 @@ func TestToRGB(t *testing.T) {
 			color: color.RGBA{R: 0, G: 0, B: 0, A: 255},
 			want:  RGB{R: 0, G: 0, B: 0},
 		},
+		{
+			name:  "translucent straight alpha",
+			color: color.NRGBA{R: 200, G: 100, B: 50, A: 128},
+			want:  RGB{R: 200, G: 100, B: 50},
+		},
 	}
 
 	for _, tt := range tests {
@@ -106,6 +237,20 @@ func TestToRGB(t *testing.T) {
 	}
 }
 
+func TestToRGBAKeepsStraightAlpha(t *testing.T) {
+	c := color.NRGBA{R: 200, G: 100, B: 50, A: 128}
+	want := RGBA{R: 200, G: 100, B: 50, A: 128}
+	if got := ToRGBA(c); got != want {
+		t.Errorf("ToRGBA() = %+v, want %+v", got, want)
+	}
+
+	// Premultiplied colours are converted back to straight alpha.
+	premultiplied := color.RGBA{R: 100, G: 50, B: 25, A: 128}
+	if got := ToRGBA(premultiplied); got.A != 128 || got.R < 198 || got.R > 200 {
+		t.Errorf("ToRGBA(premultiplied) = %+v, want about %+v", got, want)
+	}
+}
+
 func TestRGBHex(t *testing.T) {
 	tests := []struct {
 		name string
@@ -154,6 +299,83 @@ func TestRGBHex(t *testing.T) {
 	}
 }
 
+func TestRGBAHexAlphaFirst(t *testing.T) {
+	rgba := RGBA{R: 26, G: 43, B: 60, A: 128}
+
+	if got, want := rgba.HexAlphaFirst(), "#801a2b3c"; got != want {
+		t.Errorf("HexAlphaFirst() = %s, want %s", got, want)
+	}
+	if got, want := NewColorValue(rgba, RoleBackground, 0).HexAlphaFirst(), "#801a2b3c"; got != want {
+		t.Errorf("ColorValue.HexAlphaFirst() = %s, want %s", got, want)
+	}
+}
+
+func TestRGBHexWith(t *testing.T) {
+	tests := []struct {
+		name string
+		rgb  RGB
+		opts HexOptions
+		want string
+	}{
+		{
+			name: "zero options match Hex",
+			rgb:  RGB{R: 26, G: 43, B: 60},
+			want: "#1a2b3c",
+		},
+		{
+			name: "uppercase",
+			rgb:  RGB{R: 26, G: 43, B: 60},
+			opts: HexOptions{Uppercase: true},
+			want: "#1A2B3C",
+		},
+		{
+			name: "shorthand white",
+			rgb:  RGB{R: 255, G: 255, B: 255},
+			opts: HexOptions{Shorthand: true},
+			want: "#fff",
+		},
+		{
+			name: "shorthand collapses repeated nibbles",
+			rgb:  RGB{R: 0xaa, G: 0x33, B: 0x00},
+			opts: HexOptions{Shorthand: true},
+			want: "#a30",
+		},
+		{
+			name: "shorthand collapses each channel independently",
+			rgb:  RGB{R: 0xff, G: 0x00, B: 0x55},
+			opts: HexOptions{Shorthand: true},
+			want: "#f05",
+		},
+		{
+			name: "shorthand not possible stays 6-digit",
+			rgb:  RGB{R: 0xff, G: 0x00, B: 0x56},
+			opts: HexOptions{Shorthand: true},
+			want: "#ff0056",
+		},
+		{
+			name: "shorthand not possible with one channel",
+			rgb:  RGB{R: 0xff, G: 0xff, B: 0xfe},
+			opts: HexOptions{Shorthand: true},
+			want: "#fffffe",
+		},
+		{
+			name: "uppercase shorthand without hash",
+			rgb:  RGB{R: 0xcc, G: 0xdd, B: 0xee},
+			opts: HexOptions{Uppercase: true, Shorthand: true, NoHash: true},
+			want: "CDE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rgb.HexWith(tt.opts)
+			if got != tt.want {
+				t.Errorf("HexWith(%+v) = %s, want %s", tt.opts, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRGBString(t *testing.T) {
 	tests := []struct {
 		name string
@@ -400,3 +622,83 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestColorValueMix(t *testing.T) {
+	black := NewColorValue(RGBA{A: 255}, RoleBackground, 0)
+	white := NewColorValue(RGBA{R: 255, G: 255, B: 255, A: 255}, RoleForeground, 1)
+
+	tests := []struct {
+		t    float64
+		want string
+	}{
+		{-1, "#000000"},
+		{0, "#000000"},
+		{0.5, "#808080"},
+		{1, "#ffffff"},
+		{2, "#ffffff"},
+	}
+	for _, tt := range tests {
+		if got := black.Mix(white, tt.t).Hex(); got != tt.want {
+			t.Errorf("Mix(%g) = %s, want %s", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestColorValueWithLightness(t *testing.T) {
+	blue := NewColorValue(RGBA{R: 51, G: 102, B: 255, A: 128}, RoleAccent1, 3)
+	h, s, l := blue.HSL()
+	if math.Abs(h-225) > 0.5 || math.Abs(s-1) > 0.01 || math.Abs(l-0.6) > 0.01 {
+		t.Fatalf("HSL() = (%g, %g, %g), want (225, 1, 0.6)", h, s, l)
+	}
+
+	tests := []struct {
+		l    float64
+		want string
+	}{
+		{-1, "#000000"},
+		{0, "#000000"},
+		{0.25, "#001f7f"},
+		{1, "#ffffff"},
+	}
+	for _, tt := range tests {
+		got := blue.WithLightness(tt.l)
+		if got.Hex() != tt.want {
+			t.Errorf("WithLightness(%g) = %s, want %s", tt.l, got.Hex(), tt.want)
+		}
+		if got.A() != 255 || got.Role() != "" {
+			t.Errorf("WithLightness(%g) should be opaque with no role, got alpha %d role %q", tt.l, got.A(), got.Role())
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	from := NewColorValue(RGBA{R: 0, G: 0, B: 0, A: 255}, RoleAccent1, 0)
+	to := NewColorValue(RGBA{R: 0, G: 0, B: 240, A: 255}, RoleAccent2, 1)
+
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{0, nil},
+		{1, []string{"#000000"}},
+		{2, []string{"#000000", "#0000f0"}},
+		{4, []string{"#000000", "#000050", "#0000a0", "#0000f0"}},
+	}
+	for _, tt := range tests {
+		stops := Gradient(from, to, tt.n)
+		got := make([]string, 0, len(stops))
+		for _, stop := range stops {
+			got = append(got, stop.Hex())
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("Gradient(n=%d) = %v, want %v", tt.n, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Gradient(n=%d) = %v, want %v", tt.n, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package colour provides palette query helpers for plugin authors.
+package colour
+
+import (
+	"image/color"
+	"math"
+	"slices"
+)
+
+// inHueRange reports whether hue lies within [minHue, maxHue] on the colour wheel.
+// Ranges wrap around 360°, so minHue > maxHue selects across red (e.g. 330 to 30).
+// A span of 360° or more covers the whole wheel.
+func inHueRange(hue, minHue, maxHue float64) bool {
+	if maxHue-minHue >= 360 {
+		return true
+	}
+
+	hue = normaliseHue(hue)
+	minHue = normaliseHue(minHue)
+	maxHue = normaliseHue(maxHue)
+
+	if minHue <= maxHue {
+		return hue >= minHue && hue <= maxHue
+	}
+	return hue >= minHue || hue <= maxHue
+}
+
+// normaliseHue maps any angle into [0, 360).
+func normaliseHue(hue float64) float64 {
+	hue = math.Mod(hue, 360)
+	if hue < 0 {
+		hue += 360
+	}
+	return hue
+}
+
+// ColorsInHueRange returns the colours whose hue lies within [minHue, maxHue] degrees,
+// in palette order. Ranges wrap around 360°, so ColorsInHueRange(330, 30) selects reds.
+// Achromatic colours (greys, black, white) have no hue and are never included.
+func (p *Palette) ColorsInHueRange(minHue, maxHue float64) []color.Color {
+	result := make([]color.Color, 0)
+	for _, c := range p.Colors {
+		h, s, _ := rgbToHSL(ToRGB(c))
+		if s > 0 && inHueRange(h, minHue, maxHue) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// VividColors returns the colours with HSL saturation of at least minSat (0-1), in palette order.
+func (p *Palette) VividColors(minSat float64) []color.Color {
+	result := make([]color.Color, 0)
+	for _, c := range p.Colors {
+		_, s, _ := rgbToHSL(ToRGB(c))
+		if s >= minSat {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// ColorsInHueRange returns the colours in AllColours whose hue lies within
+// [minHue, maxHue] degrees, preserving AllColours order. Ranges wrap around 360°.
+// Achromatic colours are never included. Hue and saturation are computed from RGB,
+// so generated colours are filtered the same way as extracted ones.
+func (cp *CategorisedPalette) ColorsInHueRange(minHue, maxHue float64) []CategorisedColour {
+	result := make([]CategorisedColour, 0)
+	for _, cc := range cp.AllColours {
+		h, s, _ := rgbToHSL(cc.RGB)
+		if s > 0 && inHueRange(h, minHue, maxHue) {
+			result = append(result, cc)
+		}
+	}
+	return result
+}
+
+// VividColors returns the colours in AllColours with saturation of at least minSat (0-1),
+// preserving AllColours order.
+func (cp *CategorisedPalette) VividColors(minSat float64) []CategorisedColour {
+	result := make([]CategorisedColour, 0)
+	for _, cc := range cp.AllColours {
+		_, s, _ := rgbToHSL(cc.RGB)
+		if s >= minSat {
+			result = append(result, cc)
+		}
+	}
+	return result
+}
+
+// OrderedRoles returns every role colour in the palette in a fixed order: the
+// canonical AllRoles order (including extra accent slots), then any other roles
+// (such as position roles) sorted by name. Each entry's Role is set from its key. Use it instead of ranging over Colours when output must be byte-stable.
+func (cp *CategorisedPalette) OrderedRoles() []CategorisedColour {
+	result := make([]CategorisedColour, 0, len(cp.Colours))
+	seen := make(map[Role]bool, len(orderedRoles))
+	for _, role := range orderedRoles {
+		seen[role] = true
+		if cc, ok := cp.Colours[role]; ok {
+			cc.Role = role
+			result = append(result, cc)
+		}
+	}
+
+	others := make([]Role, 0)
+	for role := range cp.Colours {
+		if !seen[role] {
+			others = append(others, role)
+		}
+	}
+	slices.Sort(others)
+	for _, role := range others {
+		cc := cp.Colours[role]
+		cc.Role = role
+		result = append(result, cc)
+	}
+	return result
+}
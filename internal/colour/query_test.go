@@ -0,0 +1,158 @@
+package colour
+
+import (
+	"image/color"
+	"testing"
+)
+
+// queryTestPalette has one colour per hue family plus a grey.
+func queryTestPalette() *Palette {
+	return NewPalette([]color.Color{
+		color.RGBA{R: 230, G: 30, B: 40, A: 255},   // 0: red (hue ~357)
+		color.RGBA{R: 240, G: 140, B: 20, A: 255},  // 1: orange (hue ~33)
+		color.RGBA{R: 60, G: 180, B: 75, A: 255},   // 2: green (hue ~128)
+		color.RGBA{R: 40, G: 90, B: 220, A: 255},   // 3: blue (hue ~223)
+		color.RGBA{R: 128, G: 128, B: 128, A: 255}, // 4: grey (achromatic)
+		color.RGBA{R: 150, G: 120, B: 130, A: 255}, // 5: dusty pink (low saturation)
+	})
+}
+
+func hexesOf(colours []color.Color) []string {
+	result := make([]string, len(colours))
+	for i, c := range colours {
+		result[i] = ToRGB(c).Hex()
+	}
+	return result
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPaletteColorsInHueRange(t *testing.T) {
+	palette := queryTestPalette()
+	hex := func(i int) string { return ToRGB(palette.Colors[i]).Hex() }
+
+	tests := []struct {
+		name     string
+		min, max float64
+		want     []string
+	}{
+		{"greens", 90, 150, []string{hex(2)}},
+		{"blues", 200, 260, []string{hex(3)}},
+		{"warm wraps around red", 330, 60, []string{hex(0), hex(1), hex(5)}},
+		{"full wheel excludes grey", 0, 360, []string{hex(0), hex(1), hex(2), hex(3), hex(5)}},
+		{"empty range", 270, 300, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hexesOf(palette.ColorsInHueRange(tt.min, tt.max))
+			if !equalStrings(got, tt.want) {
+				t.Errorf("ColorsInHueRange(%v, %v) = %v, want %v", tt.min, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaletteVividColors(t *testing.T) {
+	palette := queryTestPalette()
+
+	got := hexesOf(palette.VividColors(0.5))
+	want := []string{
+		ToRGB(palette.Colors[0]).Hex(),
+		ToRGB(palette.Colors[1]).Hex(),
+		ToRGB(palette.Colors[2]).Hex(),
+		ToRGB(palette.Colors[3]).Hex(),
+	}
+	if !equalStrings(got, want) {
+		t.Errorf("VividColors(0.5) = %v, want %v", got, want)
+	}
+
+	if n := len(palette.VividColors(0)); n != len(palette.Colors) {
+		t.Errorf("VividColors(0) should return every colour, got %d", n)
+	}
+}
+
+func TestCategorisedPaletteQueries(t *testing.T) {
+	config := DefaultCategorisationConfig()
+	config.ThemeType = ThemeDark
+	categorised := Categorise(queryTestPalette(), config)
+
+	blues := categorised.ColorsInHueRange(200, 260)
+	if len(blues) == 0 {
+		t.Fatal("expected at least one blue colour")
+	}
+	for _, cc := range blues {
+		h, s, _ := rgbToHSL(cc.RGB)
+		if s == 0 || h < 200 || h > 260 {
+			t.Errorf("%s (hue %.1f) outside blue range", cc.Hex, h)
+		}
+	}
+
+	vivid := categorised.VividColors(0.6)
+	for _, cc := range vivid {
+		if _, s, _ := rgbToHSL(cc.RGB); s < 0.6 {
+			t.Errorf("%s saturation %.2f below 0.6", cc.Hex, s)
+		}
+	}
+
+	// Results preserve AllColours order.
+	lastIndex := -1
+	for _, cc := range vivid {
+		if cc.Index <= lastIndex {
+			t.Errorf("VividColors out of AllColours order at %s", cc.Hex)
+		}
+		lastIndex = cc.Index
+	}
+}
+
+func rolesOf(colours []CategorisedColour) []string {
+	result := make([]string, len(colours))
+	for i, cc := range colours {
+		result[i] = string(cc.Role)
+	}
+	return result
+}
+
+func TestOrderedRoles(t *testing.T) {
+	config := DefaultCategorisationConfig()
+	config.ThemeType = ThemeDark
+	categorised := Categorise(queryTestPalette(), config)
+	categorised.Colours[RolePositionTopRight] = CategorisedColour{Hex: "#112233"}
+	categorised.Colours[RolePositionBottom] = CategorisedColour{Hex: "#445566"}
+
+	themeData := NewThemeData(categorised, "", "")
+	first := rolesOf(themeData.OrderedRoles())
+	if len(first) != len(categorised.Colours) {
+		t.Fatalf("OrderedRoles() returned %d roles, want %d", len(first), len(categorised.Colours))
+	}
+
+	// Canonical roles come first, in AllRoles order.
+	for i, role := range themeData.AllRoles() {
+		if first[i] != string(role) {
+			t.Fatalf("OrderedRoles()[%d] = %s, want %s", i, first[i], role)
+		}
+	}
+
+	// Other roles follow, sorted by name, with Role filled in from the key.
+	extra := first[len(themeData.AllRoles()):]
+	want := []string{string(RolePositionBottom), string(RolePositionTopRight)}
+	if !equalStrings(extra, want) {
+		t.Errorf("extra roles = %v, want %v", extra, want)
+	}
+
+	for range 20 {
+		if again := rolesOf(themeData.OrderedRoles()); !equalStrings(again, first) {
+			t.Fatalf("OrderedRoles() order changed: %v vs %v", again, first)
+		}
+	}
+}
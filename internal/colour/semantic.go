@@ -2,6 +2,7 @@
 package colour
 
 import (
+	"fmt"
 	"math"
 )
 
@@ -22,6 +23,70 @@ var SemanticHues = map[Role]float64{
 	RoleNotification: 285, // Purple - badges, notifications, highlights
 }
 
+// SemanticPalette selects the set of hue anchors used for semantic roles.
+type SemanticPalette string
+
+const (
+	// SemanticPaletteStandard uses conventional red/orange/green/blue/purple semantics.
+	SemanticPaletteStandard SemanticPalette = "standard"
+	// SemanticPaletteCVDSafe avoids red/green pairs that are confusable under the most
+	// common colour vision deficiencies (deuteranopia/protanopia).
+	SemanticPaletteCVDSafe SemanticPalette = "cvd-safe"
+)
+
+// CVDSafeSemanticHues defines colour-blindness friendly hue anchors for semantic roles.
+// Based on the Okabe-Ito palette: danger and success use orange/blue, which remain
+// distinct under red-green colour vision deficiencies.
+var CVDSafeSemanticHues = map[Role]float64{
+	RoleDanger:       25,  // Vermillion - danger, errors
+	RoleWarning:      55,  // Yellow - warnings, caution
+	RoleSuccess:      210, // Blue - success, confirmation
+	RoleInfo:         170, // Bluish green - information
+	RoleNotification: 325, // Reddish purple - badges, notifications
+}
+
+// cvdSafeLightnessOffsets separates danger and success by luminance as well as hue.
+// Offsets are relative to the theme's target lightness. Orange is inherently brighter
+// than blue, so lightening danger and darkening success widens the gap in both themes.
+var cvdSafeLightnessOffsets = map[Role]float64{
+	RoleDanger:  0.08,
+	RoleSuccess: -0.08,
+}
+
+// cvdSafeHueTolerance is how far (in degrees) an extracted colour may be from a
+// cvd-safe anchor and still be used for that role.
+const cvdSafeHueTolerance = 15.0
+
+// semanticRoles lists semantic roles in assignment order.
+var semanticRoles = []Role{RoleDanger, RoleWarning, RoleSuccess, RoleInfo, RoleNotification}
+
+// ValidSemanticPalettes returns all supported semantic palettes.
+func ValidSemanticPalettes() []SemanticPalette {
+	return []SemanticPalette{SemanticPaletteStandard, SemanticPaletteCVDSafe}
+}
+
+// ParseSemanticPalette parses a semantic palette name.
+// An empty string is treated as SemanticPaletteStandard.
+func ParseSemanticPalette(s string) (SemanticPalette, error) {
+	if s == "" {
+		return SemanticPaletteStandard, nil
+	}
+	for _, p := range ValidSemanticPalettes() {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("invalid semantic palette %q (valid: standard, cvd-safe)", s)
+}
+
+// Hues returns the semantic hue anchors for the palette.
+func (sp SemanticPalette) Hues() map[Role]float64 {
+	if sp == SemanticPaletteCVDSafe {
+		return CVDSafeSemanticHues
+	}
+	return SemanticHues
+}
+
 // assignSemanticRolesWithHints assigns semantic roles (danger, warning, success, etc.) based on hue.
 // Skips roles that were explicitly provided via role hints.
 //
@@ -34,7 +99,12 @@ var SemanticHues = map[Role]float64{
 // - Purple = notification (badges, highlights)
 // - Must have good contrast with background for visibility.
 // - Enhanced saturation for visual distinctiveness.
-func assignSemanticRolesWithHints(palette *CategorisedPalette, accents []CategorisedColour, usedForSemantic map[string]bool, hintsApplied map[Role]bool) {
+func assignSemanticRolesWithHints(palette *CategorisedPalette, accents []CategorisedColour, usedForSemantic map[string]bool, hintsApplied map[Role]bool, semanticPalette SemanticPalette, boost float64) {
+	if semanticPalette == SemanticPaletteCVDSafe {
+		assignCVDSafeSemanticRoles(palette, accents, usedForSemantic, hintsApplied, boost)
+		return
+	}
+
 	// Map hue ranges to semantic roles.
 	// Red: 0-30, 330-360 (danger).
 	// Orange/Yellow: 30-60 (warning).
@@ -89,69 +159,154 @@ func assignSemanticRolesWithHints(palette *CategorisedPalette, accents []Categor
 	// Set semantic roles with enhancement (skip if role was explicitly hinted).
 	if !hintsApplied[RoleDanger] {
 		if danger != nil {
-			enhanced := enhanceSemanticColour(*danger, RoleDanger, themeType, hasBg, bg)
+			enhanced := enhanceSemanticColour(*danger, RoleDanger, themeType, hasBg, bg, boost)
 			palette.Set(RoleDanger, enhanced)
+			explainSemantic(palette, RoleDanger, danger)
 			usedForSemantic[danger.Hex] = true
 		} else {
 			// Generate fallback danger color if none found.
-			fallback := generateFallbackSemanticColour(RoleDanger, themeType, hasBg, bg)
+			fallback := generateFallbackSemanticColour(RoleDanger, themeType, hasBg, bg, boost)
 			palette.Set(RoleDanger, fallback)
+			explainSemantic(palette, RoleDanger, nil)
 		}
 	}
 
 	if !hintsApplied[RoleWarning] {
 		if warning != nil {
-			enhanced := enhanceSemanticColour(*warning, RoleWarning, themeType, hasBg, bg)
+			enhanced := enhanceSemanticColour(*warning, RoleWarning, themeType, hasBg, bg, boost)
 			palette.Set(RoleWarning, enhanced)
+			explainSemantic(palette, RoleWarning, warning)
 			usedForSemantic[warning.Hex] = true
 		} else {
-			fallback := generateFallbackSemanticColour(RoleWarning, themeType, hasBg, bg)
+			fallback := generateFallbackSemanticColour(RoleWarning, themeType, hasBg, bg, boost)
 			palette.Set(RoleWarning, fallback)
+			explainSemantic(palette, RoleWarning, nil)
 		}
 	}
 
 	if !hintsApplied[RoleSuccess] {
 		if success != nil {
-			enhanced := enhanceSemanticColour(*success, RoleSuccess, themeType, hasBg, bg)
+			enhanced := enhanceSemanticColour(*success, RoleSuccess, themeType, hasBg, bg, boost)
 			palette.Set(RoleSuccess, enhanced)
+			explainSemantic(palette, RoleSuccess, success)
 			usedForSemantic[success.Hex] = true
 		} else {
-			fallback := generateFallbackSemanticColour(RoleSuccess, themeType, hasBg, bg)
+			fallback := generateFallbackSemanticColour(RoleSuccess, themeType, hasBg, bg, boost)
 			palette.Set(RoleSuccess, fallback)
+			explainSemantic(palette, RoleSuccess, nil)
 		}
 	}
 
 	if !hintsApplied[RoleInfo] {
 		if info != nil {
-			enhanced := enhanceSemanticColour(*info, RoleInfo, themeType, hasBg, bg)
+			enhanced := enhanceSemanticColour(*info, RoleInfo, themeType, hasBg, bg, boost)
 			palette.Set(RoleInfo, enhanced)
+			explainSemantic(palette, RoleInfo, info)
 			usedForSemantic[info.Hex] = true
 		} else {
-			fallback := generateFallbackSemanticColour(RoleInfo, themeType, hasBg, bg)
+			fallback := generateFallbackSemanticColour(RoleInfo, themeType, hasBg, bg, boost)
 			palette.Set(RoleInfo, fallback)
+			explainSemantic(palette, RoleInfo, nil)
 		}
 	}
 
 	if !hintsApplied[RoleNotification] {
 		if notification != nil {
-			enhanced := enhanceSemanticColour(*notification, RoleNotification, themeType, hasBg, bg)
+			enhanced := enhanceSemanticColour(*notification, RoleNotification, themeType, hasBg, bg, boost)
 			palette.Set(RoleNotification, enhanced)
+			explainSemantic(palette, RoleNotification, notification)
 			usedForSemantic[notification.Hex] = true
 		} else {
-			fallback := generateFallbackSemanticColour(RoleNotification, themeType, hasBg, bg)
+			fallback := generateFallbackSemanticColour(RoleNotification, themeType, hasBg, bg, boost)
 			palette.Set(RoleNotification, fallback)
+			explainSemantic(palette, RoleNotification, nil)
+		}
+	}
+}
+
+// semanticHueNames names the hue family matched for each standard semantic role.
+var semanticHueNames = map[Role]string{
+	RoleDanger:       "red",
+	RoleWarning:      "orange/yellow",
+	RoleSuccess:      "green",
+	RoleInfo:         "blue",
+	RoleNotification: "purple",
+}
+
+// explainSemantic records why a standard semantic role got its colour.
+// match is the extracted colour used, or nil if a fallback was generated.
+func explainSemantic(palette *CategorisedPalette, role Role, match *CategorisedColour) {
+	if match == nil {
+		palette.explain(role, "generated: no saturated %s colour was extracted", semanticHueNames[role])
+		return
+	}
+	source := "extracted colour"
+	if match.IsGenerated {
+		source = "generated accent"
+	}
+	palette.explain(role, "most saturated %s %s (hue %.0f°, saturation %.2f), enhanced for visibility",
+		semanticHueNames[role], source, match.Hue, match.Saturation)
+}
+
+// assignCVDSafeSemanticRoles assigns semantic roles using the colour-blindness friendly
+// hue anchors. Extracted colours close to an anchor keep their hue; otherwise the anchor is
+// used. Danger and success are also separated in lightness so they stay distinguishable
+// when hue discrimination is reduced. boost (SemanticBoostAmount, set by --vibrancy)
+// scales saturation as it does for the standard set; hue and lightness stay fixed.
+func assignCVDSafeSemanticRoles(palette *CategorisedPalette, accents []CategorisedColour, usedForSemantic map[string]bool, hintsApplied map[Role]bool, boost float64) {
+	bg, hasBg := palette.Get(RoleBackground)
+	themeType := palette.ThemeType
+
+	baseLightness := 0.45
+	if themeType == ThemeDark {
+		baseLightness = 0.60
+	}
+
+	for _, role := range semanticRoles {
+		if hintsApplied[role] {
+			continue
+		}
+
+		anchor := CVDSafeSemanticHues[role]
+		hue := anchor
+		saturation := 0.75
+
+		var match *CategorisedColour
+		for i := range accents {
+			cc := &accents[i]
+			if cc.Saturation < 0.3 || usedForSemantic[cc.Hex] || HueDistance(cc.Hue, anchor) > cvdSafeHueTolerance {
+				continue
+			}
+			if match == nil || cc.Saturation > match.Saturation {
+				match = cc
+			}
+		}
+		if match != nil {
+			hue = match.Hue
+			saturation = math.Max(match.Saturation, MinSemanticSaturation)
+			usedForSemantic[match.Hex] = true
+		}
+
+		lightness := baseLightness + cvdSafeLightnessOffsets[role]
+		saturation = boostSemanticSaturation(saturation, boost)
+		palette.Set(role, generateSemanticColour(role, hue, saturation, lightness, themeType, hasBg, bg))
+		if match != nil {
+			palette.explain(role, "cvd-safe: extracted hue %.0f° within %.0f° of the %.0f° anchor, lightness separated", match.Hue, cvdSafeHueTolerance, anchor)
+		} else {
+			palette.explain(role, "generated: cvd-safe anchor hue %.0f°, no saturated extracted colour nearby", anchor)
 		}
 	}
 }
 
 // enhanceSemanticColour boosts saturation and adjusts lightness for better visibility.
-func enhanceSemanticColour(cc CategorisedColour, role Role, themeType ThemeType, hasBg bool, bg CategorisedColour) CategorisedColour {
+func enhanceSemanticColour(cc CategorisedColour, role Role, themeType ThemeType, hasBg bool, bg CategorisedColour, boost float64) CategorisedColour {
 	h, s, l := rgbToHSL(cc.RGB)
 
-	// Boost saturation to minimum threshold.
+	// Boost saturation to minimum threshold, then apply the configured boost.
 	if s < MinSemanticSaturation {
 		s = MinSemanticSaturation
 	}
+	s = boostSemanticSaturation(s, boost)
 
 	// Adjust lightness based on theme.
 	var targetLightness float64
@@ -207,23 +362,27 @@ func enhanceSemanticColour(cc CategorisedColour, role Role, themeType ThemeType,
 }
 
 // generateFallbackSemanticColour creates a semantic color when none exists in the palette.
-func generateFallbackSemanticColour(role Role, themeType ThemeType, hasBg bool, bg CategorisedColour) CategorisedColour {
+func generateFallbackSemanticColour(role Role, themeType ThemeType, hasBg bool, bg CategorisedColour, boost float64) CategorisedColour {
 	// Get standard hue for this role.
 	hue, exists := SemanticHues[role]
 	if !exists {
 		hue = 0 // Fallback to red
 	}
 
-	// Set saturation and lightness based on theme.
-	saturation := 0.75 // Vibrant
+	// Set lightness based on theme.
 	var lightness float64
-
 	if themeType == ThemeDark {
 		lightness = 0.60 // Lighter for dark backgrounds
 	} else {
 		lightness = 0.45 // Darker for light backgrounds
 	}
 
+	return generateSemanticColour(role, hue, boostSemanticSaturation(0.75, boost), lightness, themeType, hasBg, bg)
+}
+
+// generateSemanticColour creates a semantic colour from HSL values, adjusting lightness
+// to keep at least 3:1 contrast with the background when one is available.
+func generateSemanticColour(role Role, hue, saturation, lightness float64, themeType ThemeType, hasBg bool, bg CategorisedColour) CategorisedColour {
 	// Ensure good contrast with background if available.
 	var newRGB RGB
 	if hasBg {
@@ -0,0 +1,163 @@
+package colour
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+// rgbDistance returns the Euclidean distance between two colours in sRGB space.
+func rgbDistance(a, b RGB) float64 {
+	dr := float64(a.R) - float64(b.R)
+	dg := float64(a.G) - float64(b.G)
+	db := float64(a.B) - float64(b.B)
+	return math.Sqrt(dr*dr + dg*dg + db*db)
+}
+
+func TestParseSemanticPalette(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    SemanticPalette
+		wantErr bool
+	}{
+		{"", SemanticPaletteStandard, false},
+		{"standard", SemanticPaletteStandard, false},
+		{"cvd-safe", SemanticPaletteCVDSafe, false},
+		{"protan", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSemanticPalette(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSemanticPalette(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseSemanticPalette(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCVDSafeSemanticHues(t *testing.T) {
+	// Palette containing saturated red and green that the standard set would pick.
+	palette := &Palette{
+		Colors: []color.Color{
+			color.RGBA{R: 20, G: 20, B: 28, A: 255},
+			color.RGBA{R: 220, G: 220, B: 230, A: 255},
+			color.RGBA{R: 220, G: 40, B: 40, A: 255},
+			color.RGBA{R: 40, G: 200, B: 60, A: 255},
+		},
+	}
+
+	for _, themeType := range []ThemeType{ThemeDark, ThemeLight} {
+		t.Run(themeType.String(), func(t *testing.T) {
+			config := DefaultCategorisationConfig()
+			config.ThemeType = themeType
+			config.SemanticPalette = SemanticPaletteCVDSafe
+
+			categorised := Categorise(palette, config)
+
+			for _, role := range semanticRoles {
+				cc, ok := categorised.Get(role)
+				if !ok {
+					t.Fatalf("missing semantic role %s", role)
+				}
+				anchor := CVDSafeSemanticHues[role]
+				if d := HueDistance(cc.Hue, anchor); d > cvdSafeHueTolerance {
+					t.Errorf("%s hue %.1f is %.1f° from cvd-safe anchor %.0f", role, cc.Hue, d, anchor)
+				}
+			}
+
+			danger, _ := categorised.Get(RoleDanger)
+			success, _ := categorised.Get(RoleSuccess)
+
+			// Danger must not be red and success must not be green.
+			if HueDistance(danger.Hue, SemanticHues[RoleDanger]) < cvdSafeHueTolerance {
+				t.Errorf("cvd-safe danger hue %.1f should not be red", danger.Hue)
+			}
+			if HueDistance(success.Hue, SemanticHues[RoleSuccess]) < 45 {
+				t.Errorf("cvd-safe success hue %.1f should not be green", success.Hue)
+			}
+
+			// Danger and success should differ in luminance as well as hue.
+			if math.Abs(danger.Luminance-success.Luminance) < 0.05 {
+				t.Errorf("danger (%.3f) and success (%.3f) luminance too similar", danger.Luminance, success.Luminance)
+			}
+		})
+	}
+}
+
+func TestCVDSafeDistinguishableUnderDeuteranopia(t *testing.T) {
+	palette := &Palette{
+		Colors: []color.Color{
+			color.RGBA{R: 20, G: 20, B: 28, A: 255},
+			color.RGBA{R: 220, G: 220, B: 230, A: 255},
+		},
+	}
+
+	simulatedDistance := func(sp SemanticPalette) float64 {
+		config := DefaultCategorisationConfig()
+		config.ThemeType = ThemeDark
+		config.SemanticPalette = sp
+
+		categorised := Categorise(palette, config)
+		danger, _ := categorised.Get(RoleDanger)
+		success, _ := categorised.Get(RoleSuccess)
+		simDanger, err := SimulateCVD(danger.RGB, CVDDeuteranopia)
+		if err != nil {
+			t.Fatal(err)
+		}
+		simSuccess, err := SimulateCVD(success.RGB, CVDDeuteranopia)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rgbDistance(simDanger, simSuccess)
+	}
+
+	standard := simulatedDistance(SemanticPaletteStandard)
+	cvdSafe := simulatedDistance(SemanticPaletteCVDSafe)
+
+	if cvdSafe <= standard {
+		t.Errorf("cvd-safe danger/success should be more distinct under deuteranopia: standard=%.1f cvd-safe=%.1f", standard, cvdSafe)
+	}
+
+	// Require a clearly visible difference (roughly a third of the RGB cube diagonal).
+	const minDistance = 150.0
+	if cvdSafe < minDistance {
+		t.Errorf("cvd-safe danger/success simulated distance %.1f below %.1f", cvdSafe, minDistance)
+	}
+}
+
+func TestCVDSafeSemanticVibrancy(t *testing.T) {
+	palette := &Palette{
+		Colors: []color.Color{
+			color.RGBA{R: 20, G: 20, B: 28, A: 255},
+			color.RGBA{R: 220, G: 220, B: 230, A: 255},
+		},
+	}
+
+	dangerSaturation := func(vibrancy float64) float64 {
+		t.Helper()
+		config := DefaultCategorisationConfig()
+		config.ThemeType = ThemeDark
+		config.SemanticPalette = SemanticPaletteCVDSafe
+		if err := config.ApplyVibrancy(vibrancy); err != nil {
+			t.Fatal(err)
+		}
+		danger, _ := Categorise(palette, config).Get(RoleDanger)
+		return danger.Saturation
+	}
+
+	low, base, high := dangerSaturation(25), dangerSaturation(DefaultVibrancy), dangerSaturation(75)
+	if !(low < base && base < high) {
+		t.Errorf("cvd-safe danger saturation should rise with vibrancy: 25=%.3f 50=%.3f 75=%.3f", low, base, high)
+	}
+}
+
+func TestSemanticPaletteHues(t *testing.T) {
+	if SemanticPaletteStandard.Hues()[RoleSuccess] != SemanticHues[RoleSuccess] {
+		t.Error("standard palette should use SemanticHues")
+	}
+	if SemanticPaletteCVDSafe.Hues()[RoleSuccess] != CVDSafeSemanticHues[RoleSuccess] {
+		t.Error("cvd-safe palette should use CVDSafeSemanticHues")
+	}
+}
@@ -5,22 +5,22 @@ import "image/color"
 
 // generateSurfaceColors generates all surface, border, on-color, and container variants.
 // These colors are essential for UI design following Material Design 3 principles.
-func generateSurfaceColors(palette *CategorisedPalette, bg, fg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool) {
+func generateSurfaceColors(palette *CategorisedPalette, bg, fg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool, config CategorisationConfig) {
 	// Priority 1: Core surface colors.
-	generatePriority1SurfaceColors(palette, bg, fg, theme, hintsApplied)
+	generatePriority1SurfaceColors(palette, bg, fg, theme, hintsApplied, config)
 
 	// Priority 2: Surface variants, border variants, and on-colors.
-	generatePriority2Colors(palette, bg, fg, theme, hintsApplied)
+	generatePriority2Colors(palette, bg, fg, theme, hintsApplied, config)
 
 	// Priority 3: Inverse colors, scrim/shadow, container variants.
-	generatePriority3Colors(palette, bg, fg, theme, hintsApplied)
+	generatePriority3Colors(palette, bg, fg, theme, hintsApplied, config)
 }
 
 // generatePriority1SurfaceColors generates essential surface colors.
-func generatePriority1SurfaceColors(palette *CategorisedPalette, bg, fg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool) {
+func generatePriority1SurfaceColors(palette *CategorisedPalette, bg, fg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool, config CategorisationConfig) {
 	// Generate Surface (if not provided via hints).
 	if !hintsApplied[RoleSurface] {
-		surface := generateSurface(bg, theme)
+		surface := generateSurface(bg, theme, config.UseOKLCH)
 		palette.Set(RoleSurface, surface)
 	}
 
@@ -32,7 +32,7 @@ func generatePriority1SurfaceColors(palette *CategorisedPalette, bg, fg Categori
 		} else {
 			surface = bg
 		}
-		onSurface := generateOnSurface(surface, fg, theme)
+		onSurface := generateOnSurface(surface, fg, theme, config.ContrastModel)
 		palette.Set(RoleOnSurface, onSurface)
 	}
 
@@ -44,7 +44,7 @@ func generatePriority1SurfaceColors(palette *CategorisedPalette, bg, fg Categori
 		} else {
 			surface = bg
 		}
-		outline := generateOutline(surface, theme)
+		outline := generateOutline(surface, theme, config.UseOKLCH)
 		palette.Set(RoleOutline, outline)
 	}
 
@@ -56,14 +56,14 @@ func generatePriority1SurfaceColors(palette *CategorisedPalette, bg, fg Categori
 		} else {
 			surface = bg
 		}
-		border := generateBorder(surface, theme)
+		border := generateBorder(surface, theme, config.UseOKLCH)
 		palette.Set(RoleBorder, border)
 	}
 }
 
 // generateSurface creates a surface color slightly different from background.
 // Material Design 3 uses tonal elevation - surfaces are slightly lighter/darker than background.
-func generateSurface(bg CategorisedColour, theme ThemeType) CategorisedColour {
+func generateSurface(bg CategorisedColour, theme ThemeType, useOKLCH bool) CategorisedColour {
 	rgb := bg.RGB
 	h, s, l := rgbToHSL(rgb)
 
@@ -92,7 +92,7 @@ func generateSurface(bg CategorisedColour, theme ThemeType) CategorisedColour {
 		newS = 0.05
 	}
 
-	newRGB := HSLToRGB(h, newS, newL)
+	newRGB := adjustHSL(rgb, h, s, newS, newL, useOKLCH)
 	newColor := RGBToColor(newRGB)
 
 	return CategorisedColour{
@@ -112,14 +112,15 @@ func generateSurface(bg CategorisedColour, theme ThemeType) CategorisedColour {
 
 // generateOnSurface creates a high-contrast text color for surface.
 // Typically same as foreground, but can be adjusted if surface differs significantly.
-func generateOnSurface(surface, fg CategorisedColour, theme ThemeType) CategorisedColour {
+// Contrast is measured by model: WCAG 4.5:1 / 7:1 or APCA Lc 60 / 75.
+func generateOnSurface(surface, fg CategorisedColour, theme ThemeType, model ContrastModel) CategorisedColour {
 	// Check if foreground has adequate contrast with surface.
 	fgColor := fg.Colour
 	surfaceColor := surface.Colour
-	contrast := ContrastRatio(fgColor, surfaceColor)
+	contrast := model.Contrast(fgColor, surfaceColor)
 
 	// If foreground works well on surface, use it.
-	if contrast >= 4.5 {
+	if contrast >= model.threshold(false) {
 		return CategorisedColour{
 			Colour:      fg.Colour,
 			Role:        RoleOnSurface,
@@ -139,10 +140,10 @@ func generateOnSurface(surface, fg CategorisedColour, theme ThemeType) Categoris
 	rgb := fg.RGB
 	h, s, l := rgbToHSL(rgb)
 
-	// Adjust luminance to ensure 7:1 contrast (AAA).
-	minContrast := 7.0
+	// Adjust luminance to ensure enhanced (AAA) contrast.
+	minContrast := model.threshold(true)
 	var newRGB RGB
-	_, newRGB = adjustLuminanceForContrast(h, s, l, surfaceColor, minContrast, theme, 20)
+	_, newRGB = adjustLuminanceForContrastModel(h, s, l, surfaceColor, model, minContrast, theme, 20)
 
 	newColor := RGBToColor(newRGB)
 	newL := Luminance(newColor)
@@ -164,7 +165,7 @@ func generateOnSurface(surface, fg CategorisedColour, theme ThemeType) Categoris
 
 // generateOutline creates a desaturated border color with moderate contrast.
 // Used for dividers, borders, and outlines.
-func generateOutline(surface CategorisedColour, theme ThemeType) CategorisedColour {
+func generateOutline(surface CategorisedColour, theme ThemeType, useOKLCH bool) CategorisedColour {
 	rgb := surface.RGB
 	h, s, l := rgbToHSL(rgb)
 
@@ -188,7 +189,7 @@ func generateOutline(surface CategorisedColour, theme ThemeType) CategorisedColo
 		newS = 0.02
 	}
 
-	newRGB := HSLToRGB(h, newS, newL)
+	newRGB := adjustHSL(rgb, h, s, newS, newL, useOKLCH)
 	newColor := RGBToColor(newRGB)
 
 	return CategorisedColour{
@@ -208,7 +209,7 @@ func generateOutline(surface CategorisedColour, theme ThemeType) CategorisedColo
 
 // generateBorder creates a border color similar to outline but slightly more prominent.
 // Used for primary borders, focus indicators.
-func generateBorder(surface CategorisedColour, theme ThemeType) CategorisedColour {
+func generateBorder(surface CategorisedColour, theme ThemeType, useOKLCH bool) CategorisedColour {
 	rgb := surface.RGB
 	h, s, l := rgbToHSL(rgb)
 
@@ -232,7 +233,7 @@ func generateBorder(surface CategorisedColour, theme ThemeType) CategorisedColou
 		newS = 0.03
 	}
 
-	newRGB := HSLToRGB(h, newS, newL)
+	newRGB := adjustHSL(rgb, h, s, newS, newL, useOKLCH)
 	newColor := RGBToColor(newRGB)
 
 	return CategorisedColour{
@@ -251,7 +252,7 @@ func generateBorder(surface CategorisedColour, theme ThemeType) CategorisedColou
 }
 
 // generatePriority2Colors generates surface/border variants and on-colors.
-func generatePriority2Colors(palette *CategorisedPalette, bg, fg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool) {
+func generatePriority2Colors(palette *CategorisedPalette, bg, fg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool, config CategorisationConfig) {
 	surface, hasSurface := palette.Get(RoleSurface)
 	if !hasSurface {
 		surface = bg
@@ -269,29 +270,29 @@ func generatePriority2Colors(palette *CategorisedPalette, bg, fg CategorisedColo
 		if !hasVariant {
 			surfaceVariant = surface
 		}
-		onSurfaceVariant := generateOnSurface(surfaceVariant, fg, theme)
+		onSurfaceVariant := generateOnSurface(surfaceVariant, fg, theme, config.ContrastModel)
 		onSurfaceVariant.Role = RoleOnSurfaceVariant
 		palette.Set(RoleOnSurfaceVariant, onSurfaceVariant)
 	}
 
 	// Border muted.
 	if !hintsApplied[RoleBorderMuted] {
-		borderMuted := generateBorderMuted(surface, theme)
+		borderMuted := generateBorderMuted(surface, theme, config.UseOKLCH)
 		palette.Set(RoleBorderMuted, borderMuted)
 	}
 
 	// Outline variant.
 	if !hintsApplied[RoleOutlineVariant] {
-		outlineVariant := generateOutlineVariant(surface, theme)
+		outlineVariant := generateOutlineVariant(surface, theme, config.UseOKLCH)
 		palette.Set(RoleOutlineVariant, outlineVariant)
 	}
 
 	// Generate on-colors for accents.
-	generateOnColors(palette, theme, hintsApplied)
+	generateOnColors(palette, hintsApplied, max(config.AccentCount, DefaultAccentCount), config.ContrastModel)
 }
 
 // generatePriority3Colors generates inverse colors, scrim/shadow, and container variants.
-func generatePriority3Colors(palette *CategorisedPalette, bg, fg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool) {
+func generatePriority3Colors(palette *CategorisedPalette, bg, fg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool, config CategorisationConfig) {
 	// Inverse colors.
 	if !hintsApplied[RoleInverseSurface] {
 		inverseSurface := generateInverseSurface(bg, theme)
@@ -332,7 +333,7 @@ func generatePriority3Colors(palette *CategorisedPalette, bg, fg CategorisedColo
 	}
 
 	// Container elevation variants.
-	generateContainerVariants(palette, bg, theme, hintsApplied)
+	generateContainerVariants(palette, bg, theme, hintsApplied, config.UseOKLCH)
 }
 
 // generateSurfaceVariant creates an intermediate color between surface and background.
@@ -362,7 +363,7 @@ func generateSurfaceVariant(surface, bg CategorisedColour, _ ThemeType) Categori
 }
 
 // generateBorderMuted creates a more subtle border than primary border.
-func generateBorderMuted(surface CategorisedColour, theme ThemeType) CategorisedColour {
+func generateBorderMuted(surface CategorisedColour, theme ThemeType, useOKLCH bool) CategorisedColour {
 	rgb := surface.RGB
 	h, s, l := rgbToHSL(rgb)
 
@@ -377,7 +378,7 @@ func generateBorderMuted(surface CategorisedColour, theme ThemeType) Categorised
 	// Very low saturation.
 	newS := s * 0.25
 
-	newRGB := HSLToRGB(h, newS, newL)
+	newRGB := adjustHSL(rgb, h, s, newS, newL, useOKLCH)
 	newColor := RGBToColor(newRGB)
 
 	return CategorisedColour{
@@ -394,7 +395,7 @@ func generateBorderMuted(surface CategorisedColour, theme ThemeType) Categorised
 }
 
 // generateOutlineVariant creates a secondary outline color.
-func generateOutlineVariant(surface CategorisedColour, theme ThemeType) CategorisedColour {
+func generateOutlineVariant(surface CategorisedColour, theme ThemeType, useOKLCH bool) CategorisedColour {
 	rgb := surface.RGB
 	h, s, l := rgbToHSL(rgb)
 
@@ -407,7 +408,7 @@ func generateOutlineVariant(surface CategorisedColour, theme ThemeType) Categori
 	}
 
 	newS := s * 0.2
-	newRGB := HSLToRGB(h, newS, newL)
+	newRGB := adjustHSL(rgb, h, s, newS, newL, useOKLCH)
 	newColor := RGBToColor(newRGB)
 
 	return CategorisedColour{
@@ -424,22 +425,24 @@ func generateOutlineVariant(surface CategorisedColour, theme ThemeType) Categori
 }
 
 // generateOnColors generates high-contrast text colors for all accent and semantic colors.
-func generateOnColors(palette *CategorisedPalette, _ ThemeType, hintsApplied map[Role]bool) {
+// accentCount is the number of accent slots.
+func generateOnColors(palette *CategorisedPalette, hintsApplied map[Role]bool, accentCount int, model ContrastModel) {
 	// On-colors for accents.
-	generateOnColor(palette, RoleAccent1, RoleOnAccent1, hintsApplied)
-	generateOnColor(palette, RoleAccent2, RoleOnAccent2, hintsApplied)
-	generateOnColor(palette, RoleAccent3, RoleOnAccent3, hintsApplied)
-	generateOnColor(palette, RoleAccent4, RoleOnAccent4, hintsApplied)
+	for n := 1; n <= accentCount; n++ {
+		generateOnColor(palette, AccentRole(n), OnAccentRole(n), hintsApplied, model)
+	}
 
 	// On-colors for semantic roles.
-	generateOnColor(palette, RoleDanger, RoleOnDanger, hintsApplied)
-	generateOnColor(palette, RoleWarning, RoleOnWarning, hintsApplied)
-	generateOnColor(palette, RoleSuccess, RoleOnSuccess, hintsApplied)
-	generateOnColor(palette, RoleInfo, RoleOnInfo, hintsApplied)
+	generateOnColor(palette, RoleDanger, RoleOnDanger, hintsApplied, model)
+	generateOnColor(palette, RoleWarning, RoleOnWarning, hintsApplied, model)
+	generateOnColor(palette, RoleSuccess, RoleOnSuccess, hintsApplied, model)
+	generateOnColor(palette, RoleInfo, RoleOnInfo, hintsApplied, model)
 }
 
 // generateOnColor generates a high-contrast "on" color for a given background role.
-func generateOnColor(palette *CategorisedPalette, bgRole, onRole Role, hintsApplied map[Role]bool) {
+// White or black is chosen, whichever has more contrast under model. On mid-tone
+// accents the WCAG ratio often prefers black where APCA, closer to perception, prefers white.
+func generateOnColor(palette *CategorisedPalette, bgRole, onRole Role, hintsApplied map[Role]bool, model ContrastModel) {
 	if hintsApplied[onRole] {
 		return
 	}
@@ -453,8 +456,8 @@ func generateOnColor(palette *CategorisedPalette, bgRole, onRole Role, hintsAppl
 	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
 	black := color.RGBA{R: 0, G: 0, B: 0, A: 255}
 
-	whiteContrast := ContrastRatio(white, bgColor.Colour)
-	blackContrast := ContrastRatio(black, bgColor.Colour)
+	whiteContrast := model.Contrast(white, bgColor.Colour)
+	blackContrast := model.Contrast(black, bgColor.Colour)
 
 	var onColor color.Color
 	var onRGB RGB
@@ -615,7 +618,7 @@ func generateShadow(_ ThemeType) CategorisedColour {
 }
 
 // generateContainerVariants creates elevation-based container colors.
-func generateContainerVariants(palette *CategorisedPalette, bg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool) {
+func generateContainerVariants(palette *CategorisedPalette, bg CategorisedColour, theme ThemeType, hintsApplied map[Role]bool, useOKLCH bool) {
 	surface, hasSurface := palette.Get(RoleSurface)
 	if !hasSurface {
 		surface = bg
@@ -655,7 +658,7 @@ func generateContainerVariants(palette *CategorisedPalette, bg CategorisedColour
 			newL = 0.95
 		}
 
-		newRGB := HSLToRGB(h, s, newL)
+		newRGB := adjustHSL(rgb, h, s, s, newL, useOKLCH)
 		newColor := RGBToColor(newRGB)
 
 		palette.Set(container.role, CategorisedColour{
@@ -1,5 +1,7 @@
 package colour
 
+import "fmt"
+
 // ThemeData is the standard data structure passed to all plugin templates.
 // It embeds PaletteHelper to provide all color access methods and includes.
 // additional optional fields that plugins can populate.
@@ -20,6 +22,14 @@ type ThemeData struct {
 	// ColorFileName is the name of the primary color palette file being generated.
 	// This allows stub/config templates to reference the correct color file.
 	ColorFileName string
+
+	// ColorSpace is the colour space selected with --color-space (empty means sRGB).
+	// Templates opt in to it with the cssColor function.
+	ColorSpace ColorSpace
+
+	// categorise categorises the extracted colours for another theme type.
+	// Set with SetCategoriser and used by ThemeVariant.
+	categorise func(ThemeType) (*CategorisedPalette, error)
 }
 
 // NewThemeData creates a new ThemeData instance with the given palette.
@@ -31,3 +41,29 @@ func NewThemeData(palette *CategorisedPalette, wallpaperPath, themeName string)
 		ThemeName:     themeName,
 	}
 }
+
+// SetCategoriser sets the function ThemeVariant uses to categorise the extracted
+// colours for another theme type. The generate command sets it so that variants are
+// built from the raw palette with the same categorisation options.
+func (td *ThemeData) SetCategoriser(categorise func(ThemeType) (*CategorisedPalette, error)) {
+	td.categorise = categorise
+}
+
+// ThemeVariant returns the palette categorised for themeType, for plugins that write
+// both a light and a dark theme. The theme's own palette is returned if it already
+// has themeType.
+func (td *ThemeData) ThemeVariant(themeType ThemeType) (*CategorisedPalette, error) {
+	if td.Palette().ThemeType == themeType {
+		return td.Palette(), nil
+	}
+	if td.categorise == nil {
+		return nil, fmt.Errorf("no categoriser available to build a %s palette", themeType)
+	}
+	return td.categorise(themeType)
+}
+
+// OrderedRoles returns every role colour in the palette in canonical order, followed
+// by any non-standard roles sorted by name, so generated files are byte-stable.
+func (td *ThemeData) OrderedRoles() []CategorisedColour {
+	return td.Palette().OrderedRoles()
+}
@@ -17,22 +17,14 @@ func Luminance(c color.Color) float64 {
 	rb := float64(b>>8) / 255.0
 
 	// Apply gamma correction.
-	rf = gammaCorrect(rf)
-	rg = gammaCorrect(rg)
-	rb = gammaCorrect(rb)
+	rf = SRGBToLinear(rf)
+	rg = SRGBToLinear(rg)
+	rb = SRGBToLinear(rb)
 
 	// Calculate luminance using WCAG formula.
 	return 0.2126*rf + 0.7152*rg + 0.0722*rb
 }
 
-// gammaCorrect applies gamma correction to a colour component.
-func gammaCorrect(v float64) float64 {
-	if v <= 0.03928 {
-		return v / 12.92
-	}
-	return math.Pow((v+0.055)/1.055, 2.4)
-}
-
 // ContrastRatio calculates the contrast ratio between two colours according to WCAG 2.0.
 // Returns a value between 1 and 21, where 21 is maximum contrast (black vs white).
 // Meets WCAG AA standard for normal text at 4.5:1, large text at 3:1.
@@ -85,6 +77,11 @@ func AdjustLuminance(h, s, l, delta float64) RGB {
 	return HSLToRGB(h, s, newL)
 }
 
+// RGBToHSL converts RGB to HSL: hue (0-360), saturation (0-1) and lightness (0-1).
+func RGBToHSL(rgb RGB) (h, s, l float64) {
+	return rgbToHSL(rgb)
+}
+
 // rgbToHSL converts RGB to HSL colour space.
 // Returns hue (0-360), saturation (0-1), lightness (0-1).
 func rgbToHSL(rgb RGB) (h, s, l float64) {
@@ -215,11 +212,17 @@ func RGBToRGBA(rgb RGB) RGBA {
 // Used by foreground, accent, and semantic color generation to ensure WCAG compliance.
 // stepSize defaults to 0.05 if set to 0.
 func adjustLuminanceForContrast(h, s, targetLum float64, bgColor color.Color, minContrast float64, theme ThemeType, maxAttempts int) (float64, RGB) {
+	return adjustLuminanceForContrastModel(h, s, targetLum, bgColor, ContrastWCAG, minContrast, theme, maxAttempts)
+}
+
+// adjustLuminanceForContrastModel is adjustLuminanceForContrast with contrast, and
+// minContrast, measured by model.
+func adjustLuminanceForContrastModel(h, s, targetLum float64, bgColor color.Color, model ContrastModel, minContrast float64, theme ThemeType, maxAttempts int) (float64, RGB) {
 	stepSize := 0.05 // Default step size
 
 	rgb := HSLToRGB(h, s, targetLum)
 	testColor := RGBToColor(rgb)
-	contrast := ContrastRatio(testColor, bgColor)
+	contrast := model.Contrast(testColor, bgColor)
 
 	attempts := 0
 	for contrast < minContrast && attempts < maxAttempts {
@@ -230,7 +233,7 @@ func adjustLuminanceForContrast(h, s, targetLum float64, bgColor color.Color, mi
 		}
 		rgb = HSLToRGB(h, s, targetLum)
 		testColor = RGBToColor(rgb)
-		contrast = ContrastRatio(testColor, bgColor)
+		contrast = model.Contrast(testColor, bgColor)
 		attempts++
 	}
 
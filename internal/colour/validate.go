@@ -0,0 +1,73 @@
+// Package colour provides palette validation for output plugins.
+package colour
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PaletteValidationError reports roles that a plugin requires but a palette does not
+// provide in a usable form.
+type PaletteValidationError struct {
+	Missing   []Role // Required roles absent from the palette
+	Malformed []Role // Required roles present without a valid #RRGGBB hex value
+}
+
+// Error implements the error interface.
+func (e *PaletteValidationError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing roles: "+joinRoles(e.Missing))
+	}
+	if len(e.Malformed) > 0 {
+		parts = append(parts, "malformed roles: "+joinRoles(e.Malformed))
+	}
+	return "invalid palette: " + strings.Join(parts, "; ")
+}
+
+// Validate checks that each required role exists and has a well-formed colour, so
+// plugins can fail with an actionable message instead of writing a broken config.
+// Returns a *PaletteValidationError listing every problem, or nil if all roles are usable.
+func (cp *CategorisedPalette) Validate(required ...Role) error {
+	if cp == nil {
+		return fmt.Errorf("invalid palette: palette is nil")
+	}
+
+	verr := &PaletteValidationError{}
+	for _, role := range required {
+		cc, ok := cp.Colours[role]
+		switch {
+		case !ok:
+			verr.Missing = append(verr.Missing, role)
+		case !isHexColour(cc.Hex):
+			verr.Malformed = append(verr.Malformed, role)
+		}
+	}
+
+	if len(verr.Missing) == 0 && len(verr.Malformed) == 0 {
+		return nil
+	}
+	return verr
+}
+
+// isHexColour reports whether s is a colour in #RRGGBB form.
+func isHexColour(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// joinRoles joins role names with commas.
+func joinRoles(roles []Role) string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+	return strings.Join(names, ", ")
+}
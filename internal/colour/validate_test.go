@@ -0,0 +1,78 @@
+package colour
+
+import (
+	"errors"
+	"image/color"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func validateTestPalette() *CategorisedPalette {
+	return Categorise(NewPalette([]color.Color{
+		color.RGBA{R: 26, G: 27, B: 38, A: 255},
+		color.RGBA{R: 192, G: 202, B: 245, A: 255},
+		color.RGBA{R: 122, G: 162, B: 247, A: 255},
+		color.RGBA{R: 247, G: 118, B: 142, A: 255},
+	}), DefaultCategorisationConfig())
+}
+
+func TestValidate(t *testing.T) {
+	palette := validateTestPalette()
+
+	if err := palette.Validate(RoleBackground, RoleForeground, RoleAccent1); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+	if err := palette.Validate(); err != nil {
+		t.Errorf("Validate() with no roles error = %v, want nil", err)
+	}
+}
+
+func TestValidateMissingBackground(t *testing.T) {
+	palette := validateTestPalette()
+	delete(palette.Colours, RoleBackground)
+
+	err := palette.Validate(RoleBackground, RoleForeground)
+	if err == nil {
+		t.Fatal("Validate() error = nil, want missing background")
+	}
+
+	var verr *PaletteValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Validate() error type = %T, want *PaletteValidationError", err)
+	}
+	if !slices.Equal(verr.Missing, []Role{RoleBackground}) {
+		t.Errorf("Missing = %v, want [background]", verr.Missing)
+	}
+	if len(verr.Malformed) != 0 {
+		t.Errorf("Malformed = %v, want none", verr.Malformed)
+	}
+	if !strings.Contains(err.Error(), "missing roles: background") {
+		t.Errorf("Error() = %q, want it to name the missing role", err.Error())
+	}
+}
+
+func TestValidateMalformedRole(t *testing.T) {
+	palette := validateTestPalette()
+	fg := palette.Colours[RoleForeground]
+	fg.Hex = "#12345"
+	palette.Colours[RoleForeground] = fg
+
+	var verr *PaletteValidationError
+	if err := palette.Validate(RoleForeground, RoleDanger, Role("custom")); !errors.As(err, &verr) {
+		t.Fatalf("Validate() error = %v, want *PaletteValidationError", err)
+	}
+	if !slices.Equal(verr.Malformed, []Role{RoleForeground}) {
+		t.Errorf("Malformed = %v, want [foreground]", verr.Malformed)
+	}
+	if !slices.Equal(verr.Missing, []Role{"custom"}) {
+		t.Errorf("Missing = %v, want [custom]", verr.Missing)
+	}
+}
+
+func TestValidateNilPalette(t *testing.T) {
+	var palette *CategorisedPalette
+	if err := palette.Validate(RoleBackground); err == nil {
+		t.Error("Validate() on nil palette error = nil, want error")
+	}
+}
@@ -0,0 +1,62 @@
+// Package colour provides colour extraction and palette generation functionality.
+package colour
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultVibrancy is the vibrancy that leaves accent and semantic saturation unchanged.
+	DefaultVibrancy = 50.0
+
+	// DefaultSemanticBoostAmount is the semantic saturation boost the built-in semantic
+	// saturation targets are tuned for.
+	DefaultSemanticBoostAmount = 0.3
+)
+
+// ApplyVibrancy sets the accent saturation scale and semantic boost from a single
+// 0-100 "muted vs vivid" dial. Both scale linearly with vibrancy: 0 removes accent
+// saturation and the semantic boost, DefaultVibrancy keeps the defaults and 100
+// doubles them.
+func (c *CategorisationConfig) ApplyVibrancy(vibrancy float64) error {
+	if vibrancy < 0 || vibrancy > 100 {
+		return fmt.Errorf("vibrancy must be between 0 and 100, got %g", vibrancy)
+	}
+
+	factor := vibrancy / DefaultVibrancy
+	c.AccentSaturationScale = factor
+	c.SemanticBoostAmount = DefaultSemanticBoostAmount * factor
+	return nil
+}
+
+// scaleSaturation returns the colour with its HSL saturation multiplied by scale,
+// keeping hue, lightness, alpha and role metadata.
+func scaleSaturation(cc CategorisedColour, scale float64) CategorisedColour {
+	if scale == 1 {
+		return cc
+	}
+
+	h, s, l := rgbToHSL(cc.RGB)
+	s = math.Max(0, math.Min(1, s*scale))
+
+	rgb := HSLToRGB(h, s, l)
+	c := RGBToColor(rgb)
+	cc.Colour = c
+	cc.Hex = rgb.Hex()
+	cc.RGB = rgb
+	cc.RGBA = RGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: cc.RGBA.A}
+	cc.Luminance = Luminance(c)
+	cc.IsLight = cc.Luminance > 0.5
+	cc.Saturation = s
+	return cc
+}
+
+// boostSemanticSaturation scales a semantic colour's saturation by the configured
+// boost relative to DefaultSemanticBoostAmount, so the default boost leaves it unchanged.
+func boostSemanticSaturation(s, boost float64) float64 {
+	if boost == DefaultSemanticBoostAmount {
+		return s
+	}
+	return math.Max(0, math.Min(1, s*(1+boost)/(1+DefaultSemanticBoostAmount)))
+}
@@ -0,0 +1,102 @@
+package colour
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestApplyVibrancy(t *testing.T) {
+	tests := []struct {
+		vibrancy  float64
+		wantScale float64
+		wantBoost float64
+	}{
+		{0, 0, 0},
+		{25, 0.5, DefaultSemanticBoostAmount * 0.5},
+		{DefaultVibrancy, 1, DefaultSemanticBoostAmount},
+		{100, 2, DefaultSemanticBoostAmount * 2},
+	}
+
+	for _, tt := range tests {
+		config := DefaultCategorisationConfig()
+		if err := config.ApplyVibrancy(tt.vibrancy); err != nil {
+			t.Fatalf("ApplyVibrancy(%g) error = %v", tt.vibrancy, err)
+		}
+		if math.Abs(config.AccentSaturationScale-tt.wantScale) > 1e-9 {
+			t.Errorf("vibrancy %g: AccentSaturationScale = %g, want %g", tt.vibrancy, config.AccentSaturationScale, tt.wantScale)
+		}
+		if math.Abs(config.SemanticBoostAmount-tt.wantBoost) > 1e-9 {
+			t.Errorf("vibrancy %g: SemanticBoostAmount = %g, want %g", tt.vibrancy, config.SemanticBoostAmount, tt.wantBoost)
+		}
+	}
+
+	// The default vibrancy must leave the default configuration untouched.
+	config := DefaultCategorisationConfig()
+	if err := config.ApplyVibrancy(DefaultVibrancy); err != nil {
+		t.Fatal(err)
+	}
+	if config != DefaultCategorisationConfig() {
+		t.Error("default vibrancy should not change the default configuration")
+	}
+
+	for _, invalid := range []float64{-1, 101} {
+		config := DefaultCategorisationConfig()
+		if err := config.ApplyVibrancy(invalid); err == nil {
+			t.Errorf("ApplyVibrancy(%g) should fail", invalid)
+		}
+	}
+}
+
+func TestCategoriseVibrancySaturation(t *testing.T) {
+	palette := NewPalette([]color.Color{
+		color.RGBA{R: 0x1a, G: 0x1b, B: 0x26, A: 0xff},
+		color.RGBA{R: 0xc0, G: 0xca, B: 0xf5, A: 0xff},
+		color.RGBA{R: 0x4d, G: 0x66, B: 0x99, A: 0xff},
+		color.RGBA{R: 0x99, G: 0x66, B: 0x4d, A: 0xff},
+		color.RGBA{R: 0x5c, G: 0x8a, B: 0x5c, A: 0xff},
+		color.RGBA{R: 0x80, G: 0x5c, B: 0x8a, A: 0xff},
+	})
+
+	saturations := func(vibrancy float64) (accent, danger float64) {
+		t.Helper()
+		config := DefaultCategorisationConfig()
+		config.ThemeType = ThemeDark
+		if err := config.ApplyVibrancy(vibrancy); err != nil {
+			t.Fatal(err)
+		}
+		result := Categorise(palette, config)
+
+		a, ok := result.Get(RoleAccent1)
+		if !ok {
+			t.Fatal("palette has no accent1")
+		}
+		d, ok := result.Get(RoleDanger)
+		if !ok {
+			t.Fatal("palette has no danger")
+		}
+		return a.Saturation, d.Saturation
+	}
+
+	baseAccent, baseDanger := saturations(DefaultVibrancy)
+	lowAccent, lowDanger := saturations(25)
+	highAccent, highDanger := saturations(75)
+
+	if !(lowAccent < baseAccent && baseAccent < highAccent) {
+		t.Errorf("accent saturation should rise with vibrancy: 25=%.3f 50=%.3f 75=%.3f", lowAccent, baseAccent, highAccent)
+	}
+	if !(lowDanger < baseDanger && baseDanger < highDanger) {
+		t.Errorf("danger saturation should rise with vibrancy: 25=%.3f 50=%.3f 75=%.3f", lowDanger, baseDanger, highDanger)
+	}
+
+	// Accent saturation scales in proportion to vibrancy.
+	if want := lowAccent * 3; math.Abs(highAccent-want) > 1e-9 {
+		t.Errorf("accent saturation at 75 = %.4f, want three times that at 25 (%.4f)", highAccent, want)
+	}
+
+	// Semantic saturation follows the boost: (1 + boost) relative to the default.
+	ratio := (1 + DefaultSemanticBoostAmount*1.5) / (1 + DefaultSemanticBoostAmount)
+	if want := math.Min(1, baseDanger*ratio); math.Abs(highDanger-want) > 1e-9 {
+		t.Errorf("danger saturation at 75 = %.4f, want %.4f", highDanger, want)
+	}
+}
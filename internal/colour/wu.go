@@ -0,0 +1,313 @@
+// Package color provides color extraction and palette generation functionality.
+package colour
+
+import (
+	"cmp"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+)
+
+const (
+	// wuBits is the number of bits of each channel kept in the histogram.
+	wuBits = 5
+
+	// wuSide is the number of histogram cells per channel. Cell 0 is padding so
+	// cumulative moments can be looked up at a box's exclusive lower bound.
+	wuSide = 1<<wuBits + 1
+)
+
+// WuExtractor implements color extraction using Xiaolin Wu's minimum variance
+// quantisation. It is deterministic and does not need a seed.
+type WuExtractor struct {
+	keepAlpha bool // Keep source alpha instead of compositing translucent pixels over black
+}
+
+// NewWuExtractor creates a new WuExtractor with default settings.
+func NewWuExtractor() *WuExtractor {
+	return &WuExtractor{
+		keepAlpha: false,
+	}
+}
+
+// WithKeepAlpha makes extracted colors keep the alpha of their source pixels.
+// Each color's alpha is the mean alpha of its box. By default translucent
+// pixels are composited over black. Fully transparent pixels are ignored in both modes.
+func (e *WuExtractor) WithKeepAlpha(keep bool) *WuExtractor {
+	e.keepAlpha = keep
+	return e
+}
+
+// wuMoments is the cumulative colour moment histogram. Each cell holds the sums over
+// every cell at or below it on all three axes, so any box is eight lookups.
+type wuMoments struct {
+	weight     []float64 // Pixel count
+	r, g, b, a []float64 // Channel sums
+	squares    []float64 // Sum of r² + g² + b²
+}
+
+// wuIndex returns the histogram cell index of the given cell coordinates.
+func wuIndex(r, g, b int) int {
+	return (r*wuSide+g)*wuSide + b
+}
+
+// newWuMoments builds the moment histogram of the pixels.
+func newWuMoments(pixels []color.Color) *wuMoments {
+	size := wuSide * wuSide * wuSide
+	m := &wuMoments{
+		weight:  make([]float64, size),
+		r:       make([]float64, size),
+		g:       make([]float64, size),
+		b:       make([]float64, size),
+		a:       make([]float64, size),
+		squares: make([]float64, size),
+	}
+
+	const shift = 8 - wuBits
+	for _, c := range pixels {
+		p := ToRGBA(c)
+		i := wuIndex(int(p.R>>shift)+1, int(p.G>>shift)+1, int(p.B>>shift)+1)
+		r, g, b := float64(p.R), float64(p.G), float64(p.B)
+		m.weight[i]++
+		m.r[i] += r
+		m.g[i] += g
+		m.b[i] += b
+		m.a[i] += float64(p.A)
+		m.squares[i] += r*r + g*g + b*b
+	}
+
+	for _, moment := range [][]float64{m.weight, m.r, m.g, m.b, m.a, m.squares} {
+		cumulate(moment)
+	}
+	return m
+}
+
+// cumulate turns a histogram into cumulative sums along each axis in turn.
+func cumulate(moment []float64) {
+	for _, stride := range []int{wuSide * wuSide, wuSide, 1} {
+		for i := range moment {
+			if i/stride%wuSide > 0 {
+				moment[i] += moment[i-stride]
+			}
+		}
+	}
+}
+
+// wuBox is a box of histogram cells. Lower bounds are exclusive, upper bounds inclusive.
+type wuBox struct {
+	lo, hi [3]int // Red, green and blue bounds
+}
+
+// cellCount returns the number of histogram cells in the box.
+func (b wuBox) cellCount() int {
+	return (b.hi[0] - b.lo[0]) * (b.hi[1] - b.lo[1]) * (b.hi[2] - b.lo[2])
+}
+
+// volume returns the sum of a cumulative moment over the box.
+func (b wuBox) volume(moment []float64) float64 {
+	r0, g0, b0 := b.lo[0], b.lo[1], b.lo[2]
+	r1, g1, b1 := b.hi[0], b.hi[1], b.hi[2]
+	return moment[wuIndex(r1, g1, b1)] -
+		moment[wuIndex(r1, g1, b0)] -
+		moment[wuIndex(r1, g0, b1)] +
+		moment[wuIndex(r1, g0, b0)] -
+		moment[wuIndex(r0, g1, b1)] +
+		moment[wuIndex(r0, g1, b0)] +
+		moment[wuIndex(r0, g0, b1)] -
+		moment[wuIndex(r0, g0, b0)]
+}
+
+// wuStats are the weight and channel sums of a box.
+type wuStats struct {
+	weight, r, g, b float64
+}
+
+// stats returns the weight and channel sums of the box.
+func (m *wuMoments) stats(box wuBox) wuStats {
+	return wuStats{
+		weight: box.volume(m.weight),
+		r:      box.volume(m.r),
+		g:      box.volume(m.g),
+		b:      box.volume(m.b),
+	}
+}
+
+// spread returns the between-box term of the variance, (Σr² + Σg² + Σb²) / n.
+// Maximising it over a cut minimises the variance left inside the two halves.
+func (s wuStats) spread() float64 {
+	return (s.r*s.r + s.g*s.g + s.b*s.b) / s.weight
+}
+
+// variance returns the sum of squared distances from the box mean.
+func (m *wuMoments) variance(box wuBox) float64 {
+	s := m.stats(box)
+	if s.weight == 0 {
+		return 0
+	}
+	return box.volume(m.squares) - s.spread()
+}
+
+// cut splits the box where it most reduces the variance, trying each axis.
+// It returns false if no cut leaves pixels on both sides.
+func (m *wuMoments) cut(box wuBox) (lower, upper wuBox, ok bool) {
+	whole := m.stats(box)
+	best, bestAxis, bestPos := 0.0, -1, 0
+
+	for axis := range 3 {
+		for pos := box.lo[axis] + 1; pos < box.hi[axis]; pos++ {
+			half := box
+			half.hi[axis] = pos
+			low := m.stats(half)
+			high := wuStats{
+				weight: whole.weight - low.weight,
+				r:      whole.r - low.r,
+				g:      whole.g - low.g,
+				b:      whole.b - low.b,
+			}
+			if low.weight == 0 || high.weight == 0 {
+				continue
+			}
+			if spread := low.spread() + high.spread(); spread > best {
+				best, bestAxis, bestPos = spread, axis, pos
+			}
+		}
+	}
+	if bestAxis < 0 {
+		return box, box, false
+	}
+
+	lower, upper = box, box
+	lower.hi[bestAxis] = bestPos
+	upper.lo[bestAxis] = bestPos
+	return lower, upper, true
+}
+
+// Extract extracts colors from an image using Wu's quantiser.
+// Pixels are binned into a 32x32x32 histogram of colour moments and the colour
+// space is cut, always splitting the box with the largest variance where the cut
+// leaves the least variance, until there are count boxes. Returns the box
+// averages weighted by pixel count, largest first.
+//
+// As with k-means, count must be 1-256 and every unique colour is returned when
+// the image has no more than count of them. Fewer colours are returned when the
+// image's colours fall into fewer than count histogram cells.
+func (e *WuExtractor) Extract(img image.Image, count int) (*Palette, error) {
+	if img == nil {
+		return nil, fmt.Errorf("image cannot be nil")
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("color count must be at least 1, got %d", count)
+	}
+	if count > 256 {
+		return nil, fmt.Errorf("color count too large: %d (maximum: 256)", count)
+	}
+
+	// Sample pixels from the image, skipping fully transparent (e.g. masked) ones.
+	pixels := visiblePixels(samplePixels(img))
+	if !e.keepAlpha {
+		pixels = flattenPixels(pixels)
+	}
+	if len(pixels) == 0 {
+		return nil, fmt.Errorf("no visible pixels found in image")
+	}
+
+	// If we want more colors than unique colors exist, return all unique colors.
+	if unique := uniquePixels(pixels); count >= len(unique) {
+		return NewPaletteWithWeights(unique, pixelCounts(pixels, unique)), nil
+	}
+
+	moments := newWuMoments(pixels)
+	boxes := moments.cutBoxes(count)
+
+	colors := make([]color.Color, len(boxes))
+	weights := make([]float64, len(boxes))
+	for i, box := range boxes {
+		s := moments.stats(box)
+		rgb := RGB{
+			R: uint8(math.Round(s.r / s.weight)),
+			G: uint8(math.Round(s.g / s.weight)),
+			B: uint8(math.Round(s.b / s.weight)),
+		}
+		if e.keepAlpha {
+			alpha := uint8(math.Round(box.volume(moments.a) / s.weight))
+			colors[i] = color.NRGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: alpha}
+		} else {
+			colors[i] = color.RGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: 255}
+		}
+		weights[i] = s.weight
+	}
+
+	return NewPaletteWithWeights(colors, weights), nil
+}
+
+// cutBoxes splits the histogram into up to count boxes, always cutting the box
+// with the largest variance next, and returns them largest first.
+func (m *wuMoments) cutBoxes(count int) []wuBox {
+	whole := wuBox{hi: [3]int{wuSide - 1, wuSide - 1, wuSide - 1}}
+	boxes := []wuBox{whole}
+	variances := []float64{m.boxVariance(whole)}
+
+	for len(boxes) < count {
+		next := 0
+		for i, v := range variances {
+			if v > variances[next] {
+				next = i
+			}
+		}
+		if variances[next] <= 0 {
+			break // Every box holds a single histogram cell or colour.
+		}
+
+		lower, upper, ok := m.cut(boxes[next])
+		if !ok {
+			variances[next] = 0
+			continue
+		}
+		boxes[next] = lower
+		boxes = append(boxes, upper)
+		variances[next] = m.boxVariance(lower)
+		variances = append(variances, m.boxVariance(upper))
+	}
+
+	slices.SortStableFunc(boxes, func(a, b wuBox) int {
+		return cmp.Compare(m.stats(b).weight, m.stats(a).weight)
+	})
+	return boxes
+}
+
+// boxVariance returns the variance of a box, or 0 if it is a single cell and cannot be cut.
+func (m *wuMoments) boxVariance(box wuBox) float64 {
+	if box.cellCount() <= 1 {
+		return 0
+	}
+	return m.variance(box)
+}
+
+// uniquePixels returns the distinct colours of the pixels in first-seen order.
+func uniquePixels(pixels []color.Color) []color.Color {
+	unique := make([]color.Color, 0, len(pixels))
+	seen := make(map[RGBA]bool)
+	for _, p := range pixels {
+		rgba := ToRGBA(p)
+		if !seen[rgba] {
+			unique = append(unique, p)
+			seen[rgba] = true
+		}
+	}
+	return unique
+}
+
+// pixelCounts returns how many pixels have each of the unique colours.
+func pixelCounts(pixels, unique []color.Color) []float64 {
+	index := make(map[RGBA]int, len(unique))
+	for i, c := range unique {
+		index[ToRGBA(c)] = i
+	}
+	counts := make([]float64, len(unique))
+	for _, p := range pixels {
+		counts[index[ToRGBA(p)]]++
+	}
+	return counts
+}
@@ -0,0 +1,157 @@
+package colour
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+)
+
+func TestWuExtractor_Count(t *testing.T) {
+	img := gradientImage()
+
+	for _, count := range []int{1, 2, 5, 8, 16, 33, 64} {
+		palette, err := NewWuExtractor().Extract(img, count)
+		if err != nil {
+			t.Fatalf("Extract(%d) error = %v", count, err)
+		}
+		if len(palette.Colors) != count {
+			t.Errorf("Extract(%d) returned %d colours", count, len(palette.Colors))
+		}
+		if len(palette.Weights) != len(palette.Colors) {
+			t.Errorf("Extract(%d) returned %d weights for %d colours", count, len(palette.Weights), len(palette.Colors))
+		}
+	}
+}
+
+func TestWuExtractor_UniqueColours(t *testing.T) {
+	// Three quarters red, one quarter blue.
+	red := color.NRGBA{R: 220, G: 20, B: 20, A: 255}
+	blue := color.NRGBA{R: 20, G: 20, B: 220, A: 255}
+	img := quadrantImage([4]color.Color{red, red, red, blue})
+
+	// Like k-means, asking for at least as many colours as exist returns them as-is.
+	palette, err := NewWuExtractor().Extract(img, 16)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if got := paletteHexes(palette); !slices.Equal(got, []string{"#dc1414ff", "#1414dcff"}) {
+		t.Errorf("colours = %v, want red and blue", got)
+	}
+	if !slices.Equal(palette.Weights, []float64{0.75, 0.25}) {
+		t.Errorf("weights = %v, want pixel counts [0.75 0.25]", palette.Weights)
+	}
+}
+
+func TestWuExtractor_Quadrants(t *testing.T) {
+	img := quadrantImage([4]color.Color{
+		color.NRGBA{R: 200, G: 30, B: 30, A: 255},
+		color.NRGBA{R: 30, G: 200, B: 30, A: 255},
+		color.NRGBA{R: 30, G: 30, B: 200, A: 255},
+		color.NRGBA{R: 240, G: 240, B: 240, A: 255},
+	})
+
+	palette, err := NewWuExtractor().Extract(img, 3)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(palette.Colors) != 3 {
+		t.Fatalf("got %d colours, want 3", len(palette.Colors))
+	}
+
+	// Two quadrants keep their colour and the merged pair comes first.
+	if !slices.Equal(palette.Weights, []float64{0.5, 0.25, 0.25}) {
+		t.Errorf("weights = %v, want [0.5 0.25 0.25]", palette.Weights)
+	}
+	kept := 0
+	for _, hex := range paletteHexes(palette)[1:] {
+		if slices.Contains([]string{"#c81e1eff", "#1ec81eff", "#1e1ec8ff", "#f0f0f0ff"}, hex) {
+			kept++
+		}
+	}
+	if kept != 2 {
+		t.Errorf("colours = %v, want two unmerged quadrants", paletteHexes(palette))
+	}
+}
+
+func TestWuExtractor_MinimisesVariance(t *testing.T) {
+	// Two tight clusters of greys with a single outlier: the two colours should be
+	// the cluster means, not a split that isolates the outlier.
+	img := image.NewNRGBA(image.Rect(0, 0, 41, 1))
+	for x := range 20 {
+		img.Set(x, 0, color.NRGBA{R: uint8(30 + x%4), G: uint8(30 + x%4), B: uint8(30 + x%4), A: 255})
+		img.Set(x+20, 0, color.NRGBA{R: uint8(200 + x%4), G: uint8(200 + x%4), B: uint8(200 + x%4), A: 255})
+	}
+	img.Set(40, 0, color.NRGBA{R: 120, G: 120, B: 120, A: 255})
+
+	palette, err := NewWuExtractor().Extract(img, 2)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	for _, c := range palette.Colors {
+		if r := ToRGBA(c).R; r > 40 && r < 190 {
+			t.Errorf("colour %s should belong to one of the two clusters", ToRGBA(c).Hex())
+		}
+	}
+}
+
+func TestWuExtractor_Deterministic(t *testing.T) {
+	img := gradientImage()
+
+	// The seed is ignored: Wu gives the same palette with or without one.
+	seed := int64(42)
+	withSeed, err := NewExtractor(AlgorithmWu, ExtractorOptions{Seed: &seed})
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+	withoutSeed, err := NewExtractor(AlgorithmWu, ExtractorOptions{})
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+
+	first, err := withSeed.Extract(img, 8)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	again, err := withoutSeed.Extract(img, 8)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if !slices.Equal(paletteHexes(first), paletteHexes(again)) || !slices.Equal(first.Weights, again.Weights) {
+		t.Fatalf("palettes differ between runs: %v and %v", paletteHexes(first), paletteHexes(again))
+	}
+}
+
+func TestWuExtractor_KeepAlpha(t *testing.T) {
+	img := quadrantImage([4]color.Color{
+		color.NRGBA{R: 200, G: 30, B: 30, A: 128},
+		color.NRGBA{R: 200, G: 30, B: 30, A: 64},
+		color.NRGBA{R: 30, G: 30, B: 200, A: 255},
+		color.NRGBA{R: 30, G: 30, B: 200, A: 0},
+	})
+
+	palette, err := NewWuExtractor().WithKeepAlpha(true).Extract(img, 1)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	// Transparent pixels are ignored; the rest average to alpha (128 + 64 + 255) / 3.
+	if got := ToRGBA(palette.Colors[0]).A; got != 149 {
+		t.Errorf("alpha = %d, want 149", got)
+	}
+}
+
+func TestWuExtractor_Errors(t *testing.T) {
+	img := gradientImage()
+	if _, err := NewWuExtractor().Extract(nil, 4); err == nil {
+		t.Error("Extract(nil) should fail")
+	}
+	if _, err := NewWuExtractor().Extract(img, 0); err == nil {
+		t.Error("Extract() with count 0 should fail")
+	}
+	if _, err := NewWuExtractor().Extract(img, 257); err == nil {
+		t.Error("Extract() with count 257 should fail")
+	}
+	if _, err := NewWuExtractor().Extract(image.NewNRGBA(image.Rect(0, 0, 4, 4)), 4); err == nil {
+		t.Error("Extract() of a fully transparent image should fail")
+	}
+}
@@ -0,0 +1,52 @@
+// Package colour provides xterm 256-colour quantisation for terminal fallbacks.
+package colour
+
+// xtermCubeLevels are the channel values of the 6x6x6 colour cube (indices 16-231).
+var xtermCubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// Xterm256 returns the index (16-255) of the nearest colour in the xterm 256-colour
+// palette. Only the colour cube and greyscale ramp are considered, since the first
+// 16 colours are redefined by most terminal themes.
+func (rgb RGB) Xterm256() int {
+	// Nearest colour cube entry, channel by channel.
+	nearestLevel := func(v uint8) int {
+		best := 0
+		for i, level := range xtermCubeLevels {
+			if absDiff(v, level) < absDiff(v, xtermCubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	r, g, b := nearestLevel(rgb.R), nearestLevel(rgb.G), nearestLevel(rgb.B)
+	cube := RGB{R: xtermCubeLevels[r], G: xtermCubeLevels[g], B: xtermCubeLevels[b]}
+	cubeIndex := 16 + 36*r + 6*g + b
+
+	// Nearest greyscale ramp entry (232-255 are 8, 18, ..., 238).
+	average := (int(rgb.R) + int(rgb.G) + int(rgb.B)) / 3
+	step := min(max((average-8+5)/10, 0), 23)
+	grey := uint8(8 + 10*step) // #nosec G115 - step is clamped to 0-23, so grey is at most 238
+	greyIndex := 232 + step
+
+	if rgbDistanceSquared(rgb, RGB{R: grey, G: grey, B: grey}) < rgbDistanceSquared(rgb, cube) {
+		return greyIndex
+	}
+	return cubeIndex
+}
+
+// Xterm256 returns the index of the nearest colour in the xterm 256-colour palette.
+func (cv ColorValue) Xterm256() int { return cv.rgba.ToRGB().Xterm256() }
+
+// absDiff returns the absolute difference between two channel values.
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+// rgbDistanceSquared returns the squared Euclidean distance between two colours.
+func rgbDistanceSquared(a, b RGB) int {
+	dr, dg, db := absDiff(a.R, b.R), absDiff(a.G, b.G), absDiff(a.B, b.B)
+	return dr*dr + dg*dg + db*db
+}
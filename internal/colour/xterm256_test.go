@@ -0,0 +1,28 @@
+package colour
+
+import "testing"
+
+func TestXterm256(t *testing.T) {
+	tests := []struct {
+		name string
+		rgb  RGB
+		want int
+	}{
+		{"black", RGB{R: 0, G: 0, B: 0}, 16},
+		{"white", RGB{R: 255, G: 255, B: 255}, 231},
+		{"red", RGB{R: 255, G: 0, B: 0}, 196},
+		{"cube entry", RGB{R: 0x5f, G: 0x87, B: 0xaf}, 67},
+		{"near cube entry", RGB{R: 0x60, G: 0x85, B: 0xb0}, 67},
+		{"mid grey", RGB{R: 128, G: 128, B: 128}, 244},
+		{"dark grey", RGB{R: 18, G: 18, B: 18}, 233},
+		{"near black grey", RGB{R: 0x1a, G: 0x1b, B: 0x26}, 234},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rgb.Xterm256(); got != tt.want {
+				t.Errorf("Xterm256(%s) = %d, want %d", tt.rgb.Hex(), got, tt.want)
+			}
+		})
+	}
+}
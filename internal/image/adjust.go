@@ -0,0 +1,83 @@
+package image
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+// Adjustment describes tonal corrections applied to an image before colour extraction.
+// Both values are applied in linear light so that results are perceptually consistent
+// regardless of the source image's brightness.
+type Adjustment struct {
+	// Brightness multiplies linear light intensity (1.0 = unchanged, >1 brighter, <1 darker).
+	Brightness float64
+
+	// Gamma applies a power curve to linear light as v^(1/Gamma) (1.0 = unchanged,
+	// >1 lifts shadows and midtones, <1 deepens them).
+	Gamma float64
+}
+
+// DefaultAdjustment returns an adjustment that leaves the image unchanged.
+func DefaultAdjustment() Adjustment {
+	return Adjustment{Brightness: 1.0, Gamma: 1.0}
+}
+
+// IsIdentity reports whether the adjustment leaves the image unchanged.
+func (a Adjustment) IsIdentity() bool {
+	return a.Brightness == 1.0 && a.Gamma == 1.0
+}
+
+// Validate checks that the adjustment values are usable.
+func (a Adjustment) Validate() error {
+	if a.Brightness <= 0 || a.Brightness > 10 {
+		return fmt.Errorf("brightness must be greater than 0 and at most 10, got %g", a.Brightness)
+	}
+	if a.Gamma <= 0 || a.Gamma > 10 {
+		return fmt.Errorf("gamma must be greater than 0 and at most 10, got %g", a.Gamma)
+	}
+	return nil
+}
+
+// Apply returns a copy of img with the adjustment applied.
+// Pixels are converted from sRGB to linear light, scaled by brightness, passed through
+// the gamma curve, clamped, and converted back to sRGB. Alpha is preserved.
+// If the adjustment is the identity, img is returned unmodified.
+func (a Adjustment) Apply(img image.Image) image.Image {
+	if img == nil || a.IsIdentity() {
+		return img
+	}
+
+	lut := a.lookupTable()
+	bounds := img.Bounds()
+	out := image.NewNRGBA(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, _ := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			out.SetNRGBA(x, y, color.NRGBA{
+				R: lut[c.R],
+				G: lut[c.G],
+				B: lut[c.B],
+				A: c.A,
+			})
+		}
+	}
+
+	return out
+}
+
+// lookupTable precomputes the adjusted value for every 8-bit channel value.
+func (a Adjustment) lookupTable() [256]uint8 {
+	var lut [256]uint8
+	for i := range lut {
+		linear := colour.SRGBToLinear(float64(i) / 255.0)
+		linear = math.Min(linear*a.Brightness, 1.0)
+		linear = math.Pow(linear, 1.0/a.Gamma)
+		lut[i] = uint8(math.Round(colour.LinearToSRGB(linear) * 255.0))
+	}
+	return lut
+}
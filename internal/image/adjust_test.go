@@ -0,0 +1,124 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+func newUniformImage(c color.Color) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := range 4 {
+		for x := range 4 {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestAdjustmentIdentity(t *testing.T) {
+	img := newUniformImage(color.NRGBA{R: 40, G: 80, B: 120, A: 255})
+	adj := DefaultAdjustment()
+
+	if !adj.IsIdentity() {
+		t.Fatal("DefaultAdjustment should be identity")
+	}
+	if got := adj.Apply(img); got != img {
+		t.Error("identity adjustment should return the original image")
+	}
+}
+
+func TestAdjustmentBrightnessInLinearLight(t *testing.T) {
+	// sRGB 128 is ~0.2158 in linear light; doubling it should give ~0.4316 linear (~sRGB 175).
+	img := newUniformImage(color.NRGBA{R: 128, G: 128, B: 128, A: 255})
+	out := Adjustment{Brightness: 2.0, Gamma: 1.0}.Apply(img)
+
+	c := color.NRGBAModel.Convert(out.At(0, 0)).(color.NRGBA)
+	wantLinear := colour.SRGBToLinear(128.0/255.0) * 2.0
+	want := uint8(math.Round(colour.LinearToSRGB(wantLinear) * 255.0))
+	if c.R != want || c.G != want || c.B != want {
+		t.Errorf("brightness 2.0 on grey 128: got %v, want %d", c, want)
+	}
+	if want <= 128 {
+		t.Errorf("expected brightened value above 128, got %d", want)
+	}
+}
+
+func TestAdjustmentClampsHighlights(t *testing.T) {
+	img := newUniformImage(color.NRGBA{R: 250, G: 250, B: 250, A: 255})
+	out := Adjustment{Brightness: 4.0, Gamma: 1.0}.Apply(img)
+
+	c := color.NRGBAModel.Convert(out.At(0, 0)).(color.NRGBA)
+	if c.R != 255 {
+		t.Errorf("expected clamped channel 255, got %d", c.R)
+	}
+}
+
+func TestAdjustmentMonotonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		adj      Adjustment
+		brighter bool
+	}{
+		{"brightness up", Adjustment{Brightness: 1.5, Gamma: 1.0}, true},
+		{"brightness down", Adjustment{Brightness: 0.5, Gamma: 1.0}, false},
+		{"gamma up", Adjustment{Brightness: 1.0, Gamma: 2.2}, true},
+		{"gamma down", Adjustment{Brightness: 1.0, Gamma: 0.5}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lut := tt.adj.lookupTable()
+			if lut[0] != 0 {
+				t.Errorf("black should stay black, got %d", lut[0])
+			}
+			for i := 1; i < 255; i++ {
+				if lut[i] < lut[i-1] {
+					t.Fatalf("lookup table not monotonic at %d", i)
+				}
+				if tt.brighter && lut[i] < uint8(i) {
+					t.Fatalf("expected value %d to brighten, got %d", i, lut[i])
+				}
+				if !tt.brighter && lut[i] > uint8(i) {
+					t.Fatalf("expected value %d to darken, got %d", i, lut[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAdjustmentPreservesAlpha(t *testing.T) {
+	img := newUniformImage(color.NRGBA{R: 60, G: 60, B: 60, A: 128})
+	out := Adjustment{Brightness: 2.0, Gamma: 1.5}.Apply(img)
+
+	c := color.NRGBAModel.Convert(out.At(1, 1)).(color.NRGBA)
+	if c.A != 128 {
+		t.Errorf("alpha should be preserved, got %d", c.A)
+	}
+}
+
+func TestAdjustmentValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		adj     Adjustment
+		wantErr bool
+	}{
+		{"default", DefaultAdjustment(), false},
+		{"valid", Adjustment{Brightness: 2.5, Gamma: 0.8}, false},
+		{"zero brightness", Adjustment{Brightness: 0, Gamma: 1}, true},
+		{"negative gamma", Adjustment{Brightness: 1, Gamma: -1}, true},
+		{"huge brightness", Adjustment{Brightness: 100, Gamma: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.adj.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
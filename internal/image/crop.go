@@ -0,0 +1,200 @@
+package image
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+)
+
+// DefaultBorderTolerance is the per-channel difference (0-255) within which border
+// pixels are considered the same colour when trimming borders.
+const DefaultBorderTolerance = 12
+
+// Crop describes the region of an image used for colour extraction.
+// Values are pixels, or percentages of the image size when Percent is set.
+// The zero value selects the whole image.
+type Crop struct {
+	X, Y          float64
+	Width, Height float64
+	Percent       bool
+}
+
+// ParseCrop parses a crop region in "x,y,w,h" form.
+// When percent is true, the values are percentages (0-100) of the image dimensions.
+func ParseCrop(s string, percent bool) (Crop, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return Crop{}, fmt.Errorf("crop must be in x,y,w,h form, got %q", s)
+	}
+
+	var values [4]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return Crop{}, fmt.Errorf("invalid crop value %q: %w", part, err)
+		}
+		if v < 0 {
+			return Crop{}, fmt.Errorf("crop values must not be negative, got %g", v)
+		}
+		values[i] = v
+	}
+
+	crop := Crop{X: values[0], Y: values[1], Width: values[2], Height: values[3], Percent: percent}
+	if crop.Width == 0 || crop.Height == 0 {
+		return Crop{}, fmt.Errorf("crop width and height must be greater than 0")
+	}
+	if percent && (crop.X+crop.Width > 100 || crop.Y+crop.Height > 100) {
+		return Crop{}, fmt.Errorf("crop percentages must stay within 0-100, got %q", s)
+	}
+	return crop, nil
+}
+
+// ParseCropFlags parses the --crop and --crop-percent flag values.
+// At most one may be set; if neither is, the zero Crop is returned.
+func ParseCropFlags(crop, cropPercent string) (Crop, error) {
+	switch {
+	case crop != "" && cropPercent != "":
+		return Crop{}, fmt.Errorf("crop and crop-percent cannot be used together")
+	case crop != "":
+		return ParseCrop(crop, false)
+	case cropPercent != "":
+		return ParseCrop(cropPercent, true)
+	default:
+		return Crop{}, nil
+	}
+}
+
+// IsZero reports whether the crop selects the whole image.
+func (c Crop) IsZero() bool {
+	return c == Crop{}
+}
+
+// Rect returns the crop rectangle within bounds. Regions extending past the image edges
+// are clipped; a region entirely outside the image returns an error.
+func (c Crop) Rect(bounds image.Rectangle) (image.Rectangle, error) {
+	if c.IsZero() {
+		return bounds, nil
+	}
+
+	x, y, w, h := c.X, c.Y, c.Width, c.Height
+	if c.Percent {
+		x = x * float64(bounds.Dx()) / 100
+		y = y * float64(bounds.Dy()) / 100
+		w = w * float64(bounds.Dx()) / 100
+		h = h * float64(bounds.Dy()) / 100
+	}
+
+	minX := bounds.Min.X + int(x+0.5)
+	minY := bounds.Min.Y + int(y+0.5)
+	rect := image.Rect(minX, minY, minX+max(int(w+0.5), 1), minY+max(int(h+0.5), 1)).Intersect(bounds)
+	if rect.Empty() {
+		return image.Rectangle{}, fmt.Errorf("crop region lies outside the %dx%d image", bounds.Dx(), bounds.Dy())
+	}
+	return rect, nil
+}
+
+// Apply returns the cropped image. If the crop is zero, img is returned unmodified.
+func (c Crop) Apply(img image.Image) (image.Image, error) {
+	if img == nil || c.IsZero() {
+		return img, nil
+	}
+
+	rect, err := c.Rect(img.Bounds())
+	if err != nil {
+		return nil, err
+	}
+	return copyRect(img, rect), nil
+}
+
+// TrimBorders removes uniform borders such as letterboxing from the edges of img.
+// Each edge is trimmed while its outermost row or column is a single colour (within
+// tolerance per channel) matching that edge's corner. An axis whose trimming would leave
+// nothing, as with a single-colour image, is left untrimmed. If nothing is trimmed, img
+// is returned unmodified.
+func TrimBorders(img image.Image, tolerance uint8) image.Image {
+	if img == nil {
+		return img
+	}
+
+	bounds := img.Bounds()
+
+	top, bottom := bounds.Min.Y, bounds.Max.Y
+	topRef := img.At(bounds.Min.X, top)
+	for top < bottom && uniformRow(img, top, bounds.Min.X, bounds.Max.X, topRef, tolerance) {
+		top++
+	}
+	bottomRef := img.At(bounds.Min.X, bottom-1)
+	for bottom > top && uniformRow(img, bottom-1, bounds.Min.X, bounds.Max.X, bottomRef, tolerance) {
+		bottom--
+	}
+	if top >= bottom {
+		top, bottom = bounds.Min.Y, bounds.Max.Y
+	}
+
+	left, right := bounds.Min.X, bounds.Max.X
+	leftRef := img.At(left, top)
+	for left < right && uniformColumn(img, left, top, bottom, leftRef, tolerance) {
+		left++
+	}
+	rightRef := img.At(right-1, top)
+	for right > left && uniformColumn(img, right-1, top, bottom, rightRef, tolerance) {
+		right--
+	}
+	if left >= right {
+		left, right = bounds.Min.X, bounds.Max.X
+	}
+
+	rect := image.Rect(left, top, right, bottom)
+	if rect == bounds {
+		return img
+	}
+	return copyRect(img, rect)
+}
+
+// uniformRow reports whether every pixel in row y between x0 and x1 matches ref.
+func uniformRow(img image.Image, y, x0, x1 int, ref color.Color, tolerance uint8) bool {
+	for x := x0; x < x1; x++ {
+		if !similarColour(img.At(x, y), ref, tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// uniformColumn reports whether every pixel in column x between y0 and y1 matches ref.
+func uniformColumn(img image.Image, x, y0, y1 int, ref color.Color, tolerance uint8) bool {
+	for y := y0; y < y1; y++ {
+		if !similarColour(img.At(x, y), ref, tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// similarColour reports whether each channel of a and b differs by at most tolerance.
+func similarColour(a, b color.Color, tolerance uint8) bool {
+	ca, _ := color.NRGBAModel.Convert(a).(color.NRGBA)
+	cb, _ := color.NRGBAModel.Convert(b).(color.NRGBA)
+	return channelDiff(ca.R, cb.R) <= tolerance &&
+		channelDiff(ca.G, cb.G) <= tolerance &&
+		channelDiff(ca.B, cb.B) <= tolerance &&
+		channelDiff(ca.A, cb.A) <= tolerance
+}
+
+// channelDiff returns the absolute difference between two channel values.
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// copyRect copies rect from img into a new image whose bounds start at the origin.
+func copyRect(img image.Image, rect image.Rectangle) image.Image {
+	out := image.NewNRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(out, out.Bounds(), img, rect.Min, draw.Src)
+	return out
+}
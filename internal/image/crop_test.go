@@ -0,0 +1,164 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// newSplitImage returns a w x h image whose left half is left and right half is right.
+func newSplitImage(w, h int, left, right color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			if x < w/2 {
+				img.Set(x, y, left)
+			} else {
+				img.Set(x, y, right)
+			}
+		}
+	}
+	return img
+}
+
+func TestParseCrop(t *testing.T) {
+	tests := []struct {
+		input   string
+		percent bool
+		want    Crop
+		wantErr bool
+	}{
+		{"10,20,300,200", false, Crop{X: 10, Y: 20, Width: 300, Height: 200}, false},
+		{" 0, 0, 50, 100 ", true, Crop{Width: 50, Height: 100, Percent: true}, false},
+		{"10,20,300", false, Crop{}, true},
+		{"a,0,10,10", false, Crop{}, true},
+		{"-1,0,10,10", false, Crop{}, true},
+		{"0,0,0,10", false, Crop{}, true},
+		{"60,0,50,100", true, Crop{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCrop(tt.input, tt.percent)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCrop(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCrop(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseCropFlags(t *testing.T) {
+	if crop, err := ParseCropFlags("", ""); err != nil || !crop.IsZero() {
+		t.Errorf("no flags should give the zero crop, got %+v, %v", crop, err)
+	}
+	if _, err := ParseCropFlags("0,0,10,10", "0,0,50,50"); err == nil {
+		t.Error("expected error when both crop and crop-percent are set")
+	}
+	if crop, err := ParseCropFlags("", "0,0,50,50"); err != nil || !crop.Percent {
+		t.Errorf("crop-percent should give a percentage crop, got %+v, %v", crop, err)
+	}
+}
+
+func TestCropApply(t *testing.T) {
+	red := color.NRGBA{R: 220, A: 255}
+	blue := color.NRGBA{B: 220, A: 255}
+	img := newSplitImage(100, 40, red, blue)
+
+	tests := []struct {
+		name string
+		crop Crop
+		want color.NRGBA
+	}{
+		{"pixels left half", Crop{X: 0, Y: 0, Width: 50, Height: 40}, red},
+		{"percent right half", Crop{X: 50, Y: 0, Width: 50, Height: 100, Percent: true}, blue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.crop.Apply(img)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if b := out.Bounds(); b.Dx() != 50 || b.Dy() != 40 || b.Min != (image.Point{}) {
+				t.Errorf("cropped bounds = %v, want 50x40 at origin", b)
+			}
+			for _, p := range []image.Point{{0, 0}, {49, 39}} {
+				if got := color.NRGBAModel.Convert(out.At(p.X, p.Y)); got != tt.want {
+					t.Errorf("pixel %v = %v, want %v", p, got, tt.want)
+				}
+			}
+		})
+	}
+
+	// Regions past the edge are clipped; regions outside the image are rejected.
+	out, err := Crop{X: 80, Y: 0, Width: 100, Height: 100}.Apply(img)
+	if err != nil || out.Bounds().Dx() != 20 || out.Bounds().Dy() != 40 {
+		t.Errorf("expected clipped 20x40 crop, got %v, %v", out.Bounds(), err)
+	}
+	if _, err := (Crop{X: 200, Y: 0, Width: 10, Height: 10}).Apply(img); err == nil {
+		t.Error("expected error for crop outside the image")
+	}
+
+	if out, _ := (Crop{}).Apply(img); out != image.Image(img) {
+		t.Error("zero crop should return the original image")
+	}
+}
+
+func TestTrimBordersRemovesBlackBorder(t *testing.T) {
+	black := color.NRGBA{A: 255}
+	img := image.NewNRGBA(image.Rect(0, 0, 120, 80))
+	for y := range 80 {
+		for x := range 120 {
+			// Letterbox bars top/bottom and pillarbox bars left/right around varied content.
+			if y < 10 || y >= 70 || x < 6 || x >= 114 {
+				img.Set(x, y, black)
+			} else {
+				img.Set(x, y, color.NRGBA{R: uint8(100 + x), G: uint8(60 + y), B: 150, A: 255})
+			}
+		}
+	}
+
+	out := TrimBorders(img, DefaultBorderTolerance)
+	if b := out.Bounds(); b.Dx() != 108 || b.Dy() != 60 {
+		t.Fatalf("trimmed bounds = %v, want 108x60", b)
+	}
+	for _, p := range []image.Point{{0, 0}, {107, 0}, {0, 59}, {107, 59}} {
+		if c := color.NRGBAModel.Convert(out.At(p.X, p.Y)).(color.NRGBA); c == black {
+			t.Errorf("pixel %v is still border black", p)
+		}
+	}
+}
+
+func TestTrimBordersLeavesUnborderedImages(t *testing.T) {
+	solid := newUniformImage(color.NRGBA{R: 10, G: 10, B: 10, A: 255})
+	if out := TrimBorders(solid, DefaultBorderTolerance); out != solid {
+		t.Error("uniform image should be returned unmodified")
+	}
+
+	// Each half is uniform, so trimming from both sides would consume the whole image.
+	split := newSplitImage(20, 20, color.NRGBA{R: 200, A: 255}, color.NRGBA{G: 200, A: 255})
+	if out := TrimBorders(split, DefaultBorderTolerance); out != image.Image(split) {
+		t.Errorf("trimming that empties the image should return it unmodified, got %v", out.Bounds())
+	}
+}
+
+func TestTrimBordersFlatContent(t *testing.T) {
+	// A letterboxed flat colour keeps its full width once the bars are removed.
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 40))
+	for y := range 40 {
+		for x := range 40 {
+			if y < 5 || y >= 35 {
+				img.Set(x, y, color.NRGBA{A: 255})
+			} else {
+				img.Set(x, y, color.NRGBA{R: 30, G: 170, B: 160, A: 255})
+			}
+		}
+	}
+
+	out := TrimBorders(img, DefaultBorderTolerance)
+	if b := out.Bounds(); b.Dx() != 40 || b.Dy() != 30 {
+		t.Errorf("trimmed bounds = %v, want 40x30", b)
+	}
+}
@@ -0,0 +1,88 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"net/url"
+	"strings"
+)
+
+// dataURIPrefix starts an RFC 2397 data URI.
+const dataURIPrefix = "data:"
+
+// IsDataURI reports whether path is a data URI such as "data:image/png;base64,...".
+func IsDataURI(path string) bool {
+	return len(path) >= len(dataURIPrefix) && strings.EqualFold(path[:len(dataURIPrefix)], dataURIPrefix)
+}
+
+// dataURIBytes returns the payload of a data URI. The media type must be an
+// image type, or omitted. Payloads are base64 encoded (";base64") or percent encoded.
+func dataURIBytes(uri string) ([]byte, error) {
+	if !IsDataURI(uri) {
+		return nil, fmt.Errorf("not a data URI")
+	}
+	header, payload, ok := strings.Cut(uri[len(dataURIPrefix):], ",")
+	if !ok {
+		return nil, fmt.Errorf("invalid data URI: missing ',' before the data")
+	}
+
+	params := strings.Split(header, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+	if mediaType != "" && !strings.HasPrefix(mediaType, "image/") {
+		return nil, fmt.Errorf("data URI media type %q is not an image", mediaType)
+	}
+
+	isBase64 := false
+	for _, param := range params[1:] {
+		if strings.EqualFold(strings.TrimSpace(param), "base64") {
+			isBase64 = true
+		}
+	}
+
+	if !isBase64 {
+		data, err := url.PathUnescape(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data URI payload: %w", err)
+		}
+		return []byte(data), nil
+	}
+
+	// Tolerate line breaks and URL-safe or unpadded encodings from scripts.
+	payload = strings.Join(strings.Fields(payload), "")
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if data, err := enc.DecodeString(payload); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid data URI: payload is not valid base64")
+}
+
+// DecodeDataURI decodes an image from a data URI, e.g. "data:image/png;base64,...".
+// Supported formats are those Load supports.
+func DecodeDataURI(uri string) (image.Image, error) {
+	data, err := dataURIBytes(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data URI image (format: %s): %w", format, err)
+	}
+	return img, nil
+}
+
+// validateDataURI checks that a data URI holds an image in a supported format
+// without decoding the pixels.
+func validateDataURI(uri string) error {
+	data, err := dataURIBytes(uri)
+	if err != nil {
+		return err
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("unsupported or invalid image format in data URI: %w", err)
+	}
+	return nil
+}
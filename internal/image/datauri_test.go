@@ -0,0 +1,85 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// pngDataURI returns a data URI of a w x h PNG filled with c.
+func pngDataURI(t *testing.T, w, h int, c color.Color) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestDecodeDataURI(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	uri := pngDataURI(t, 4, 3, red)
+
+	img, err := DecodeDataURI(uri)
+	if err != nil {
+		t.Fatalf("DecodeDataURI() error = %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(4, 3) {
+		t.Errorf("size = %v, want 4x3", got)
+	}
+	if got := color.RGBAModel.Convert(img.At(1, 1)); got != red {
+		t.Errorf("pixel = %v, want %v", got, red)
+	}
+
+	// SmartLoader and path helpers accept data URIs too.
+	if _, err := NewSmartLoader().Load(uri); err != nil {
+		t.Errorf("SmartLoader.Load() error = %v", err)
+	}
+	if err := ValidateImagePath(uri); err != nil {
+		t.Errorf("ValidateImagePath() error = %v", err)
+	}
+	if !IsImagePath(uri) {
+		t.Error("IsImagePath() = false, want true")
+	}
+	if resolved, err := ResolveImagePath(uri); err != nil || resolved != uri {
+		t.Errorf("ResolveImagePath() changed the data URI (err %v)", err)
+	}
+
+	// Unpadded payloads split over lines, as some scripts produce, still decode.
+	payload := strings.TrimRight(strings.TrimPrefix(uri, "data:image/png;base64,"), "=")
+	wrapped := "DATA:image/png;base64," + payload[:20] + "\n" + payload[20:]
+	if _, err := DecodeDataURI(wrapped); err != nil {
+		t.Errorf("DecodeDataURI() of wrapped payload error = %v", err)
+	}
+}
+
+func TestDecodeDataURIErrors(t *testing.T) {
+	tests := map[string]string{
+		"not a data URI":    "image.png",
+		"missing comma":     "data:image/png;base64",
+		"not an image type": "data:text/plain;base64,aGVsbG8=",
+		"invalid base64":    "data:image/png;base64,!!!",
+		"not an image":      "data:image/png;base64,aGVsbG8=",
+	}
+
+	for name, uri := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodeDataURI(uri); err == nil {
+				t.Errorf("DecodeDataURI(%q) should fail", uri)
+			}
+			if uri != "image.png" && IsImagePath(uri) {
+				t.Errorf("IsImagePath(%q) = true, want false", uri)
+			}
+		})
+	}
+}
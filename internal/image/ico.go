@@ -0,0 +1,247 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+const (
+	// icoHeaderLen and icoEntryLen are the sizes of the ICONDIR header and of each
+	// ICONDIRENTRY in the directory that follows it.
+	icoHeaderLen = 6
+	icoEntryLen  = 16
+
+	// dibHeaderLen is the size of the BITMAPINFOHEADER that starts a BMP frame.
+	dibHeaderLen = 40
+
+	// maxDIBDimension bounds BMP frames; icons are at most 256 pixels square.
+	maxDIBDimension = 4096
+)
+
+// pngSignature starts ICO frames stored as PNG rather than BMP.
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// errTruncatedICO is returned when a frame's data is shorter than its headers claim.
+var errTruncatedICO = errors.New("ico: truncated image data")
+
+func init() {
+	image.RegisterFormat("ico", "\x00\x00\x01\x00", decodeICO, decodeICOConfig)
+}
+
+// icoFrame is one image from an ICO file's directory.
+type icoFrame struct {
+	data          []byte
+	width, height int
+	bitCount      int
+}
+
+// decodeICO decodes the largest image in an ICO file. Icons hold the same picture at
+// several resolutions, and the largest one carries the most colour detail.
+func decodeICO(r io.Reader) (image.Image, error) {
+	frame, err := largestICOFrame(r)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(frame.data, pngSignature) {
+		return png.Decode(bytes.NewReader(frame.data))
+	}
+	return decodeDIB(frame.data)
+}
+
+// decodeICOConfig returns the dimensions of the image decodeICO would decode.
+func decodeICOConfig(r io.Reader) (image.Config, error) {
+	frame, err := largestICOFrame(r)
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{ColorModel: color.NRGBAModel, Width: frame.width, Height: frame.height}, nil
+}
+
+// largestICOFrame parses the icon directory and returns the frame with the most
+// pixels, preferring the higher bit depth between frames of the same size. Sizes are
+// read from each frame's own header, since the directory stores 256 and larger as 0.
+func largestICOFrame(r io.Reader) (icoFrame, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return icoFrame{}, err
+	}
+	if len(data) < icoHeaderLen || binary.LittleEndian.Uint16(data[0:2]) != 0 || binary.LittleEndian.Uint16(data[2:4]) != 1 {
+		return icoFrame{}, errors.New("ico: invalid header")
+	}
+
+	count := int(binary.LittleEndian.Uint16(data[4:6]))
+	if count == 0 {
+		return icoFrame{}, errors.New("ico: no images in file")
+	}
+	if len(data) < icoHeaderLen+count*icoEntryLen {
+		return icoFrame{}, errTruncatedICO
+	}
+
+	var best icoFrame
+	found := false
+	for i := range count {
+		entry := data[icoHeaderLen+i*icoEntryLen:]
+		size := int(binary.LittleEndian.Uint32(entry[8:12]))
+		offset := int(binary.LittleEndian.Uint32(entry[12:16]))
+		if offset < 0 || size <= 0 || offset > len(data) || size > len(data)-offset {
+			continue
+		}
+
+		frame, err := parseICOFrame(data[offset : offset+size])
+		if err != nil {
+			continue
+		}
+		pixels, bestPixels := frame.width*frame.height, best.width*best.height
+		if !found || pixels > bestPixels || (pixels == bestPixels && frame.bitCount > best.bitCount) {
+			best, found = frame, true
+		}
+	}
+
+	if !found {
+		return icoFrame{}, errors.New("ico: no readable images in file")
+	}
+	return best, nil
+}
+
+// parseICOFrame reads the size and bit depth of a PNG or BMP frame.
+func parseICOFrame(data []byte) (icoFrame, error) {
+	if bytes.HasPrefix(data, pngSignature) {
+		config, err := png.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return icoFrame{}, err
+		}
+		return icoFrame{data: data, width: config.Width, height: config.Height, bitCount: 32}, nil
+	}
+
+	if len(data) < dibHeaderLen {
+		return icoFrame{}, errTruncatedICO
+	}
+	// BMP frames store the height of the colour and mask bitmaps together.
+	width := int(int32(binary.LittleEndian.Uint32(data[4:8])))
+	height := abs(int(int32(binary.LittleEndian.Uint32(data[8:12])))) / 2
+	if width <= 0 || height <= 0 || width > maxDIBDimension || height > maxDIBDimension {
+		return icoFrame{}, fmt.Errorf("ico: invalid image size %dx%d", width, height)
+	}
+	bitCount := int(binary.LittleEndian.Uint16(data[14:16]))
+	return icoFrame{data: data, width: width, height: height, bitCount: bitCount}, nil
+}
+
+// decodeDIB decodes an uncompressed BMP frame (1, 4, 8, 24 or 32 bits per pixel)
+// together with its 1-bit transparency mask. 32-bit frames carry their own alpha;
+// the mask is only used when every alpha byte is zero, as in some older icons.
+func decodeDIB(data []byte) (image.Image, error) {
+	frame, err := parseICOFrame(data)
+	if err != nil {
+		return nil, err
+	}
+	width, height, bpp := frame.width, frame.height, frame.bitCount
+
+	headerLen := int(binary.LittleEndian.Uint32(data[0:4]))
+	compression := binary.LittleEndian.Uint32(data[16:20])
+	topDown := int32(binary.LittleEndian.Uint32(data[8:12])) < 0
+	if headerLen < dibHeaderLen || headerLen > len(data) {
+		return nil, errTruncatedICO
+	}
+
+	// BI_RGB, or BI_BITFIELDS with its three masks after the header (32-bit only).
+	pixelOffset := headerLen
+	switch {
+	case compression == 0:
+	case compression == 3 && bpp == 32:
+		if headerLen == dibHeaderLen {
+			pixelOffset += 12
+		}
+	default:
+		return nil, fmt.Errorf("ico: unsupported BMP compression %d", compression)
+	}
+
+	var palette []color.NRGBA
+	switch bpp {
+	case 1, 4, 8:
+		colours := int(binary.LittleEndian.Uint32(data[32:36]))
+		if colours == 0 || colours > 1<<bpp {
+			colours = 1 << bpp
+		}
+		if len(data) < pixelOffset+colours*4 {
+			return nil, errTruncatedICO
+		}
+		palette = make([]color.NRGBA, colours)
+		for i := range palette {
+			c := data[pixelOffset+i*4:]
+			palette[i] = color.NRGBA{R: c[2], G: c[1], B: c[0], A: 0xff}
+		}
+		pixelOffset += colours * 4
+	case 24, 32:
+	default:
+		return nil, fmt.Errorf("ico: unsupported bit depth %d", bpp)
+	}
+
+	rowLen := (width*bpp + 31) / 32 * 4
+	maskRowLen := (width + 31) / 32 * 4
+	maskOffset := pixelOffset + rowLen*height
+	if len(data) < maskOffset {
+		return nil, errTruncatedICO
+	}
+	// Some encoders omit the mask; the frame is then fully opaque.
+	hasMask := len(data) >= maskOffset+maskRowLen*height
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	anyAlpha := false
+	for row := range height {
+		y := height - 1 - row
+		if topDown {
+			y = row
+		}
+		pixels := data[pixelOffset+row*rowLen:]
+		for x := range width {
+			var c color.NRGBA
+			switch bpp {
+			case 32:
+				c = color.NRGBA{R: pixels[x*4+2], G: pixels[x*4+1], B: pixels[x*4], A: pixels[x*4+3]}
+				anyAlpha = anyAlpha || c.A != 0
+			case 24:
+				c = color.NRGBA{R: pixels[x*3+2], G: pixels[x*3+1], B: pixels[x*3], A: 0xff}
+			default:
+				bit := x * bpp
+				index := int(pixels[bit/8]>>(8-bpp-bit%8)) & (1<<bpp - 1)
+				if index < len(palette) {
+					c = palette[index]
+				}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	if bpp == 32 && anyAlpha {
+		return img, nil
+	}
+	for row := range height {
+		y := height - 1 - row
+		if topDown {
+			y = row
+		}
+		for x := range width {
+			c := img.NRGBAAt(x, y)
+			c.A = 0xff
+			if hasMask && data[maskOffset+row*maskRowLen+x/8]&(0x80>>(x%8)) != 0 {
+				c.A = 0
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img, nil
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
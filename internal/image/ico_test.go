@@ -0,0 +1,208 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+// solidImage returns a w x h image filled with c.
+func solidImage(w, h int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// pngFrame encodes img as a PNG ICO frame.
+func pngFrame(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// dibHeader returns a BITMAPINFOHEADER for a bottom-up ICO frame.
+func dibHeader(w, h, bpp, colours int) []byte {
+	header := make([]byte, dibHeaderLen)
+	binary.LittleEndian.PutUint32(header[0:4], dibHeaderLen)
+	binary.LittleEndian.PutUint32(header[4:8], uint32(w))
+	binary.LittleEndian.PutUint32(header[8:12], uint32(h*2)) // colour and mask bitmaps
+	binary.LittleEndian.PutUint16(header[12:14], 1)
+	binary.LittleEndian.PutUint16(header[14:16], uint16(bpp))
+	binary.LittleEndian.PutUint32(header[32:36], uint32(colours))
+	return header
+}
+
+// bmp32Frame encodes img as a 32-bit BGRA ICO frame with an all-opaque mask.
+func bmp32Frame(img *image.NRGBA) []byte {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	frame := dibHeader(w, h, 32, 0)
+	for y := h - 1; y >= 0; y-- {
+		for x := range w {
+			c := img.NRGBAAt(x, y)
+			frame = append(frame, c.B, c.G, c.R, c.A)
+		}
+	}
+	return append(frame, make([]byte, (w+31)/32*4*h)...)
+}
+
+// bmp8Frame encodes a w x h 8-bit paletted ICO frame whose left half uses palette[0]
+// and right half palette[1]. Pixels in the top row are masked out.
+func bmp8Frame(w, h int, palette [2]color.NRGBA) []byte {
+	frame := dibHeader(w, h, 8, 2)
+	for _, c := range palette {
+		frame = append(frame, c.B, c.G, c.R, 0)
+	}
+	rowLen := (w*8 + 31) / 32 * 4
+	for range h {
+		row := make([]byte, rowLen)
+		for x := w / 2; x < w; x++ {
+			row[x] = 1
+		}
+		frame = append(frame, row...)
+	}
+	maskRowLen := (w + 31) / 32 * 4
+	for row := range h {
+		mask := make([]byte, maskRowLen)
+		if row == h-1 { // Rows are bottom-up, so the last row is the top of the image.
+			for x := range w {
+				mask[x/8] |= 0x80 >> (x % 8)
+			}
+		}
+		frame = append(frame, mask...)
+	}
+	return frame
+}
+
+// buildICO assembles frames into an ICO file. The directory's size fields are set
+// to 0 (meaning 256) to check that sizes are read from the frames themselves.
+func buildICO(frames ...[]byte) []byte {
+	header := make([]byte, icoHeaderLen+len(frames)*icoEntryLen)
+	binary.LittleEndian.PutUint16(header[2:4], 1)
+	binary.LittleEndian.PutUint16(header[4:6], uint16(len(frames)))
+
+	offset := len(header)
+	var body []byte
+	for i, frame := range frames {
+		entry := header[icoHeaderLen+i*icoEntryLen:]
+		binary.LittleEndian.PutUint16(entry[4:6], 1)
+		binary.LittleEndian.PutUint32(entry[8:12], uint32(len(frame)))
+		binary.LittleEndian.PutUint32(entry[12:16], uint32(offset))
+		offset += len(frame)
+		body = append(body, frame...)
+	}
+	return append(header, body...)
+}
+
+func TestDecodeICOPicksLargestFrame(t *testing.T) {
+	red := color.NRGBA{R: 220, G: 40, B: 40, A: 255}
+	blue := color.NRGBA{R: 40, G: 80, B: 220, A: 255}
+	green := color.NRGBA{R: 40, G: 200, B: 80, A: 255}
+	ico := buildICO(
+		bmp32Frame(solidImage(16, 16, red)),
+		pngFrame(t, solidImage(48, 48, blue)),
+		bmp8Frame(32, 32, [2]color.NRGBA{green, green}),
+	)
+
+	path := filepath.Join(t.TempDir(), "app.ico")
+	if err := os.WriteFile(path, ico, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+
+	if err := ValidateImagePath(path); err != nil {
+		t.Errorf("ValidateImagePath() error = %v", err)
+	}
+	img, err := NewSmartLoader().Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(48, 48) {
+		t.Fatalf("decoded frame size = %v, want 48x48", got)
+	}
+	if got := color.NRGBAModel.Convert(img.At(10, 10)); got != blue {
+		t.Errorf("decoded frame colour = %v, want %v", got, blue)
+	}
+
+	palette, err := colour.NewKMeansExtractor().WithSeed(1).Extract(img, 1)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(palette.Colors) != 1 || colour.ToRGB(palette.Colors[0]) != colour.ToRGB(blue) {
+		t.Errorf("Extract() = %v, want the icon's blue", palette.Colors)
+	}
+}
+
+func TestDecodeICO32BitFrame(t *testing.T) {
+	top := color.NRGBA{R: 200, G: 100, B: 50, A: 255}
+	bottom := color.NRGBA{R: 10, G: 120, B: 240, A: 128}
+	img := solidImage(64, 64, top)
+	for y := 32; y < 64; y++ {
+		for x := range 64 {
+			img.SetNRGBA(x, y, bottom)
+		}
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(buildICO(bmp32Frame(img))))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	// Rows are stored bottom-up, so orientation and alpha both survive the round trip.
+	if got := color.NRGBAModel.Convert(decoded.At(0, 0)); got != top {
+		t.Errorf("top pixel = %v, want %v", got, top)
+	}
+	if got := color.NRGBAModel.Convert(decoded.At(0, 63)); got != bottom {
+		t.Errorf("bottom pixel = %v, want %v", got, bottom)
+	}
+}
+
+func TestDecodeICOPalettedFrameWithMask(t *testing.T) {
+	left := color.NRGBA{R: 255, G: 200, A: 255}
+	right := color.NRGBA{G: 60, B: 120, A: 255}
+
+	decoded, format, err := image.Decode(bytes.NewReader(buildICO(bmp8Frame(16, 8, [2]color.NRGBA{left, right}))))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if format != "ico" {
+		t.Errorf("format = %q, want ico", format)
+	}
+
+	if got := color.NRGBAModel.Convert(decoded.At(2, 5)); got != left {
+		t.Errorf("left pixel = %v, want %v", got, left)
+	}
+	if got := color.NRGBAModel.Convert(decoded.At(12, 5)); got != right {
+		t.Errorf("right pixel = %v, want %v", got, right)
+	}
+	if _, _, _, a := decoded.At(12, 0).RGBA(); a != 0 {
+		t.Errorf("masked top row pixel should be transparent, got alpha %d", a)
+	}
+}
+
+func TestDecodeICOInvalid(t *testing.T) {
+	valid := buildICO(bmp32Frame(solidImage(8, 8, color.NRGBA{R: 1, A: 255})))
+	tests := map[string][]byte{
+		"empty directory": buildICO(),
+		"truncated frame": valid[:len(valid)-200],
+		"bad header":      append([]byte{0, 0, 2, 0}, valid[4:]...),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decodeICO(bytes.NewReader(data)); err == nil {
+				t.Error("decodeICO() should return an error")
+			}
+		})
+	}
+}
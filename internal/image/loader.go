@@ -37,7 +37,7 @@ func NewFileLoader() *FileLoader {
 }
 
 // Load loads an image from a file path.
-// Supported formats: JPEG, PNG, GIF, WebP.
+// Supported formats: JPEG, PNG, GIF, WebP and ICO (largest embedded image).
 func (l *FileLoader) Load(path string) (image.Image, error) {
 	// Validate path.
 	if path == "" {
@@ -75,16 +75,22 @@ func (l *FileLoader) Load(path string) (image.Image, error) {
 }
 
 // ValidateImagePath checks if the given path is valid and points to a supported image file or directory.
-// Supports both local file paths, directories, and HTTP(S) URLs.
+// Supports local file paths, directories, HTTP(S) URLs and data URIs.
 // For local files, it verifies the file exists and can be decoded.
 // For directories, it verifies the directory exists (actual scanning happens later).
 // For HTTP(S) URLs, it just validates the URL format (actual fetching happens later).
+// For data URIs, it verifies the payload holds a supported image.
 func ValidateImagePath(path string) error {
 	// Check if path is empty.
 	if path == "" {
 		return fmt.Errorf("image path cannot be empty")
 	}
 
+	// Inline images are checked without touching the filesystem.
+	if IsDataURI(path) {
+		return validateDataURI(path)
+	}
+
 	// Check if it's an HTTP(S) URL.
 	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
 		// URL validation - just ensure it looks like a valid URL.
@@ -127,7 +133,7 @@ func ValidateImagePath(path string) error {
 
 // SupportedImageExtensions returns a list of supported image file extensions.
 func SupportedImageExtensions() []string {
-	return []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+	return []string{".jpg", ".jpeg", ".png", ".gif", ".webp", ".ico"}
 }
 
 // isImageFile checks if a file has a supported image extension.
@@ -136,6 +142,30 @@ func isImageFile(path string) bool {
 	return slices.Contains(SupportedImageExtensions(), ext)
 }
 
+// IsImagePath reports whether path names an image: a local file or URL with a
+// supported extension, a local file whose contents decode as an image, or an
+// image data URI.
+func IsImagePath(path string) bool {
+	if IsDataURI(path) {
+		return validateDataURI(path) == nil
+	}
+	if isImageFile(path) {
+		return true
+	}
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return false
+	}
+
+	file, err := os.Open(path) // #nosec G304 - User-specified image path, intended to be read
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	_, _, err = image.DecodeConfig(file)
+	return err == nil
+}
+
 // ScanDirectoryForImages scans a directory and returns all valid image files.
 // It does not recurse into subdirectories, but follows symlinks.
 func ScanDirectoryForImages(dirPath string) ([]string, error) {
@@ -199,10 +229,10 @@ func SelectRandomImage(imagePaths []string) (string, error) {
 // ResolveImagePath resolves a path that could be a file or directory.
 // If the path is a directory, it scans for images and returns a random one.
 // If the path is a file, it returns the path as-is.
-// For HTTP(S) URLs, it returns the URL as-is.
+// For HTTP(S) URLs and data URIs, it returns the URL as-is.
 func ResolveImagePath(path string) (string, error) {
 	// URLs are returned as-is.
-	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") || IsDataURI(path) {
 		return path, nil
 	}
 
@@ -247,7 +277,7 @@ func GetImageDimensions(path string) (width, height int, err error) {
 	return config.Width, config.Height, nil
 }
 
-// SmartLoader loads images from both local files and HTTP(S) URLs.
+// SmartLoader loads images from local files, HTTP(S) URLs and data URIs.
 type SmartLoader struct {
 	fileLoader *FileLoader
 }
@@ -259,8 +289,13 @@ func NewSmartLoader() *SmartLoader {
 	}
 }
 
-// Load loads an image from either a local file path or HTTP(S) URL.
+// Load loads an image from a local file path, an HTTP(S) URL or a data URI.
 func (l *SmartLoader) Load(path string) (image.Image, error) {
+	// Inline image, e.g. data:image/png;base64,...
+	if IsDataURI(path) {
+		return DecodeDataURI(path)
+	}
+
 	// Check if it's an HTTP(S) URL.
 	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
 		return l.loadFromURL(path)
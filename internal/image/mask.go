@@ -0,0 +1,40 @@
+package image
+
+import (
+	"image"
+	"image/color"
+)
+
+// MaskThreshold is the grey level (0-255) below which a mask pixel excludes the
+// source pixel beneath it.
+const MaskThreshold = 128
+
+// ApplyMask returns a copy of img in which every pixel under a dark mask pixel is
+// fully transparent, so colour extraction ignores it. The mask is stretched to the
+// size of img, and mask pixels darker than MaskThreshold (including transparent
+// ones) exclude the pixel beneath them.
+func ApplyMask(img, mask image.Image) image.Image {
+	if img == nil || mask == nil {
+		return img
+	}
+
+	bounds := img.Bounds()
+	maskBounds := mask.Bounds()
+	out := image.NewNRGBA(bounds)
+	if bounds.Empty() || maskBounds.Empty() {
+		return out
+	}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		my := maskBounds.Min.Y + (y-bounds.Min.Y)*maskBounds.Dy()/bounds.Dy()
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			mx := maskBounds.Min.X + (x-bounds.Min.X)*maskBounds.Dx()/bounds.Dx()
+			if gray, _ := color.GrayModel.Convert(mask.At(mx, my)).(color.Gray); gray.Y < MaskThreshold {
+				continue // Left fully transparent.
+			}
+			out.Set(x, y, img.At(x, y))
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,58 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestApplyMask(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	img := newSplitImage(100, 50, red, blue)
+
+	// A smaller mask is stretched: black on the left excludes the red half.
+	mask := newSplitImage(10, 5, color.Black, color.White)
+
+	masked := ApplyMask(img, mask)
+	if masked.Bounds() != img.Bounds() {
+		t.Fatalf("Bounds() = %v, want %v", masked.Bounds(), img.Bounds())
+	}
+
+	for _, pt := range []image.Point{{0, 0}, {49, 25}, {10, 49}} {
+		if _, _, _, a := masked.At(pt.X, pt.Y).RGBA(); a != 0 {
+			t.Errorf("pixel %v should be excluded, got %v", pt, masked.At(pt.X, pt.Y))
+		}
+	}
+	for _, pt := range []image.Point{{50, 0}, {99, 49}} {
+		if got := color.NRGBAModel.Convert(masked.At(pt.X, pt.Y)); got != blue {
+			t.Errorf("pixel %v = %v, want %v", pt, got, blue)
+		}
+	}
+}
+
+func TestApplyMaskThreshold(t *testing.T) {
+	img := newSplitImage(4, 4, color.White, color.White)
+
+	tests := []struct {
+		name     string
+		mask     color.Color
+		excluded bool
+	}{
+		{"black", color.Black, true},
+		{"dark grey", color.Gray{Y: MaskThreshold - 1}, true},
+		{"mid grey", color.Gray{Y: MaskThreshold}, false},
+		{"white", color.White, false},
+		{"transparent", color.Transparent, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mask := newSplitImage(4, 4, tt.mask, tt.mask)
+			_, _, _, a := ApplyMask(img, mask).At(1, 1).RGBA()
+			if got := a == 0; got != tt.excluded {
+				t.Errorf("excluded = %v, want %v", got, tt.excluded)
+			}
+		})
+	}
+}
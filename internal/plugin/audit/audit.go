@@ -0,0 +1,121 @@
+// Package audit provides an append-only log of external plugin executions.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry records a single external plugin execution.
+type Entry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Plugin     string    `json:"plugin"`
+	Path       string    `json:"path"`
+	Protocol   string    `json:"protocol"`
+	Operation  string    `json:"operation"`
+	ArgsHash   string    `json:"args_hash"`
+	ExitStatus int       `json:"exit_status"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// Log appends entries to a JSON Lines audit file.
+// A nil *Log is valid and discards all entries.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// New creates an audit log writing to path.
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// DefaultPath returns the default audit log location.
+// Uses $XDG_DATA_HOME/tinct/audit.jsonl, falling back to ~/.local/share/tinct/audit.jsonl.
+func DefaultPath() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "tinct", "audit.jsonl"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".local", "share", "tinct", "audit.jsonl"), nil
+}
+
+// Path returns the audit log file path.
+func (l *Log) Path() string {
+	if l == nil {
+		return ""
+	}
+	return l.path
+}
+
+// Record appends an entry to the audit log.
+func (l *Log) Record(entry Entry) error {
+	if l == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// HashArgs returns a SHA-256 hash of the JSON encoding of args.
+// Arguments are hashed rather than stored so secrets passed to plugins are not logged.
+func HashArgs(args any) string {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ExitStatus derives a process exit status from an execution error.
+// Returns 0 for success, the process exit code for exit errors, and -1 otherwise.
+func ExitStatus(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	return -1
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func readEntries(t *testing.T, path string) []Entry {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestRecordAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "audit.jsonl")
+	log := New(path)
+
+	for i := range 3 {
+		err := log.Record(Entry{
+			Timestamp:  time.Now(),
+			Plugin:     "example",
+			Path:       "/plugins/example",
+			Protocol:   "json-stdio",
+			Operation:  "output",
+			ArgsHash:   HashArgs(map[string]any{"run": i}),
+			ExitStatus: 0,
+			DurationMs: 12,
+		})
+		if err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	entries := readEntries(t, path)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].ArgsHash == entries[1].ArgsHash {
+		t.Error("different args should produce different hashes")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("audit log permissions = %o, want 600", info.Mode().Perm())
+	}
+}
+
+func TestNilLogDiscards(t *testing.T) {
+	var log *Log
+	if err := log.Record(Entry{Plugin: "x"}); err != nil {
+		t.Errorf("nil log Record() error = %v", err)
+	}
+	if log.Path() != "" {
+		t.Error("nil log should have empty path")
+	}
+}
+
+func TestHashArgs(t *testing.T) {
+	a := HashArgs(map[string]any{"key": "secret"})
+	b := HashArgs(map[string]any{"key": "secret"})
+	if a != b {
+		t.Error("hash should be deterministic")
+	}
+	if len(a) != 64 {
+		t.Errorf("expected sha256 hex digest, got %q", a)
+	}
+}
+
+func TestExitStatus(t *testing.T) {
+	if got := ExitStatus(nil); got != 0 {
+		t.Errorf("ExitStatus(nil) = %d, want 0", got)
+	}
+	if got := ExitStatus(errors.New("boom")); got != -1 {
+		t.Errorf("ExitStatus(generic) = %d, want -1", got)
+	}
+
+	err := exec.Command("sh", "-c", "exit 3").Run()
+	if got := ExitStatus(err); got != 3 {
+		t.Errorf("ExitStatus(exit 3) = %d, want 3", got)
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join("/data", "tinct", "audit.jsonl") {
+		t.Errorf("DefaultPath() = %s", path)
+	}
+
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("HOME", "/home/test")
+	path, err = DefaultPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join("/home/test", ".local", "share", "tinct", "audit.jsonl") {
+		t.Errorf("DefaultPath() = %s", path)
+	}
+}
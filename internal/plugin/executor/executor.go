@@ -13,6 +13,7 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	goplug "github.com/hashicorp/go-plugin"
 
 	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/audit"
 	"github.com/jmylchreest/tinct/internal/plugin/input"
 	"github.com/jmylchreest/tinct/internal/plugin/protocol"
 	"github.com/jmylchreest/tinct/pkg/plugin"
@@ -34,6 +36,8 @@ type PluginExecutor struct {
 	verbose           bool
 	lastWallpaperPath string        // Stores wallpaper path from JSON stdio plugins
 	processRunner     ProcessRunner // Abstraction for running external processes
+	auditLog          *audit.Log    // Optional audit log for executions (nil = disabled)
+	pluginName        string        // Plugin name recorded in audit entries
 }
 
 // NewWithVerbose creates a new PluginExecutor with verbose logging control.
@@ -63,8 +67,17 @@ func NewWithVerboseAndRunner(pluginPath string, verbose bool, runner ProcessRunn
 	return executor, nil
 }
 
+// SetAuditLog enables audit logging of plugin executions under the given plugin name.
+// Passing a nil log disables auditing.
+func (e *PluginExecutor) SetAuditLog(log *audit.Log, pluginName string) {
+	e.auditLog = log
+	e.pluginName = pluginName
+}
+
 // ExecuteInput runs an input plugin and returns colors.
-func (e *PluginExecutor) ExecuteInput(ctx context.Context, opts plugin.InputOptions) ([]color.Color, error) {
+func (e *PluginExecutor) ExecuteInput(ctx context.Context, opts plugin.InputOptions) (colors []color.Color, err error) {
+	defer e.recordAudit("input", opts.PluginArgs, time.Now(), &err, nil)
+
 	switch e.protocolType {
 	case protocol.PluginTypeGoPlugin:
 		return e.executeInputGoPlugin(ctx, opts)
@@ -76,7 +89,9 @@ func (e *PluginExecutor) ExecuteInput(ctx context.Context, opts plugin.InputOpti
 }
 
 // ExecuteOutput runs an output plugin and returns generated files.
-func (e *PluginExecutor) ExecuteOutput(ctx context.Context, palette plugin.PaletteData) (map[string][]byte, error) {
+func (e *PluginExecutor) ExecuteOutput(ctx context.Context, palette plugin.PaletteData) (files map[string][]byte, err error) {
+	defer e.recordAudit("output", palette.PluginArgs, time.Now(), &err, nil)
+
 	switch e.protocolType {
 	case protocol.PluginTypeGoPlugin:
 		return e.executeOutputGoPlugin(ctx, palette)
@@ -89,6 +104,8 @@ func (e *PluginExecutor) ExecuteOutput(ctx context.Context, palette plugin.Palet
 
 // PreExecute runs the output plugin's pre-execution hook.
 func (e *PluginExecutor) PreExecute(ctx context.Context) (skip bool, reason string, err error) {
+	defer e.recordAudit("pre-execute", []string{"--pre-execute"}, time.Now(), &err, &skip)
+
 	switch e.protocolType {
 	case protocol.PluginTypeGoPlugin:
 		return e.preExecuteGoPlugin(ctx)
@@ -100,7 +117,9 @@ func (e *PluginExecutor) PreExecute(ctx context.Context) (skip bool, reason stri
 }
 
 // PostExecute runs the output plugin's post-execution hook.
-func (e *PluginExecutor) PostExecute(ctx context.Context, writtenFiles []string) error {
+func (e *PluginExecutor) PostExecute(ctx context.Context, writtenFiles []string) (err error) {
+	defer e.recordAudit("post-execute", writtenFiles, time.Now(), &err, nil)
+
 	switch e.protocolType {
 	case protocol.PluginTypeGoPlugin:
 		return e.postExecuteGoPlugin(ctx, writtenFiles)
@@ -111,6 +130,43 @@ func (e *PluginExecutor) PostExecute(ctx context.Context, writtenFiles []string)
 	}
 }
 
+// recordAudit appends an audit entry for an execution if auditing is enabled.
+// A JSON-stdio pre-execute skip is recorded with exit status 1, matching the protocol.
+func (e *PluginExecutor) recordAudit(operation string, args any, start time.Time, errp *error, skipped *bool) {
+	if e.auditLog == nil {
+		return
+	}
+
+	var err error
+	if errp != nil {
+		err = *errp
+	}
+
+	entry := audit.Entry{
+		Timestamp:  start.UTC(),
+		Plugin:     e.pluginName,
+		Path:       e.path,
+		Protocol:   string(e.protocolType),
+		Operation:  operation,
+		ArgsHash:   audit.HashArgs(args),
+		ExitStatus: audit.ExitStatus(err),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if entry.Plugin == "" {
+		entry.Plugin = filepath.Base(e.path)
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if skipped != nil && *skipped && err == nil && e.protocolType == protocol.PluginTypeJSON {
+		entry.ExitStatus = 1
+	}
+
+	if recordErr := e.auditLog.Record(entry); recordErr != nil && e.verbose {
+		fmt.Fprintf(os.Stderr, "   Warning: failed to write audit log: %v\n", recordErr)
+	}
+}
+
 // Close cleans up any resources held by the executor.
 func (e *PluginExecutor) Close() {
 	if e.client != nil {
@@ -158,15 +214,50 @@ func (e *PluginExecutor) GetWallpaperPath() string {
 	}
 }
 
-// --- Go-Plugin RPC implementations ---
+// VerifyType checks that the plugin serves the RPC interface for pluginType
+// ("input" or "output"), so a plugin whose --plugin-info type contradicts what it
+// implements is caught before it is used. JSON stdio plugins have no RPC interface
+// and always pass.
+func (e *PluginExecutor) VerifyType(pluginType string) error {
+	if e.protocolType != protocol.PluginTypeGoPlugin {
+		return nil
+	}
+
+	var other string
+	switch pluginType {
+	case "input":
+		other = "output"
+	case "output":
+		other = "input"
+	default:
+		return fmt.Errorf("unknown plugin type: %s", pluginType)
+	}
 
-func (e *PluginExecutor) getInputRPCClient(_ context.Context) (*plugin.InputPluginRPCClient, error) {
-	if e.rpcClient != nil {
-		if client, ok := e.rpcClient.(*plugin.InputPluginRPCClient); ok {
-			return client, nil
-		}
+	client := e.newGoPluginClient(map[string]goplug.Plugin{
+		"input":  &plugin.InputPluginRPC{},
+		"output": &plugin.OutputPluginRPC{},
+	})
+	defer client.Kill()
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		return fmt.Errorf("failed to get RPC client: %w", err)
 	}
 
+	if _, err := rpcClient.Dispense(pluginType); err == nil {
+		return nil
+	}
+	if _, err := rpcClient.Dispense(other); err == nil {
+		return fmt.Errorf("plugin declares type %s but serves the %s plugin interface", pluginType, other)
+	}
+	return fmt.Errorf("plugin declares type %s but serves neither the input nor the output plugin interface", pluginType)
+}
+
+// --- Go-Plugin RPC implementations ---
+
+// newGoPluginClient creates a go-plugin client for the plugin serving the given
+// plugin interfaces, logging only in verbose mode.
+func (e *PluginExecutor) newGoPluginClient(plugins map[string]goplug.Plugin) *goplug.Client {
 	// Configure logger based on verbose flag.
 	var logger hclog.Logger
 	if e.verbose {
@@ -183,17 +274,27 @@ func (e *PluginExecutor) getInputRPCClient(_ context.Context) (*plugin.InputPlug
 		})
 	}
 
-	// Initialize go-plugin client.
-	e.client = goplug.NewClient(&goplug.ClientConfig{
-		HandshakeConfig: protocol.Handshake,
-		Plugins: map[string]goplug.Plugin{
-			"input": &plugin.InputPluginRPC{},
-		},
+	return goplug.NewClient(&goplug.ClientConfig{
+		HandshakeConfig:  protocol.Handshake,
+		Plugins:          plugins,
 		Cmd:              exec.Command(e.path), //nolint:gosec // G204: Plugin path validated during installation and locked in plugin.lock
 		AllowedProtocols: []goplug.Protocol{goplug.ProtocolNetRPC},
 		Logger:           logger,
 		SyncStderr:       os.Stderr, // Forward plugin stderr to parent
 	})
+}
+
+func (e *PluginExecutor) getInputRPCClient(_ context.Context) (*plugin.InputPluginRPCClient, error) {
+	if e.rpcClient != nil {
+		if client, ok := e.rpcClient.(*plugin.InputPluginRPCClient); ok {
+			return client, nil
+		}
+	}
+
+	// Initialize go-plugin client.
+	e.client = e.newGoPluginClient(map[string]goplug.Plugin{
+		"input": &plugin.InputPluginRPC{},
+	})
 
 	// Connect via RPC.
 	rpcClient, err := e.client.Client()
@@ -225,32 +326,9 @@ func (e *PluginExecutor) getOutputRPCClient(_ context.Context) (*plugin.OutputPl
 		}
 	}
 
-	// Configure logger based on verbose flag.
-	var logger hclog.Logger
-	if e.verbose {
-		logger = hclog.New(&hclog.LoggerOptions{
-			Name:   "plugin",
-			Output: log.Writer(),
-			Level:  hclog.Debug,
-		})
-	} else {
-		logger = hclog.New(&hclog.LoggerOptions{
-			Name:   "plugin",
-			Output: io.Discard,
-			Level:  hclog.Off,
-		})
-	}
-
 	// Initialize go-plugin client.
-	e.client = goplug.NewClient(&goplug.ClientConfig{
-		HandshakeConfig: protocol.Handshake,
-		Plugins: map[string]goplug.Plugin{
-			"output": &plugin.OutputPluginRPC{},
-		},
-		Cmd:              exec.Command(e.path), //nolint:gosec // G204: Plugin path validated during installation and locked in plugin.lock
-		AllowedProtocols: []goplug.Protocol{goplug.ProtocolNetRPC},
-		Logger:           logger,
-		SyncStderr:       os.Stderr, // Forward plugin stderr to parent
+	e.client = e.newGoPluginClient(map[string]goplug.Plugin{
+		"output": &plugin.OutputPluginRPC{},
 	})
 
 	// Connect via RPC.
@@ -391,7 +469,7 @@ func (e *PluginExecutor) executeInputJSON(ctx context.Context, opts plugin.Input
 				})
 			}
 		} else {
-			for _, cc := range categorised.Colours {
+			for _, cc := range categorised.OrderedRoles() {
 				colors = append(colors, color.RGBA{
 					R: cc.RGB.R,
 					G: cc.RGB.G,
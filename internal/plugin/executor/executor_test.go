@@ -4,12 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"image/color"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	goplug "github.com/hashicorp/go-plugin"
+
+	"github.com/jmylchreest/tinct/internal/plugin/audit"
 	"github.com/jmylchreest/tinct/internal/plugin/protocol"
 	"github.com/jmylchreest/tinct/pkg/plugin"
 )
@@ -362,3 +367,230 @@ func copyTestScript(t *testing.T, scriptName string) string {
 
 	return pluginPath
 }
+
+// TestAuditLogEntryPerExecution tests that each plugin execution writes an audit entry.
+func TestAuditLogEntryPerExecution(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog := audit.New(logPath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Successful input execution.
+	inputExec, err := NewWithVerbose(copyTestScript(t, "input-with-colors.sh"), false)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	defer inputExec.Close()
+	inputExec.SetAuditLog(auditLog, "colors")
+
+	if _, err := inputExec.ExecuteInput(ctx, plugin.InputOptions{PluginArgs: map[string]any{"token": "secret"}}); err != nil {
+		t.Fatalf("ExecuteInput() error = %v", err)
+	}
+
+	// Failing input execution.
+	errorExec, err := NewWithVerbose(copyTestScript(t, "input-error.sh"), false)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	defer errorExec.Close()
+	errorExec.SetAuditLog(auditLog, "broken")
+
+	if _, err := errorExec.ExecuteInput(ctx, plugin.InputOptions{}); err == nil {
+		t.Fatal("expected error from failing plugin")
+	}
+
+	// Pre-execute skip.
+	skipExec, err := NewWithVerbose(copyTestScript(t, "output-preexecute-skip.sh"), false)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	defer skipExec.Close()
+	skipExec.SetAuditLog(auditLog, "")
+
+	if skip, _, err := skipExec.PreExecute(ctx); err != nil || !skip {
+		t.Fatalf("PreExecute() = %v, %v; want skip", skip, err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 audit entries, got %d:\n%s", len(lines), data)
+	}
+
+	entries := make([]audit.Entry, len(lines))
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &entries[i]); err != nil {
+			t.Fatalf("invalid audit entry %q: %v", line, err)
+		}
+	}
+
+	first := entries[0]
+	if first.Plugin != "colors" || first.Operation != "input" || first.Protocol != string(protocol.PluginTypeJSON) {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+	if first.Path != inputExec.path {
+		t.Errorf("expected path %s, got %s", inputExec.path, first.Path)
+	}
+	if first.ExitStatus != 0 || first.Error != "" {
+		t.Errorf("expected success entry, got exit %d error %q", first.ExitStatus, first.Error)
+	}
+	if first.ArgsHash != audit.HashArgs(map[string]any{"token": "secret"}) {
+		t.Error("args hash does not match plugin args")
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Error("audit log must not contain raw plugin args")
+	}
+	if first.Timestamp.IsZero() || first.DurationMs < 0 {
+		t.Errorf("expected timestamp and duration, got %+v", first)
+	}
+
+	if entries[1].Plugin != "broken" || entries[1].ExitStatus != 1 || entries[1].Error == "" {
+		t.Errorf("expected failing entry with exit status 1, got %+v", entries[1])
+	}
+
+	if entries[2].Plugin != "output-preexecute-skip.sh" || entries[2].Operation != "pre-execute" || entries[2].ExitStatus != 1 {
+		t.Errorf("expected pre-execute skip entry, got %+v", entries[2])
+	}
+}
+
+// TestNoAuditLogByDefault tests that executions are not audited unless enabled.
+func TestNoAuditLogByDefault(t *testing.T) {
+	executor, err := NewWithVerbose(copyTestScript(t, "input-with-colors.sh"), false)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	defer executor.Close()
+
+	if executor.auditLog != nil {
+		t.Error("audit log should be disabled by default")
+	}
+}
+
+// Environment variables that turn the test binary into a mock go-plugin plugin:
+// it declares mockPluginTypeEnv in --plugin-info and serves the RPC interface in
+// mockPluginServesEnv.
+const (
+	mockPluginTypeEnv   = "TINCT_TEST_MOCK_PLUGIN_TYPE"
+	mockPluginServesEnv = "TINCT_TEST_MOCK_PLUGIN_SERVES"
+)
+
+func TestMain(m *testing.M) {
+	if serves := os.Getenv(mockPluginServesEnv); serves != "" {
+		serveMockGoPlugin(os.Getenv(mockPluginTypeEnv), serves)
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// serveMockGoPlugin answers --plugin-info with the declared type, or serves the
+// given RPC interface over go-plugin.
+func serveMockGoPlugin(declared, serves string) {
+	if slices.Contains(os.Args[1:], "--plugin-info") {
+		_ = json.NewEncoder(os.Stdout).Encode(plugin.PluginInfo{
+			Name:            "mock",
+			Type:            declared,
+			Version:         "1.0.0",
+			ProtocolVersion: protocol.ProtocolVersion,
+			PluginProtocol:  "go-plugin",
+		})
+		return
+	}
+
+	plugins := map[string]goplug.Plugin{}
+	switch serves {
+	case "input":
+		plugins["input"] = &plugin.InputPluginRPC{Impl: &mockInputPlugin{}}
+	case "output":
+		plugins["output"] = &plugin.OutputPluginRPC{Impl: &mockOutputPlugin{}}
+	}
+	goplug.Serve(&goplug.ServeConfig{
+		HandshakeConfig: protocol.Handshake,
+		Plugins:         plugins,
+	})
+}
+
+// mockInputPlugin is a minimal go-plugin input plugin.
+type mockInputPlugin struct{}
+
+func (p *mockInputPlugin) Generate(context.Context, plugin.InputOptions) ([]color.Color, error) {
+	return []color.Color{color.Black}, nil
+}
+func (p *mockInputPlugin) GetMetadata() plugin.PluginInfo { return plugin.PluginInfo{Name: "mock"} }
+func (p *mockInputPlugin) WallpaperPath() string          { return "" }
+func (p *mockInputPlugin) GetFlagHelp() []plugin.FlagHelp { return nil }
+
+// mockOutputPlugin is a minimal go-plugin output plugin.
+type mockOutputPlugin struct{}
+
+func (p *mockOutputPlugin) Generate(context.Context, plugin.PaletteData) (map[string][]byte, error) {
+	return nil, nil
+}
+func (p *mockOutputPlugin) PreExecute(context.Context) (bool, string, error) { return false, "", nil }
+func (p *mockOutputPlugin) PostExecute(context.Context, []string) error      { return nil }
+func (p *mockOutputPlugin) GetMetadata() plugin.PluginInfo                   { return plugin.PluginInfo{Name: "mock"} }
+func (p *mockOutputPlugin) GetFlagHelp() []plugin.FlagHelp                   { return nil }
+
+// TestVerifyType tests that a go-plugin plugin must serve the interface its
+// --plugin-info type declares.
+func TestVerifyType(t *testing.T) {
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatalf("Failed to find test binary: %v", err)
+	}
+
+	tests := []struct {
+		declared string
+		serves   string
+		wantErr  string
+	}{
+		{declared: "input", serves: "input"},
+		{declared: "output", serves: "output"},
+		{declared: "output", serves: "input", wantErr: "declares type output but serves the input plugin interface"},
+		{declared: "input", serves: "output", wantErr: "declares type input but serves the output plugin interface"},
+		{declared: "input", serves: "none", wantErr: "serves neither"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.declared+"/"+tt.serves, func(t *testing.T) {
+			t.Setenv(mockPluginTypeEnv, tt.declared)
+			t.Setenv(mockPluginServesEnv, tt.serves)
+
+			executor, err := NewWithVerbose(self, false)
+			if err != nil {
+				t.Fatalf("Failed to create executor: %v", err)
+			}
+			defer executor.Close()
+			if executor.protocolType != protocol.PluginTypeGoPlugin {
+				t.Fatalf("Expected protocol type go-plugin, got %s", executor.protocolType)
+			}
+
+			err = executor.VerifyType(tt.declared)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("VerifyType() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("VerifyType() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestVerifyTypeJSON tests that JSON stdio plugins are not probed.
+func TestVerifyTypeJSON(t *testing.T) {
+	executor, err := NewWithVerbose(copyTestScript(t, "basic-input.sh"), false)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	defer executor.Close()
+
+	if err := executor.VerifyType("input"); err != nil {
+		t.Errorf("VerifyType() error = %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+// Package clipboard provides an input plugin that extracts a colour palette from an
+// image on the clipboard, such as a screenshot or a picture copied from a browser.
+package clipboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/image"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/input/shared/seed"
+)
+
+// DefaultCount is the default number of colours to extract.
+const DefaultCount = 16
+
+// clipboardTool is a command that writes the clipboard's PNG image to stdout.
+type clipboardTool struct {
+	name    string
+	args    []string
+	display string // Environment variable set when the tool's display server is running
+}
+
+// clipboardTools lists the supported clipboard tools in order of preference.
+var clipboardTools = []clipboardTool{
+	{name: "wl-paste", args: []string{"--type", "image/png"}, display: "WAYLAND_DISPLAY"},
+	{name: "xclip", args: []string{"-selection", "clipboard", "-t", "image/png", "-o"}, display: "DISPLAY"},
+}
+
+// seedModes are the --clipboard.seed-mode values. Path-based modes are left out
+// because a clipboard image has no path.
+var seedModes = []seed.Mode{seed.ModeContent, seed.ModeManual, seed.ModeRandom}
+
+// Plugin implements the input.Plugin interface for clipboard image extraction.
+type Plugin struct {
+	count     int
+	seedMode  string // Seed mode: "content", "manual", "random"
+	seedValue int64  // Seed value (only used when seedMode is "manual")
+}
+
+// New creates a new clipboard input plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		count:     DefaultCount,
+		seedMode:  string(seed.ModeContent),
+		seedValue: 0,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "clipboard"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Extract colours from an image on the clipboard (wl-paste or xclip)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&p.count, "clipboard.count", DefaultCount, "Number of colours to extract (1-256)")
+	cmd.Flags().StringVar(&p.seedMode, "clipboard.seed-mode", string(seed.ModeContent), "K-means seed mode: content, manual, random")
+	cmd.Flags().Int64Var(&p.seedValue, "clipboard.seed-value", 0, "K-means seed value (only used with --clipboard.seed-mode=manual)")
+}
+
+// Validate checks if the plugin has all required inputs configured and a
+// clipboard tool is available.
+func (p *Plugin) Validate() error {
+	if p.count < 1 || p.count > 256 {
+		return fmt.Errorf("--clipboard.count must be between 1 and 256, got %d", p.count)
+	}
+
+	mode, err := seed.ParseMode(p.seedMode)
+	if err != nil || !slices.Contains(seedModes, mode) {
+		return fmt.Errorf("invalid seed mode '%s' (valid: content, manual, random)", p.seedMode)
+	}
+
+	if _, err := detectTool(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "clipboard.count", Type: "int", Default: "16", Description: "Number of colours to extract (1-256)", Required: false},
+		{Name: "clipboard.seed-mode", Type: "string", Default: "content", Description: "K-means seed mode: content, manual, random", Required: false},
+		{Name: "clipboard.seed-value", Type: "int64", Default: "0", Description: "K-means seed value (only used with --clipboard.seed-mode=manual)", Required: false},
+	}
+}
+
+// Generate reads the clipboard image and extracts colours from it with k-means.
+// Returns only the extracted colors - categorization happens separately.
+func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*colour.Palette, error) {
+	tool, err := detectTool()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Verbose {
+		fmt.Printf("→ Reading clipboard image with %s\n", tool.name)
+	}
+
+	path, err := pasteImage(ctx, tool)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(path)
+
+	// Load the image using SmartLoader, as the image plugin does.
+	loader := image.NewSmartLoader()
+	img, err := loader.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load clipboard image: %w", err)
+	}
+
+	if opts.Verbose {
+		fmt.Printf("→ Clipboard image: %dx%d\n", img.Bounds().Dx(), img.Bounds().Dy())
+	}
+
+	seedMode, err := seed.ParseMode(p.seedMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed mode: %w", err)
+	}
+
+	seedConfig := seed.Config{
+		Mode:  seedMode,
+		Value: nil,
+	}
+	if seedMode == seed.ModeManual {
+		seedConfig.Value = &p.seedValue
+	}
+
+	calculatedSeed, err := seed.Calculate(img, path, seedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate seed: %w", err)
+	}
+
+	// Extract palette using k-means with deterministic seed.
+	extractorOpts := colour.ExtractorOptions{}
+	if seedMode != seed.ModeRandom {
+		// Only set seed if not in random mode.
+		extractorOpts.Seed = &calculatedSeed
+	}
+
+	extractor, err := colour.NewExtractor(colour.AlgorithmKMeans, extractorOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create extractor: %w", err)
+	}
+
+	if opts.Verbose {
+		if extractorOpts.Seed != nil {
+			fmt.Printf("→ Using seed mode: %s (seed: %d)\n", p.seedMode, calculatedSeed)
+		} else {
+			fmt.Printf("→ Using seed mode: %s (non-deterministic)\n", p.seedMode)
+		}
+	}
+
+	palette, err := extractor.Extract(img, p.count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract colours: %w", err)
+	}
+	return palette, nil
+}
+
+// detectTool returns the clipboard tool to read images with. A tool whose display
+// server is running is preferred; otherwise the first installed tool is used.
+func detectTool() (clipboardTool, error) {
+	var installed []clipboardTool
+	for _, tool := range clipboardTools {
+		if _, err := exec.LookPath(tool.name); err == nil {
+			installed = append(installed, tool)
+		}
+	}
+	if len(installed) == 0 {
+		return clipboardTool{}, fmt.Errorf("no clipboard tool found: install wl-clipboard (Wayland) or xclip (X11)")
+	}
+
+	for _, tool := range installed {
+		if os.Getenv(tool.display) != "" {
+			return tool, nil
+		}
+	}
+	return installed[0], nil
+}
+
+// pasteImage writes the clipboard image to a temporary file and returns its path.
+// The caller removes the file.
+func pasteImage(ctx context.Context, tool clipboardTool) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, tool.name, tool.args...) // #nosec G204 - Command and arguments come from the fixed clipboardTools list
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := bytes.TrimSpace(stderr.Bytes()); len(msg) > 0 {
+			return "", fmt.Errorf("clipboard does not contain a PNG image (%s: %s)", tool.name, msg)
+		}
+		return "", fmt.Errorf("failed to read clipboard with %s: %w", tool.name, err)
+	}
+	if stdout.Len() == 0 {
+		return "", fmt.Errorf("clipboard does not contain a PNG image")
+	}
+
+	f, err := os.CreateTemp("", "tinct-clipboard-*.png")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err := f.Write(stdout.Bytes()); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write clipboard image: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write clipboard image: %w", err)
+	}
+	return f.Name(), nil
+}
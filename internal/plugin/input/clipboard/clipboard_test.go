@@ -0,0 +1,168 @@
+// Package clipboard provides tests for the clipboard input plugin.
+package clipboard
+
+import (
+	"context"
+	"fmt"
+	goimage "image"
+	"image/color"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+)
+
+// fakeTools puts executable scripts with the given names and bodies on an
+// otherwise empty PATH, and clears the display variables.
+func fakeTools(t *testing.T, scripts map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, body := range scripts {
+		script := "#!/bin/sh\n" + body + "\n"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil { // #nosec G306 - Test tool needs execute permission
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("DISPLAY", "")
+}
+
+// writeTestPNG writes a PNG that is 3/4 blue and 1/4 orange, returning its path.
+func writeTestPNG(t *testing.T) string {
+	t.Helper()
+	img := goimage.NewRGBA(goimage.Rect(0, 0, 40, 40))
+	for y := range 40 {
+		for x := range 40 {
+			c := color.RGBA{R: 0x1e, G: 0x3a, B: 0x8a, A: 255}
+			if x >= 30 {
+				c = color.RGBA{R: 0xf9, G: 0x73, B: 0x16, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "clip.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create image: %v", err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	return path
+}
+
+func TestDetectTool(t *testing.T) {
+	tests := []struct {
+		name    string
+		tools   []string
+		wayland string
+		x11     string
+		want    string
+	}{
+		{name: "wayland session", tools: []string{"wl-paste", "xclip"}, wayland: "wayland-1", x11: ":0", want: "wl-paste"},
+		{name: "x11 session", tools: []string{"wl-paste", "xclip"}, x11: ":0", want: "xclip"},
+		{name: "only xclip installed", tools: []string{"xclip"}, wayland: "wayland-1", want: "xclip"},
+		{name: "no session", tools: []string{"wl-paste", "xclip"}, want: "wl-paste"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scripts := make(map[string]string)
+			for _, name := range tt.tools {
+				scripts[name] = "exit 0"
+			}
+			fakeTools(t, scripts)
+			t.Setenv("WAYLAND_DISPLAY", tt.wayland)
+			t.Setenv("DISPLAY", tt.x11)
+
+			tool, err := detectTool()
+			if err != nil {
+				t.Fatalf("detectTool() error = %v", err)
+			}
+			if tool.name != tt.want {
+				t.Errorf("detectTool() = %s, want %s", tool.name, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	fakeTools(t, nil)
+	if err := New().Validate(); err == nil || !strings.Contains(err.Error(), "wl-clipboard") {
+		t.Errorf("Validate() without a clipboard tool error = %v, want a hint to install one", err)
+	}
+
+	fakeTools(t, map[string]string{"xclip": "exit 0"})
+	tests := []struct {
+		name     string
+		count    int
+		seedMode string
+		wantErr  bool
+	}{
+		{name: "defaults", count: DefaultCount, seedMode: "content"},
+		{name: "manual seed", count: 8, seedMode: "manual"},
+		{name: "count too low", count: 0, seedMode: "content", wantErr: true},
+		{name: "count too high", count: 257, seedMode: "content", wantErr: true},
+		{name: "path seed mode", count: DefaultCount, seedMode: "filepath", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New()
+			p.count = tt.count
+			p.seedMode = tt.seedMode
+			if err := p.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	cat, err := exec.LookPath("cat")
+	if err != nil {
+		t.Skip("cat not available")
+	}
+	image := writeTestPNG(t)
+	fakeTools(t, map[string]string{"wl-paste": fmt.Sprintf("%s '%s'", cat, image)})
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	p := New()
+	p.count = 2
+	palette, err := p.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(palette.Colors) != 2 {
+		t.Fatalf("Generate() returned %d colours, want 2", len(palette.Colors))
+	}
+	dominant, err := palette.Dominant()
+	if err != nil {
+		t.Fatalf("Dominant() error = %v", err)
+	}
+	if got := colour.ToRGB(dominant).Hex(); got != "#1e3a8a" {
+		t.Errorf("dominant colour = %s, want #1e3a8a", got)
+	}
+
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("temporary clipboard image was not removed: %v", entries)
+	}
+}
+
+func TestGenerateWithoutImage(t *testing.T) {
+	fakeTools(t, map[string]string{"wl-paste": "echo 'No suitable type of content copied' >&2\nexit 1"})
+
+	_, err := New().Generate(context.Background(), input.GenerateOptions{})
+	if err == nil || !strings.Contains(err.Error(), "does not contain a PNG image") {
+		t.Errorf("Generate() error = %v, want the clipboard to be reported empty", err)
+	}
+}
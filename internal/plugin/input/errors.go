@@ -0,0 +1,60 @@
+// Package input provides the interface and base types for input plugins.
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrRateLimited indicates the input source rejected a request because of a quota or
+// rate limit. Plugins wrap provider errors with it so callers can apply an ErrorPolicy.
+var ErrRateLimited = errors.New("rate limited")
+
+// OnErrorMode selects what happens when an input plugin is rate limited.
+type OnErrorMode string
+
+const (
+	// OnErrorFail returns the error (default).
+	OnErrorFail OnErrorMode = "fail"
+	// OnErrorUseCache reuses the most recently cached result, if the plugin has one.
+	OnErrorUseCache OnErrorMode = "use-cache"
+	// OnErrorFallback runs a secondary input plugin instead.
+	OnErrorFallback OnErrorMode = "fallback"
+)
+
+// ErrorPolicy describes how to recover from a rate-limited input plugin.
+type ErrorPolicy struct {
+	Mode   OnErrorMode
+	Plugin string // Secondary input plugin name (only for OnErrorFallback)
+}
+
+// ParseErrorPolicy parses an --on-error value: "fail", "use-cache" or "fallback:<plugin>".
+// An empty string is treated as "fail".
+func ParseErrorPolicy(s string) (ErrorPolicy, error) {
+	switch {
+	case s == "" || s == string(OnErrorFail):
+		return ErrorPolicy{Mode: OnErrorFail}, nil
+	case s == string(OnErrorUseCache):
+		return ErrorPolicy{Mode: OnErrorUseCache}, nil
+	case strings.HasPrefix(s, string(OnErrorFallback)+":"):
+		plugin := strings.TrimPrefix(s, string(OnErrorFallback)+":")
+		if plugin == "" {
+			return ErrorPolicy{}, fmt.Errorf("fallback requires a plugin name (e.g. fallback:file)")
+		}
+		return ErrorPolicy{Mode: OnErrorFallback, Plugin: plugin}, nil
+	default:
+		return ErrorPolicy{}, fmt.Errorf("invalid on-error policy %q (valid: fail, use-cache, fallback:<plugin>)", s)
+	}
+}
+
+// String returns the policy in --on-error syntax.
+func (p ErrorPolicy) String() string {
+	if p.Mode == OnErrorFallback {
+		return string(OnErrorFallback) + ":" + p.Plugin
+	}
+	if p.Mode == "" {
+		return string(OnErrorFail)
+	}
+	return string(p.Mode)
+}
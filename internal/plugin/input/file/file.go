@@ -7,13 +7,15 @@ import (
 	"fmt"
 	"image/color"
 	"os"
-	"strconv"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/jmylchreest/tinct/internal/colour"
 	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/input/shared/css"
 )
 
 // Plugin implements the input.Plugin interface for file-based palette loading.
@@ -46,7 +48,7 @@ func (p *Plugin) Version() string {
 
 // RegisterFlags registers plugin-specific flags with the cobra command.
 func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
-	cmd.Flags().StringVar(&p.path, "file.path", "", "Path to palette file (JSON or text, optional)")
+	cmd.Flags().StringVar(&p.path, "file.path", "", "Path to palette file (JSON, text, or CSS/SCSS stylesheet, optional)")
 	cmd.Flags().StringArrayVar(&p.colourOverrides, "colour", []string{}, "Colour override (role=hex, repeatable)")
 }
 
@@ -70,7 +72,7 @@ func (p *Plugin) Validate() error {
 // GetFlagHelp returns help information for all plugin flags.
 func (p *Plugin) GetFlagHelp() []input.FlagHelp {
 	return []input.FlagHelp{
-		{Name: "file.path", Type: "string", Default: "", Description: "Path to palette file (JSON or text, optional)", Required: false},
+		{Name: "file.path", Type: "string", Default: "", Description: "Path to palette file (JSON, text, or CSS/SCSS stylesheet, optional)", Required: false},
 		{Name: "colour", Type: "stringArray", Default: "[]", Description: "Colour override (role=hex, repeatable)", Required: false},
 	}
 }
@@ -141,6 +143,11 @@ func (p *Plugin) loadFromFile(path string) ([]color.Color, map[colour.Role]int,
 		return nil, nil, err
 	}
 
+	// Stylesheets are recognised by extension.
+	if isStylesheet(path) {
+		return p.parseStylesheet(string(data))
+	}
+
 	// Try JSON first (categorised palette format).
 	var categorised colour.CategorisedPalette
 	if err := json.Unmarshal(data, &categorised); err == nil {
@@ -148,14 +155,15 @@ func (p *Plugin) loadFromFile(path string) ([]color.Color, map[colour.Role]int,
 		colors := make([]color.Color, 0)
 		roleHints := make(map[colour.Role]int)
 
-		for role, catColor := range categorised.Colours {
-			roleHints[role] = len(colors)
-			colors = append(colors, catColor.Colour)
+		// Colour is not serialised, so rebuild each colour from its RGB.
+		for _, catColor := range categorised.OrderedRoles() {
+			roleHints[catColor.Role] = len(colors)
+			colors = append(colors, catColor.RGB.ToColor())
 		}
 
 		// Also add any colors from AllColours that aren't in roles.
 		for _, catColor := range categorised.AllColours {
-			colors = append(colors, catColor.Colour)
+			colors = append(colors, catColor.RGB.ToColor())
 		}
 
 		return colors, roleHints, nil
@@ -165,6 +173,39 @@ func (p *Plugin) loadFromFile(path string) ([]color.Color, map[colour.Role]int,
 	return p.parseTextFormat(string(data))
 }
 
+// stylesheetExtensions lists the file extensions parsed as CSS/SCSS stylesheets.
+var stylesheetExtensions = []string{".css", ".scss", ".sass", ".less"}
+
+// isStylesheet reports whether path has a stylesheet extension.
+func isStylesheet(path string) bool {
+	return slices.Contains(stylesheetExtensions, strings.ToLower(filepath.Ext(path)))
+}
+
+// parseStylesheet extracts colours from a CSS/SCSS stylesheet in document order.
+// Custom properties and variables named after a role (e.g. --background, $accent-1)
+// become role hints; all other colours are added without a role.
+func (p *Plugin) parseStylesheet(content string) ([]color.Color, map[colour.Role]int, error) {
+	parsed, err := css.Parse(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	colors := make([]color.Color, 0, len(parsed))
+	roleHints := make(map[colour.Role]int)
+	for _, c := range parsed {
+		rgb, err := colour.ParseHex(c.Hex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid colour '%s' for %s: %w", c.Hex, c.Name, err)
+		}
+		if role, err := parseColourRole(c.Name); err == nil {
+			roleHints[role] = len(colors)
+		}
+		colors = append(colors, rgb.ToColor())
+	}
+
+	return colors, roleHints, nil
+}
+
 // parseTextFormat parses a simple text format palette file.
 // Format: hex colors (one per line) or role=hex (one per line), # for comments.
 func (p *Plugin) parseTextFormat(content string) ([]color.Color, map[colour.Role]int, error) {
@@ -184,12 +225,12 @@ func (p *Plugin) parseTextFormat(content string) ([]color.Color, map[colour.Role
 		if !strings.Contains(line, "=") {
 			// Just a hex color.
 			hex := line
-			rgb, err := parseHex(hex)
+			rgb, err := colour.ParseHex(hex)
 			if err != nil {
 				return nil, nil, fmt.Errorf("line %d: invalid hex colour '%s': %w", lineNum+1, hex, err)
 			}
 
-			colors = append(colors, rgbToColor(rgb))
+			colors = append(colors, rgb.ToColor())
 			continue
 		}
 
@@ -206,11 +247,11 @@ func (p *Plugin) parseTextFormat(content string) ([]color.Color, map[colour.Role
 		lowerRole := strings.ToLower(roleName)
 		if strings.HasPrefix(lowerRole, "colour") || strings.HasPrefix(lowerRole, "color") {
 			// Indexed color - just add the hex without role hint.
-			rgb, err := parseHex(hex)
+			rgb, err := colour.ParseHex(hex)
 			if err != nil {
 				return nil, nil, fmt.Errorf("line %d: invalid hex colour '%s': %w", lineNum+1, hex, err)
 			}
-			colors = append(colors, rgbToColor(rgb))
+			colors = append(colors, rgb.ToColor())
 			continue
 		}
 
@@ -220,13 +261,13 @@ func (p *Plugin) parseTextFormat(content string) ([]color.Color, map[colour.Role
 			return nil, nil, fmt.Errorf("line %d: %w", lineNum+1, err)
 		}
 
-		rgb, err := parseHex(hex)
+		rgb, err := colour.ParseHex(hex)
 		if err != nil {
 			return nil, nil, fmt.Errorf("line %d: invalid hex colour '%s': %w", lineNum+1, hex, err)
 		}
 
 		roleHints[role] = len(colors)
-		colors = append(colors, rgbToColor(rgb))
+		colors = append(colors, rgb.ToColor())
 	}
 
 	return colors, roleHints, nil
@@ -254,13 +295,13 @@ func (p *Plugin) parseOverrides(overrides []string) ([]color.Color, map[colour.R
 		}
 
 		// Parse hex colour.
-		rgb, err := parseHex(hex)
+		rgb, err := colour.ParseHex(hex)
 		if err != nil {
 			return nil, nil, fmt.Errorf("invalid hex colour '%s': %w", hex, err)
 		}
 
 		roleHints[role] = len(colors)
-		colors = append(colors, rgbToColor(rgb))
+		colors = append(colors, rgb.ToColor())
 	}
 
 	return colors, roleHints, nil
@@ -338,47 +379,3 @@ func parseColourRole(name string) (colour.Role, error) {
 
 	return role, nil
 }
-
-// parseHex parses a hex colour string into an RGB struct.
-// Supports formats: #RRGGBB, RRGGBB, #RGB, RGB.
-func parseHex(hex string) (colour.RGB, error) {
-	// Remove # prefix if present.
-	hex = strings.TrimPrefix(hex, "#")
-
-	// Expand shorthand format (RGB -> RRGGBB).
-	if len(hex) == 3 {
-		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
-	}
-
-	// Validate length.
-	if len(hex) != 6 {
-		return colour.RGB{}, fmt.Errorf("invalid hex colour length: expected 6 characters, got %d", len(hex))
-	}
-
-	// Parse hex values.
-	r, err := strconv.ParseUint(hex[0:2], 16, 8)
-	if err != nil {
-		return colour.RGB{}, fmt.Errorf("invalid red component: %w", err)
-	}
-
-	g, err := strconv.ParseUint(hex[2:4], 16, 8)
-	if err != nil {
-		return colour.RGB{}, fmt.Errorf("invalid green component: %w", err)
-	}
-
-	b, err := strconv.ParseUint(hex[4:6], 16, 8)
-	if err != nil {
-		return colour.RGB{}, fmt.Errorf("invalid blue component: %w", err)
-	}
-
-	return colour.RGB{
-		R: uint8(r),
-		G: uint8(g),
-		B: uint8(b),
-	}, nil
-}
-
-// rgbToColor converts an RGB struct to a color.Color interface.
-func rgbToColor(rgb colour.RGB) color.Color {
-	return color.RGBA{R: rgb.R, G: rgb.G, B: rgb.B, A: 255}
-}
@@ -3,6 +3,7 @@ package file
 
 import (
 	"context"
+	"image/color"
 	"os"
 	"path/filepath"
 	"testing"
@@ -299,6 +300,45 @@ bb9af7
 	}
 }
 
+// TestGenerateWithCategorisedJSON tests loading a palette saved by extract --format json.
+func TestGenerateWithCategorisedJSON(t *testing.T) {
+	source := colour.NewPalette([]color.Color{
+		color.RGBA{R: 0x1a, G: 0x1b, B: 0x26, A: 0xff},
+		color.RGBA{R: 0xc0, G: 0xca, B: 0xf5, A: 0xff},
+		color.RGBA{R: 0x7a, G: 0xa2, B: 0xf7, A: 0xff},
+	})
+	data, err := colour.Categorise(source, colour.DefaultCategorisationConfig()).ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+
+	jsonFile := filepath.Join(t.TempDir(), "palette.json")
+	if err := os.WriteFile(jsonFile, data, 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	plugin := New()
+	plugin.path = jsonFile
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	bgIdx, ok := palette.RoleHints[colour.RoleBackground]
+	if !ok {
+		t.Fatal("Expected a background role hint")
+	}
+	if got := colour.ToRGB(palette.Colors[bgIdx]).Hex(); got != "#1a1b26" {
+		t.Errorf("background = %s, want #1a1b26", got)
+	}
+	for i, c := range palette.Colors {
+		if c == nil {
+			t.Fatalf("colour %d is nil", i)
+		}
+	}
+}
+
 // TestGenerateWithRoleBasedTextFile tests generating palette from text file with role assignments.
 func TestGenerateWithRoleBasedTextFile(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "tinct-file-tests-")
@@ -508,3 +548,49 @@ c0caf5
 		t.Errorf("Expected 0 hints (no roles), got %d", len(hints))
 	}
 }
+
+// TestGenerateWithStylesheet tests generating a palette from a CSS file.
+func TestGenerateWithStylesheet(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cssFile := filepath.Join(tempDir, "theme.css")
+	content := `:root {
+  --background: #1a1b26;
+  --accent1: rgb(122, 162, 247);
+  --brand: hsl(0, 100%, 50%);
+}
+
+a { color: #9ece6a; }
+`
+	if err := os.WriteFile(cssFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	plugin := New()
+	plugin.path = cssFile
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := []string{"#1a1b26", "#7aa2f7", "#ff0000", "#9ece6a"}
+	if len(palette.Colors) != len(want) {
+		t.Fatalf("Expected %d colors, got %d", len(want), len(palette.Colors))
+	}
+	for i, hex := range want {
+		if got := colour.ToRGB(palette.Colors[i]).Hex(); got != hex {
+			t.Errorf("Colors[%d] = %s, want %s", i, got, hex)
+		}
+	}
+
+	if idx, ok := palette.RoleHints[colour.RoleBackground]; !ok || idx != 0 {
+		t.Errorf("Expected background role hint at index 0, got %d (present: %v)", idx, ok)
+	}
+	if idx, ok := palette.RoleHints[colour.RoleAccent1]; !ok || idx != 1 {
+		t.Errorf("Expected accent1 role hint at index 1, got %d (present: %v)", idx, ok)
+	}
+	if len(palette.RoleHints) != 2 {
+		t.Errorf("Expected 2 role hints, got %d", len(palette.RoleHints))
+	}
+}
@@ -5,14 +5,22 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"image/color"
 	_ "image/jpeg" // Required for JPEG image decoding
 	_ "image/png"  // Required for PNG image decoding
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"cloud.google.com/go/auth"
+	"cloud.google.com/go/auth/credentials"
+	"cloud.google.com/go/auth/httptransport"
 	"github.com/spf13/cobra"
 	"google.golang.org/genai"
 
@@ -21,6 +29,7 @@ import (
 	"github.com/jmylchreest/tinct/internal/plugin/input"
 	"github.com/jmylchreest/tinct/internal/plugin/input/shared/regions"
 	"github.com/jmylchreest/tinct/internal/plugin/input/shared/seed"
+	httputil "github.com/jmylchreest/tinct/internal/util/http"
 )
 
 const (
@@ -48,11 +57,16 @@ const (
 
 	// defaultBackend is the default backend used when none is specified.
 	defaultBackend = "gemini-api"
+
+	// vertexAIScope is the OAuth scope Application Default Credentials are requested
+	// with for the Vertex AI backend.
+	vertexAIScope = "https://www.googleapis.com/auth/cloud-platform"
 )
 
 // Plugin implements the input.Plugin interface for Google Imagen image generation.
 type Plugin struct {
 	prompt         string
+	promptFile     string
 	model          string
 	aspectRatio    string
 	imageSize      string
@@ -66,6 +80,11 @@ type Plugin struct {
 	samplePercent   int
 	sampleMethod    string
 
+	// Cropping applied before extraction
+	crop            string
+	cropPercent     string
+	autoCropBorders bool
+
 	// Seed configuration
 	seedMode  string
 	seedValue int64
@@ -85,6 +104,20 @@ type Plugin struct {
 	// Prompt control flags
 	noExtendedPrompt bool
 	noNegativePrompt bool
+
+	// generateImageFunc creates the image at outputPath (replaced in tests to simulate API errors).
+	generateImageFunc func(ctx context.Context, outputPath string, verbose bool) error
+
+	// stdin is read for --prompt-file - (replaced in tests).
+	stdin io.Reader
+
+	// API transport: httpClient retries transient 429/5xx responses; Vertex AI
+	// requests add Application Default Credentials from detectCredentials (replaced
+	// in tests) on top of it. baseURL overrides the API endpoint (empty uses the SDK
+	// default, set in tests).
+	httpClient        *http.Client
+	detectCredentials func() (*auth.Credentials, error)
+	baseURL           string
 }
 
 // New creates a new Google Gen AI input plugin with default settings.
@@ -95,7 +128,7 @@ func New() *Plugin {
 		defaultCacheDir = filepath.Join(home, ".cache", "tinct", "google-genai")
 	}
 
-	return &Plugin{
+	p := &Plugin{
 		model:           defaultModel,
 		aspectRatio:     "16:9",
 		imageSize:       "2K",
@@ -110,6 +143,13 @@ func New() *Plugin {
 		cacheDir:        defaultCacheDir,
 		cacheOverwrite:  false,
 	}
+	p.generateImageFunc = p.generateImage
+	p.stdin = os.Stdin
+	p.httpClient = &http.Client{
+		Transport: httputil.NewRetryTransport(http.DefaultTransport, httputil.DefaultRetryPolicy()),
+	}
+	p.detectCredentials = detectDefaultCredentials
+	return p
 }
 
 // Name returns the plugin name.
@@ -130,6 +170,7 @@ func (p *Plugin) Version() string {
 // RegisterFlags registers plugin-specific flags.
 func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&p.prompt, "prompt", "", "Text description for image generation (required)")
+	cmd.Flags().StringVar(&p.promptFile, "prompt-file", "", "Read the prompt from a file (- or @- for stdin)")
 	cmd.Flags().StringVar(&p.model, "model", p.model, "Imagen model to use")
 	cmd.Flags().StringVar(&p.aspectRatio, "aspect-ratio", p.aspectRatio, "Image aspect ratio (1:1, 3:4, 4:3, 9:16, 16:9, 21:9)")
 	cmd.Flags().StringVar(&p.imageSize, "image-size", p.imageSize, "Image size (1K or 2K, only for Standard/Ultra models)")
@@ -143,6 +184,11 @@ func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
 	cmd.Flags().IntVar(&p.samplePercent, "sample-percent", p.samplePercent, "Percentage of edge to sample (1-50)")
 	cmd.Flags().StringVar(&p.sampleMethod, "sample-method", p.sampleMethod, "Sampling method (average or dominant)")
 
+	// Cropping flags
+	cmd.Flags().StringVar(&p.crop, "crop", "", "Crop to a pixel region \"x,y,w,h\" before extraction")
+	cmd.Flags().StringVar(&p.cropPercent, "crop-percent", "", "Crop to a region \"x,y,w,h\" given as percentages of the image size")
+	cmd.Flags().BoolVar(&p.autoCropBorders, "auto-crop-borders", false, "Detect and remove uniform borders (e.g. letterboxing) before extraction")
+
 	// Seed flags
 	cmd.Flags().StringVar(&p.seedMode, "seed-mode", p.seedMode, "Seed mode (content, manual, random)")
 	cmd.Flags().Int64Var(&p.seedValue, "seed-value", p.seedValue, "Manual seed value")
@@ -168,7 +214,63 @@ func (p *Plugin) Validate() error {
 		return nil
 	}
 	if p.prompt == "" {
-		return fmt.Errorf("prompt is required")
+		if p.promptFile == "" {
+			return fmt.Errorf("prompt is required")
+		}
+		if err := checkPromptFile(p.promptFile); err != nil {
+			return err
+		}
+	}
+	if _, err := image.ParseCropFlags(p.crop, p.cropPercent); err != nil {
+		return fmt.Errorf("invalid crop: %w", err)
+	}
+	return nil
+}
+
+// checkPromptFile checks that a --prompt-file path exists and can be read, without
+// reading it. Stdin ("-" or "@-") is only read when the prompt is loaded.
+func checkPromptFile(path string) error {
+	if path == "-" || path == "@-" {
+		return nil
+	}
+	file, err := os.Open(path) // #nosec G304 - User-specified prompt file
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("prompt file %s is a directory", path)
+	}
+	return nil
+}
+
+// loadPromptFile reads the prompt from --prompt-file when --prompt is not given.
+// --prompt takes precedence; a path of "-" or "@-" reads from stdin. It is called
+// from Generate, so Validate has no side effects.
+func (p *Plugin) loadPromptFile() error {
+	if p.prompt != "" || p.promptFile == "" {
+		return nil
+	}
+
+	var data []byte
+	var err error
+	if p.promptFile == "-" || p.promptFile == "@-" {
+		data, err = io.ReadAll(p.stdin)
+	} else {
+		data, err = os.ReadFile(p.promptFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file: %w", err)
+	}
+
+	p.prompt = strings.TrimSpace(string(data))
+	if p.prompt == "" {
+		return fmt.Errorf("prompt file %s is empty", p.promptFile)
 	}
 	return nil
 }
@@ -183,6 +285,10 @@ func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*col
 		os.Exit(0)
 	}
 
+	if err := p.loadPromptFile(); err != nil {
+		return nil, err
+	}
+
 	if opts.Verbose {
 		fmt.Fprintf(os.Stderr, "Google Gen AI Plugin Configuration:\n")
 		fmt.Fprintf(os.Stderr, "  Prompt: %s\n", p.prompt)
@@ -206,6 +312,9 @@ func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*col
 		return nil, fmt.Errorf("failed to determine image path: %w", err)
 	}
 
+	// Record provenance unless the image came from an unrelated cache entry.
+	recordGeneration := true
+
 	// Generate image if needed
 	if p.cacheOverwrite || !fileExists(imagePath) {
 		enhancedPrompt := p.enhancePromptForWallpaper(p.prompt)
@@ -214,11 +323,18 @@ func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*col
 			p.backend, p.model, p.prompt, additionalPrompt)
 		fmt.Fprintf(os.Stderr, "Waiting for response...\n")
 
-		if err := p.generateImage(ctx, imagePath, opts.Verbose); err != nil {
-			return nil, fmt.Errorf("failed to generate image: %w", err)
+		if err := p.generateImageFunc(ctx, imagePath, opts.Verbose); err != nil {
+			err = wrapRateLimitError(err)
+			cachedPath, ok := p.cachedImageForError(err, opts.OnError)
+			if !ok {
+				return nil, fmt.Errorf("failed to generate image: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: image generation rate limited, using cached image: %s\n", cachedPath)
+			imagePath = cachedPath
+			recordGeneration = false
+		} else {
+			fmt.Fprintf(os.Stderr, "Image generated: %s\n", imagePath)
 		}
-
-		fmt.Fprintf(os.Stderr, "Image generated: %s\n", imagePath)
 	} else {
 		fmt.Fprintf(os.Stderr, "Using cached image: %s\n", imagePath)
 	}
@@ -236,6 +352,10 @@ func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*col
 		return nil, fmt.Errorf("failed to extract colors: %w", err)
 	}
 
+	if recordGeneration {
+		p.recordGenerationMeta(palette)
+	}
+
 	if opts.Verbose {
 		fmt.Fprintf(os.Stderr, "Successfully extracted %d colors\n", len(palette.Colors))
 	}
@@ -248,6 +368,71 @@ func (p *Plugin) WallpaperPath() string {
 	return p.loadedImagePath
 }
 
+// recordGenerationMeta stores the prompt and model settings that produced the image in
+// the palette metadata, so saved palettes and exported themes can be reproduced.
+func (p *Plugin) recordGenerationMeta(palette *colour.Palette) {
+	palette.SetMeta(colour.MetaPrompt, p.prompt)
+	palette.SetMeta(colour.MetaModel, p.model)
+	palette.SetMeta(colour.MetaBackend, p.backend)
+	palette.SetMeta(colour.MetaAspectRatio, p.aspectRatio)
+	if negativePrompt := p.effectiveNegativePrompt(); negativePrompt != "" {
+		palette.SetMeta(colour.MetaNegativePrompt, negativePrompt)
+	}
+}
+
+// effectiveNegativePrompt returns the negative prompt sent to the API, or "" when none is
+// used. Only Imagen models on the Vertex AI backend accept negative prompts.
+func (p *Plugin) effectiveNegativePrompt() string {
+	if p.noNegativePrompt || p.backend != "vertex-ai" || isGeminiModel(p.model) {
+		return ""
+	}
+	return buildNegativePrompt(p.negativePrompt)
+}
+
+// wrapRateLimitError marks quota and rate-limit API errors with input.ErrRateLimited.
+func wrapRateLimitError(err error) error {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) && (apiErr.Code == http.StatusTooManyRequests || strings.Contains(apiErr.Status, "RESOURCE_EXHAUSTED")) {
+		return fmt.Errorf("%w: %w", input.ErrRateLimited, err)
+	}
+	return err
+}
+
+// cachedImageForError returns a previously generated image to use when generation was
+// rate limited and the error policy allows falling back to the cache.
+func (p *Plugin) cachedImageForError(err error, policy input.ErrorPolicy) (string, bool) {
+	if !errors.Is(err, input.ErrRateLimited) || policy.Mode != input.OnErrorUseCache {
+		return "", false
+	}
+	return p.latestCachedImage()
+}
+
+// latestCachedImage finds the most recently modified non-empty image in the cache directory.
+func (p *Plugin) latestCachedImage() (string, bool) {
+	entries, err := os.ReadDir(p.cacheDir)
+	if err != nil {
+		return "", false
+	}
+
+	var latest string
+	var latestTime time.Time
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".png") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Size() == 0 {
+			continue
+		}
+		if latest == "" || info.ModTime().After(latestTime) {
+			latest = filepath.Join(p.cacheDir, entry.Name())
+			latestTime = info.ModTime()
+		}
+	}
+
+	return latest, latest != ""
+}
+
 // getImagePath determines where to save/load the generated image.
 func (p *Plugin) getImagePath() (string, error) {
 	if !p.cacheEnabled {
@@ -273,10 +458,17 @@ func (p *Plugin) getImagePath() (string, error) {
 	return filepath.Join(p.cacheDir, filename), nil
 }
 
-// clientSetup encapsulates client configuration, creation, and logging.
-// Returns the configured client or an error.
-func (p *Plugin) clientSetup(ctx context.Context, verbose bool) (*genai.Client, error) {
-	clientConfig := &genai.ClientConfig{}
+// detectDefaultCredentials finds Application Default Credentials for Vertex AI.
+func detectDefaultCredentials() (*auth.Credentials, error) {
+	return credentials.DetectDefault(&credentials.DetectOptions{Scopes: []string{vertexAIScope}})
+}
+
+// clientConfig returns the Gen AI client configuration for the selected backend.
+// Both backends send requests through the retrying HTTP client.
+func (p *Plugin) clientConfig(ctx context.Context) (*genai.ClientConfig, error) {
+	clientConfig := &genai.ClientConfig{
+		HTTPOptions: genai.HTTPOptions{BaseURL: p.baseURL},
+	}
 
 	if p.backend == "vertex-ai" {
 		clientConfig.Backend = genai.BackendVertexAI
@@ -291,6 +483,39 @@ func (p *Plugin) clientSetup(ctx context.Context, verbose bool) (*genai.Client,
 			return nil, fmt.Errorf("GOOGLE_API_KEY environment variable is required\nGet one at: https://aistudio.google.com/api-keys")
 		}
 		clientConfig.APIKey = apiKey
+		clientConfig.HTTPClient = p.httpClient
+		return clientConfig, nil
+	}
+
+	// The SDK skips Application Default Credentials when an HTTPClient is set, so
+	// build the authenticated client the way the SDK does, on the retrying transport.
+	creds, err := p.detectCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find default credentials: %w", err)
+	}
+	quotaProject, err := creds.QuotaProjectID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota project ID: %w", err)
+	}
+	client, err := httptransport.NewClient(&httptransport.Options{
+		Credentials:      creds,
+		Headers:          http.Header{"X-Goog-User-Project": []string{quotaProject}},
+		BaseRoundTripper: p.httpClient.Transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vertex AI HTTP client: %w", err)
+	}
+	clientConfig.HTTPClient = client
+
+	return clientConfig, nil
+}
+
+// clientSetup encapsulates client configuration, creation, and logging.
+// Returns the configured client or an error.
+func (p *Plugin) clientSetup(ctx context.Context, verbose bool) (*genai.Client, error) {
+	clientConfig, err := p.clientConfig(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create client
@@ -503,6 +728,18 @@ func (p *Plugin) extractColors(imagePath string, verbose bool) (*colour.Palette,
 		return nil, fmt.Errorf("failed to load image: %w", err)
 	}
 
+	// Crop before extraction so borders generated by the model don't pollute the palette
+	crop, err := image.ParseCropFlags(p.crop, p.cropPercent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid crop: %w", err)
+	}
+	if img, err = crop.Apply(img); err != nil {
+		return nil, fmt.Errorf("failed to crop image: %w", err)
+	}
+	if p.autoCropBorders {
+		img = image.TrimBorders(img, image.DefaultBorderTolerance)
+	}
+
 	// Prepare extractor options with seed
 	extractorOpts := colour.ExtractorOptions{}
 
@@ -551,6 +788,11 @@ func (p *Plugin) extractColors(imagePath string, verbose bool) (*colour.Palette,
 		return nil, fmt.Errorf("failed to extract colors: %w", err)
 	}
 
+	palette.SetMeta(colour.MetaSeedMode, p.seedMode)
+	if extractorOpts.Seed != nil {
+		palette.SetMeta(colour.MetaSeed, strconv.FormatInt(*extractorOpts.Seed, 10))
+	}
+
 	// If ambience extraction is disabled, return main colors
 	if !p.extractAmbience {
 		return palette, nil
@@ -603,7 +845,9 @@ func (p *Plugin) extractColors(imagePath string, verbose bool) (*colour.Palette,
 			len(palette.Colors), len(regionPalette.Colors), totalColors)
 	}
 
-	return colour.NewPaletteWithWeights(allColors, weights), nil
+	combined := colour.NewPaletteWithWeights(allColors, weights)
+	combined.Meta = palette.Meta
+	return combined, nil
 }
 
 func fileExists(path string) bool {
@@ -614,7 +858,8 @@ func fileExists(path string) bool {
 // GetFlagHelp returns help information for all plugin flags.
 func (p *Plugin) GetFlagHelp() []input.FlagHelp {
 	return []input.FlagHelp{
-		{Name: "prompt", Type: "string", Default: "", Description: "Text description for image generation (required)", Required: true},
+		{Name: "prompt", Type: "string", Default: "", Description: "Text description for image generation (required unless --prompt-file is set)", Required: true},
+		{Name: "prompt-file", Type: "string", Default: "", Description: "Read the prompt from a file (- or @- for stdin)", Required: false},
 		{Name: "model", Type: "string", Default: defaultModel, Description: "Image generation model to use", Required: false},
 		{Name: "aspect-ratio", Type: "string", Default: "16:9", Description: "Image aspect ratio (1:1, 3:4, 4:3, 9:16, 16:9, 21:9)", Required: false},
 		{Name: "image-size", Type: "string", Default: "2K", Description: "Image size (1K or 2K, only for Standard/Ultra models)", Required: false},
@@ -625,6 +870,9 @@ func (p *Plugin) GetFlagHelp() []input.FlagHelp {
 		{Name: "regions", Type: "int", Default: "8", Description: "Number of edge regions (4, 8, 12, 16)", Required: false},
 		{Name: "sample-percent", Type: "int", Default: "10", Description: "Percentage of edge to sample (1-50)", Required: false},
 		{Name: "sample-method", Type: "string", Default: "average", Description: "Sampling method (average or dominant)", Required: false},
+		{Name: "crop", Type: "string", Default: "", Description: "Crop to a pixel region \"x,y,w,h\" before extraction", Required: false},
+		{Name: "crop-percent", Type: "string", Default: "", Description: "Crop to a region \"x,y,w,h\" given as percentages of the image size", Required: false},
+		{Name: "auto-crop-borders", Type: "bool", Default: "false", Description: "Detect and remove uniform borders before extraction", Required: false},
 		{Name: "seed-mode", Type: "string", Default: "content", Description: "Seed mode (content, manual, random)", Required: false},
 		{Name: "seed-value", Type: "int64", Default: "0", Description: "Manual seed value", Required: false},
 		{Name: "cache", Type: "bool", Default: "true", Description: "Enable image caching", Required: false},
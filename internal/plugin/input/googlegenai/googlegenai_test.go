@@ -2,12 +2,27 @@ package googlegenai
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
+	"sync/atomic"
 	"testing"
 
-	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"cloud.google.com/go/auth"
 	"github.com/spf13/cobra"
+	"google.golang.org/genai"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
 )
 
 // TestNew tests creating a new plugin with defaults.
@@ -117,6 +132,7 @@ func TestRegisterFlags(t *testing.T) {
 	// Check that flags were registered
 	flags := []string{
 		"prompt",
+		"prompt-file",
 		"model",
 		"aspect-ratio",
 		"image-size",
@@ -127,6 +143,9 @@ func TestRegisterFlags(t *testing.T) {
 		"regions",
 		"sample-percent",
 		"sample-method",
+		"crop",
+		"crop-percent",
+		"auto-crop-borders",
 		"seed-mode",
 		"seed-value",
 		"cache",
@@ -183,6 +202,103 @@ func TestValidateListModels(t *testing.T) {
 	}
 }
 
+// TestPromptFile tests that Validate only checks --prompt-file and Generate loads it.
+func TestPromptFile(t *testing.T) {
+	promptPath := filepath.Join(t.TempDir(), "prompt.txt")
+	if err := os.WriteFile(promptPath, []byte("a quiet harbour at dawn,\nsoft pastel light\n"), 0o600); err != nil {
+		t.Fatalf("failed to write prompt file: %v", err)
+	}
+
+	plugin := New()
+	plugin.promptFile = promptPath
+
+	if err := plugin.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if plugin.prompt != "" {
+		t.Errorf("Validate() should not load the prompt, got %q", plugin.prompt)
+	}
+
+	if _, err := plugin.Generate(context.Background(), input.GenerateOptions{DryRun: true}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if want := "a quiet harbour at dawn,\nsoft pastel light"; plugin.prompt != want {
+		t.Errorf("prompt = %q, want %q", plugin.prompt, want)
+	}
+}
+
+// TestPromptFlagOverridesFile tests that --prompt takes precedence over --prompt-file.
+func TestPromptFlagOverridesFile(t *testing.T) {
+	promptPath := filepath.Join(t.TempDir(), "prompt.txt")
+	if err := os.WriteFile(promptPath, []byte("from file"), 0o600); err != nil {
+		t.Fatalf("failed to write prompt file: %v", err)
+	}
+
+	plugin := New()
+	plugin.prompt = "from flag"
+	plugin.promptFile = promptPath
+
+	if err := plugin.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if _, err := plugin.Generate(context.Background(), input.GenerateOptions{DryRun: true}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if plugin.prompt != "from flag" {
+		t.Errorf("prompt = %q, want --prompt to take precedence", plugin.prompt)
+	}
+}
+
+// TestPromptFileStdin tests that stdin is read by Generate, not Validate.
+func TestPromptFileStdin(t *testing.T) {
+	for _, path := range []string{"-", "@-"} {
+		stdin := strings.NewReader("neon rain\n")
+		plugin := New()
+		plugin.promptFile = path
+		plugin.stdin = stdin
+
+		if err := plugin.Validate(); err != nil {
+			t.Fatalf("Validate() with %s error = %v", path, err)
+		}
+		if stdin.Len() == 0 {
+			t.Errorf("Validate() with %s should not read stdin", path)
+		}
+
+		if _, err := plugin.Generate(context.Background(), input.GenerateOptions{DryRun: true}); err != nil {
+			t.Fatalf("Generate() with %s error = %v", path, err)
+		}
+		if plugin.prompt != "neon rain" {
+			t.Errorf("prompt from %s = %q, want %q", path, plugin.prompt, "neon rain")
+		}
+	}
+}
+
+// TestPromptFileErrors tests missing and empty prompt files.
+func TestPromptFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{filepath.Join(dir, "missing.txt"), dir} {
+		plugin := New()
+		plugin.promptFile = path
+		if err := plugin.Validate(); err == nil {
+			t.Errorf("Validate() should fail for prompt file %s", path)
+		}
+	}
+
+	// An empty file passes Validate but is rejected when the prompt is loaded.
+	emptyPath := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(emptyPath, []byte("  \n"), 0o600); err != nil {
+		t.Fatalf("failed to write prompt file: %v", err)
+	}
+	plugin := New()
+	plugin.promptFile = emptyPath
+	if err := plugin.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if _, err := plugin.Generate(context.Background(), input.GenerateOptions{DryRun: true}); err == nil || !strings.Contains(err.Error(), "is empty") {
+		t.Errorf("Generate() with an empty prompt file error = %v, want an empty prompt error", err)
+	}
+}
+
 // TestGetFlagHelp tests GetFlagHelp method.
 func TestGetFlagHelp(t *testing.T) {
 	plugin := New()
@@ -271,3 +387,313 @@ func TestGenerateRequiresAPIKey(t *testing.T) {
 		t.Error("Expected error when API key is not set")
 	}
 }
+
+// staticToken is a TokenProvider returning a fixed bearer token.
+type staticToken string
+
+func (s staticToken) Token(context.Context) (*auth.Token, error) {
+	return &auth.Token{Value: string(s), Type: "Bearer"}, nil
+}
+
+// testCredentials returns Vertex AI credentials with a fixed token and quota project.
+func testCredentials() (*auth.Credentials, error) {
+	return auth.NewCredentials(&auth.CredentialsOptions{
+		TokenProvider: staticToken("test-token"),
+		QuotaProjectIDProvider: auth.CredentialsPropertyFunc(func(context.Context) (string, error) {
+			return "test-project", nil
+		}),
+	}), nil
+}
+
+func TestClientConfigRetryClient(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	plugin := New()
+	plugin.backend = "gemini-api"
+	config, err := plugin.clientConfig(context.Background())
+	if err != nil {
+		t.Fatalf("clientConfig() error = %v", err)
+	}
+	if config.HTTPClient != plugin.httpClient {
+		t.Error("Gemini API backend should use the retrying HTTP client")
+	}
+
+	// Vertex AI gets its own authenticated client built on the retrying transport.
+	plugin.backend = "vertex-ai"
+	plugin.detectCredentials = testCredentials
+	config, err = plugin.clientConfig(context.Background())
+	if err != nil {
+		t.Fatalf("clientConfig() error = %v", err)
+	}
+	if config.HTTPClient == nil || config.HTTPClient == plugin.httpClient {
+		t.Error("Vertex AI backend should use an authenticated client, not the bare retrying client")
+	}
+
+	plugin.detectCredentials = func() (*auth.Credentials, error) { return nil, errors.New("no credentials") }
+	if _, err := plugin.clientConfig(context.Background()); err == nil {
+		t.Error("clientConfig() should fail when default credentials cannot be found")
+	}
+}
+
+// writeCachedImage writes a small PNG into dir to act as a previously generated image.
+func writeCachedImage(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for y := range 16 {
+		for x := range 16 {
+			img.Set(x, y, color.RGBA{R: uint8(x * 16), G: 80, B: uint8(y * 16), A: 255})
+		}
+	}
+
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create cached image: %v", err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("failed to encode cached image: %v", err)
+	}
+	return path
+}
+
+// rateLimitedPlugin returns a plugin whose image generation always fails with a 429.
+func rateLimitedPlugin(t *testing.T) *Plugin {
+	t.Helper()
+
+	plugin := New()
+	plugin.prompt = "a rate limited prompt"
+	plugin.colours = 4
+	plugin.cacheDir = t.TempDir()
+	plugin.generateImageFunc = func(context.Context, string, bool) error {
+		return fmt.Errorf("image generation failed: %w", genai.APIError{Code: 429, Status: "RESOURCE_EXHAUSTED", Message: "quota exceeded"})
+	}
+	return plugin
+}
+
+func TestRateLimitUsesCachedImage(t *testing.T) {
+	plugin := rateLimitedPlugin(t)
+	cachedPath := writeCachedImage(t, plugin.cacheDir, "genai-previous.png")
+
+	policy, err := input.ParseErrorPolicy("use-cache")
+	if err != nil {
+		t.Fatalf("ParseErrorPolicy() error = %v", err)
+	}
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{OnError: policy})
+	if err != nil {
+		t.Fatalf("Generate() should fall back to cached image, got error: %v", err)
+	}
+	if len(palette.Colors) == 0 {
+		t.Error("expected colours extracted from the cached image")
+	}
+	if plugin.WallpaperPath() != cachedPath {
+		t.Errorf("WallpaperPath() = %s, want cached image %s", plugin.WallpaperPath(), cachedPath)
+	}
+}
+
+func TestRateLimitFailsByDefault(t *testing.T) {
+	plugin := rateLimitedPlugin(t)
+	writeCachedImage(t, plugin.cacheDir, "genai-previous.png")
+
+	_, err := plugin.Generate(context.Background(), input.GenerateOptions{})
+	if err == nil {
+		t.Fatal("expected error without an on-error policy")
+	}
+	if !errors.Is(err, input.ErrRateLimited) {
+		t.Errorf("error should wrap input.ErrRateLimited: %v", err)
+	}
+}
+
+func TestRateLimitUseCacheWithoutCache(t *testing.T) {
+	plugin := rateLimitedPlugin(t)
+
+	_, err := plugin.Generate(context.Background(), input.GenerateOptions{OnError: input.ErrorPolicy{Mode: input.OnErrorUseCache}})
+	if !errors.Is(err, input.ErrRateLimited) {
+		t.Errorf("expected rate limit error when no cached image exists, got %v", err)
+	}
+}
+
+func TestParseErrorPolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    input.ErrorPolicy
+		wantErr bool
+	}{
+		{"", input.ErrorPolicy{Mode: input.OnErrorFail}, false},
+		{"fail", input.ErrorPolicy{Mode: input.OnErrorFail}, false},
+		{"use-cache", input.ErrorPolicy{Mode: input.OnErrorUseCache}, false},
+		{"fallback:file", input.ErrorPolicy{Mode: input.OnErrorFallback, Plugin: "file"}, false},
+		{"fallback:", input.ErrorPolicy{}, true},
+		{"retry", input.ErrorPolicy{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := input.ParseErrorPolicy(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseErrorPolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseErrorPolicy(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestGenerateRetriesTransientErrors verifies the API client retries 429 responses
+// before succeeding, so a briefly rate-limited request still produces an image.
+func TestGenerateRetriesTransientErrors(t *testing.T) {
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+
+	imageData, err := os.ReadFile(writeCachedImage(t, t.TempDir(), "source.png"))
+	if err != nil {
+		t.Fatalf("failed to read test image: %v", err)
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"code":429,"status":"RESOURCE_EXHAUSTED","message":"quota exceeded"}}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"candidates":[{"content":{"role":"model","parts":[{"inlineData":{"mimeType":"image/png","data":%q}}]}}]}`,
+			base64.StdEncoding.EncodeToString(imageData))
+	}))
+	defer server.Close()
+
+	plugin := New()
+	plugin.prompt = "a retried prompt"
+	plugin.model = "gemini-2.5-flash-image"
+	plugin.baseURL = server.URL
+
+	outputPath := filepath.Join(t.TempDir(), "generated.png")
+	if err := plugin.generateImage(context.Background(), outputPath, false); err != nil {
+		t.Fatalf("generateImage() error = %v", err)
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 requests (two 429s then success), got %d", got)
+	}
+	written, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("generated image not written: %v", err)
+	}
+	if len(written) != len(imageData) {
+		t.Errorf("written image is %d bytes, want %d", len(written), len(imageData))
+	}
+}
+
+// TestGenerateRetriesTransientErrorsVertexAI verifies Vertex AI requests are both
+// authenticated with the default credentials and retried on 429 responses.
+func TestGenerateRetriesTransientErrorsVertexAI(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "test-project")
+	t.Setenv("GOOGLE_CLOUD_LOCATION", "us-central1")
+
+	imageData, err := os.ReadFile(writeCachedImage(t, t.TempDir(), "source.png"))
+	if err != nil {
+		t.Fatalf("failed to read test image: %v", err)
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want the default credentials' token", got)
+		}
+		if requests.Load() <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"code":429,"status":"RESOURCE_EXHAUSTED","message":"quota exceeded"}}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"candidates":[{"content":{"role":"model","parts":[{"inlineData":{"mimeType":"image/png","data":%q}}]}}]}`,
+			base64.StdEncoding.EncodeToString(imageData))
+	}))
+	defer server.Close()
+
+	plugin := New()
+	plugin.prompt = "a retried prompt"
+	plugin.model = "gemini-2.5-flash-image"
+	plugin.backend = "vertex-ai"
+	plugin.baseURL = server.URL
+	plugin.detectCredentials = testCredentials
+
+	outputPath := filepath.Join(t.TempDir(), "generated.png")
+	if err := plugin.generateImage(context.Background(), outputPath, false); err != nil {
+		t.Fatalf("generateImage() error = %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 requests (two 429s then success), got %d", got)
+	}
+}
+
+// TestGenerateRecordsMetadata verifies the prompt and model settings are stored in the palette metadata.
+func TestGenerateRecordsMetadata(t *testing.T) {
+	plugin := New()
+	plugin.prompt = "misty mountain lake"
+	plugin.model = "imagen-4.0-generate-001"
+	plugin.backend = "vertex-ai"
+	plugin.negativePrompt = "people"
+	plugin.colours = 4
+	plugin.seedMode = "manual"
+	plugin.seedValue = 42
+	plugin.cacheDir = t.TempDir()
+	plugin.generateImageFunc = func(_ context.Context, outputPath string, _ bool) error {
+		writeCachedImage(t, filepath.Dir(outputPath), filepath.Base(outputPath))
+		return nil
+	}
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := map[string]string{
+		colour.MetaPrompt:         "misty mountain lake",
+		colour.MetaModel:          "imagen-4.0-generate-001",
+		colour.MetaBackend:        "vertex-ai",
+		colour.MetaAspectRatio:    "16:9",
+		colour.MetaNegativePrompt: buildNegativePrompt("people"),
+		colour.MetaSeedMode:       "manual",
+		colour.MetaSeed:           "42",
+	}
+	for key, value := range want {
+		if got := palette.Meta[key]; got != value {
+			t.Errorf("Meta[%q] = %q, want %q", key, got, value)
+		}
+	}
+
+	// Metadata survives categorisation so saved palettes and exports record it.
+	categorised := colour.Categorise(palette, colour.DefaultCategorisationConfig())
+	if categorised.Meta[colour.MetaPrompt] != "misty mountain lake" {
+		t.Errorf("categorised Meta = %v, want prompt recorded", categorised.Meta)
+	}
+}
+
+// TestGenerateMetadataOmitsUnusedNegativePrompt verifies the negative prompt is only recorded when sent.
+func TestGenerateMetadataOmitsUnusedNegativePrompt(t *testing.T) {
+	plugin := New()
+	plugin.prompt = "desert dunes"
+	plugin.negativePrompt = "people"
+	plugin.colours = 4
+	plugin.cacheDir = t.TempDir()
+	plugin.generateImageFunc = func(_ context.Context, outputPath string, _ bool) error {
+		writeCachedImage(t, filepath.Dir(outputPath), filepath.Base(outputPath))
+		return nil
+	}
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := palette.Meta[colour.MetaNegativePrompt]; ok {
+		t.Errorf("negative prompt should not be recorded for the %s backend", plugin.backend)
+	}
+	if palette.Meta[colour.MetaBackend] != defaultBackend {
+		t.Errorf("Meta[backend] = %q, want %q", palette.Meta[colour.MetaBackend], defaultBackend)
+	}
+}
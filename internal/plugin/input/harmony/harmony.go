@@ -0,0 +1,259 @@
+// Package harmony provides an input plugin that generates a colour palette from a
+// single base colour using a colour harmony scheme.
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+)
+
+const (
+	// DefaultCount is the default number of colours to generate.
+	DefaultCount = 12
+
+	// MaxCount is the maximum number of colours to generate.
+	MaxCount = 256
+
+	// lightnessStep is how far each pass over the scheme's hues moves lightness
+	// from the base colour, alternating lighter and darker.
+	lightnessStep = 0.12
+
+	// Lightness bounds for the generated accent colours, so they stay usable
+	// against both dark and light backgrounds.
+	minAccentLightness = 0.25
+	maxAccentLightness = 0.85
+)
+
+// Relative weights of the generated colours. The dark neutral is the most dominant
+// so auto theme detection picks a dark theme; use --theme light for a light one.
+const (
+	weightDarkNeutral  = 3.0
+	weightLightNeutral = 2.0
+	weightBase         = 1.5
+	weightHarmony      = 1.0
+)
+
+// schemes maps --harmony.scheme values to hue offsets from the base colour, in
+// the order they are listed in help and errors.
+var schemes = []struct {
+	name    string
+	offsets []float64
+}{
+	{"complementary", []float64{0, 180}},
+	{"analogous", []float64{0, 30, -30}},
+	{"triadic", []float64{0, 120, 240}},
+	{"tetradic", []float64{0, 90, 180, 270}},
+	{"split-complementary", []float64{0, 150, 210}},
+	{"monochromatic", []float64{0}},
+}
+
+// schemeNames is the comma-separated list of --harmony.scheme values.
+var schemeNames = func() string {
+	names := make([]string, len(schemes))
+	for i, s := range schemes {
+		names[i] = s.name
+	}
+	return strings.Join(names, ", ")
+}()
+
+// schemeOffsets returns the hue offsets for a --harmony.scheme value.
+func schemeOffsets(name string) ([]float64, error) {
+	for _, s := range schemes {
+		if name == s.name {
+			return s.offsets, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid scheme '%s' (valid: %s)", name, schemeNames)
+}
+
+// Plugin implements the input.Plugin interface for harmony-based palette generation.
+type Plugin struct {
+	base   string
+	scheme string
+	count  int
+	space  string // Colour space hue and lightness are varied in: hsl or oklch
+}
+
+// New creates a new harmony input plugin.
+func New() *Plugin {
+	return &Plugin{
+		scheme: "complementary",
+		count:  DefaultCount,
+		space:  "oklch",
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "harmony"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Generate a palette from a base colour using a colour harmony scheme"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.base, "harmony.base", "", "Base colour as hex, e.g. '#7aa2f7' (required)")
+	cmd.Flags().StringVar(&p.scheme, "harmony.scheme", "complementary", "Harmony scheme: "+schemeNames)
+	cmd.Flags().IntVar(&p.count, "harmony.count", DefaultCount, fmt.Sprintf("Number of colours to generate (up to %d)", MaxCount))
+	cmd.Flags().StringVar(&p.space, "harmony.space", "oklch", "Colour space to rotate hue and vary lightness in: hsl, oklch")
+}
+
+// Validate checks if the plugin has all required inputs configured.
+func (p *Plugin) Validate() error {
+	if p.base == "" {
+		return fmt.Errorf("--harmony.base is required")
+	}
+	if _, err := colour.ParseHex(p.base); err != nil {
+		return fmt.Errorf("invalid --harmony.base '%s': %w", p.base, err)
+	}
+
+	offsets, err := schemeOffsets(p.scheme)
+	if err != nil {
+		return err
+	}
+
+	// Two neutrals plus every hue of the scheme.
+	minCount := len(offsets) + 2
+	if p.count < minCount || p.count > MaxCount {
+		return fmt.Errorf("--harmony.count must be between %d and %d for the %s scheme, got %d", minCount, MaxCount, p.scheme, p.count)
+	}
+
+	if p.space != "hsl" && p.space != "oklch" {
+		return fmt.Errorf("invalid --harmony.space '%s' (valid: hsl, oklch)", p.space)
+	}
+
+	return nil
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "harmony.base", Type: "string", Default: "", Description: "Base colour as hex, e.g. '#7aa2f7' (required)", Required: true},
+		{Name: "harmony.scheme", Type: "string", Default: "complementary", Description: "Harmony scheme: " + schemeNames, Required: false},
+		{Name: "harmony.count", Type: "int", Default: strconv.Itoa(DefaultCount), Description: fmt.Sprintf("Number of colours to generate (up to %d)", MaxCount), Required: false},
+		{Name: "harmony.space", Type: "string", Default: "oklch", Description: "Colour space to rotate hue and vary lightness in: hsl, oklch", Required: false},
+	}
+}
+
+// Generate builds a palette from the base colour. It holds a dark and a light
+// neutral tinted with the base hue, for the background and foreground, then the
+// base colour and the scheme's other hues, repeated at alternately lighter and
+// darker steps until the palette has the requested number of colours.
+func (p *Plugin) Generate(_ context.Context, opts input.GenerateOptions) (*colour.Palette, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	base, _ := colour.ParseHex(p.base)
+	offsets, _ := schemeOffsets(p.scheme)
+
+	if opts.Verbose {
+		fmt.Printf("→ Generating %d colours from %s (%s, %s)\n", p.count, base.Hex(), p.scheme, p.space)
+	}
+
+	tone := p.toner(base)
+	colors := []color.Color{
+		tone.rgb(0, tone.fromHSL(0.12), 0.15).ToColor(),
+		tone.rgb(0, tone.fromHSL(0.94), 0.1).ToColor(),
+	}
+	weights := []float64{weightDarkNeutral, weightLightNeutral}
+
+	for i := 0; len(colors) < p.count; i++ {
+		offset := offsets[i%len(offsets)]
+		rgb := base
+		if i > 0 {
+			rgb = tone.rgb(offset, tone.passLightness(i/len(offsets)), 1)
+		}
+		colors = append(colors, rgb.ToColor())
+
+		if i == 0 {
+			weights = append(weights, weightBase)
+		} else {
+			weights = append(weights, weightHarmony)
+		}
+
+		if opts.Verbose {
+			fmt.Printf("   %s (hue %+.0f°)\n", rgb.Hex(), offset)
+		}
+	}
+
+	return colour.NewPaletteWithWeights(colors, weights), nil
+}
+
+// toner varies the base colour in one colour space. Lightness is in that space's
+// units: HSL lightness, or OKLCH perceptual lightness.
+type toner struct {
+	// rgb returns the base colour with its hue rotated by offset degrees, lightness
+	// set to l and chroma (or saturation) scaled by chroma.
+	rgb func(offset, l, chroma float64) colour.RGB
+
+	// fromHSL converts an HSL lightness to the space's lightness, via the grey
+	// with that HSL lightness.
+	fromHSL func(l float64) float64
+
+	// baseLightness is the base colour's lightness.
+	baseLightness float64
+}
+
+// toner returns the toner for the configured colour space.
+func (p *Plugin) toner(base colour.RGB) toner {
+	if p.space == "hsl" {
+		h, s, l := colour.RGBToHSL(base)
+		return toner{
+			rgb: func(offset, l, chroma float64) colour.RGB {
+				return colour.HSLToRGB(normaliseHue(h+offset), s*chroma, l)
+			},
+			fromHSL:       func(l float64) float64 { return l },
+			baseLightness: l,
+		}
+	}
+
+	l, c, h := colour.RGBToOKLCH(base)
+	return toner{
+		rgb: func(offset, l, chroma float64) colour.RGB {
+			return colour.OKLCHToRGB(l, c*chroma, normaliseHue(h+offset))
+		},
+		fromHSL: func(l float64) float64 {
+			grey, _, _ := colour.RGBToOKLCH(colour.HSLToRGB(0, 0, l))
+			return grey
+		},
+		baseLightness: l,
+	}
+}
+
+// passLightness returns the lightness for the given pass over the scheme's hues:
+// the base lightness first, then alternately lighter and darker steps, kept
+// within the accent lightness bounds.
+func (t toner) passLightness(pass int) float64 {
+	step := float64((pass+1)/2) * lightnessStep
+	if pass%2 == 0 {
+		step = -step
+	}
+	lo, hi := t.fromHSL(minAccentLightness), t.fromHSL(maxAccentLightness)
+	return math.Max(lo, math.Min(hi, t.baseLightness+step))
+}
+
+// normaliseHue wraps a hue in degrees into 0-360.
+func normaliseHue(h float64) float64 {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
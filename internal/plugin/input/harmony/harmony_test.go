@@ -0,0 +1,126 @@
+// Package harmony provides tests for the harmony input plugin.
+package harmony
+
+import (
+	"context"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+)
+
+// hueLightness returns the hue and lightness of c in the named colour space.
+func hueLightness(c color.Color, space string) (h, l float64) {
+	rgb := colour.ToRGB(c)
+	if space == "hsl" {
+		h, _, l = colour.RGBToHSL(rgb)
+		return h, l
+	}
+	l, _, h = colour.RGBToOKLCH(rgb)
+	return h, l
+}
+
+// hueDistance returns the angle between two hues in degrees (0-180).
+func hueDistance(a, b float64) float64 {
+	d := math.Abs(normaliseHue(a - b))
+	return math.Min(d, 360-d)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		scheme  string
+		count   int
+		space   string
+		wantErr bool
+	}{
+		{name: "defaults", base: "#7aa2f7", scheme: "complementary", count: DefaultCount, space: "oklch"},
+		{name: "shorthand hex", base: "7af", scheme: "triadic", count: 5, space: "hsl"},
+		{name: "missing base", base: "", scheme: "complementary", count: DefaultCount, space: "oklch", wantErr: true},
+		{name: "invalid base", base: "#7aa2fz", scheme: "complementary", count: DefaultCount, space: "oklch", wantErr: true},
+		{name: "unknown scheme", base: "#7aa2f7", scheme: "pentadic", count: DefaultCount, space: "oklch", wantErr: true},
+		{name: "too few for scheme", base: "#7aa2f7", scheme: "tetradic", count: 5, space: "oklch", wantErr: true},
+		{name: "too many", base: "#7aa2f7", scheme: "complementary", count: MaxCount + 1, space: "oklch", wantErr: true},
+		{name: "unknown space", base: "#7aa2f7", scheme: "complementary", count: DefaultCount, space: "lab", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New()
+			p.base, p.scheme, p.count, p.space = tt.base, tt.scheme, tt.count, tt.space
+			if err := p.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateSchemes(t *testing.T) {
+	const baseHex = "#7aa2f7"
+	for _, scheme := range schemes {
+		for _, space := range []string{"hsl", "oklch"} {
+			t.Run(scheme.name+"/"+space, func(t *testing.T) {
+				p := New()
+				p.base, p.scheme, p.space = baseHex, scheme.name, space
+
+				palette, err := p.Generate(context.Background(), input.GenerateOptions{})
+				if err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+				if palette.Len() != DefaultCount || len(palette.Weights) != DefaultCount {
+					t.Fatalf("Generate() returned %d colours and %d weights, want %d", palette.Len(), len(palette.Weights), DefaultCount)
+				}
+
+				hexes := palette.ToHex()
+				if hexes[2] != baseHex {
+					t.Errorf("first harmony colour = %s, want the base colour %s", hexes[2], baseHex)
+				}
+				baseHue, _ := hueLightness(palette.Colors[2], space)
+
+				// The first pass holds each of the scheme's hues in order.
+				for i, offset := range scheme.offsets {
+					h, _ := hueLightness(palette.Colors[2+i], space)
+					if d := hueDistance(h, baseHue+offset); d > 2 {
+						t.Errorf("colour %d (%s) hue %.0f, want %.0f (off by %.0f)", 2+i, hexes[2+i], h, normaliseHue(baseHue+offset), d)
+					}
+				}
+
+				// The neutrals are the darkest and lightest colours.
+				_, dark := hueLightness(palette.Colors[0], space)
+				_, light := hueLightness(palette.Colors[1], space)
+				for i, c := range palette.Colors[2:] {
+					if _, l := hueLightness(c, space); l <= dark || l >= light {
+						t.Errorf("colour %d (%s) lightness %.2f outside the neutrals %.2f-%.2f", 2+i, hexes[2+i], l, dark, light)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestGenerateCategorises(t *testing.T) {
+	p := New()
+	p.base, p.scheme = "#e0af68", "triadic"
+
+	palette, err := p.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	result := colour.Categorise(palette, colour.DefaultCategorisationConfig())
+	if result.ThemeType != colour.ThemeDark {
+		t.Errorf("ThemeType = %v, want dark (the dark neutral dominates)", result.ThemeType)
+	}
+	bg, _ := result.Get(colour.RoleBackground)
+	if want := palette.ToHex()[0]; bg.Hex != want {
+		t.Errorf("background = %s, want the dark neutral %s", bg.Hex, want)
+	}
+	for _, role := range []colour.Role{colour.RoleForeground, colour.RoleAccent1, colour.RoleAccent2, colour.RoleAccent3} {
+		if _, ok := result.Get(role); !ok {
+			t.Errorf("%s missing", role)
+		}
+	}
+}
@@ -4,6 +4,8 @@ package image
 import (
 	"context"
 	"fmt"
+	goimage "image"
+	"image/color"
 	"os"
 	"slices"
 	"strconv"
@@ -30,14 +32,63 @@ const (
 	MainColorWeightRatio = 0.9
 )
 
+// algorithms maps --image.algorithm values to extraction algorithms, in the order
+// they are listed in help and errors.
+var algorithms = []struct {
+	name      string
+	algorithm colour.Algorithm
+}{
+	{"kmeans", colour.AlgorithmKMeans},
+	{"median-cut", colour.AlgorithmMedianCut},
+	{"octree", colour.AlgorithmOctree},
+	{"wu", colour.AlgorithmWu},
+}
+
+// algorithmNames is the comma-separated list of --image.algorithm values.
+var algorithmNames = func() string {
+	names := make([]string, len(algorithms))
+	for i, a := range algorithms {
+		names[i] = a.name
+	}
+	return strings.Join(names, ", ")
+}()
+
+// parseAlgorithm returns the extraction algorithm for an --image.algorithm value.
+// The --backend spelling (e.g. "mediancut") is accepted too.
+func parseAlgorithm(name string) (colour.Algorithm, error) {
+	for _, a := range algorithms {
+		if name == a.name || colour.Algorithm(name) == a.algorithm {
+			return a.algorithm, nil
+		}
+	}
+	return "", fmt.Errorf("invalid algorithm '%s' (valid: %s)", name, algorithmNames)
+}
+
 // Note: SeedMode, SeedConfig, and seed calculation functions have been moved to
 // internal/plugin/input/shared/seed package for reuse by other image-processing plugins.
 
 // Plugin implements the input.Plugin interface for image-based colour extraction.
 type Plugin struct {
-	path    string
+	paths   []string // One image per monitor; positional roles are prefixed when more than one
 	colours int
 
+	// Tonal pre-adjustment applied before extraction (in linear light).
+	brightness float64 // Linear light multiplier (1.0 = unchanged)
+	gamma      float64 // Gamma curve exponent (1.0 = unchanged)
+
+	// Cropping applied before extraction.
+	crop            string // Pixel crop region "x,y,w,h"
+	cropPercent     string // Percentage crop region "x,y,w,h"
+	autoCropBorders bool   // Remove uniform borders such as letterboxing
+	mask            string // Mask image path; dark mask pixels exclude source pixels
+
+	// Extraction algorithm.
+	algorithm string // Colour extraction algorithm (see algorithms); empty uses the --backend option (kmeans by default)
+	distance  string // K-means colour distance metric: rgb, cie76, cie2000
+
+	// Alpha handling.
+	keepAlpha bool // Keep source alpha in extracted colours instead of compositing over black
+
 	// Region extraction (ambient lighting).
 	extractAmbience bool   // Whether to extract edge/corner regions (default: false)
 	regions         int    // Number of regions to extract (4, 8, 12, 16, 0=disabled)
@@ -55,7 +106,7 @@ type Plugin struct {
 	cacheOverwrite bool   // Allow overwriting existing cached images
 
 	// Wallpaper support.
-	loadedImagePath string // Stores the actual path to the loaded image (for wallpaper setting)
+	loadedImagePaths []string // Stores the actual paths to the loaded images (for wallpaper setting)
 }
 
 // New creates a new image input plugin with default settings.
@@ -80,6 +131,11 @@ func New() *Plugin {
 
 	return &Plugin{
 		colours:         16,
+		brightness:      1.0,
+		gamma:           1.0,
+		algorithm:       "",
+		distance:        string(colour.DistanceRGB),
+		keepAlpha:       false,
 		extractAmbience: false,
 		regions:         8,
 		samplePercent:   10,
@@ -110,9 +166,26 @@ func (p *Plugin) Version() string {
 
 // RegisterFlags registers plugin-specific flags with the cobra command.
 func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(&p.path, "image.path", "p", "", "Path to image file, directory, or HTTP(S) URL (required, directories will select a random image)")
+	cmd.Flags().StringArrayVarP(&p.paths, "image.path", "p", nil, "Path to image file, directory, HTTP(S) URL or data URI (required, directories will select a random image; repeat for one image per monitor)")
 	cmd.Flags().IntVarP(&p.colours, "image.colours", "c", 16, "Number of colours to extract (1-256)")
 
+	// Tonal adjustment flags (applied in linear light before extraction).
+	cmd.Flags().Float64Var(&p.brightness, "image.brightness", 1.0, "Brightness multiplier applied before extraction (1.0 = unchanged, >1 brighter)")
+	cmd.Flags().Float64Var(&p.gamma, "image.gamma", 1.0, "Gamma correction applied before extraction (1.0 = unchanged, >1 lifts shadows)")
+
+	// Cropping flags (applied before tonal adjustment and extraction).
+	cmd.Flags().StringVar(&p.crop, "image.crop", "", "Crop to a pixel region \"x,y,w,h\" before extraction")
+	cmd.Flags().StringVar(&p.cropPercent, "image.crop-percent", "", "Crop to a region \"x,y,w,h\" given as percentages of the image size")
+	cmd.Flags().BoolVar(&p.autoCropBorders, "image.auto-crop-borders", false, "Detect and remove uniform borders (e.g. letterboxing) before extraction")
+	cmd.Flags().StringVar(&p.mask, "image.mask", "", "Mask image stretched over the source; black areas are excluded from extraction")
+
+	// Extraction algorithm flag.
+	cmd.Flags().StringVarP(&p.algorithm, "image.algorithm", "a", "", "Colour extraction algorithm: "+algorithmNames+" (default: --backend, or kmeans)")
+	cmd.Flags().StringVar(&p.distance, "image.distance", string(colour.DistanceRGB), "K-means colour distance: rgb, cie76, cie2000 (CIELAB metrics are perceptual but slower)")
+
+	// Alpha handling flags.
+	cmd.Flags().BoolVar(&p.keepAlpha, "image.keep-alpha", false, "Keep the alpha of translucent pixels in extracted colours (fully transparent pixels are ignored)")
+
 	// Region extraction flags (for ambient lighting).
 	cmd.Flags().BoolVar(&p.extractAmbience, "image.extractAmbience", false, "Extract edge/corner colors for ambient lighting (with reduced weight)")
 	cmd.Flags().IntVar(&p.regions, "image.regions", 8, "Number of edge/corner regions to extract (4, 8, 12, 16)")
@@ -120,7 +193,7 @@ func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&p.sampleMethod, "image.sample-method", "average", "Sampling method: 'average' or 'dominant'")
 
 	// Seed configuration flags.
-	cmd.Flags().StringVar(&p.seedMode, "image.seed-mode", string(seed.ModeContent), "K-means seed mode: content, filepath, manual, random")
+	cmd.Flags().StringVar(&p.seedMode, "image.seed-mode", string(seed.ModeContent), "K-means seed mode: content, filepath, name, manual, random")
 	cmd.Flags().Int64Var(&p.seedValue, "image.seed-value", 0, "K-means seed value (only used with --image.seed-mode=manual)")
 
 	// Remote image caching flags (use struct values as defaults, which may come from env vars).
@@ -132,11 +205,16 @@ func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
 
 // Validate checks if the plugin has all required inputs configured.
 func (p *Plugin) Validate() error {
-	if p.path == "" {
+	if len(p.paths) == 0 {
 		return fmt.Errorf("image path or URL is required (use --image.path or -p)")
 	}
-	if err := image.ValidateImagePath(p.path); err != nil {
-		return fmt.Errorf("invalid image path or URL: %w", err)
+	for _, path := range p.paths {
+		if path == "" {
+			return fmt.Errorf("image path or URL is required (use --image.path or -p)")
+		}
+		if err := image.ValidateImagePath(path); err != nil {
+			return fmt.Errorf("invalid image path or URL: %w", err)
+		}
 	}
 
 	// Validate colours.
@@ -144,6 +222,23 @@ func (p *Plugin) Validate() error {
 		return fmt.Errorf("colours must be between 1 and 256, got %d", p.colours)
 	}
 
+	// Validate tonal adjustment.
+	if err := p.adjustment().Validate(); err != nil {
+		return fmt.Errorf("invalid image adjustment: %w", err)
+	}
+
+	// Validate crop region.
+	if _, err := image.ParseCropFlags(p.crop, p.cropPercent); err != nil {
+		return fmt.Errorf("invalid crop: %w", err)
+	}
+
+	// Validate mask image.
+	if p.mask != "" {
+		if err := image.ValidateImagePath(p.mask); err != nil {
+			return fmt.Errorf("invalid mask image: %w", err)
+		}
+	}
+
 	// Validate regions (if ambient extraction is enabled).
 	if p.extractAmbience {
 		if _, err := regions.ConfigurationFromInt(p.regions); err != nil {
@@ -159,37 +254,114 @@ func (p *Plugin) Validate() error {
 		}
 	}
 
+	// Validate extraction algorithm.
+	if p.algorithm != "" {
+		if _, err := parseAlgorithm(p.algorithm); err != nil {
+			return err
+		}
+	}
+
+	// Validate k-means distance metric.
+	if _, err := colour.ParseDistanceMetric(p.distance); err != nil {
+		return err
+	}
+
 	// Validate seed mode.
 	validSeedModes := []string{
 		string(seed.ModeContent),
 		string(seed.ModeFilepath),
+		string(seed.ModeName),
 		string(seed.ModeManual),
 		string(seed.ModeRandom),
 	}
 	valid := slices.Contains(validSeedModes, p.seedMode)
 	if !valid {
-		return fmt.Errorf("invalid seed mode '%s' (valid: content, filepath, manual, random)", p.seedMode)
+		return fmt.Errorf("invalid seed mode '%s' (valid: content, filepath, name, manual, random)", p.seedMode)
 	}
 
 	return nil
 }
 
+// cropImage applies the configured crop region and border trimming.
+func (p *Plugin) cropImage(img goimage.Image, verbose bool) (goimage.Image, error) {
+	crop, err := image.ParseCropFlags(p.crop, p.cropPercent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid crop: %w", err)
+	}
+	if !crop.IsZero() {
+		if img, err = crop.Apply(img); err != nil {
+			return nil, fmt.Errorf("failed to crop image: %w", err)
+		}
+		if verbose {
+			fmt.Printf("→ Cropped image to %dx%d\n", img.Bounds().Dx(), img.Bounds().Dy())
+		}
+	}
+
+	if p.autoCropBorders {
+		before := img.Bounds()
+		img = image.TrimBorders(img, image.DefaultBorderTolerance)
+		if verbose && img.Bounds() != before {
+			fmt.Printf("→ Removed borders: %dx%d → %dx%d\n", before.Dx(), before.Dy(), img.Bounds().Dx(), img.Bounds().Dy())
+		}
+	}
+
+	return img, nil
+}
+
+// extractionAlgorithm returns the algorithm to extract colours with: --image.algorithm
+// when set, otherwise the generate backend, defaulting to k-means.
+func (p *Plugin) extractionAlgorithm(opts input.GenerateOptions) colour.Algorithm {
+	if p.algorithm != "" {
+		if algorithm, err := parseAlgorithm(p.algorithm); err == nil {
+			return algorithm
+		}
+		return colour.Algorithm(p.algorithm)
+	}
+	if opts.Backend != "" {
+		return colour.Algorithm(opts.Backend)
+	}
+	return colour.AlgorithmKMeans
+}
+
+// adjustment returns the configured tonal adjustment.
+func (p *Plugin) adjustment() image.Adjustment {
+	return image.Adjustment{Brightness: p.brightness, Gamma: p.gamma}
+}
+
 // WallpaperPath returns the path to the source image for wallpaper setting.
 // Implements the input.WallpaperProvider interface.
+// With multiple images this is the first (monitor 0) image.
 func (p *Plugin) WallpaperPath() string {
-	return p.loadedImagePath
+	if len(p.loadedImagePaths) == 0 {
+		return ""
+	}
+	return p.loadedImagePaths[0]
+}
+
+// WallpaperPaths returns the paths of all loaded images, one per monitor.
+func (p *Plugin) WallpaperPaths() []string {
+	return p.loadedImagePaths
 }
 
 // GetFlagHelp returns help information for all plugin flags.
 func (p *Plugin) GetFlagHelp() []input.FlagHelp {
 	return []input.FlagHelp{
-		{Name: "image.path", Shorthand: "p", Type: "string", Default: "", Description: "Path to image file, directory, or HTTP(S) URL (required)", Required: true},
+		{Name: "image.path", Shorthand: "p", Type: "string", Default: "", Description: "Path to image file, directory, HTTP(S) URL or data URI (required, repeat for one image per monitor)", Required: true},
 		{Name: "image.colours", Shorthand: "c", Type: "int", Default: "16", Description: "Number of colours to extract (1-256)", Required: false},
+		{Name: "image.brightness", Type: "float64", Default: "1.0", Description: "Brightness multiplier applied before extraction (1.0 = unchanged)", Required: false},
+		{Name: "image.gamma", Type: "float64", Default: "1.0", Description: "Gamma correction applied before extraction (1.0 = unchanged)", Required: false},
+		{Name: "image.crop", Type: "string", Default: "", Description: "Crop to a pixel region \"x,y,w,h\" before extraction", Required: false},
+		{Name: "image.crop-percent", Type: "string", Default: "", Description: "Crop to a region \"x,y,w,h\" given as percentages of the image size", Required: false},
+		{Name: "image.auto-crop-borders", Type: "bool", Default: "false", Description: "Detect and remove uniform borders before extraction", Required: false},
+		{Name: "image.mask", Type: "string", Default: "", Description: "Mask image stretched over the source; black areas are excluded from extraction", Required: false},
+		{Name: "image.algorithm", Shorthand: "a", Type: "string", Default: "", Description: "Colour extraction algorithm: " + algorithmNames + " (default: --backend, or kmeans)", Required: false},
+		{Name: "image.distance", Type: "string", Default: "rgb", Description: "K-means colour distance: rgb, cie76, cie2000", Required: false},
+		{Name: "image.keep-alpha", Type: "bool", Default: "false", Description: "Keep the alpha of translucent pixels in extracted colours", Required: false},
 		{Name: "image.extractAmbience", Type: "bool", Default: "false", Description: "Extract edge/corner colors for ambient lighting", Required: false},
 		{Name: "image.regions", Type: "int", Default: "8", Description: "Number of edge/corner regions (4, 8, 12, 16)", Required: false},
 		{Name: "image.sample-size", Type: "int", Default: "10", Description: "Percentage of edge to sample (1-50)", Required: false},
 		{Name: "image.sample-method", Type: "string", Default: "average", Description: "Sampling method: 'average' or 'dominant'", Required: false},
-		{Name: "image.seed-mode", Type: "string", Default: "content", Description: "K-means seed mode: content, filepath, manual, random", Required: false},
+		{Name: "image.seed-mode", Type: "string", Default: "content", Description: "K-means seed mode: content, filepath, name, manual, random", Required: false},
 		{Name: "image.seed-value", Type: "int64", Default: "0", Description: "K-means seed value (only used with --image.seed-mode=manual)", Required: false},
 		{Name: "image.cache", Type: "bool", Default: fmt.Sprintf("%v", p.cacheEnabled), Description: "Enable caching of remote images", Required: false},
 		{Name: "image.cache-dir", Type: "string", Default: p.cacheDir, Description: "Directory to cache downloaded images", Required: false},
@@ -199,21 +371,56 @@ func (p *Plugin) GetFlagHelp() []input.FlagHelp {
 }
 
 // Generate creates a raw colour palette by extracting colours from the image.
+// When several image paths are given (one per monitor), each image contributes its own
+// colours and, with ambient extraction, its own positional roles under a monitor prefix
+// (e.g. "monitor1.positionLeft"). The first image's regions are also exposed under the
+// unprefixed roles so existing templates keep working.
 // Returns only the extracted colors - categorization happens separately.
 func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*colour.Palette, error) {
-	// Validate the backend first before doing any expensive operations.
-	if opts.Backend != "kmeans" {
-		return nil, fmt.Errorf("invalid backend: %s (only kmeans is currently supported)", opts.Backend)
+	// Validate the algorithm first before doing any expensive operations.
+	if algorithm := p.extractionAlgorithm(opts); !colour.IsValidAlgorithm(algorithm) {
+		if p.algorithm == "" {
+			return nil, fmt.Errorf("invalid backend: %s (valid backends: %v)", algorithm, colour.ValidAlgorithms())
+		}
+		return nil, fmt.Errorf("invalid algorithm '%s' (valid: %s)", p.algorithm, algorithmNames)
 	}
 
+	if len(p.paths) == 0 {
+		return nil, fmt.Errorf("image path or URL is required (use --image.path or -p)")
+	}
+
+	p.loadedImagePaths = make([]string, 0, len(p.paths))
+	palettes := make([]*colour.Palette, 0, len(p.paths))
+
+	for monitor, path := range p.paths {
+		if opts.Verbose && len(p.paths) > 1 {
+			fmt.Printf("→ Monitor %d: %s\n", monitor, displayPath(path))
+		}
+
+		palette, err := p.extractFromPath(ctx, path, monitor, opts)
+		if err != nil {
+			if len(p.paths) > 1 {
+				return nil, fmt.Errorf("monitor %d: %w", monitor, err)
+			}
+			return nil, err
+		}
+		palettes = append(palettes, palette)
+	}
+
+	return combinePalettes(palettes), nil
+}
+
+// extractFromPath extracts the palette (and optional ambient regions) from a single image.
+// The monitor index is used to prefix positional roles when several images are given.
+func (p *Plugin) extractFromPath(ctx context.Context, path string, monitor int, opts input.GenerateOptions) (*colour.Palette, error) {
 	// Resolve the path - if it's a directory, select a random image.
-	resolvedPath, err := image.ResolveImagePath(p.path)
+	resolvedPath, err := image.ResolveImagePath(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve image path: %w", err)
 	}
 
 	// If a random image was selected from a directory, log it.
-	if opts.Verbose && resolvedPath != p.path {
+	if opts.Verbose && resolvedPath != path {
 		fmt.Printf("→ Selected random image from directory: %s\n", resolvedPath)
 	}
 
@@ -253,7 +460,41 @@ func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*col
 	}
 
 	// Store the wallpaper path (local file for remote images, original path otherwise).
-	p.loadedImagePath = wallpaperPath
+	// Inline data URIs have no file to use as a wallpaper.
+	if image.IsDataURI(resolvedPath) {
+		wallpaperPath = ""
+	}
+	p.loadedImagePaths = append(p.loadedImagePaths, wallpaperPath)
+
+	// Mask in source coordinates, before cropping, so the mask lines up with the original image.
+	if p.mask != "" {
+		mask, err := loader.Load(p.mask)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load mask image: %w", err)
+		}
+		img = image.ApplyMask(img, mask)
+		if opts.Verbose {
+			fmt.Printf("→ Masked image with %s\n", p.mask)
+		}
+	}
+
+	// Crop before anything else so borders and excluded areas never reach the palette.
+	img, err = p.cropImage(img, opts.Verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply brightness/gamma pre-adjustment so dark or washed-out images yield usable colours.
+	adjustment := p.adjustment()
+	if !adjustment.IsIdentity() {
+		if err := adjustment.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid image adjustment: %w", err)
+		}
+		if opts.Verbose {
+			fmt.Printf("→ Adjusting image (brightness: %.2f, gamma: %.2f)\n", adjustment.Brightness, adjustment.Gamma)
+		}
+		img = adjustment.Apply(img)
+	}
 
 	// Calculate seed based on configured mode using shared utility.
 	// Use the resolved path for filepath-based seeds.
@@ -277,21 +518,32 @@ func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*col
 
 	// Extract palette using k-means with deterministic seed.
 	// Create the colour extractor with seed configuration.
-	extractorOpts := colour.ExtractorOptions{}
+	extractorOpts := colour.ExtractorOptions{
+		KeepAlpha:      p.keepAlpha,
+		DistanceMetric: colour.DistanceMetric(p.distance),
+	}
 	if seedMode != seed.ModeRandom {
 		// Only set seed if not in random mode.
 		extractorOpts.Seed = &calculatedSeed
 	}
 
-	extractor, err := colour.NewExtractor(colour.Algorithm(opts.Backend), extractorOpts)
+	algorithm := p.extractionAlgorithm(opts)
+	extractor, err := colour.NewExtractor(algorithm, extractorOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create extractor: %w", err)
 	}
 
 	if opts.Verbose {
-		if extractorOpts.Seed != nil {
+		switch {
+		case algorithm == colour.AlgorithmMedianCut:
+			fmt.Printf("→ Using median cut (deterministic, seed mode not used)\n")
+		case algorithm == colour.AlgorithmOctree:
+			fmt.Printf("→ Using octree quantisation (deterministic, seed mode not used)\n")
+		case algorithm == colour.AlgorithmWu:
+			fmt.Printf("→ Using Wu quantisation (deterministic, seed mode not used)\n")
+		case extractorOpts.Seed != nil:
 			fmt.Printf("→ Using seed mode: %s (seed: %d)\n", p.seedMode, calculatedSeed)
-		} else {
+		default:
 			fmt.Printf("→ Using seed mode: %s (non-deterministic)\n", p.seedMode)
 		}
 	}
@@ -378,6 +630,11 @@ func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*col
 		palette.Weights = weights
 	}
 
+	// Give each image its own positional roles when several monitors are configured.
+	if len(p.paths) > 1 {
+		regionPalette.RoleHints = monitorRoleHints(regionPalette.RoleHints, monitor)
+	}
+
 	// Adjust role hints indices to account for the merged colors.
 	if regionPalette.RoleHints != nil {
 		if palette.RoleHints == nil {
@@ -391,3 +648,63 @@ func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*col
 
 	return palette, nil
 }
+
+// monitorRoleHints prefixes positional role hints with the monitor index.
+// Hints for the first monitor are also kept under their unprefixed role.
+func monitorRoleHints(hints map[colour.Role]int, monitor int) map[colour.Role]int {
+	prefixed := make(map[colour.Role]int, len(hints)*2)
+	for role, index := range hints {
+		prefixed[colour.MonitorRole(monitor, role)] = index
+		if monitor == 0 {
+			prefixed[role] = index
+		}
+	}
+	return prefixed
+}
+
+// combinePalettes merges per-image palettes into one, giving each image an equal share
+// of the total weight and offsetting role hint indices.
+func combinePalettes(palettes []*colour.Palette) *colour.Palette {
+	if len(palettes) == 1 {
+		return palettes[0]
+	}
+
+	combined := &colour.Palette{
+		Colors:    make([]color.Color, 0),
+		Weights:   make([]float64, 0),
+		RoleHints: make(map[colour.Role]int),
+	}
+	share := 1.0 / float64(len(palettes))
+
+	for _, palette := range palettes {
+		offset := len(combined.Colors)
+		numColors := len(palette.Colors)
+
+		total := 0.0
+		for _, w := range palette.Weights {
+			total += w
+		}
+		for i := range numColors {
+			if palette.Weights != nil && total > 0 {
+				combined.Weights = append(combined.Weights, palette.Weights[i]/total*share)
+			} else {
+				combined.Weights = append(combined.Weights, share/float64(numColors))
+			}
+		}
+
+		combined.Colors = append(combined.Colors, palette.Colors...)
+		for role, index := range palette.RoleHints {
+			combined.RoleHints[role] = index + offset
+		}
+	}
+
+	return combined
+}
+
+// displayPath shortens data URIs for log messages; other paths are returned as-is.
+func displayPath(path string) string {
+	if image.IsDataURI(path) {
+		return fmt.Sprintf("inline data URI (%d bytes)", len(path))
+	}
+	return path
+}
@@ -2,6 +2,7 @@ package image
 
 import (
 	"context"
+	"encoding/base64"
 	"image"
 	"image/color"
 	"image/png"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/jmylchreest/tinct/internal/colour"
 	"github.com/jmylchreest/tinct/internal/plugin/input"
 )
 
@@ -129,6 +131,11 @@ func TestRegisterFlags(t *testing.T) {
 	flags := []string{
 		"image.path",
 		"image.colours",
+		"image.crop",
+		"image.crop-percent",
+		"image.auto-crop-borders",
+		"image.keep-alpha",
+		"image.mask",
 		"image.extractAmbience",
 		"image.regions",
 		"image.sample-size",
@@ -164,7 +171,7 @@ func TestValidateWithPath(t *testing.T) {
 	// Note: We can't easily test successful validation without creating
 	// a valid image file, so we just test that the path is checked.
 	plugin := New()
-	plugin.path = "/tmp/nonexistent.jpg"
+	plugin.paths = []string{"/tmp/nonexistent.jpg"}
 
 	err := plugin.Validate()
 	// Should fail because file doesn't exist or isn't a valid image.
@@ -216,7 +223,7 @@ func TestWallpaperPath(t *testing.T) {
 
 	// Set a path.
 	testPath := "/tmp/test-image.jpg"
-	plugin.loadedImagePath = testPath
+	plugin.loadedImagePaths = []string{testPath}
 
 	if path := plugin.WallpaperPath(); path != testPath {
 		t.Errorf("Expected wallpaper path '%s', got '%s'", testPath, path)
@@ -243,7 +250,7 @@ func TestGenerateRequiresPath(t *testing.T) {
 // TestGenerateNonExistentFile tests generating from non-existent file.
 func TestGenerateNonExistentFile(t *testing.T) {
 	plugin := New()
-	plugin.path = "/nonexistent/file.jpg"
+	plugin.paths = []string{"/nonexistent/file.jpg"}
 
 	ctx := context.Background()
 	opts := input.GenerateOptions{
@@ -372,7 +379,7 @@ func TestGenerateWithValidImage(t *testing.T) {
 	createTestImage(t, imagePath)
 
 	plugin := New()
-	plugin.path = imagePath
+	plugin.paths = []string{imagePath}
 
 	ctx := context.Background()
 	opts := input.GenerateOptions{
@@ -415,7 +422,7 @@ func TestGenerateWithInvalidBackend(t *testing.T) {
 	createTestImage(t, imagePath)
 
 	plugin := New()
-	plugin.path = imagePath
+	plugin.paths = []string{imagePath}
 
 	ctx := context.Background()
 	opts := input.GenerateOptions{
@@ -428,6 +435,145 @@ func TestGenerateWithInvalidBackend(t *testing.T) {
 	}
 }
 
+// TestGenerateWithMedianCutBackend tests extracting with the median cut backend.
+func TestGenerateWithMedianCutBackend(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "test.png")
+	createTestImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "mediancut"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(palette.Colors) == 0 {
+		t.Error("Generate() returned empty palette")
+	}
+}
+
+// TestGenerateWithOctreeBackend tests extracting with the octree backend.
+func TestGenerateWithOctreeBackend(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "test.png")
+	createTestImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "octree"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(palette.Colors) == 0 {
+		t.Error("Generate() returned empty palette")
+	}
+}
+
+// TestGenerateWithAlgorithm tests --image.algorithm overrides the backend option.
+func TestGenerateWithAlgorithm(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "test.png")
+	createTestImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+	plugin.colours = 2
+	plugin.algorithm = "wu"
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "invalid-backend"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(palette.Colors) != 2 {
+		t.Errorf("Generate() returned %d colours, want 2", len(palette.Colors))
+	}
+
+	// Without an algorithm or backend (e.g. tinct extract), k-means is used.
+	plugin.algorithm = ""
+	if got := plugin.extractionAlgorithm(input.GenerateOptions{}); got != colour.AlgorithmKMeans {
+		t.Errorf("extractionAlgorithm() = %q, want kmeans", got)
+	}
+	if got := plugin.extractionAlgorithm(input.GenerateOptions{Backend: "octree"}); got != colour.AlgorithmOctree {
+		t.Errorf("extractionAlgorithm() = %q, want the backend", got)
+	}
+}
+
+// TestGenerateFromDataURI tests extracting colours from an inline base64 PNG.
+func TestGenerateFromDataURI(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "test.png")
+	createTestImage(t, imagePath)
+	data, err := os.ReadFile(imagePath)
+	if err != nil {
+		t.Fatalf("failed to read test image: %v", err)
+	}
+
+	plugin := New()
+	plugin.paths = []string{"data:image/png;base64," + base64.StdEncoding.EncodeToString(data)}
+	plugin.colours = 4
+	if err := plugin.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(palette.Colors) != 4 {
+		t.Errorf("Generate() returned %d colours, want 4", len(palette.Colors))
+	}
+	if got := plugin.WallpaperPath(); got != "" {
+		t.Errorf("WallpaperPath() = %q, want none for an inline image", got)
+	}
+}
+
+// TestValidateAlgorithm tests validation of --image.algorithm.
+func TestValidateAlgorithm(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "test.png")
+	createTestImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+
+	for _, algorithm := range []string{"", "kmeans", "median-cut", "mediancut", "octree", "wu"} {
+		plugin.algorithm = algorithm
+		if err := plugin.Validate(); err != nil {
+			t.Errorf("Validate() with algorithm %q error = %v", algorithm, err)
+		}
+	}
+
+	plugin.algorithm = "dominant"
+	err := plugin.Validate()
+	if err == nil {
+		t.Fatal("expected error for unimplemented algorithm")
+	}
+	if want := "invalid algorithm 'dominant' (valid: kmeans, median-cut, octree, wu)"; err.Error() != want {
+		t.Errorf("Validate() error = %q, want %q", err, want)
+	}
+}
+
+// TestParseAlgorithm tests --image.algorithm values map to extraction algorithms.
+func TestParseAlgorithm(t *testing.T) {
+	tests := map[string]colour.Algorithm{
+		"kmeans":     colour.AlgorithmKMeans,
+		"median-cut": colour.AlgorithmMedianCut,
+		"mediancut":  colour.AlgorithmMedianCut,
+		"octree":     colour.AlgorithmOctree,
+		"wu":         colour.AlgorithmWu,
+	}
+	for name, want := range tests {
+		got, err := parseAlgorithm(name)
+		if err != nil || got != want {
+			t.Errorf("parseAlgorithm(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+
+	// The flag value reaches the extractor.
+	plugin := New()
+	plugin.algorithm = "median-cut"
+	if got := plugin.extractionAlgorithm(input.GenerateOptions{Backend: "kmeans"}); got != colour.AlgorithmMedianCut {
+		t.Errorf("extractionAlgorithm() = %q, want mediancut", got)
+	}
+}
+
 // createTestImage creates a simple PNG image for testing with distinct colors.
 func createTestImage(t *testing.T, path string) {
 	t.Helper()
@@ -474,3 +620,538 @@ func createTestImage(t *testing.T, path string) {
 		t.Fatalf("Failed to encode PNG: %v", err)
 	}
 }
+
+// TestAdjustmentShiftsPaletteLuminance verifies brightness/gamma shift extracted luminance predictably.
+func TestAdjustmentShiftsPaletteLuminance(t *testing.T) {
+	tempDir := t.TempDir()
+	imagePath := filepath.Join(tempDir, "test.png")
+	createTestImage(t, imagePath)
+
+	averageLuminance := func(brightness, gamma float64) float64 {
+		t.Helper()
+		plugin := New()
+		plugin.paths = []string{imagePath}
+		plugin.colours = 4
+		plugin.brightness = brightness
+		plugin.gamma = gamma
+
+		palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "kmeans"})
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		total := 0.0
+		for _, c := range palette.Colors {
+			total += colour.Luminance(c)
+		}
+		return total / float64(len(palette.Colors))
+	}
+
+	base := averageLuminance(1.0, 1.0)
+
+	tests := []struct {
+		name       string
+		brightness float64
+		gamma      float64
+		brighter   bool
+	}{
+		{"brightness up", 1.8, 1.0, true},
+		{"brightness down", 0.5, 1.0, false},
+		{"gamma up", 1.0, 2.0, true},
+		{"gamma down", 1.0, 0.6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := averageLuminance(tt.brightness, tt.gamma)
+			if tt.brighter && got <= base {
+				t.Errorf("expected luminance above %.4f, got %.4f", base, got)
+			}
+			if !tt.brighter && got >= base {
+				t.Errorf("expected luminance below %.4f, got %.4f", base, got)
+			}
+		})
+	}
+
+	// Brightness operates in linear light, so halving it should roughly halve luminance.
+	halved := averageLuminance(0.5, 1.0)
+	if ratio := halved / base; ratio < 0.45 || ratio > 0.55 {
+		t.Errorf("expected halving brightness to halve luminance, got ratio %.3f", ratio)
+	}
+}
+
+// TestValidateAdjustment tests validation of brightness and gamma values.
+func TestValidateAdjustment(t *testing.T) {
+	tempDir := t.TempDir()
+	imagePath := filepath.Join(tempDir, "test.png")
+	createTestImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+
+	plugin.brightness = 0
+	if err := plugin.Validate(); err == nil {
+		t.Error("expected error for zero brightness")
+	}
+
+	plugin.brightness = 1.0
+	plugin.gamma = -1
+	if err := plugin.Validate(); err == nil {
+		t.Error("expected error for negative gamma")
+	}
+
+	plugin.gamma = 2.2
+	if err := plugin.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// createSolidImage writes a single-colour PNG so region colours are predictable.
+func createSolidImage(t *testing.T, path string, c color.RGBA) {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := range 64 {
+		for x := range 64 {
+			img.Set(x, y, c)
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create image file: %v", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+}
+
+// TestMultipleImagesPerMonitorRegions verifies each image's regions are keyed by monitor.
+func TestMultipleImagesPerMonitorRegions(t *testing.T) {
+	tempDir := t.TempDir()
+	red := color.RGBA{R: 200, G: 30, B: 30, A: 255}
+	blue := color.RGBA{R: 30, G: 30, B: 200, A: 255}
+	leftPath := filepath.Join(tempDir, "left.png")
+	rightPath := filepath.Join(tempDir, "right.png")
+	createSolidImage(t, leftPath, red)
+	createSolidImage(t, rightPath, blue)
+
+	plugin := New()
+	plugin.paths = []string{leftPath, rightPath}
+	plugin.colours = 2
+	plugin.extractAmbience = true
+	plugin.regions = 4
+
+	if err := plugin.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "kmeans"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	hintColour := func(role colour.Role) (colour.RGB, bool) {
+		index, ok := palette.RoleHints[role]
+		if !ok {
+			return colour.RGB{}, false
+		}
+		return colour.ToRGB(palette.Colors[index]), true
+	}
+
+	for monitor, want := range []color.RGBA{red, blue} {
+		role := colour.MonitorRole(monitor, colour.RolePositionTopLeft)
+		got, ok := hintColour(role)
+		if !ok {
+			t.Fatalf("missing role hint %s", role)
+		}
+		if got != colour.ToRGB(want) {
+			t.Errorf("%s = %s, want %s", role, got.Hex(), colour.ToRGB(want).Hex())
+		}
+	}
+
+	// Monitor 0 regions are also available under the unprefixed roles.
+	if got, ok := hintColour(colour.RolePositionTopLeft); !ok || got != colour.ToRGB(red) {
+		t.Errorf("unprefixed %s should match monitor 0, got %s", colour.RolePositionTopLeft, got.Hex())
+	}
+
+	if paths := plugin.WallpaperPaths(); len(paths) != 2 || paths[0] != leftPath || paths[1] != rightPath {
+		t.Errorf("WallpaperPaths() = %v", paths)
+	}
+	if plugin.WallpaperPath() != leftPath {
+		t.Errorf("WallpaperPath() = %s, want %s", plugin.WallpaperPath(), leftPath)
+	}
+
+	// Each image receives an equal share of the total weight.
+	total := 0.0
+	for _, w := range palette.Weights {
+		total += w
+	}
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("combined weights sum to %.4f, want 1.0", total)
+	}
+}
+
+// TestSingleImageRegionsUnprefixed verifies a single image keeps plain positional roles.
+func TestSingleImageRegionsUnprefixed(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "solid.png")
+	createSolidImage(t, imagePath, color.RGBA{R: 40, G: 160, B: 90, A: 255})
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+	plugin.colours = 2
+	plugin.extractAmbience = true
+	plugin.regions = 4
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "kmeans"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if _, ok := palette.RoleHints[colour.RolePositionTopLeft]; !ok {
+		t.Error("single image should expose unprefixed positional roles")
+	}
+	if _, ok := palette.RoleHints[colour.MonitorRole(0, colour.RolePositionTopLeft)]; ok {
+		t.Error("single image should not add monitor-prefixed roles")
+	}
+}
+
+// paletteContains reports whether the palette has a colour within 30 of want on each channel.
+func paletteContains(palette *colour.Palette, want color.RGBA) bool {
+	near := func(a, b uint8) bool { return max(a, b)-min(a, b) <= 30 }
+	for _, c := range palette.Colors {
+		rgb := colour.ToRGB(c)
+		if near(rgb.R, want.R) && near(rgb.G, want.G) && near(rgb.B, want.B) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestCropChangesPalette verifies cropping restricts extraction to the selected region.
+func TestCropChangesPalette(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "split.png")
+	red := color.RGBA{R: 210, G: 40, B: 40, A: 255}
+	blue := color.RGBA{R: 40, G: 40, B: 210, A: 255}
+
+	img := image.NewRGBA(image.Rect(0, 0, 80, 40))
+	for y := range 40 {
+		for x := range 80 {
+			if x < 40 {
+				img.Set(x, y, red)
+			} else {
+				img.Set(x, y, blue)
+			}
+		}
+	}
+	writePNG(t, imagePath, img)
+
+	extract := func(crop, cropPercent string) *colour.Palette {
+		t.Helper()
+		plugin := New()
+		plugin.paths = []string{imagePath}
+		plugin.colours = 2
+		plugin.crop = crop
+		plugin.cropPercent = cropPercent
+		if err := plugin.Validate(); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "kmeans"})
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		return palette
+	}
+
+	full := extract("", "")
+	if !paletteContains(full, red) || !paletteContains(full, blue) {
+		t.Fatalf("uncropped palette should contain red and blue: %v", full.ToHex())
+	}
+
+	left := extract("0,0,40,40", "")
+	if !paletteContains(left, red) || paletteContains(left, blue) {
+		t.Errorf("pixel crop of the left half should contain only red: %v", left.ToHex())
+	}
+
+	right := extract("", "50,0,50,100")
+	if !paletteContains(right, blue) || paletteContains(right, red) {
+		t.Errorf("percent crop of the right half should contain only blue: %v", right.ToHex())
+	}
+}
+
+// TestAutoCropBordersRemovesBlackBorder verifies letterboxing is excluded from the palette.
+func TestAutoCropBordersRemovesBlackBorder(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "letterboxed.png")
+	black := color.RGBA{A: 255}
+	teal := color.RGBA{R: 30, G: 170, B: 160, A: 255}
+
+	img := image.NewRGBA(image.Rect(0, 0, 60, 60))
+	for y := range 60 {
+		for x := range 60 {
+			if y < 15 || y >= 45 {
+				img.Set(x, y, black)
+			} else {
+				img.Set(x, y, teal)
+			}
+		}
+	}
+	writePNG(t, imagePath, img)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+	plugin.colours = 2
+	plugin.autoCropBorders = true
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "kmeans"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if paletteContains(palette, black) {
+		t.Errorf("auto-cropped palette should not contain the black border: %v", palette.ToHex())
+	}
+	if !paletteContains(palette, teal) {
+		t.Errorf("auto-cropped palette should contain the image content: %v", palette.ToHex())
+	}
+}
+
+// TestValidateCrop tests validation of crop flags.
+func TestValidateCrop(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "test.png")
+	createTestImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+
+	plugin.crop = "0,0,10"
+	if err := plugin.Validate(); err == nil {
+		t.Error("expected error for malformed crop")
+	}
+
+	plugin.crop = "0,0,10,10"
+	plugin.cropPercent = "0,0,50,50"
+	if err := plugin.Validate(); err == nil {
+		t.Error("expected error when crop and crop-percent are both set")
+	}
+}
+
+// writePNG encodes img to path.
+func writePNG(t *testing.T, path string, img image.Image) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create image file: %v", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+}
+
+// createTranslucentImage creates a PNG with a fully transparent strip, a
+// semi-transparent red region and an opaque blue region.
+func createTranslucentImage(t *testing.T, path string) {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+	for y := range 100 {
+		for x := range 100 {
+			switch {
+			case x < 10:
+				img.SetNRGBA(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: 0})
+			case x < 50:
+				// Slight variation so extraction has to cluster.
+				img.SetNRGBA(x, y, color.NRGBA{R: 200, G: uint8(60 + x%5), B: 60, A: 128})
+			default:
+				img.SetNRGBA(x, y, color.NRGBA{R: 40, G: 90, B: 200, A: 255})
+			}
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create image file: %v", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+}
+
+// TestGenerateKeepAlpha verifies source alpha survives extraction and categorisation.
+func TestGenerateKeepAlpha(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "translucent.png")
+	createTranslucentImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+	plugin.colours = 2
+	plugin.keepAlpha = true
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "kmeans"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(palette.Colors) != 2 {
+		t.Fatalf("Expected 2 colours, got %d", len(palette.Colors))
+	}
+
+	var translucent, opaque bool
+	for _, c := range palette.Colors {
+		rgba := colour.ToRGBA(c)
+		switch {
+		case rgba.A == 255 && rgba.B == 200:
+			opaque = true
+		case rgba.A >= 127 && rgba.A <= 129 && rgba.R == 200:
+			translucent = true
+		default:
+			t.Errorf("Unexpected colour %+v (transparent pixels should be ignored)", rgba)
+		}
+	}
+	if !translucent || !opaque {
+		t.Errorf("Expected a translucent red and an opaque blue, got %v", palette.Colors)
+	}
+
+	config := colour.DefaultCategorisationConfig()
+	config.ThemeType = colour.ThemeDark
+	categorised := colour.Categorise(palette, config)
+
+	found := false
+	for _, cc := range categorised.AllColours {
+		if cc.RGBA.A != 255 {
+			found = true
+			if cc.RGB != cc.RGBA.ToRGB() {
+				t.Errorf("RGB %+v does not match RGBA %+v", cc.RGB, cc.RGBA)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected categorised palette to keep a translucent colour")
+	}
+}
+
+// TestGenerateDiscardsAlphaByDefault verifies translucent pixels are flattened over black.
+func TestGenerateDiscardsAlphaByDefault(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "translucent.png")
+	createTranslucentImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+	plugin.colours = 3
+
+	palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "kmeans"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, c := range palette.Colors {
+		if rgba := colour.ToRGBA(c); rgba.A != 255 {
+			t.Errorf("Expected opaque colour, got %+v", rgba)
+		}
+	}
+}
+
+// TestGenerateWithMask verifies colours under black mask areas are excluded.
+func TestGenerateWithMask(t *testing.T) {
+	tempDir := t.TempDir()
+	imagePath := filepath.Join(tempDir, "test.png")
+	createTestImage(t, imagePath)
+
+	// A 20x20 mask that is black over the bottom-right (purple) quadrant; it is
+	// stretched over the 100x100 image.
+	mask := image.NewGray(image.Rect(0, 0, 20, 20))
+	for y := range 20 {
+		for x := range 20 {
+			if x < 10 || y < 10 {
+				mask.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+	maskPath := filepath.Join(tempDir, "mask.png")
+	f, err := os.Create(maskPath)
+	if err != nil {
+		t.Fatalf("Failed to create mask file: %v", err)
+	}
+	if err := png.Encode(f, mask); err != nil {
+		t.Fatalf("Failed to encode mask: %v", err)
+	}
+	f.Close()
+
+	purple := colour.RGB{R: 187, G: 154, B: 247}
+	extract := func(maskPath string) map[colour.RGB]bool {
+		t.Helper()
+		plugin := New()
+		plugin.paths = []string{imagePath}
+		plugin.colours = 4
+		plugin.mask = maskPath
+		if err := plugin.Validate(); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+
+		palette, err := plugin.Generate(context.Background(), input.GenerateOptions{Backend: "kmeans"})
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		found := make(map[colour.RGB]bool)
+		for _, c := range palette.Colors {
+			found[colour.ToRGB(c)] = true
+		}
+		return found
+	}
+
+	if !extract("")[purple] {
+		t.Fatal("purple should be extracted without a mask")
+	}
+
+	masked := extract(maskPath)
+	if masked[purple] {
+		t.Error("purple is under the black mask area and should not be extracted")
+	}
+	for _, want := range []colour.RGB{{R: 26, G: 27, B: 38}, {R: 122, G: 162, B: 247}, {R: 192, G: 202, B: 245}} {
+		if !masked[want] {
+			t.Errorf("unmasked colour %s missing from palette", want.Hex())
+		}
+	}
+	if masked[colour.RGB{}] {
+		t.Error("masked pixels should be ignored, not extracted as black")
+	}
+}
+
+// TestValidateMissingMask tests validation of a mask path that does not exist.
+func TestValidateMissingMask(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "test.png")
+	createTestImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+	plugin.mask = filepath.Join(t.TempDir(), "missing.png")
+
+	if err := plugin.Validate(); err == nil {
+		t.Error("Validate() should fail for a missing mask image")
+	}
+}
+
+// TestValidateDistance tests --image.distance validation.
+func TestValidateDistance(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "test.png")
+	createTestImage(t, imagePath)
+
+	plugin := New()
+	plugin.paths = []string{imagePath}
+
+	for _, distance := range []string{"rgb", "cie76", "cie2000"} {
+		plugin.distance = distance
+		if err := plugin.Validate(); err != nil {
+			t.Errorf("Validate() with distance %q error = %v", distance, err)
+		}
+	}
+
+	plugin.distance = "lab"
+	if err := plugin.Validate(); err == nil {
+		t.Error("expected error for invalid distance metric")
+	}
+}
@@ -0,0 +1,278 @@
+// Package named provides an input plugin serving well-known built-in colour schemes
+// such as Catppuccin, Nord, Dracula and Gruvbox.
+package named
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+)
+
+// paletteFiles holds one JSON definition per built-in palette.
+//
+//go:embed palettes/*.json
+var paletteFiles embed.FS
+
+// hintRoles are the roles a palette definition may assign to its colours.
+var hintRoles = map[colour.Role]bool{
+	colour.RoleBackground:   true,
+	colour.RoleForeground:   true,
+	colour.RoleAccent1:      true,
+	colour.RoleAccent2:      true,
+	colour.RoleAccent3:      true,
+	colour.RoleAccent4:      true,
+	colour.RoleDanger:       true,
+	colour.RoleWarning:      true,
+	colour.RoleSuccess:      true,
+	colour.RoleInfo:         true,
+	colour.RoleNotification: true,
+}
+
+// Definition is a built-in palette as stored in palettes/<name>.json.
+type Definition struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Theme       string             `json:"theme"` // "dark" or "light"
+	Colours     []DefinitionColour `json:"colours"`
+}
+
+// DefinitionColour is one colour of a built-in palette. Role is optional and
+// pins the colour to that role during categorisation.
+type DefinitionColour struct {
+	Name string      `json:"name"`
+	Hex  string      `json:"hex"`
+	Role colour.Role `json:"role,omitempty"`
+}
+
+var (
+	definitionsOnce sync.Once
+	definitions     []Definition
+	definitionsErr  error
+)
+
+// Definitions returns the built-in palettes sorted by name.
+func Definitions() ([]Definition, error) {
+	definitionsOnce.Do(func() {
+		definitions, definitionsErr = loadDefinitions()
+	})
+	return definitions, definitionsErr
+}
+
+// loadDefinitions parses and validates the embedded palette definitions.
+func loadDefinitions() ([]Definition, error) {
+	entries, err := paletteFiles.ReadDir("palettes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in palettes: %w", err)
+	}
+
+	defs := make([]Definition, 0, len(entries))
+	for _, entry := range entries {
+		data, err := paletteFiles.ReadFile(path.Join("palettes", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read built-in palette %s: %w", entry.Name(), err)
+		}
+
+		var def Definition
+		if err := json.Unmarshal(data, &def); err != nil {
+			return nil, fmt.Errorf("failed to parse built-in palette %s: %w", entry.Name(), err)
+		}
+		if err := def.validate(); err != nil {
+			return nil, fmt.Errorf("invalid built-in palette %s: %w", entry.Name(), err)
+		}
+		defs = append(defs, def)
+	}
+
+	slices.SortFunc(defs, func(a, b Definition) int { return strings.Compare(a.Name, b.Name) })
+	return defs, nil
+}
+
+// validate checks that the definition has valid colours, known roles assigned at
+// most once, and a background and foreground.
+func (d Definition) validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if d.Theme != "dark" && d.Theme != "light" {
+		return fmt.Errorf("theme must be dark or light, got %q", d.Theme)
+	}
+
+	assigned := make(map[colour.Role]bool)
+	for _, c := range d.Colours {
+		if _, err := colour.ParseHex(c.Hex); err != nil {
+			return fmt.Errorf("colour %s: %w", c.Name, err)
+		}
+		if c.Role == "" {
+			continue
+		}
+		if !hintRoles[c.Role] {
+			return fmt.Errorf("colour %s: unsupported role %q", c.Name, c.Role)
+		}
+		if assigned[c.Role] {
+			return fmt.Errorf("role %s assigned more than once", c.Role)
+		}
+		assigned[c.Role] = true
+	}
+
+	if !assigned[colour.RoleBackground] || !assigned[colour.RoleForeground] {
+		return fmt.Errorf("background and foreground roles are required")
+	}
+	return nil
+}
+
+// Lookup returns the built-in palette with the given name (case-insensitive).
+func Lookup(name string) (Definition, error) {
+	defs, err := Definitions()
+	if err != nil {
+		return Definition{}, err
+	}
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, def := range defs {
+		if def.Name == name {
+			return def, nil
+		}
+	}
+	return Definition{}, fmt.Errorf("unknown palette '%s' (available: %s)", name, strings.Join(Names(), ", "))
+}
+
+// Names returns the names of the built-in palettes, sorted.
+func Names() []string {
+	defs, _ := Definitions()
+	names := make([]string, len(defs))
+	for i, def := range defs {
+		names[i] = def.Name
+	}
+	return names
+}
+
+// ListPalettes writes the built-in palettes, one per line, to w.
+func ListPalettes(w io.Writer) error {
+	defs, err := Definitions()
+	if err != nil {
+		return err
+	}
+
+	width := 0
+	for _, def := range defs {
+		width = max(width, len(def.Name))
+	}
+	for _, def := range defs {
+		if _, err := fmt.Fprintf(w, "%-*s  %-5s  %s\n", width, def.Name, def.Theme, def.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Palette converts the definition to a palette with role hints for its assigned roles.
+func (d Definition) Palette() *colour.Palette {
+	colors := make([]color.Color, 0, len(d.Colours))
+	roleHints := make(map[colour.Role]int)
+	for _, c := range d.Colours {
+		rgb, _ := colour.ParseHex(c.Hex) // Validated when loaded.
+		if c.Role != "" {
+			roleHints[c.Role] = len(colors)
+		}
+		colors = append(colors, rgb.ToColor())
+	}
+	return colour.NewPaletteWithRoleHints(colors, roleHints)
+}
+
+// Plugin implements the input.Plugin interface for built-in named palettes.
+type Plugin struct {
+	palette string
+	list    bool
+}
+
+// New creates a new named palette input plugin.
+func New() *Plugin {
+	return &Plugin{}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "named"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Use a built-in colour scheme (Catppuccin, Nord, Dracula, Gruvbox, ...)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.palette, "named.palette", "", "Built-in palette name, e.g. catppuccin-mocha (required, see --named.list)")
+	cmd.Flags().BoolVar(&p.list, "named.list", false, "List the built-in palettes and exit")
+}
+
+// Validate checks if the plugin has all required inputs configured.
+func (p *Plugin) Validate() error {
+	// Skip validation if just listing palettes.
+	if p.list {
+		return nil
+	}
+	if p.palette == "" {
+		return fmt.Errorf("--named.palette is required (see --named.list)")
+	}
+	if _, err := Lookup(p.palette); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "named.palette", Type: "string", Default: "", Description: "Built-in palette name, e.g. catppuccin-mocha (required, see --named.list)", Required: true},
+		{Name: "named.list", Type: "bool", Default: "false", Description: "List the built-in palettes and exit", Required: false},
+	}
+}
+
+// Generate returns the selected built-in palette.
+func (p *Plugin) Generate(_ context.Context, opts input.GenerateOptions) (*colour.Palette, error) {
+	// If the list flag is set, list palettes and exit.
+	if p.list {
+		if err := ListPalettes(os.Stdout); err != nil {
+			return nil, fmt.Errorf("failed to list palettes: %w", err)
+		}
+		os.Exit(0)
+	}
+
+	def, err := Lookup(p.palette)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Verbose {
+		fmt.Printf("→ Using built-in palette: %s (%d colours, %s)\n", def.Name, len(def.Colours), def.Theme)
+	}
+
+	return def.Palette(), nil
+}
+
+// ThemeHint returns the theme type of the selected palette.
+func (p *Plugin) ThemeHint() string {
+	def, err := Lookup(p.palette)
+	if err != nil {
+		return ""
+	}
+	return def.Theme
+}
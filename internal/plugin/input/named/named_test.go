@@ -0,0 +1,163 @@
+// Package named provides tests for the named palette input plugin.
+package named
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+)
+
+func TestDefinitionsLoad(t *testing.T) {
+	defs, err := Definitions()
+	if err != nil {
+		t.Fatalf("Definitions() error = %v", err)
+	}
+
+	names := Names()
+	for _, want := range []string{"catppuccin-mocha", "dracula", "gruvbox-dark", "nord", "solarized-dark"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("Names() = %v, missing %s", names, want)
+		}
+	}
+	if !slices.IsSorted(names) {
+		t.Errorf("Names() = %v, want sorted", names)
+	}
+
+	for _, def := range defs {
+		if def.Description == "" {
+			t.Errorf("%s has no description", def.Name)
+		}
+		if len(def.Colours) < 8 {
+			t.Errorf("%s has %d colours, want at least 8", def.Name, len(def.Colours))
+		}
+	}
+}
+
+func TestDefinitionValidate(t *testing.T) {
+	valid := Definition{
+		Name:  "test",
+		Theme: "dark",
+		Colours: []DefinitionColour{
+			{Name: "bg", Hex: "#000000", Role: colour.RoleBackground},
+			{Name: "fg", Hex: "#ffffff", Role: colour.RoleForeground},
+		},
+	}
+	if err := valid.validate(); err != nil {
+		t.Errorf("validate() error = %v", err)
+	}
+
+	tests := map[string]func(d *Definition){
+		"bad theme":      func(d *Definition) { d.Theme = "dim" },
+		"bad hex":        func(d *Definition) { d.Colours[0].Hex = "#00000g" },
+		"unknown role":   func(d *Definition) { d.Colours[0].Role = "sidebar" },
+		"duplicate role": func(d *Definition) { d.Colours[1].Role = colour.RoleBackground },
+		"no foreground":  func(d *Definition) { d.Colours[1].Role = "" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			def := valid
+			def.Colours = slices.Clone(valid.Colours)
+			mutate(&def)
+			if err := def.validate(); err == nil {
+				t.Error("validate() should fail")
+			}
+		})
+	}
+}
+
+func TestGenerateCategorisesCanonicalRoles(t *testing.T) {
+	tests := []struct {
+		palette   string
+		hint      string
+		theme     colour.ThemeType
+		wantRoles map[colour.Role]string
+	}{
+		{
+			palette: "catppuccin-mocha",
+			hint:    "dark",
+			theme:   colour.ThemeDark,
+			wantRoles: map[colour.Role]string{
+				colour.RoleBackground: "#1e1e2e",
+				colour.RoleForeground: "#cdd6f4",
+				colour.RoleAccent1:    "#89b4fa",
+				colour.RoleDanger:     "#f38ba8",
+			},
+		},
+		{
+			palette: "Solarized-Light",
+			hint:    "light",
+			theme:   colour.ThemeLight,
+			wantRoles: map[colour.Role]string{
+				colour.RoleBackground: "#fdf6e3",
+				colour.RoleForeground: "#657b83",
+				colour.RoleAccent1:    "#268bd2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.palette, func(t *testing.T) {
+			p := New()
+			p.palette = tt.palette
+			if err := p.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := p.ThemeHint(); got != tt.hint {
+				t.Errorf("ThemeHint() = %q, want %q", got, tt.hint)
+			}
+
+			palette, err := p.Generate(context.Background(), input.GenerateOptions{})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			result := colour.Categorise(palette, colour.DefaultCategorisationConfig())
+			if result.ThemeType != tt.theme {
+				t.Errorf("ThemeType = %v, want %v", result.ThemeType, tt.theme)
+			}
+			for role, want := range tt.wantRoles {
+				if got, _ := result.Get(role); got.Hex != want {
+					t.Errorf("%s = %s, want %s", role, got.Hex, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateUnknownPalette(t *testing.T) {
+	p := New()
+	if err := p.Validate(); err == nil {
+		t.Error("Validate() without a palette should fail")
+	}
+
+	p.palette = "not-a-theme"
+	err := p.Validate()
+	if err == nil || !strings.Contains(err.Error(), "nord") {
+		t.Errorf("Validate() error = %v, want the available palettes listed", err)
+	}
+
+	p.list = true
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate() with --named.list error = %v", err)
+	}
+}
+
+func TestListPalettes(t *testing.T) {
+	var out bytes.Buffer
+	if err := ListPalettes(&out); err != nil {
+		t.Fatalf("ListPalettes() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(Names()) {
+		t.Fatalf("ListPalettes() wrote %d lines, want %d", len(lines), len(Names()))
+	}
+	if !strings.HasPrefix(lines[0], Names()[0]) || !strings.Contains(out.String(), "dracula") {
+		t.Errorf("ListPalettes() output:\n%s", out.String())
+	}
+}
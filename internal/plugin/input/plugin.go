@@ -27,6 +27,25 @@ type GenerateOptions struct {
 
 	// PluginArgs are custom arguments for this plugin.
 	PluginArgs map[string]any
+
+	// Count is the number of colours to extract (0 = plugin default).
+	Count int
+
+	// SeedMode is the seed mode for non-deterministic extraction ("" = plugin default).
+	SeedMode string
+
+	// SeedValue is the manual seed value, nil when not given.
+	SeedValue *int64
+
+	// ExtractAmbience requests edge and corner colours for ambient lighting.
+	ExtractAmbience bool
+
+	// Regions is the number of ambient regions to extract (0 = plugin default).
+	Regions int
+
+	// OnError controls recovery when the plugin is rate limited.
+	// Plugins that keep a cache honour OnErrorUseCache; the CLI handles OnErrorFallback.
+	OnError ErrorPolicy
 }
 
 // GenerateResult holds the result of input plugin generation.
@@ -0,0 +1,205 @@
+// Package pywal provides an input plugin that imports a pywal colour scheme from
+// its colors.json cache file, so an existing pywal theme carries over to tinct.
+package pywal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+)
+
+// ansiColourCount is the number of colours pywal writes, color0 to color15.
+const ansiColourCount = 16
+
+// colorsFile is the part of pywal's colors.json that tinct reads.
+type colorsFile struct {
+	Wallpaper string            `json:"wallpaper"`
+	Special   specialColours    `json:"special"`
+	Colors    map[string]string `json:"colors"`
+}
+
+// specialColours is the "special" section of pywal's colors.json.
+type specialColours struct {
+	Background string `json:"background"`
+	Foreground string `json:"foreground"`
+	Cursor     string `json:"cursor"`
+}
+
+// DefaultPath returns where pywal writes colors.json: $PYWAL_CACHE_DIR/colors.json,
+// or ~/.cache/wal/colors.json when the variable is unset.
+func DefaultPath() (string, error) {
+	if dir := os.Getenv("PYWAL_CACHE_DIR"); dir != "" {
+		return filepath.Join(dir, "colors.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".cache", "wal", "colors.json"), nil
+}
+
+// Plugin implements the input.Plugin interface for pywal scheme import.
+type Plugin struct {
+	path string
+}
+
+// New creates a new pywal input plugin.
+func New() *Plugin {
+	return &Plugin{}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "pywal"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Import a pywal colour scheme from its colors.json cache"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.path, "pywal.file", "", "Path to a pywal colors.json (default: ~/.cache/wal/colors.json)")
+}
+
+// Validate checks if the plugin has all required inputs configured.
+func (p *Plugin) Validate() error {
+	path, err := p.resolvePath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no pywal colour scheme at %s (run wal first or pass --pywal.file)", path)
+		}
+		return fmt.Errorf("failed to access %s: %w", path, err)
+	}
+	return nil
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "pywal.file", Type: "string", Default: "~/.cache/wal/colors.json", Description: "Path to a pywal colors.json", Required: false},
+	}
+}
+
+// Generate loads the pywal scheme as a palette of color0 to color15, with its
+// special background and foreground pinned as role hints.
+func (p *Plugin) Generate(_ context.Context, opts input.GenerateOptions) (*colour.Palette, error) {
+	path, err := p.resolvePath()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := loadColorsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Verbose {
+		fmt.Printf("→ Importing pywal scheme: %s\n", path)
+		if scheme.Wallpaper != "" {
+			fmt.Printf("  Wallpaper: %s\n", scheme.Wallpaper)
+		}
+	}
+
+	return scheme.palette()
+}
+
+// resolvePath returns --pywal.file, or pywal's default cache file.
+func (p *Plugin) resolvePath() (string, error) {
+	if p.path != "" {
+		return p.path, nil
+	}
+	return DefaultPath()
+}
+
+// loadColorsFile reads and parses a pywal colors.json.
+func loadColorsFile(path string) (*colorsFile, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - User-specified pywal cache file, intended to be read
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pywal colour scheme: %w", err)
+	}
+
+	var scheme colorsFile
+	if err := json.Unmarshal(data, &scheme); err != nil {
+		return nil, fmt.Errorf("failed to parse pywal colour scheme %s: %w", path, err)
+	}
+	return &scheme, nil
+}
+
+// palette converts the scheme to a palette. The colour list is color0 to color15
+// in order; the background and foreground hint at their matching colour, or are
+// appended when pywal's special colours differ from all sixteen.
+func (s *colorsFile) palette() (*colour.Palette, error) {
+	colours := make([]colour.RGB, 0, ansiColourCount+2)
+	for i := range ansiColourCount {
+		key := "color" + strconv.Itoa(i)
+		hex, ok := s.Colors[key]
+		if !ok {
+			return nil, fmt.Errorf("not a pywal colour scheme: missing colors.%s", key)
+		}
+		rgb, err := colour.ParseHex(hex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid colors.%s '%s': %w", key, hex, err)
+		}
+		colours = append(colours, rgb)
+	}
+
+	roleHints := make(map[colour.Role]int, 2)
+	for _, special := range []struct {
+		role colour.Role
+		name string
+		hex  string
+	}{
+		{colour.RoleBackground, "background", s.Special.Background},
+		{colour.RoleForeground, "foreground", s.Special.Foreground},
+	} {
+		if special.hex == "" {
+			return nil, fmt.Errorf("not a pywal colour scheme: missing special.%s", special.name)
+		}
+		rgb, err := colour.ParseHex(special.hex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid special.%s '%s': %w", special.name, special.hex, err)
+		}
+
+		idx := indexOf(colours, rgb)
+		if idx < 0 {
+			idx = len(colours)
+			colours = append(colours, rgb)
+		}
+		roleHints[special.role] = idx
+	}
+
+	colors := make([]color.Color, len(colours))
+	for i, rgb := range colours {
+		colors[i] = rgb.ToColor()
+	}
+	return colour.NewPaletteWithRoleHints(colors, roleHints), nil
+}
+
+// indexOf returns the index of the first colour equal to rgb, or -1.
+func indexOf(colours []colour.RGB, rgb colour.RGB) int {
+	for i, c := range colours {
+		if c == rgb {
+			return i
+		}
+	}
+	return -1
+}
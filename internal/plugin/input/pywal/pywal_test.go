@@ -0,0 +1,160 @@
+// Package pywal provides tests for the pywal input plugin.
+package pywal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+)
+
+// walDark is color0 to color15 of a typical dark pywal scheme.
+var walDark = []string{
+	"#0f1417", "#5d6a73", "#7a8791", "#8f9ba3", "#a3aeb5", "#b8c1c6", "#ccd3d7", "#d6dde1",
+	"#959a9d", "#5d6a73", "#7a8791", "#8f9ba3", "#a3aeb5", "#b8c1c6", "#ccd3d7", "#d6dde1",
+}
+
+// writeColorsFile writes a pywal colors.json with the given special colours and
+// color0 to color15 to dir, returning its path.
+func writeColorsFile(t *testing.T, dir, background, foreground string, colours []string) string {
+	t.Helper()
+	scheme := map[string]any{
+		"wallpaper": "/home/user/wallpaper.jpg",
+		"alpha":     "100",
+		"special": map[string]string{
+			"background": background,
+			"foreground": foreground,
+			"cursor":     foreground,
+		},
+	}
+	colors := make(map[string]string, len(colours))
+	for i, hex := range colours {
+		colors["color"+strconv.Itoa(i)] = hex
+	}
+	scheme["colors"] = colors
+
+	data, err := json.Marshal(scheme)
+	if err != nil {
+		t.Fatalf("failed to marshal scheme: %v", err)
+	}
+	path := filepath.Join(dir, "colors.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write scheme: %v", err)
+	}
+	return path
+}
+
+func TestGenerate(t *testing.T) {
+	p := New()
+	p.path = writeColorsFile(t, t.TempDir(), walDark[0], walDark[7], walDark)
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	palette, err := p.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(palette.Colors) != ansiColourCount {
+		t.Fatalf("Generate() returned %d colours, want %d", len(palette.Colors), ansiColourCount)
+	}
+	for i, want := range walDark {
+		if got := colour.ToRGB(palette.Colors[i]).Hex(); got != want {
+			t.Errorf("colour %d = %s, want %s", i, got, want)
+		}
+	}
+	if palette.RoleHints[colour.RoleBackground] != 0 || palette.RoleHints[colour.RoleForeground] != 7 {
+		t.Errorf("RoleHints = %v, want background 0 and foreground 7", palette.RoleHints)
+	}
+
+	result := colour.Categorise(palette, colour.DefaultCategorisationConfig())
+	if result.ThemeType != colour.ThemeDark {
+		t.Errorf("ThemeType = %v, want dark", result.ThemeType)
+	}
+	for role, want := range map[colour.Role]string{colour.RoleBackground: walDark[0], colour.RoleForeground: walDark[7]} {
+		if got, _ := result.Get(role); got.Hex != want {
+			t.Errorf("%s = %s, want %s", role, got.Hex, want)
+		}
+	}
+}
+
+func TestGenerateAppendsDistinctSpecialColours(t *testing.T) {
+	p := New()
+	p.path = writeColorsFile(t, t.TempDir(), "#000000", "#FFFFFF", walDark)
+
+	palette, err := p.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(palette.Colors) != ansiColourCount+2 {
+		t.Fatalf("Generate() returned %d colours, want %d", len(palette.Colors), ansiColourCount+2)
+	}
+	for role, want := range map[colour.Role]string{colour.RoleBackground: "#000000", colour.RoleForeground: "#ffffff"} {
+		idx, ok := palette.RoleHints[role]
+		if !ok {
+			t.Errorf("missing %s role hint", role)
+			continue
+		}
+		if got := colour.ToRGB(palette.Colors[idx]).Hex(); got != want {
+			t.Errorf("%s hint = %s, want %s", role, got, want)
+		}
+	}
+}
+
+func TestGenerateInvalidScheme(t *testing.T) {
+	tests := []struct {
+		name       string
+		background string
+		colours    []string
+		wantErr    string
+	}{
+		{name: "missing colour", background: walDark[0], colours: walDark[:8], wantErr: "colors.color8"},
+		{name: "missing background", colours: walDark, wantErr: "special.background"},
+		{name: "bad hex", background: walDark[0], colours: append([]string{"#zzzzzz"}, walDark[1:]...), wantErr: "colors.color0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New()
+			p.path = writeColorsFile(t, t.TempDir(), tt.background, walDark[7], tt.colours)
+			_, err := p.Generate(context.Background(), input.GenerateOptions{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Generate() error = %v, want it to mention %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PYWAL_CACHE_DIR", "")
+
+	got, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath() error = %v", err)
+	}
+	if want := filepath.Join(home, ".cache", "wal", "colors.json"); got != want {
+		t.Errorf("DefaultPath() = %s, want %s", got, want)
+	}
+
+	p := New()
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "--pywal.file") {
+		t.Errorf("Validate() without a pywal cache error = %v, want a hint to pass --pywal.file", err)
+	}
+
+	cacheDir := t.TempDir()
+	t.Setenv("PYWAL_CACHE_DIR", cacheDir)
+	writeColorsFile(t, cacheDir, walDark[0], walDark[7], walDark)
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate() with PYWAL_CACHE_DIR error = %v", err)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"image/color"
-	"strconv"
 	"strings"
 	"time"
 
@@ -273,7 +272,7 @@ func (p *Plugin) buildPalette(colors map[string]string, verbose bool) (*colour.P
 	// First, add ALL colors to the palette.
 	colorNameToIndex := make(map[string]int)
 	for name, hex := range colors {
-		rgb, err := parseHex(hex)
+		rgb, err := colour.ParseHex(hex)
 		if err != nil {
 			if verbose {
 				fmt.Printf("   Skipping invalid color '%s': %v\n", name, err)
@@ -341,43 +340,6 @@ func (p *Plugin) buildPalette(colors map[string]string, verbose bool) (*colour.P
 	return colour.NewPalette(colorColors), nil
 }
 
-// parseHex parses a hex color string into an RGB struct.
-// Supports formats: #RRGGBB, RRGGBB, #RGB, RGB.
-func parseHex(hex string) (colour.RGB, error) {
-	hex = strings.TrimSpace(hex)
-	hex = strings.TrimPrefix(hex, "#")
-
-	// Expand shorthand format (RGB -> RRGGBB).
-	if len(hex) == 3 {
-		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
-	}
-
-	if len(hex) != 6 {
-		return colour.RGB{}, fmt.Errorf("invalid hex color length: expected 6 characters, got %d", len(hex))
-	}
-
-	r, err := strconv.ParseUint(hex[0:2], 16, 8)
-	if err != nil {
-		return colour.RGB{}, fmt.Errorf("invalid red component: %w", err)
-	}
-
-	g, err := strconv.ParseUint(hex[2:4], 16, 8)
-	if err != nil {
-		return colour.RGB{}, fmt.Errorf("invalid green component: %w", err)
-	}
-
-	b, err := strconv.ParseUint(hex[4:6], 16, 8)
-	if err != nil {
-		return colour.RGB{}, fmt.Errorf("invalid blue component: %w", err)
-	}
-
-	return colour.RGB{
-		R: uint8(r),
-		G: uint8(g),
-		B: uint8(b),
-	}, nil
-}
-
 // parseColourRole parses a role name string into a Role constant.
 func parseColourRole(name string) (colour.Role, error) {
 	name = strings.ToLower(name)
@@ -0,0 +1,309 @@
+// Package css provides colour parsing for CSS and SCSS stylesheets.
+// It is shared by input plugins that read colours from stylesheets.
+package css
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+)
+
+// NamedColour is a colour found in a stylesheet.
+type NamedColour struct {
+	// Name is the variable name without its "--" or "$" prefix, or "color-rrggbb"
+	// for colours used directly in properties.
+	Name string
+
+	// Hex is the colour in #rrggbb (or #rgb) form.
+	Hex string
+}
+
+var (
+	// cssVarRegex matches CSS custom properties (--variable-name: value;).
+	cssVarRegex = regexp.MustCompile(`--([a-zA-Z0-9_-]+)\s*:\s*([^;]+);`)
+
+	// scssVarRegex matches SCSS variables ($variable-name: value;).
+	scssVarRegex = regexp.MustCompile(`\$([a-zA-Z0-9_-]+)\s*:\s*([^;]+);`)
+
+	// colorPropRegex matches colour-valued properties (color, background-color, etc.).
+	colorPropRegex = regexp.MustCompile(`(?:color|background-color|background|border-color|fill|stroke)\s*:\s*([^;]+);`)
+)
+
+// Parse extracts colours from CSS or SCSS content in document order.
+// CSS custom properties and SCSS variables keep their names; a variable defined more
+// than once keeps its first position and its last value. Colours used directly in
+// colour properties are added afterwards, skipping any already found.
+// Supports hex, rgb, rgba, hsl, hsla, oklch and oklab values.
+func Parse(content string) ([]NamedColour, error) {
+	colours := make([]NamedColour, 0)
+	byName := make(map[string]int)
+
+	addVariables := func(re *regexp.Regexp) {
+		for _, match := range re.FindAllStringSubmatchIndex(content, -1) {
+			name := content[match[2]:match[3]]
+			hex := ExtractColor(strings.TrimSpace(content[match[4]:match[5]]))
+			if hex == "" {
+				continue
+			}
+			if idx, ok := byName[name]; ok {
+				colours[idx].Hex = hex
+				continue
+			}
+			byName[name] = len(colours)
+			colours = append(colours, NamedColour{Name: name, Hex: hex})
+		}
+	}
+	addVariables(cssVarRegex)
+	addVariables(scssVarRegex)
+
+	// Colours used directly in properties, skipping duplicates.
+	for _, match := range colorPropRegex.FindAllStringSubmatch(content, -1) {
+		hex := ExtractColor(strings.TrimSpace(match[1]))
+		if hex == "" {
+			continue
+		}
+		found := false
+		for _, existing := range colours {
+			if existing.Hex == hex {
+				found = true
+				break
+			}
+		}
+		if !found {
+			colours = append(colours, NamedColour{Name: fmt.Sprintf("color-%s", hex[1:]), Hex: hex})
+		}
+	}
+
+	if len(colours) == 0 {
+		return nil, fmt.Errorf("no colors found in CSS")
+	}
+
+	return colours, nil
+}
+
+// ExtractColor extracts a colour value and converts it to #rrggbb hex format.
+// Returns an empty string if value contains no recognised colour.
+// Supports: hex, rgb, rgba, hsl, hsla, oklch, oklab.
+func ExtractColor(value string) string {
+	value = strings.TrimSpace(value)
+
+	// Hex color.
+	if hexColor := extractHexColor(value); hexColor != "" {
+		return hexColor
+	}
+
+	// RGB/RGBA.
+	if rgbColor := convertRGBToHex(value); rgbColor != "" {
+		return rgbColor
+	}
+
+	// HSL/HSLA.
+	if hslColor := convertHSLToHex(value); hslColor != "" {
+		return hslColor
+	}
+
+	// OKLCH.
+	if oklchColor := convertOKLCHToHex(value); oklchColor != "" {
+		return oklchColor
+	}
+
+	// OKLAB.
+	if oklabColor := convertOKLABToHex(value); oklabColor != "" {
+		return oklabColor
+	}
+
+	return ""
+}
+
+// extractHexColor extracts hex color from a value string.
+func extractHexColor(value string) string {
+	hexRegex := regexp.MustCompile(`#([0-9a-fA-F]{6}|[0-9a-fA-F]{3})\b`)
+	if match := hexRegex.FindString(value); match != "" {
+		return match
+	}
+	return ""
+}
+
+// convertRGBToHex extracts rgb/rgba color and converts to hex.
+func convertRGBToHex(value string) string {
+	rgbRegex := regexp.MustCompile(`rgba?\s*\(\s*([0-9.]+)\s*,?\s*([0-9.]+)\s*,?\s*([0-9.]+)`)
+	matches := rgbRegex.FindStringSubmatch(value)
+	if len(matches) == 4 {
+		// Regex guarantees these are valid floats, errors ignored
+		r, _ := strconv.ParseFloat(matches[1], 64) //nolint:errcheck
+		g, _ := strconv.ParseFloat(matches[2], 64) //nolint:errcheck
+		b, _ := strconv.ParseFloat(matches[3], 64) //nolint:errcheck
+		return fmt.Sprintf("#%02x%02x%02x",
+			clamp(int(r), 255),
+			clamp(int(g), 255),
+			clamp(int(b), 255))
+	}
+	return ""
+}
+
+// convertHSLToHex extracts hsl/hsla color and converts to hex.
+func convertHSLToHex(value string) string {
+	hslRegex := regexp.MustCompile(`hsla?\s*\(\s*([0-9.]+)\s*,?\s*([0-9.]+)%?\s*,?\s*([0-9.]+)%?`)
+	matches := hslRegex.FindStringSubmatch(value)
+	if len(matches) == 4 {
+		// Regex guarantees these are valid floats, errors ignored
+		h, _ := strconv.ParseFloat(matches[1], 64) //nolint:errcheck
+		s, _ := strconv.ParseFloat(matches[2], 64) //nolint:errcheck
+		l, _ := strconv.ParseFloat(matches[3], 64) //nolint:errcheck
+
+		// Handle percentage values.
+		if s > 1 {
+			s /= 100.0
+		}
+		if l > 1 {
+			l /= 100.0
+		}
+
+		rgb := hslToRGB(h, s, l)
+		return fmt.Sprintf("#%02x%02x%02x", rgb.R, rgb.G, rgb.B)
+	}
+	return ""
+}
+
+// convertOKLCHToHex extracts oklch color and converts to hex.
+// Format: oklch(L C H) where L is 0-1, C is 0-0.4, H is 0-360.
+func convertOKLCHToHex(value string) string {
+	oklchRegex := regexp.MustCompile(`oklch\s*\(\s*([0-9.]+)\s+([0-9.]+)\s+([0-9.]+)`)
+	matches := oklchRegex.FindStringSubmatch(value)
+	if len(matches) == 4 {
+		// Regex guarantees these are valid floats, errors ignored
+		l, _ := strconv.ParseFloat(matches[1], 64) //nolint:errcheck
+		c, _ := strconv.ParseFloat(matches[2], 64) //nolint:errcheck
+		h, _ := strconv.ParseFloat(matches[3], 64) //nolint:errcheck
+
+		rgb := oklchToRGB(l, c, h)
+		return fmt.Sprintf("#%02x%02x%02x", rgb.R, rgb.G, rgb.B)
+	}
+	return ""
+}
+
+// convertOKLABToHex extracts oklab color and converts to hex.
+// Format: oklab(L a b) where L is 0-1, a and b are typically -0.4 to 0.4.
+func convertOKLABToHex(value string) string {
+	oklabRegex := regexp.MustCompile(`oklab\s*\(\s*([0-9.-]+)\s+([0-9.-]+)\s+([0-9.-]+)`)
+	matches := oklabRegex.FindStringSubmatch(value)
+	if len(matches) == 4 {
+		// Regex guarantees these are valid floats, errors ignored
+		l, _ := strconv.ParseFloat(matches[1], 64) //nolint:errcheck
+		a, _ := strconv.ParseFloat(matches[2], 64) //nolint:errcheck
+		b, _ := strconv.ParseFloat(matches[3], 64) //nolint:errcheck
+
+		rgb := oklabToRGB(l, a, b)
+		return fmt.Sprintf("#%02x%02x%02x", rgb.R, rgb.G, rgb.B)
+	}
+	return ""
+}
+
+// clamp restricts a value to the range 0-maxVal.
+//
+//nolint:unparam // maxVal is always 255 for RGB but keeping generic for clarity
+func clamp(val, maxVal int) int {
+	if val < 0 {
+		return 0
+	}
+	if val > maxVal {
+		return maxVal
+	}
+	return val
+}
+
+// hslToRGB converts HSL to RGB.
+func hslToRGB(h, s, l float64) colour.RGB {
+	h /= 360.0
+
+	var r, g, b float64
+
+	if s == 0 {
+		r = l
+		g = l
+		b = l
+	} else {
+		var q float64
+		if l < 0.5 {
+			q = l * (1 + s)
+		} else {
+			q = l + s - l*s
+		}
+		p := 2*l - q
+
+		r = hueToRGB(p, q, h+1.0/3.0)
+		g = hueToRGB(p, q, h)
+		b = hueToRGB(p, q, h-1.0/3.0)
+	}
+
+	return colour.RGB{
+		R: uint8(clamp(int(r*255), 255)), // #nosec G115 -- clamped to 0-255
+		G: uint8(clamp(int(g*255), 255)), // #nosec G115 -- clamped to 0-255
+		B: uint8(clamp(int(b*255), 255)), // #nosec G115 -- clamped to 0-255
+	}
+}
+
+// hueToRGB is a helper for HSL to RGB conversion.
+func hueToRGB(p, q, t float64) float64 {
+	if t < 0 {
+		t++
+	}
+	if t > 1 {
+		t--
+	}
+	if t < 1.0/6.0 {
+		return p + (q-p)*6*t
+	}
+	if t < 1.0/2.0 {
+		return q
+	}
+	if t < 2.0/3.0 {
+		return p + (q-p)*(2.0/3.0-t)*6
+	}
+	return p
+}
+
+// oklchToRGB converts OKLCH to RGB.
+// OKLCH: Lightness (0-1), Chroma (0-0.4), Hue (0-360).
+func oklchToRGB(l, c, h float64) colour.RGB {
+	// Convert OKLCH to OKLAB.
+	hRad := h * math.Pi / 180.0
+	a := c * math.Cos(hRad)
+	b := c * math.Sin(hRad)
+
+	// Convert OKLAB to RGB.
+	return oklabToRGB(l, a, b)
+}
+
+// oklabToRGB converts OKLAB to RGB.
+// OKLAB: Lightness (0-1), a (-0.4 to 0.4), b (-0.4 to 0.4).
+// Reference: https://bottosson.github.io/posts/oklab/.
+func oklabToRGB(l, a, b float64) colour.RGB {
+	// OKLAB to linear RGB (D65 illuminant).
+	lVal := l + 0.3963377774*a + 0.2158037573*b
+	mVal := l - 0.1055613458*a - 0.0638541728*b
+	sVal := l - 0.0894841775*a - 1.2914855480*b
+
+	lVal = lVal * lVal * lVal
+	mVal = mVal * mVal * mVal
+	sVal = sVal * sVal * sVal
+
+	r := +4.0767416621*lVal - 3.3077115913*mVal + 0.2309699292*sVal
+	g := -1.2684380046*lVal + 2.6097574011*mVal - 0.3413193965*sVal
+	bVal := -0.0041960863*lVal - 0.7034186147*mVal + 1.7076147010*sVal
+
+	// Convert linear RGB to sRGB (gamma correction).
+	r = colour.LinearToSRGB(r)
+	g = colour.LinearToSRGB(g)
+	bVal = colour.LinearToSRGB(bVal)
+
+	return colour.RGB{
+		R: uint8(clamp(int(r*255+0.5), 255)),    // #nosec G115 -- clamped to 0-255
+		G: uint8(clamp(int(g*255+0.5), 255)),    // #nosec G115 -- clamped to 0-255
+		B: uint8(clamp(int(bVal*255+0.5), 255)), // #nosec G115 -- clamped to 0-255
+	}
+}
@@ -0,0 +1,69 @@
+package css
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	content := `:root {
+  --background: #1a1b26;
+  --accent1: rgb(122, 162, 247);
+  --brand: hsl(0, 100%, 50%);
+  --spacing: 4px;
+}
+
+$surface: #24283b;
+$danger: #f7768e;
+
+.dark { --background: #000000; }
+
+a { color: #9ece6a; }
+p { color: #24283b; }
+`
+	got, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []NamedColour{
+		{Name: "background", Hex: "#000000"},
+		{Name: "accent1", Hex: "#7aa2f7"},
+		{Name: "brand", Hex: "#ff0000"},
+		{Name: "surface", Hex: "#24283b"},
+		{Name: "danger", Hex: "#f7768e"},
+		{Name: "color-1a1b26", Hex: "#1a1b26"}, // Overridden --background value, still used in a property.
+		{Name: "color-9ece6a", Hex: "#9ece6a"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Parse() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestParseNoColours(t *testing.T) {
+	if _, err := Parse(":root { --spacing: 4px; }"); err == nil {
+		t.Error("Parse() expected error for stylesheet without colours")
+	}
+}
+
+func TestExtractColor(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"#abc", "#abc"},
+		{"#1A1B26", "#1A1B26"},
+		{"rgba(255, 0, 0, 0.5)", "#ff0000"},
+		{"hsl(120, 100%, 50%)", "#00ff00"},
+		{"oklch(1 0 0)", "#ffffff"},
+		{"4px", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := ExtractColor(tt.value); got != tt.want {
+				t.Errorf("ExtractColor(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
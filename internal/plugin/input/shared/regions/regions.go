@@ -319,6 +319,7 @@ func (s *Sampler) extractColorFromRegion(img image.Image, rect image.Rectangle)
 }
 
 // extractAverageColor calculates the average color of all pixels in a region.
+// Fully transparent (e.g. masked) pixels are skipped.
 func (s *Sampler) extractAverageColor(img image.Image, rect image.Rectangle) color.Color {
 	var totalR, totalG, totalB uint64
 	var count uint64
@@ -326,7 +327,10 @@ func (s *Sampler) extractAverageColor(img image.Image, rect image.Rectangle) col
 	// Sample every pixel in the region.
 	for y := rect.Min.Y; y < rect.Max.Y; y++ {
 		for x := rect.Min.X; x < rect.Max.X; x++ {
-			r, g, b, _ := img.At(x, y).RGBA()
+			r, g, b, a := img.At(x, y).RGBA()
+			if a == 0 {
+				continue
+			}
 			// RGBA() returns values in range [0, 65535], convert to [0, 255].
 			totalR += uint64(r >> 8)
 			totalG += uint64(g >> 8)
@@ -349,13 +353,17 @@ func (s *Sampler) extractAverageColor(img image.Image, rect image.Rectangle) col
 
 // extractDominantColor finds the most frequent color in a region.
 // Colors are quantized to reduce the number of unique colors.
+// Fully transparent (e.g. masked) pixels are skipped.
 func (s *Sampler) extractDominantColor(img image.Image, rect image.Rectangle) color.Color {
 	// Map to count color frequencies (quantized to reduce unique colors).
 	colorCounts := make(map[uint32]int)
 
 	for y := rect.Min.Y; y < rect.Max.Y; y++ {
 		for x := rect.Min.X; x < rect.Max.X; x++ {
-			r, g, b, _ := img.At(x, y).RGBA()
+			r, g, b, a := img.At(x, y).RGBA()
+			if a == 0 {
+				continue
+			}
 			// Quantize to 5-bit (32 values) per channel to reduce unique colors.
 			// Safe conversion: (r >> 8) is already in 0-255 range, & 0xF8 keeps it there
 			r8 := uint8(min((r>>8)&0xF8, 255)) // #nosec G115 - value masked to 8 bits and bounded by min
@@ -383,6 +383,29 @@ func TestDominantColorMethod(t *testing.T) {
 	}
 }
 
+func TestTransparentPixelsSkipped(t *testing.T) {
+	// Mostly transparent (masked) with a strip of green.
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+	for y := range 100 {
+		for x := range 10 {
+			img.Set(x, y, color.RGBA{R: 0, G: 200, B: 0, A: 255})
+		}
+	}
+
+	sampler := &Sampler{SamplePercent: 20}
+	rect := image.Rect(0, 0, 100, 100)
+
+	for name, c := range map[string]color.Color{
+		"average":  sampler.extractAverageColor(img, rect),
+		"dominant": sampler.extractDominantColor(img, rect),
+	} {
+		r, g, b, _ := c.RGBA()
+		if r>>8 > 10 || g>>8 < 190 || b>>8 > 10 {
+			t.Errorf("%s: transparent pixels should be ignored, got R=%d G=%d B=%d", name, r>>8, g>>8, b>>8)
+		}
+	}
+}
+
 func BenchmarkExtract4Regions(b *testing.B) {
 	img := createTestImage(1920, 1080)
 	sampler := NewSampler()
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"image"
 	"math/rand"
+	"net/url"
+	"path"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -22,6 +24,8 @@ const (
 	ModeContent Mode = "content"
 	// ModeFilepath generates seed from absolute file path hash (deterministic by path).
 	ModeFilepath Mode = "filepath"
+	// ModeName generates seed from the image file name hash (deterministic by name).
+	ModeName Mode = "name"
 	// ModeManual uses a user-provided seed value.
 	ModeManual Mode = "manual"
 	// ModeRandom uses non-deterministic random seed (varies each run).
@@ -36,7 +40,7 @@ type Config struct {
 
 // Calculate determines the seed value based on the seed mode.
 // img: the image to extract seed from (required for ModeContent)
-// imagePath: the path to the image file (required for ModeFilepath)
+// imagePath: the path to the image file (required for ModeFilepath and ModeName)
 // config: seed configuration
 func Calculate(img image.Image, imagePath string, config Config) (int64, error) {
 	switch config.Mode {
@@ -50,6 +54,11 @@ func Calculate(img image.Image, imagePath string, config Config) (int64, error)
 			return 0, fmt.Errorf("image path is required for filepath-based seed mode")
 		}
 		return CalculateFilepathSeed(imagePath)
+	case ModeName:
+		if imagePath == "" {
+			return 0, fmt.Errorf("image path is required for name-based seed mode")
+		}
+		return CalculateNameSeed(imagePath)
 	case ModeManual:
 		if config.Value == nil {
 			return 0, fmt.Errorf("seed value is required for manual seed mode")
@@ -130,6 +139,30 @@ func CalculateFilepathSeed(imagePath string) (int64, error) {
 	return seed, nil
 }
 
+// CalculateNameSeed generates a deterministic seed from the image file name, ignoring
+// its directory. Re-downloaded or re-encoded copies of a wallpaper keep the same name,
+// so they produce the same palette even though their bytes differ. For URLs the last
+// path segment is used, without any query string.
+func CalculateNameSeed(imagePath string) (int64, error) {
+	name := filepath.Base(imagePath)
+	if isURL(imagePath) {
+		u, err := url.Parse(imagePath)
+		if err != nil {
+			return 0, fmt.Errorf("invalid image URL: %w", err)
+		}
+		name = path.Base(u.Path)
+	}
+	if name == "" || name == "." || name == "/" || name == string(filepath.Separator) {
+		return 0, fmt.Errorf("image path %q has no file name", imagePath)
+	}
+
+	hasher := sha256.New()
+	hasher.Write([]byte(name))
+	hash := hasher.Sum(nil)
+	seed := int64(binary.LittleEndian.Uint64(hash[:8])) // #nosec G115 -- hash conversion is safe
+	return seed, nil
+}
+
 // GenerateRandomSeed generates a non-deterministic random seed.
 func GenerateRandomSeed() int64 {
 	// #nosec G404 -- Random seed generation is intentionally non-deterministic
@@ -143,7 +176,7 @@ func isURL(path string) bool {
 
 // ValidModes returns a list of valid seed modes.
 func ValidModes() []Mode {
-	return []Mode{ModeContent, ModeFilepath, ModeManual, ModeRandom}
+	return []Mode{ModeContent, ModeFilepath, ModeName, ModeManual, ModeRandom}
 }
 
 // ParseMode converts a string to a Mode.
@@ -153,5 +186,5 @@ func ParseMode(s string) (Mode, error) {
 	if slices.Contains(ValidModes(), mode) {
 		return mode, nil
 	}
-	return "", fmt.Errorf("invalid seed mode: %s (valid: content, filepath, manual, random)", s)
+	return "", fmt.Errorf("invalid seed mode: %s (valid: content, filepath, name, manual, random)", s)
 }
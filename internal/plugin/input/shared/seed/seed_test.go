@@ -0,0 +1,109 @@
+package seed
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestImage writes a small PNG filled with c and returns the loaded image.
+func writeTestImage(t *testing.T, path string, c color.Color) image.Image {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := range 4 {
+		for x := range 4 {
+			img.Set(x, y, c)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create image: %v", err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	return img
+}
+
+func TestCalculateNameSeedIgnoresContentAndDirectory(t *testing.T) {
+	dir := t.TempDir()
+	pathA := filepath.Join(dir, "original", "mountains.png")
+	pathB := filepath.Join(dir, "redownloaded", "mountains.png")
+	imgA := writeTestImage(t, pathA, color.RGBA{R: 200, G: 40, B: 40, A: 255})
+	imgB := writeTestImage(t, pathB, color.RGBA{R: 198, G: 42, B: 40, A: 255})
+
+	config := Config{Mode: ModeName}
+	seedA, err := Calculate(imgA, pathA, config)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	seedB, err := Calculate(imgB, pathB, config)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if seedA != seedB {
+		t.Errorf("name seeds differ for the same file name: %d != %d", seedA, seedB)
+	}
+
+	// The images really differ, so content seeds must not match.
+	contentA, _ := Calculate(imgA, pathA, Config{Mode: ModeContent})
+	contentB, _ := Calculate(imgB, pathB, Config{Mode: ModeContent})
+	if contentA == contentB {
+		t.Error("content seeds match for different images")
+	}
+
+	other, err := CalculateNameSeed(filepath.Join(dir, "original", "forest.png"))
+	if err != nil {
+		t.Fatalf("CalculateNameSeed() error = %v", err)
+	}
+	if other == seedA {
+		t.Error("name seeds match for different file names")
+	}
+}
+
+func TestCalculateNameSeedURL(t *testing.T) {
+	fromURL, err := CalculateNameSeed("https://example.com/walls/mountains.png?w=1920")
+	if err != nil {
+		t.Fatalf("CalculateNameSeed() error = %v", err)
+	}
+	fromFile, err := CalculateNameSeed("/home/user/Pictures/mountains.png")
+	if err != nil {
+		t.Fatalf("CalculateNameSeed() error = %v", err)
+	}
+	if fromURL != fromFile {
+		t.Errorf("URL and file with the same name give different seeds: %d != %d", fromURL, fromFile)
+	}
+}
+
+func TestCalculateNameSeedRequiresPath(t *testing.T) {
+	if _, err := Calculate(nil, "", Config{Mode: ModeName}); err == nil {
+		t.Error("Calculate() expected error without an image path")
+	}
+	if _, err := CalculateNameSeed("https://example.com/"); err == nil {
+		t.Error("CalculateNameSeed() expected error for a URL without a file name")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, mode := range ValidModes() {
+		got, err := ParseMode(string(mode))
+		if err != nil || got != mode {
+			t.Errorf("ParseMode(%q) = %q, %v", mode, got, err)
+		}
+	}
+	if got, err := ParseMode("name"); err != nil || got != ModeName {
+		t.Errorf("ParseMode(name) = %q, %v, want %q", got, err, ModeName)
+	}
+	if _, err := ParseMode("bogus"); err == nil {
+		t.Error("ParseMode(bogus) expected error")
+	}
+}
@@ -0,0 +1,315 @@
+// Package video provides an input plugin that extracts a colour palette from one or
+// more frames of a video, grabbed with ffmpeg.
+package video
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	goimage "image"
+	"image/draw"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/image"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/input/shared/seed"
+)
+
+const (
+	// DefaultCount is the default number of colours to extract.
+	DefaultCount = 16
+
+	// MaxFrames is the maximum number of frames --video.frames may sample.
+	MaxFrames = 100
+
+	// frameWidth is the width frames are scaled to before extraction, which keeps
+	// many stacked 4K frames from using gigabytes of memory.
+	frameWidth = 640
+)
+
+// installHint tells users where to get ffmpeg.
+const installHint = "install ffmpeg with your package manager (e.g. 'pacman -S ffmpeg', 'apt install ffmpeg', 'brew install ffmpeg') or from https://ffmpeg.org/download.html"
+
+// Plugin implements the input.Plugin interface for video frame extraction.
+type Plugin struct {
+	path      string
+	timestamp string // Position of the single frame to grab; empty means the midpoint
+	frames    int    // Number of evenly spaced frames to combine
+	count     int
+}
+
+// New creates a new video input plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		frames: 1,
+		count:  DefaultCount,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "video"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Extract colours from video frames (requires ffmpeg)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.path, "video.path", "", "Path or URL of the video (required)")
+	cmd.Flags().StringVar(&p.timestamp, "video.timestamp", "", "Frame position as seconds or [HH:]MM:SS[.ms] (default: the midpoint)")
+	cmd.Flags().IntVar(&p.frames, "video.frames", 1, fmt.Sprintf("Combine colours from N evenly spaced frames (1-%d)", MaxFrames))
+	cmd.Flags().IntVar(&p.count, "video.count", DefaultCount, "Number of colours to extract (1-256)")
+}
+
+// Validate checks if the plugin has all required inputs configured and ffmpeg is
+// available.
+func (p *Plugin) Validate() error {
+	if p.path == "" {
+		return fmt.Errorf("--video.path is required")
+	}
+	if !isURL(p.path) {
+		if _, err := os.Stat(p.path); err != nil {
+			return fmt.Errorf("video not found: %w", err)
+		}
+	}
+
+	if p.count < 1 || p.count > 256 {
+		return fmt.Errorf("--video.count must be between 1 and 256, got %d", p.count)
+	}
+	if p.frames < 1 || p.frames > MaxFrames {
+		return fmt.Errorf("--video.frames must be between 1 and %d, got %d", MaxFrames, p.frames)
+	}
+
+	if p.timestamp != "" {
+		if p.frames > 1 {
+			return fmt.Errorf("--video.timestamp and --video.frames cannot be combined (frames are spread over the whole video)")
+		}
+		if _, err := parseTimestamp(p.timestamp); err != nil {
+			return fmt.Errorf("invalid --video.timestamp: %w", err)
+		}
+	}
+
+	tools := []string{"ffmpeg"}
+	if p.timestamp == "" {
+		// The video's duration is needed to find the midpoint or spread frames.
+		tools = append(tools, "ffprobe")
+	}
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("%s not found: %s", tool, installHint)
+		}
+	}
+
+	return nil
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "video.path", Type: "string", Default: "", Description: "Path or URL of the video", Required: true},
+		{Name: "video.timestamp", Type: "string", Default: "", Description: "Frame position as seconds or [HH:]MM:SS[.ms] (default: the midpoint)", Required: false},
+		{Name: "video.frames", Type: "int", Default: "1", Description: fmt.Sprintf("Combine colours from N evenly spaced frames (1-%d)", MaxFrames), Required: false},
+		{Name: "video.count", Type: "int", Default: "16", Description: "Number of colours to extract (1-256)", Required: false},
+	}
+}
+
+// Generate grabs the configured frames and extracts colours from them with k-means.
+// Several frames are stacked into one image first, so the palette reflects all of them.
+// Returns only the extracted colors - categorization happens separately.
+func (p *Plugin) Generate(ctx context.Context, opts input.GenerateOptions) (*colour.Palette, error) {
+	positions, err := p.framePositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "tinct-video-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	loader := image.NewSmartLoader()
+	frames := make([]goimage.Image, 0, len(positions))
+	for i, position := range positions {
+		if opts.Verbose {
+			fmt.Printf("→ Grabbing frame at %s\n", formatSeconds(position))
+		}
+
+		framePath := filepath.Join(tmpDir, fmt.Sprintf("frame-%03d.png", i))
+		if err := grabFrame(ctx, p.path, position, framePath); err != nil {
+			return nil, err
+		}
+
+		frame, err := loader.Load(framePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load frame at %s: %w", formatSeconds(position), err)
+		}
+		frames = append(frames, frame)
+	}
+
+	img := stackFrames(frames)
+
+	// Extract palette using k-means with a content-based seed, so the same video
+	// and positions always give the same palette.
+	calculatedSeed, err := seed.Calculate(img, p.path, seed.Config{Mode: seed.ModeContent})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate seed: %w", err)
+	}
+
+	extractor, err := colour.NewExtractor(colour.AlgorithmKMeans, colour.ExtractorOptions{Seed: &calculatedSeed})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create extractor: %w", err)
+	}
+
+	palette, err := extractor.Extract(img, p.count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract colours: %w", err)
+	}
+	return palette, nil
+}
+
+// framePositions returns the positions, in seconds, of the frames to grab: the
+// --video.timestamp, or the midpoints of --video.frames equal parts of the video.
+func (p *Plugin) framePositions(ctx context.Context) ([]float64, error) {
+	if p.timestamp != "" {
+		position, err := parseTimestamp(p.timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --video.timestamp: %w", err)
+		}
+		return []float64{position}, nil
+	}
+
+	duration, err := probeDuration(ctx, p.path)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := max(p.frames, 1)
+	positions := make([]float64, frames)
+	for i := range positions {
+		positions[i] = duration * (float64(i) + 0.5) / float64(frames)
+	}
+	return positions, nil
+}
+
+// probeDuration returns the length of the video in seconds using ffprobe.
+func probeDuration(ctx context.Context, path string) (float64, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "ffprobe", "-v", "error", "-show_entries", "format=duration", "-of", "default=noprint_wrappers=1:nokey=1", "-i", path) // #nosec G204 - Fixed command; the video path is passed as a single argument
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return 0, fmt.Errorf("failed to read video duration: %w%s", err, stderrSuffix(stderr.String()))
+	}
+
+	duration, err := strconv.ParseFloat(strings.TrimSpace(stdout.String()), 64)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("failed to read video duration: ffprobe reported %q", strings.TrimSpace(stdout.String()))
+	}
+	return duration, nil
+}
+
+// grabFrame writes the frame at position seconds to outPath as a PNG, scaled to
+// frameWidth pixels wide.
+func grabFrame(ctx context.Context, path string, position float64, outPath string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-v", "error", // #nosec G204 - Fixed command; the video path is passed as a single argument
+		"-ss", strconv.FormatFloat(position, 'f', 3, 64),
+		"-i", path,
+		"-frames:v", "1",
+		"-vf", fmt.Sprintf("scale=%d:-2", frameWidth),
+		"-y", outPath)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to grab frame at %s: %w%s", formatSeconds(position), err, stderrSuffix(stderr.String()))
+	}
+
+	// ffmpeg succeeds without writing a frame when seeking past the end.
+	if info, err := os.Stat(outPath); err != nil || info.Size() == 0 {
+		return fmt.Errorf("no frame at %s (is the video that long?)", formatSeconds(position))
+	}
+	return nil
+}
+
+// stackFrames returns the frames stacked vertically in one image, or the frame
+// itself when there is only one.
+func stackFrames(frames []goimage.Image) goimage.Image {
+	if len(frames) == 1 {
+		return frames[0]
+	}
+
+	width, height := 0, 0
+	for _, frame := range frames {
+		width = max(width, frame.Bounds().Dx())
+		height += frame.Bounds().Dy()
+	}
+
+	stacked := goimage.NewRGBA(goimage.Rect(0, 0, width, height))
+	y := 0
+	for _, frame := range frames {
+		b := frame.Bounds()
+		draw.Draw(stacked, goimage.Rect(0, y, b.Dx(), y+b.Dy()), frame, b.Min, draw.Src)
+		y += b.Dy()
+	}
+	return stacked
+}
+
+// parseTimestamp parses a position given as seconds ("90", "90.5") or as
+// [HH:]MM:SS[.ms] ("1:30", "01:02:03.5") into seconds.
+func parseTimestamp(s string) (float64, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("'%s' is not seconds or [HH:]MM:SS", s)
+	}
+
+	seconds := 0.0
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+			return 0, fmt.Errorf("'%s' is not seconds or [HH:]MM:SS", s)
+		}
+		// Only the seconds field may have a fraction, and minutes and seconds
+		// after a colon must be below 60.
+		last := i == len(parts)-1
+		if (!last && v != float64(int(v))) || (i > 0 && v >= 60) {
+			return 0, fmt.Errorf("'%s' is not seconds or [HH:]MM:SS", s)
+		}
+		seconds = seconds*60 + v
+	}
+	return seconds, nil
+}
+
+// formatSeconds formats a position in seconds as HH:MM:SS.mmm for messages.
+func formatSeconds(seconds float64) string {
+	ms := int64(seconds*1000 + 0.5)
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// stderrSuffix returns ": <stderr>" for a command's non-empty stderr.
+func stderrSuffix(stderr string) string {
+	if msg := strings.TrimSpace(stderr); msg != "" {
+		return ": " + msg
+	}
+	return ""
+}
+
+// isURL reports whether path is an HTTP(S) URL, which ffmpeg reads directly.
+func isURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
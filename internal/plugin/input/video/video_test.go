@@ -0,0 +1,265 @@
+// Package video provides tests for the video input plugin.
+package video
+
+import (
+	"context"
+	"fmt"
+	goimage "image"
+	"image/color"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+)
+
+var (
+	blue   = color.RGBA{R: 0x1e, G: 0x3a, B: 0x8a, A: 255}
+	orange = color.RGBA{R: 0xf9, G: 0x73, B: 0x16, A: 255}
+)
+
+// fakeTools puts executable scripts with the given names and bodies on an
+// otherwise empty PATH.
+func fakeTools(t *testing.T, scripts map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, body := range scripts {
+		script := "#!/bin/sh\n" + body + "\n"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil { // #nosec G306 - Test tool needs execute permission
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", dir)
+}
+
+// writeFrame writes a solid-colour PNG frame, returning its path.
+func writeFrame(t *testing.T, c color.Color) string {
+	t.Helper()
+	img := goimage.NewRGBA(goimage.Rect(0, 0, 32, 18))
+	for y := range 18 {
+		for x := range 32 {
+			img.Set(x, y, c)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "frame.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create frame: %v", err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("failed to encode frame: %v", err)
+	}
+	return path
+}
+
+// fakeFFmpeg installs fake ffprobe and ffmpeg for a 100 second video. ffmpeg
+// writes the orange frame at orangeAt seconds and the blue frame elsewhere, and
+// logs each requested position to the returned file.
+func fakeFFmpeg(t *testing.T, orangeAt string) (logPath string) {
+	t.Helper()
+	cp, err := exec.LookPath("cp")
+	if err != nil {
+		t.Skip("cp not available")
+	}
+
+	logPath = filepath.Join(t.TempDir(), "positions.log")
+	ffmpeg := fmt.Sprintf(`echo "$4" >> '%s'
+for a; do out=$a; done
+case "$4" in
+  %s) src='%s' ;;
+  *) src='%s' ;;
+esac
+%s "$src" "$out"`, logPath, orangeAt, writeFrame(t, orange), writeFrame(t, blue), cp)
+
+	fakeTools(t, map[string]string{
+		"ffprobe": "echo 100.0",
+		"ffmpeg":  ffmpeg,
+	})
+	return logPath
+}
+
+// readPositions returns the frame positions logged by the fake ffmpeg.
+func readPositions(t *testing.T, logPath string) []string {
+	t.Helper()
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read ffmpeg log: %v", err)
+	}
+	return strings.Fields(string(data))
+}
+
+// videoFile returns the path of an empty stand-in video file.
+func videoFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "clip.mp4")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("failed to write video: %v", err)
+	}
+	return path
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "90", want: 90},
+		{in: "90.5", want: 90.5},
+		{in: "1:30", want: 90},
+		{in: "01:02:03.5", want: 3723.5},
+		{in: "", wantErr: true},
+		{in: "1:60", wantErr: true},
+		{in: "1.5:30", wantErr: true},
+		{in: "-5", wantErr: true},
+		{in: "inf", wantErr: true},
+		{in: "1:2:3:4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseTimestamp(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTimestamp(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseTimestamp(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	video := videoFile(t)
+
+	fakeTools(t, nil)
+	p := New()
+	p.path = video
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "ffmpeg.org") {
+		t.Errorf("Validate() without ffmpeg error = %v, want an install hint", err)
+	}
+
+	// A timestamp needs only ffmpeg; the midpoint needs ffprobe too.
+	fakeTools(t, map[string]string{"ffmpeg": "exit 0"})
+	p.timestamp = "10"
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate() with --video.timestamp error = %v", err)
+	}
+	p.timestamp = ""
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "ffprobe") {
+		t.Errorf("Validate() without ffprobe error = %v, want ffprobe reported missing", err)
+	}
+
+	fakeTools(t, map[string]string{"ffmpeg": "exit 0", "ffprobe": "exit 0"})
+	tests := []struct {
+		name      string
+		path      string
+		timestamp string
+		frames    int
+		count     int
+		wantErr   bool
+	}{
+		{name: "defaults", path: video, frames: 1, count: DefaultCount},
+		{name: "frames", path: video, frames: 10, count: 8},
+		{name: "url", path: "https://example.com/clip.mp4", frames: 1, count: DefaultCount},
+		{name: "no path", frames: 1, count: DefaultCount, wantErr: true},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.mp4"), frames: 1, count: DefaultCount, wantErr: true},
+		{name: "too many frames", path: video, frames: MaxFrames + 1, count: DefaultCount, wantErr: true},
+		{name: "bad count", path: video, frames: 1, count: 0, wantErr: true},
+		{name: "bad timestamp", path: video, timestamp: "soon", frames: 1, count: DefaultCount, wantErr: true},
+		{name: "timestamp with frames", path: video, timestamp: "10", frames: 3, count: DefaultCount, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New()
+			p.path = tt.path
+			p.timestamp = tt.timestamp
+			p.frames = tt.frames
+			p.count = tt.count
+			if err := p.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateMidpoint(t *testing.T) {
+	logPath := fakeFFmpeg(t, "50.000")
+
+	p := New()
+	p.path = videoFile(t)
+	p.count = 1
+	palette, err := p.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if got := readPositions(t, logPath); len(got) != 1 || got[0] != "50.000" {
+		t.Errorf("frame positions = %v, want [50.000]", got)
+	}
+	if got := colour.ToRGB(palette.Colors[0]).Hex(); got != "#f97316" {
+		t.Errorf("colour = %s, want the midpoint frame's #f97316", got)
+	}
+}
+
+func TestGenerateTimestamp(t *testing.T) {
+	logPath := fakeFFmpeg(t, "90.000")
+
+	p := New()
+	p.path = videoFile(t)
+	p.timestamp = "1:30"
+	p.count = 1
+	if _, err := p.Generate(context.Background(), input.GenerateOptions{}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if got := readPositions(t, logPath); len(got) != 1 || got[0] != "90.000" {
+		t.Errorf("frame positions = %v, want [90.000]", got)
+	}
+}
+
+func TestGenerateFrames(t *testing.T) {
+	logPath := fakeFFmpeg(t, "87.500")
+
+	p := New()
+	p.path = videoFile(t)
+	p.frames = 4
+	p.count = 2
+	palette, err := p.Generate(context.Background(), input.GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := []string{"12.500", "37.500", "62.500", "87.500"}
+	if got := readPositions(t, logPath); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("frame positions = %v, want %v", got, want)
+	}
+
+	// Three blue frames and one orange frame give one palette with both colours.
+	got := make([]string, 0, len(palette.Colors))
+	for _, c := range palette.Colors {
+		got = append(got, colour.ToRGB(c).Hex())
+	}
+	if !slices.Contains(got, "#1e3a8a") || !slices.Contains(got, "#f97316") {
+		t.Errorf("colours = %v, want #1e3a8a and #f97316", got)
+	}
+}
+
+func TestGenerateNoFrame(t *testing.T) {
+	fakeTools(t, map[string]string{"ffmpeg": "exit 0"})
+
+	p := New()
+	p.path = videoFile(t)
+	p.timestamp = "500"
+	_, err := p.Generate(context.Background(), input.GenerateOptions{})
+	if err == nil || !strings.Contains(err.Error(), "no frame at 00:08:20.000") {
+		t.Errorf("Generate() error = %v, want no frame reported", err)
+	}
+}
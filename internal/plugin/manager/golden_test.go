@@ -0,0 +1,148 @@
+package manager
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// updateGolden rewrites the golden files instead of comparing against them:
+//
+//	go test ./internal/plugin/manager -run TestBuiltinOutputGolden -update
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+// goldenHome is the home directory used while generating golden files, so output
+// that embeds default paths does not depend on who runs the tests.
+const goldenHome = "/home/tinct"
+
+// TestBuiltinOutputGolden runs every built-in output plugin on a fixed palette and
+// compares the generated files byte for byte with testdata/golden/<plugin>/<file>.
+// The golden directory must hold exactly the generated files.
+// Any change to a template shows up here as a reviewable diff.
+func TestBuiltinOutputGolden(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("golden files contain POSIX default paths")
+	}
+	t.Setenv("HOME", goldenHome)
+
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugins := NewBuilder().Build().AllOutputPlugins()
+
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			files, err := plugins[name].Generate(colour.NewThemeData(palette, "", ""))
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			dir := filepath.Join("testdata", "golden", name)
+			golden := make(map[string][]byte, len(files))
+			for file, content := range files {
+				goldenName, err := goldenFileName(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				golden[goldenName] = content
+			}
+
+			if *updateGolden {
+				writeGoldenFiles(t, dir, golden)
+				return
+			}
+
+			for file, content := range golden {
+				want, err := os.ReadFile(filepath.Join(dir, file)) // #nosec G304 - test reads its own golden files
+				if err != nil {
+					t.Fatalf("missing golden file (run with -update): %v", err)
+				}
+				if !bytes.Equal(content, want) {
+					t.Errorf("%s differs from its golden file (run with -update and review the diff)", file)
+				}
+			}
+			for _, file := range listGoldenFiles(t, dir) {
+				if _, ok := golden[file]; !ok {
+					t.Errorf("stale golden file %s is no longer generated (run with -update)", file)
+				}
+			}
+		})
+	}
+}
+
+// goldenParentDir stands in for each leading ".." of an output path, so files a
+// plugin writes beside its own directory stay inside its golden directory.
+const goldenParentDir = "_parent_"
+
+// goldenFileName maps an output path to its golden file name relative to the
+// plugin's golden directory. Absolute paths are rejected.
+func goldenFileName(file string) (string, error) {
+	if filepath.IsAbs(file) {
+		return "", fmt.Errorf("output path %q is absolute", file)
+	}
+	parts := strings.Split(filepath.ToSlash(filepath.Clean(file)), "/")
+	for i, part := range parts {
+		if part != ".." {
+			break
+		}
+		parts[i] = goldenParentDir
+	}
+	name := filepath.Join(parts...)
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("output path %q escapes the golden directory", file)
+	}
+	return name, nil
+}
+
+// listGoldenFiles returns the paths of all files under dir, relative to dir.
+func listGoldenFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("failed to list %s: %v", dir, err)
+	}
+	return files
+}
+
+// writeGoldenFiles replaces dir with the generated files.
+func writeGoldenFiles(t *testing.T, dir string, files map[string][]byte) {
+	t.Helper()
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("failed to clear %s: %v", dir, err)
+	}
+	for file, content := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { // #nosec G301 - test data directory
+			t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil { // #nosec G306 - test data file
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+}
@@ -10,30 +10,65 @@ import (
 	"os/exec"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/audit"
 	"github.com/jmylchreest/tinct/internal/plugin/executor"
 	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/input/clipboard"
 	"github.com/jmylchreest/tinct/internal/plugin/input/file"
 	"github.com/jmylchreest/tinct/internal/plugin/input/googlegenai"
+	"github.com/jmylchreest/tinct/internal/plugin/input/harmony"
 	"github.com/jmylchreest/tinct/internal/plugin/input/image"
+	"github.com/jmylchreest/tinct/internal/plugin/input/named"
+	"github.com/jmylchreest/tinct/internal/plugin/input/pywal"
 	"github.com/jmylchreest/tinct/internal/plugin/input/remotecss"
 	"github.com/jmylchreest/tinct/internal/plugin/input/remotejson"
+	"github.com/jmylchreest/tinct/internal/plugin/input/video"
 	"github.com/jmylchreest/tinct/internal/plugin/output"
 	"github.com/jmylchreest/tinct/internal/plugin/output/alacritty"
+	"github.com/jmylchreest/tinct/internal/plugin/output/android"
+	"github.com/jmylchreest/tinct/internal/plugin/output/bat"
+	"github.com/jmylchreest/tinct/internal/plugin/output/btop"
+	"github.com/jmylchreest/tinct/internal/plugin/output/cava"
+	"github.com/jmylchreest/tinct/internal/plugin/output/css"
+	"github.com/jmylchreest/tinct/internal/plugin/output/discord"
 	"github.com/jmylchreest/tinct/internal/plugin/output/dunst"
+	"github.com/jmylchreest/tinct/internal/plugin/output/emacs"
+	"github.com/jmylchreest/tinct/internal/plugin/output/eww"
+	"github.com/jmylchreest/tinct/internal/plugin/output/foot"
 	"github.com/jmylchreest/tinct/internal/plugin/output/fuzzel"
+	"github.com/jmylchreest/tinct/internal/plugin/output/ghostty"
+	"github.com/jmylchreest/tinct/internal/plugin/output/gimp"
+	"github.com/jmylchreest/tinct/internal/plugin/output/gtk"
+	"github.com/jmylchreest/tinct/internal/plugin/output/helix"
 	"github.com/jmylchreest/tinct/internal/plugin/output/hyprland"
 	"github.com/jmylchreest/tinct/internal/plugin/output/hyprlock"
 	"github.com/jmylchreest/tinct/internal/plugin/output/hyprpaper"
 	"github.com/jmylchreest/tinct/internal/plugin/output/kitty"
+	"github.com/jmylchreest/tinct/internal/plugin/output/mpv"
 	"github.com/jmylchreest/tinct/internal/plugin/output/neovim"
+	"github.com/jmylchreest/tinct/internal/plugin/output/polybar"
+	"github.com/jmylchreest/tinct/internal/plugin/output/qt"
+	"github.com/jmylchreest/tinct/internal/plugin/output/rofi"
+	"github.com/jmylchreest/tinct/internal/plugin/output/scss"
+	"github.com/jmylchreest/tinct/internal/plugin/output/starship"
+	"github.com/jmylchreest/tinct/internal/plugin/output/sway"
+	"github.com/jmylchreest/tinct/internal/plugin/output/swaylock"
 	"github.com/jmylchreest/tinct/internal/plugin/output/swayosd"
+	"github.com/jmylchreest/tinct/internal/plugin/output/tailwind"
+	"github.com/jmylchreest/tinct/internal/plugin/output/tmux"
+	"github.com/jmylchreest/tinct/internal/plugin/output/vim"
+	"github.com/jmylchreest/tinct/internal/plugin/output/vscode"
 	"github.com/jmylchreest/tinct/internal/plugin/output/waybar"
+	"github.com/jmylchreest/tinct/internal/plugin/output/wezterm"
 	"github.com/jmylchreest/tinct/internal/plugin/output/wofi"
+	"github.com/jmylchreest/tinct/internal/plugin/output/xresources"
+	"github.com/jmylchreest/tinct/internal/plugin/output/zathura"
 	"github.com/jmylchreest/tinct/internal/plugin/output/zellij"
 	"github.com/jmylchreest/tinct/internal/plugin/protocol"
 	"github.com/jmylchreest/tinct/pkg/plugin"
@@ -52,6 +87,9 @@ type Config struct {
 	// EnabledPlugins is a list of plugin names to explicitly enable.
 	// If set, only these plugins are enabled (whitelist mode).
 	EnabledPlugins []string
+
+	// AuditEnabled records every external plugin execution to the audit log.
+	AuditEnabled bool
 }
 
 // Builder provides a fluent interface for constructing a Manager with configuration.
@@ -80,7 +118,7 @@ func (b *Builder) WithConfig(config Config) *Builder {
 }
 
 // WithEnvConfig loads configuration from environment variables.
-// Reads TINCT_DISABLED_PLUGINS and TINCT_ENABLED_PLUGINS.
+// Reads TINCT_DISABLED_PLUGINS, TINCT_ENABLED_PLUGINS and TINCT_AUDIT.
 func (b *Builder) WithEnvConfig() *Builder {
 	b.useEnv = true
 	return b
@@ -113,6 +151,9 @@ func (b *Builder) Build() *Manager {
 		if enabled := os.Getenv("TINCT_ENABLED_PLUGINS"); enabled != "" {
 			config.EnabledPlugins = parsePluginList(enabled)
 		}
+		if auditEnabled, err := strconv.ParseBool(os.Getenv("TINCT_AUDIT")); err == nil {
+			config.AuditEnabled = auditEnabled
+		}
 	}
 
 	// Apply lock file config if specified (overrides env).
@@ -128,6 +169,11 @@ func (b *Builder) Build() *Manager {
 	// Register built-in plugins.
 	m.registerBuiltinPlugins()
 
+	// Enable auditing if configured. Failure to resolve the default path leaves auditing off.
+	if config.AuditEnabled {
+		_ = m.EnableAudit("")
+	}
+
 	return m
 }
 
@@ -136,6 +182,7 @@ type Manager struct {
 	config         Config
 	inputRegistry  *input.Registry
 	outputRegistry *output.Registry
+	auditLog       *audit.Log
 }
 
 // registerBuiltinPlugins registers all built-in plugins.
@@ -146,19 +193,52 @@ func (m *Manager) registerBuiltinPlugins() {
 	m.inputRegistry.Register(remotejson.New())
 	m.inputRegistry.Register(remotecss.New())
 	m.inputRegistry.Register(googlegenai.New())
+	m.inputRegistry.Register(harmony.New())
+	m.inputRegistry.Register(named.New())
+	m.inputRegistry.Register(pywal.New())
+	m.inputRegistry.Register(clipboard.New())
+	m.inputRegistry.Register(video.New())
 
 	// Register output plugins.
 	m.outputRegistry.Register(alacritty.New())
+	m.outputRegistry.Register(android.New())
+	m.outputRegistry.Register(bat.New())
+	m.outputRegistry.Register(btop.New())
+	m.outputRegistry.Register(cava.New())
+	m.outputRegistry.Register(css.New())
+	m.outputRegistry.Register(discord.New())
 	m.outputRegistry.Register(dunst.New())
+	m.outputRegistry.Register(emacs.New())
+	m.outputRegistry.Register(eww.New())
+	m.outputRegistry.Register(foot.New())
 	m.outputRegistry.Register(fuzzel.New())
+	m.outputRegistry.Register(ghostty.New())
+	m.outputRegistry.Register(gimp.New())
+	m.outputRegistry.Register(gtk.New())
+	m.outputRegistry.Register(helix.New())
 	m.outputRegistry.Register(hyprland.New())
 	m.outputRegistry.Register(hyprlock.New())
 	m.outputRegistry.Register(hyprpaper.New())
 	m.outputRegistry.Register(kitty.New())
+	m.outputRegistry.Register(mpv.New())
 	m.outputRegistry.Register(neovim.New())
+	m.outputRegistry.Register(polybar.New())
+	m.outputRegistry.Register(qt.New())
+	m.outputRegistry.Register(rofi.New())
+	m.outputRegistry.Register(scss.New())
+	m.outputRegistry.Register(starship.New())
+	m.outputRegistry.Register(sway.New())
+	m.outputRegistry.Register(swaylock.New())
 	m.outputRegistry.Register(swayosd.New())
+	m.outputRegistry.Register(tailwind.New())
+	m.outputRegistry.Register(tmux.New())
+	m.outputRegistry.Register(vim.New())
+	m.outputRegistry.Register(vscode.New())
 	m.outputRegistry.Register(waybar.New())
+	m.outputRegistry.Register(wezterm.New())
 	m.outputRegistry.Register(wofi.New())
+	m.outputRegistry.Register(xresources.New())
+	m.outputRegistry.Register(zathura.New())
 	m.outputRegistry.Register(zellij.New())
 }
 
@@ -205,8 +285,8 @@ func (m *Manager) IsOutputDisabled(plugin output.Plugin) bool {
 func (m *Manager) isDisabled(pluginType, name string) bool {
 	fullName := fmt.Sprintf("%s:%s", pluginType, name)
 
-	// Check if "all" is explicitly disabled.
-	if slices.Contains(m.config.DisabledPlugins, "all") {
+	// Check if "all" or "<type>:all" is explicitly disabled.
+	if containsAll(m.config.DisabledPlugins, pluginType) {
 		return true
 	}
 
@@ -224,8 +304,8 @@ func (m *Manager) isDisabled(pluginType, name string) bool {
 func (m *Manager) isEnabled(pluginType, name string) bool {
 	fullName := fmt.Sprintf("%s:%s", pluginType, name)
 
-	// Check if "all" is explicitly disabled (takes precedence over everything).
-	if slices.Contains(m.config.DisabledPlugins, "all") {
+	// Check if "all" or "<type>:all" is explicitly disabled (takes precedence over everything).
+	if containsAll(m.config.DisabledPlugins, pluginType) {
 		return false
 	}
 
@@ -236,8 +316,8 @@ func (m *Manager) isEnabled(pluginType, name string) bool {
 		}
 	}
 
-	// Check if "all" is enabled (enables all plugins).
-	if slices.Contains(m.config.EnabledPlugins, "all") {
+	// Check if "all" or "<type>:all" is enabled (enables all plugins, or all of this type).
+	if containsAll(m.config.EnabledPlugins, pluginType) {
 		return true
 	}
 
@@ -257,6 +337,44 @@ func (m *Manager) isEnabled(pluginType, name string) bool {
 	return false
 }
 
+// allPlugins is the pseudo-plugin name matching every plugin.
+// Prefixed with a type ("output:all") it matches every plugin of that type.
+const allPlugins = "all"
+
+// pluginTypes are the plugin types a type-scoped "all" can refer to.
+var pluginTypes = []string{"input", "output"}
+
+// containsAll reports whether list contains "all" or "<pluginType>:all".
+func containsAll(list []string, pluginType string) bool {
+	return slices.Contains(list, allPlugins) || slices.Contains(list, pluginType+":"+allPlugins)
+}
+
+// WithoutAll returns list with "all" and "<pluginType>:all" removed.
+// A global "all" is narrowed to the other plugin types, so enabling or
+// disabling every plugin of one type leaves the other types unchanged.
+func WithoutAll(list []string, pluginType string) []string {
+	result := make([]string, 0, len(list))
+	global := false
+	for _, item := range list {
+		switch item {
+		case allPlugins:
+			global = true
+		case pluginType + ":" + allPlugins:
+		default:
+			result = append(result, item)
+		}
+	}
+
+	if global {
+		for _, other := range pluginTypes {
+			if other != pluginType && !slices.Contains(result, other+":"+allPlugins) {
+				result = append(result, other+":"+allPlugins)
+			}
+		}
+	}
+	return result
+}
+
 // FilterInputPlugins returns only enabled input plugins.
 func (m *Manager) FilterInputPlugins() map[string]input.Plugin {
 	enabled := make(map[string]input.Plugin)
@@ -314,6 +432,8 @@ func (m *Manager) AllOutputPlugins() map[string]output.Plugin {
 // UpdateConfig updates the manager's configuration without recreating plugin instances.
 // This preserves flag bindings and other plugin state.
 func (m *Manager) UpdateConfig(config Config) {
+	// Auditing enabled at build time or via EnableAudit stays on.
+	config.AuditEnabled = config.AuditEnabled || m.auditLog != nil
 	m.config = config
 }
 
@@ -362,10 +482,12 @@ func (m *Manager) RegisterExternalPlugin(name, pluginType, path, description str
 	switch pluginType {
 	case "output":
 		plugin := NewExternalOutputPlugin(name, description, path)
+		plugin.SetAuditLog(m.auditLog)
 		m.outputRegistry.Register(plugin)
 		return nil
 	case "input":
 		plugin := NewExternalInputPlugin(name, description, path)
+		plugin.SetAuditLog(m.auditLog)
 		m.inputRegistry.Register(plugin)
 		return nil
 	default:
@@ -373,6 +495,39 @@ func (m *Manager) RegisterExternalPlugin(name, pluginType, path, description str
 	}
 }
 
+// EnableAudit enables the external plugin execution audit log.
+// An empty path uses audit.DefaultPath(). Already registered external plugins are updated.
+func (m *Manager) EnableAudit(path string) error {
+	if path == "" {
+		defaultPath, err := audit.DefaultPath()
+		if err != nil {
+			return fmt.Errorf("failed to determine audit log path: %w", err)
+		}
+		path = defaultPath
+	}
+
+	m.config.AuditEnabled = true
+	m.auditLog = audit.New(path)
+
+	for _, p := range m.inputRegistry.All() {
+		if ext, ok := p.(*ExternalInputPlugin); ok {
+			ext.SetAuditLog(m.auditLog)
+		}
+	}
+	for _, p := range m.outputRegistry.All() {
+		if ext, ok := p.(*ExternalOutputPlugin); ok {
+			ext.SetAuditLog(m.auditLog)
+		}
+	}
+
+	return nil
+}
+
+// AuditLog returns the audit log, or nil if auditing is disabled.
+func (m *Manager) AuditLog() *audit.Log {
+	return m.auditLog
+}
+
 // PluginInfo holds metadata returned by a plugin's --plugin-info command.
 type PluginInfo struct {
 	Name            string `json:"name"`
@@ -418,8 +573,12 @@ func (m *Manager) GetConfig() Config {
 }
 
 // SetDisabled adds a plugin to the disabled list.
+// The name "all" disables every plugin of pluginType.
 func (m *Manager) SetDisabled(pluginType, name string) {
 	fullName := fmt.Sprintf("%s:%s", pluginType, name)
+	if name == allPlugins {
+		m.config.EnabledPlugins = WithoutAll(m.config.EnabledPlugins, pluginType)
+	}
 
 	// Remove from enabled list if present.
 	for i, enabled := range m.config.EnabledPlugins {
@@ -437,8 +596,12 @@ func (m *Manager) SetDisabled(pluginType, name string) {
 }
 
 // SetEnabled adds a plugin to the enabled list (whitelist mode).
+// The name "all" enables every plugin of pluginType.
 func (m *Manager) SetEnabled(pluginType, name string) {
 	fullName := fmt.Sprintf("%s:%s", pluginType, name)
+	if name == allPlugins {
+		m.config.DisabledPlugins = WithoutAll(m.config.DisabledPlugins, pluginType)
+	}
 
 	// Remove from disabled list if present.
 	for i, disabled := range m.config.DisabledPlugins {
@@ -463,6 +626,7 @@ type ExternalInputPlugin struct {
 	args         map[string]any
 	dryRun       bool
 	lastExecutor *executor.PluginExecutor // Store last executor to query wallpaper path
+	auditLog     *audit.Log               // Optional audit log for executions
 }
 
 // NewExternalInputPlugin creates a new external input plugin wrapper.
@@ -484,6 +648,11 @@ func (p *ExternalInputPlugin) Description() string {
 	return p.description
 }
 
+// Path returns the path of the plugin executable.
+func (p *ExternalInputPlugin) Path() string {
+	return p.path
+}
+
 // Version returns the plugin's version.
 // For external plugins, this queries the plugin executable.
 func (p *ExternalInputPlugin) Version() string {
@@ -517,6 +686,21 @@ func (p *ExternalInputPlugin) GetDryRun() bool {
 	return p.dryRun
 }
 
+// SetAuditLog sets the audit log used to record executions (nil disables auditing).
+func (p *ExternalInputPlugin) SetAuditLog(log *audit.Log) {
+	p.auditLog = log
+}
+
+// newExecutor creates a plugin executor with auditing configured.
+func (p *ExternalInputPlugin) newExecutor(verbose bool) (*executor.PluginExecutor, error) {
+	exec, err := executor.NewWithVerbose(p.path, verbose)
+	if err != nil {
+		return nil, err
+	}
+	exec.SetAuditLog(p.auditLog, p.name)
+	return exec, nil
+}
+
 // Generate executes the external plugin and returns a palette.
 // Uses the hybrid executor which automatically detects and uses the appropriate
 // protocol (go-plugin RPC or JSON-stdio).
@@ -527,7 +711,7 @@ func (p *ExternalInputPlugin) Generate(ctx context.Context, opts input.GenerateO
 	}
 
 	// Create executor (detects protocol automatically).
-	exec, err := executor.NewWithVerbose(p.path, opts.Verbose)
+	exec, err := p.newExecutor(opts.Verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create plugin executor: %w", err)
 	}
@@ -535,18 +719,8 @@ func (p *ExternalInputPlugin) Generate(ctx context.Context, opts input.GenerateO
 	// Store the executor so we can query wallpaper path later
 	p.lastExecutor = exec
 
-	// Merge plugin args from opts with plugin's own args.
-	mergedArgs := make(map[string]any)
-	maps.Copy(mergedArgs, p.args)
-	maps.Copy(mergedArgs, opts.PluginArgs)
-
 	// Convert to protocol format.
-	protocolOpts := plugin.InputOptions{
-		Verbose:         opts.Verbose,
-		DryRun:          opts.DryRun || p.dryRun,
-		ColourOverrides: opts.ColourOverrides,
-		PluginArgs:      mergedArgs,
-	}
+	protocolOpts := p.ProtocolOptions(opts)
 
 	// Debug: show what's being sent to plugin.
 	if opts.Verbose {
@@ -564,6 +738,27 @@ func (p *ExternalInputPlugin) Generate(ctx context.Context, opts input.GenerateO
 	return colour.NewPalette(colors), nil
 }
 
+// ProtocolOptions returns the options sent to the plugin for the given generate options,
+// merging the plugin's own args with those in opts.
+func (p *ExternalInputPlugin) ProtocolOptions(opts input.GenerateOptions) plugin.InputOptions {
+	mergedArgs := make(map[string]any)
+	maps.Copy(mergedArgs, p.args)
+	maps.Copy(mergedArgs, opts.PluginArgs)
+
+	return plugin.InputOptions{
+		Verbose:         opts.Verbose,
+		DryRun:          opts.DryRun || p.dryRun,
+		ColourOverrides: opts.ColourOverrides,
+		PluginArgs:      mergedArgs,
+		Count:           opts.Count,
+		Backend:         opts.Backend,
+		SeedMode:        opts.SeedMode,
+		SeedValue:       opts.SeedValue,
+		ExtractAmbience: opts.ExtractAmbience,
+		Regions:         opts.Regions,
+	}
+}
+
 // RegisterFlags is a no-op for external plugins (they don't have flags).
 func (p *ExternalInputPlugin) RegisterFlags(_ *cobra.Command) {
 	// External plugins don't register flags in Tinct.
@@ -612,6 +807,7 @@ type ExternalOutputPlugin struct {
 	args        map[string]any
 	dryRun      bool
 	verbose     bool
+	auditLog    *audit.Log // Optional audit log for executions
 }
 
 // NewExternalOutputPlugin creates a new external output plugin wrapper.
@@ -633,6 +829,11 @@ func (p *ExternalOutputPlugin) Description() string {
 	return p.description
 }
 
+// Path returns the path of the plugin executable.
+func (p *ExternalOutputPlugin) Path() string {
+	return p.path
+}
+
 // Version returns the plugin's version.
 // For external plugins, this queries the plugin executable.
 func (p *ExternalOutputPlugin) Version() string {
@@ -666,6 +867,21 @@ func (p *ExternalOutputPlugin) GetDryRun() bool {
 	return p.dryRun
 }
 
+// SetAuditLog sets the audit log used to record executions (nil disables auditing).
+func (p *ExternalOutputPlugin) SetAuditLog(log *audit.Log) {
+	p.auditLog = log
+}
+
+// newExecutor creates a plugin executor with auditing configured.
+func (p *ExternalOutputPlugin) newExecutor(verbose bool) (*executor.PluginExecutor, error) {
+	exec, err := executor.NewWithVerbose(p.path, verbose)
+	if err != nil {
+		return nil, err
+	}
+	exec.SetAuditLog(p.auditLog, p.name)
+	return exec, nil
+}
+
 // SetVerbose sets the verbose flag for this plugin.
 func (p *ExternalOutputPlugin) SetVerbose(verbose bool) {
 	p.verbose = verbose
@@ -679,17 +895,14 @@ func (p *ExternalOutputPlugin) GetVerbose() bool {
 // Generate executes the external plugin and returns its output.
 func (p *ExternalOutputPlugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
 	// Create executor (detects protocol automatically).
-	exec, err := executor.NewWithVerbose(p.path, p.verbose)
+	exec, err := p.newExecutor(p.verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create plugin executor: %w", err)
 	}
 	defer exec.Close()
 
-	// Extract palette from themeData.
-	palette := themeData.Palette()
-
 	// Convert to protocol format.
-	paletteData := convertCategorisedPaletteToProtocol(palette, p.args, p.dryRun)
+	paletteData := p.ProtocolPalette(themeData.Palette())
 
 	// Execute output plugin.
 	files, err := exec.ExecuteOutput(context.Background(), paletteData)
@@ -705,6 +918,11 @@ func (p *ExternalOutputPlugin) Generate(themeData *colour.ThemeData) (map[string
 	return files, nil
 }
 
+// ProtocolPalette returns the palette data sent to the plugin for the given palette.
+func (p *ExternalOutputPlugin) ProtocolPalette(palette *colour.CategorisedPalette) plugin.PaletteData {
+	return convertCategorisedPaletteToProtocol(palette, p.args, p.dryRun)
+}
+
 // RegisterFlags is a no-op for external plugins (they don't have flags).
 func (p *ExternalOutputPlugin) RegisterFlags(_ *cobra.Command) {
 	// External plugins don't register flags in Tinct.
@@ -727,7 +945,7 @@ func (p *ExternalOutputPlugin) DefaultOutputDir() string {
 // Implements the output.PreExecuteHook interface.
 func (p *ExternalOutputPlugin) PreExecute(ctx context.Context) (skip bool, reason string, err error) {
 	// Create executor (detects protocol automatically).
-	exec, err := executor.NewWithVerbose(p.path, p.verbose)
+	exec, err := p.newExecutor(p.verbose)
 	if err != nil {
 		return false, "", fmt.Errorf("failed to create plugin executor: %w", err)
 	}
@@ -759,7 +977,7 @@ func (p *ExternalOutputPlugin) GetFlagHelp() []input.FlagHelp {
 // Implements the output.PostExecuteHook interface.
 func (p *ExternalOutputPlugin) PostExecute(ctx context.Context, writtenFiles []string) error {
 	// Create executor (detects protocol automatically).
-	exec, err := executor.NewWithVerbose(p.path, p.verbose)
+	exec, err := p.newExecutor(p.verbose)
 	if err != nil {
 		return fmt.Errorf("failed to create plugin executor: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -137,7 +138,7 @@ func TestBuildRegistersBuiltinPlugins(t *testing.T) {
 	}
 
 	// Check for specific built-in plugins.
-	expectedInputs := []string{"image", "file", "remote-json", "remote-css", "google-genai"}
+	expectedInputs := []string{"image", "file", "remote-json", "remote-css", "google-genai", "harmony", "named", "pywal", "clipboard", "video"}
 	for _, name := range expectedInputs {
 		if _, ok := manager.GetInputPlugin(name); !ok {
 			t.Errorf("Built-in input plugin '%s' not registered", name)
@@ -439,6 +440,62 @@ func TestSetEnabledRemovesFromDisabled(t *testing.T) {
 	}
 }
 
+// TestSetEnabledAllOfType tests that enabling all output plugins leaves input plugins disabled.
+func TestSetEnabledAllOfType(t *testing.T) {
+	inputPlugin := &mockInputPlugin{name: "test-input"}
+	outputPlugin := &mockOutputPlugin{name: "test-output"}
+
+	for _, disabled := range [][]string{nil, {"all"}} {
+		manager := NewBuilder().WithConfig(Config{DisabledPlugins: disabled}).Build()
+
+		manager.SetEnabled("output", "all")
+
+		if !manager.IsOutputEnabled(outputPlugin) {
+			t.Errorf("disabled=%v: output plugin should be enabled after enabling output:all", disabled)
+		}
+		if manager.IsInputEnabled(inputPlugin) {
+			t.Errorf("disabled=%v: input plugin should stay disabled after enabling output:all", disabled)
+		}
+	}
+}
+
+// TestSetDisabledAllOfType tests that disabling all input plugins leaves output plugins enabled.
+func TestSetDisabledAllOfType(t *testing.T) {
+	manager := NewBuilder().WithConfig(Config{EnabledPlugins: []string{"all"}}).Build()
+
+	manager.SetDisabled("input", "all")
+
+	if manager.IsInputEnabled(&mockInputPlugin{name: "test-input"}) {
+		t.Error("input plugin should be disabled after disabling input:all")
+	}
+	if !manager.IsOutputEnabled(&mockOutputPlugin{name: "test-output"}) {
+		t.Error("output plugin should stay enabled after disabling input:all")
+	}
+	if manager.IsOutputDisabled(&mockOutputPlugin{name: "test-output"}) {
+		t.Error("output plugin should not be reported as disabled")
+	}
+}
+
+// TestWithoutAll tests removing global and type-scoped "all" entries.
+func TestWithoutAll(t *testing.T) {
+	tests := []struct {
+		list []string
+		want []string
+	}{
+		{[]string{"kitty", "output:all"}, []string{"kitty"}},
+		{[]string{"all", "kitty"}, []string{"kitty", "input:all"}},
+		{[]string{"all", "input:all"}, []string{"input:all"}},
+		{[]string{"input:all"}, []string{"input:all"}},
+	}
+
+	for _, tt := range tests {
+		got := WithoutAll(tt.list, "output")
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("WithoutAll(%v, output) = %v, want %v", tt.list, got, tt.want)
+		}
+	}
+}
+
 // TestRegisterExternalPluginInvalidPath tests registering with invalid path.
 func TestRegisterExternalPluginInvalidPath(t *testing.T) {
 	manager := NewBuilder().Build()
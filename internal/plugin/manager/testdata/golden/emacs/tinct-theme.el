@@ -0,0 +1,44 @@
+;;; tinct-theme.el --- Theme generated by Tinct -*- lexical-binding: t -*-
+
+;; https://github.com/jmylchreest/tinct
+;; Detected theme: dark
+;; Load it with:
+;;   (add-to-list 'custom-theme-load-path "/home/tinct/.config/emacs/themes")
+;;   (load-theme 'tinct t)
+
+;;; Code:
+
+(deftheme tinct "Colours generated by Tinct.")
+
+(custom-theme-set-faces
+ 'tinct
+ ;; Interface
+ '(default ((t (:background "#1a1b26" :foreground "#c0caf5"))))
+ '(cursor ((t (:background "#8855d0"))))
+ '(region ((t (:background "#3f404c" :extend t))))
+ '(hl-line ((t (:background "#272837" :extend t))))
+ '(fringe ((t (:background "#1a1b26"))))
+ '(line-number ((t (:foreground "#99a3cf"))))
+ '(line-number-current-line ((t (:foreground "#c0caf5"))))
+ '(minibuffer-prompt ((t (:foreground "#8855d0" :weight bold))))
+ '(link ((t (:foreground "#8855d0" :underline t))))
+ '(mode-line ((t (:background "#272837" :foreground "#c0caf5"))))
+ '(mode-line-inactive ((t (:background "#272837" :foreground "#99a3cf"))))
+
+ ;; Syntax
+ '(font-lock-comment-face ((t (:foreground "#99a3cf" :slant italic))))
+ '(font-lock-string-face ((t (:foreground "#c2303c"))))
+ '(font-lock-keyword-face ((t (:foreground "#c0b432"))))
+ '(font-lock-function-name-face ((t (:foreground "#8855d0"))))
+ '(font-lock-constant-face ((t (:foreground "#2e9a24"))))
+ '(font-lock-type-face ((t (:foreground "#4c99e5"))))
+ '(font-lock-variable-name-face ((t (:foreground "#c0caf5"))))
+
+ ;; Diagnostics
+ '(error ((t (:foreground "#d0414d" :weight bold))))
+ '(warning ((t (:foreground "#cfc342" :weight bold))))
+ '(success ((t (:foreground "#3cc92e" :weight bold)))))
+
+(provide-theme 'tinct)
+
+;;; tinct-theme.el ends here
@@ -0,0 +1,31 @@
+// Tinct Tailwind CSS colours (dark theme)
+// Generated by tinct - https://github.com/jmylchreest/tinct
+//
+// Add to tailwind.config.js:
+//   const { colors } = require("/home/tinct/.config/tinct/tinct-colors.js");
+//   module.exports = { theme: { extend: { colors } } };
+
+const colors = {
+  background: "#1a1b26",
+  foreground: "#c0caf5",
+
+  primary: "#8855d0",
+  secondary: "#c0b432",
+
+  danger: "#d0414d",
+  warning: "#cfc342",
+  success: "#3cc92e",
+  info: "#4c99e5",
+
+  surface: {
+    DEFAULT: "#272837",
+    lowest: "#1e1f2b",
+    low: "#222331",
+    container: "#272837",
+    high: "#2b2c3c",
+    highest: "#2f3042",
+    variant: "#212230",
+  },
+};
+
+module.exports = { colors };
@@ -0,0 +1,35 @@
+" Vim colorscheme generated by Tinct
+" https://github.com/jmylchreest/tinct
+" Detected theme: dark
+" Select it with `colorscheme tinct` in ~/.vimrc (use `set termguicolors` for exact colours)
+
+set background=dark
+highlight clear
+if exists("syntax_on")
+  syntax reset
+endif
+let g:colors_name = "tinct"
+
+" Interface
+highlight Normal guifg=#c0caf5 guibg=#1a1b26 ctermfg=153 ctermbg=234
+highlight Visual guibg=#3f404c ctermbg=238
+highlight CursorLine guibg=#272837 ctermbg=236 gui=NONE cterm=NONE
+highlight LineNr guifg=#99a3cf ctermfg=110
+highlight CursorLineNr guifg=#c0caf5 ctermfg=153
+highlight StatusLine guifg=#c0caf5 guibg=#272837 ctermfg=153 ctermbg=236 gui=NONE cterm=NONE
+highlight StatusLineNC guifg=#99a3cf guibg=#272837 ctermfg=110 ctermbg=236 gui=NONE cterm=NONE
+highlight Pmenu guifg=#c0caf5 guibg=#272837 ctermfg=153 ctermbg=236
+highlight PmenuSel guifg=#1a1b26 guibg=#8855d0 ctermfg=234 ctermbg=98
+
+" Syntax
+highlight Comment guifg=#99a3cf ctermfg=110 gui=italic cterm=italic
+highlight Constant guifg=#2e9a24 ctermfg=28
+highlight String guifg=#c2303c ctermfg=131
+highlight Identifier guifg=#8855d0 ctermfg=98
+highlight Statement guifg=#c0b432 ctermfg=143
+highlight Type guifg=#4c99e5 ctermfg=68
+
+" Diagnostics
+highlight Error guifg=#d0414d guibg=NONE ctermfg=167 ctermbg=NONE gui=bold cterm=bold
+highlight ErrorMsg guifg=#d0414d guibg=NONE ctermfg=167 ctermbg=NONE
+highlight WarningMsg guifg=#cfc342 ctermfg=179
@@ -0,0 +1,41 @@
+-- WezTerm colour theme generated by Tinct
+-- https://github.com/jmylchreest/tinct
+--
+-- Detected theme: dark
+--
+-- Load this table in your wezterm.lua with:
+--   config.colors = dofile("/home/tinct/.config/wezterm/tinct.lua")
+
+return {
+  foreground = "#c0caf5",
+  background = "#1a1b26",
+
+  cursor_bg = "#8855d0",
+  cursor_fg = "#1a1b26",
+  cursor_border = "#8855d0",
+
+  selection_bg = "#8855d0",
+  selection_fg = "#1a1b26",
+
+  ansi = {
+    "#1a1b26", -- black
+    "#d0414d", -- red
+    "#3cc92e", -- green
+    "#cfc342", -- yellow
+    "#8855d0", -- blue
+    "#c2303c", -- magenta
+    "#c0b432", -- cyan
+    "#99a3cf", -- white
+  },
+
+  brights = {
+    "#3f404c", -- bright black
+    "#d0414d", -- bright red
+    "#3cc92e", -- bright green
+    "#cfc342", -- bright yellow
+    "#4c99e5", -- bright blue
+    "#2e9a24", -- bright magenta
+    "#c0b432", -- bright cyan
+    "#c0caf5", -- bright white
+  },
+}
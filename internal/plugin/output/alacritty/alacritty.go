@@ -9,6 +9,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/spf13/cobra"
@@ -31,8 +34,10 @@ func GetEmbeddedTemplates() embed.FS {
 
 // Plugin implements the output.Plugin interface for Alacritty terminal.
 type Plugin struct {
-	outputDir string
-	verbose   bool
+	outputDir  string
+	addImport  bool
+	configPath string // alacritty.toml to update with --alacritty.import (empty uses the default)
+	verbose    bool
 }
 
 // New creates a new Alacritty output plugin with default settings.
@@ -61,6 +66,7 @@ func (p *Plugin) Version() string {
 // RegisterFlags registers plugin-specific flags with the cobra command.
 func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&p.outputDir, "alacritty.output-dir", "", "Output directory (default: ~/.config/alacritty)")
+	cmd.Flags().BoolVar(&p.addImport, "alacritty.import", false, "Add the theme to the import list in alacritty.toml if missing")
 }
 
 // SetVerbose enables or disables verbose logging for the plugin.
@@ -79,6 +85,7 @@ func (p *Plugin) GetEmbeddedFS() any {
 func (p *Plugin) GetFlagHelp() []input.FlagHelp {
 	return []input.FlagHelp{
 		{Name: "alacritty.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/alacritty)", Required: false},
+		{Name: "alacritty.import", Type: "bool", Default: "false", Description: "Add the theme to the import list in alacritty.toml if missing", Required: false},
 	}
 }
 
@@ -180,17 +187,34 @@ func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err er
 
 // PostExecute provides usage instructions for applying the theme.
 // Implements the output.PostExecuteHook interface.
-func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, generatedFiles []string) error {
+func (p *Plugin) PostExecute(_ context.Context, execCtx output.ExecutionContext, generatedFiles []string) error {
+	themePath := filepath.Join(p.DefaultOutputDir(), "tinct-colors.toml")
+
+	if p.addImport && !execCtx.DryRun && len(generatedFiles) > 0 {
+		configPath := p.alacrittyConfigPath()
+		added, err := ensureImport(configPath, themePath)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", configPath, err)
+		}
+		if added && p.verbose {
+			fmt.Fprintf(os.Stderr, "   Added theme import to %s\n", configPath)
+		}
+		return nil
+	}
+
 	if p.verbose && len(generatedFiles) > 0 {
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "   Alacritty theme generated successfully!\n")
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "   To use this theme, add to your alacritty.toml:\n")
 		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "   [general]\n")
 		fmt.Fprintf(os.Stderr, "   import = [\n")
-		fmt.Fprintf(os.Stderr, "     \"%s\"\n", filepath.Join(p.DefaultOutputDir(), "tinct-colors.toml"))
+		fmt.Fprintf(os.Stderr, "     \"%s\"\n", themePath)
 		fmt.Fprintf(os.Stderr, "   ]\n")
 		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "   Or rerun with --alacritty.import to add it automatically.\n")
+		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "   Note: Alacritty automatically reloads config when files change.\n")
 		fmt.Fprintf(os.Stderr, "   New colors will apply immediately to all open terminals.\n")
 		fmt.Fprintf(os.Stderr, "\n")
@@ -198,3 +222,149 @@ func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, gener
 
 	return nil
 }
+
+// alacrittyConfigPath returns the alacritty.toml that --alacritty.import updates.
+func (p *Plugin) alacrittyConfigPath() string {
+	if p.configPath != "" {
+		return p.configPath
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".config", "alacritty", "alacritty.toml")
+	}
+	return filepath.Join(home, ".config", "alacritty", "alacritty.toml")
+}
+
+// importArrayPattern matches the start of an import array, either under [general]
+// (Alacritty 0.14+) or at the top level (older releases).
+var importArrayPattern = regexp.MustCompile(`(?m)^[ \t]*import[ \t]*=[ \t]*\[`)
+
+// generalTablePattern matches the [general] table header.
+var generalTablePattern = regexp.MustCompile(`(?m)^[ \t]*\[general\][ \t]*$`)
+
+// ensureImport adds themePath to the import list in the Alacritty config at configPath,
+// creating the file if needed. Returns false if the theme is already imported.
+func ensureImport(configPath, themePath string) (bool, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	updated, added := addImport(string(content), themePath)
+	if !added {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+		return false, err
+	}
+	if err := os.WriteFile(configPath, []byte(updated), 0o644); err != nil { // #nosec G306 - Config file needs standard read permissions
+		return false, err
+	}
+	return true, nil
+}
+
+// addImport returns config with themePath appended to its import array. Imports are
+// appended last so the generated colours override earlier imported themes. An import
+// array is created under [general] when none exists. Returns false if the array
+// already imports themePath, written either in full or with ~ or $HOME.
+func addImport(config, themePath string) (string, bool) {
+	quoted := strconv.Quote(themePath)
+
+	// Append to an existing import array.
+	if loc := importArrayPattern.FindStringIndex(config); loc != nil {
+		imports, insert, ok := scanImportArray(config[loc[1]:])
+		if ok {
+			want := expandImportPath(themePath)
+			for _, path := range imports {
+				if expandImportPath(path) == want {
+					return config, false
+				}
+			}
+
+			// Insert straight after the last entry, ahead of any trailing comma or comment.
+			insert += loc[1]
+			if len(imports) > 0 {
+				quoted = ", " + quoted
+			}
+			return config[:insert] + quoted + config[insert:], true
+		}
+	}
+
+	// Add the key to an existing [general] table.
+	if loc := generalTablePattern.FindStringIndex(config); loc != nil {
+		return config[:loc[1]] + "\nimport = [" + quoted + "]" + config[loc[1]:], true
+	}
+
+	// Otherwise create the table.
+	if config != "" && !strings.HasSuffix(config, "\n") {
+		config += "\n"
+	}
+	if config != "" {
+		config += "\n"
+	}
+	return config + "[general]\nimport = [" + quoted + "]\n", true
+}
+
+// scanImportArray reads a TOML array of strings starting just after its opening
+// bracket. It returns the array's strings and the offset just past the last of
+// them, or 0 if the array is empty. Brackets inside quoted strings and comments
+// are skipped. ok is false if the array is not terminated.
+func scanImportArray(s string) (imports []string, insert int, ok bool) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case ']':
+			return imports, insert, true
+		case '#':
+			next := strings.IndexByte(s[i:], '\n')
+			if next < 0 {
+				return nil, 0, false
+			}
+			i += next
+		case '\'':
+			closing := strings.IndexByte(s[i+1:], '\'')
+			if closing < 0 {
+				return nil, 0, false
+			}
+			imports = append(imports, s[i+1:i+1+closing])
+			i += closing + 1
+			insert = i + 1
+		case '"':
+			j := i + 1
+			for j < len(s) && s[j] != '"' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(s) {
+				return nil, 0, false
+			}
+			path, err := strconv.Unquote(s[i : j+1])
+			if err != nil {
+				path = s[i+1 : j]
+			}
+			imports = append(imports, path)
+			i = j
+			insert = i + 1
+		}
+	}
+	return nil, 0, false
+}
+
+// expandImportPath expands a leading ~ or $HOME in an import path, as Alacritty
+// does, so differently written paths to the same file compare equal.
+func expandImportPath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	for _, prefix := range []string{"~", "$HOME", "${HOME}"} {
+		if rest, ok := strings.CutPrefix(path, prefix); ok && (rest == "" || rest[0] == '/') {
+			return filepath.Join(home, rest)
+		}
+	}
+	return filepath.Clean(path)
+}
@@ -1,10 +1,14 @@
 package alacritty
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
 	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
 )
 
@@ -57,3 +61,180 @@ func TestAlacrittyPlugin_ContentValidation(t *testing.T) {
 		}
 	}
 }
+
+// TestAlacrittyPlugin_LightThemeMapping tests that light themes anchor black/white to the theme.
+func TestAlacrittyPlugin_LightThemeMapping(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeLight)
+	plugin := New()
+
+	themeData := colour.NewThemeData(palette, "", "")
+	files, err := plugin.Generate(themeData)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["tinct-colors.toml"])
+
+	helper := colour.NewPaletteHelper(palette)
+	foreground := helper.Get(colour.RoleForeground)
+	background := helper.Get(colour.RoleBackground)
+
+	normal := tomlTable(content, "[colors.normal]")
+	if !strings.Contains(normal, "black = '"+foreground.Hex()+"'") {
+		t.Errorf("light theme normal black should be the foreground %s:\n%s", foreground.Hex(), normal)
+	}
+	bright := tomlTable(content, "[colors.bright]")
+	if !strings.Contains(bright, "white = '"+background.Hex()+"'") {
+		t.Errorf("light theme bright white should be the background %s:\n%s", background.Hex(), bright)
+	}
+}
+
+// tomlTable returns the body of the named TOML table.
+func tomlTable(content, header string) string {
+	_, body, found := strings.Cut(content, header+"\n")
+	if !found {
+		return ""
+	}
+	if end := strings.Index(body, "\n["); end >= 0 {
+		body = body[:end]
+	}
+	return body
+}
+
+// TestAddImport tests adding the theme to alacritty.toml import lists.
+func TestAddImport(t *testing.T) {
+	t.Setenv("HOME", "/home/user")
+	const theme = "/home/user/.config/alacritty/tinct-colors.toml"
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+		added  bool
+	}{
+		{
+			name:   "empty config",
+			config: "",
+			want:   "[general]\nimport = [\"" + theme + "\"]\n",
+			added:  true,
+		},
+		{
+			name:   "no general table",
+			config: "[window]\nopacity = 0.9",
+			want:   "[window]\nopacity = 0.9\n\n[general]\nimport = [\"" + theme + "\"]\n",
+			added:  true,
+		},
+		{
+			name:   "general table without import",
+			config: "[general]\nlive_config_reload = true\n",
+			want:   "[general]\nimport = [\"" + theme + "\"]\nlive_config_reload = true\n",
+			added:  true,
+		},
+		{
+			name:   "existing single line import",
+			config: "[general]\nimport = [\"~/themes/base.toml\"]\n",
+			want:   "[general]\nimport = [\"~/themes/base.toml\", \"" + theme + "\"]\n",
+			added:  true,
+		},
+		{
+			name:   "existing multi-line import with trailing comma",
+			config: "import = [\n  \"~/themes/base.toml\",\n]\n",
+			want:   "import = [\n  \"~/themes/base.toml\", \"" + theme + "\",\n]\n",
+			added:  true,
+		},
+		{
+			name:   "empty import array with comment",
+			config: "import = [ # none yet\n]\n",
+			want:   "import = [\"" + theme + "\" # none yet\n]\n",
+			added:  true,
+		},
+		{
+			name:   "empty import array",
+			config: "[general]\nimport = []\n",
+			want:   "[general]\nimport = [\"" + theme + "\"]\n",
+			added:  true,
+		},
+		{
+			name:   "already imported",
+			config: "[general]\nimport = [\"" + theme + "\"]\n",
+			want:   "[general]\nimport = [\"" + theme + "\"]\n",
+			added:  false,
+		},
+		{
+			name:   "already imported with tilde",
+			config: "[general]\nimport = [\"~/.config/alacritty/tinct-colors.toml\"]\n",
+			want:   "[general]\nimport = [\"~/.config/alacritty/tinct-colors.toml\"]\n",
+			added:  false,
+		},
+		{
+			name:   "already imported with $HOME",
+			config: "import = [\n  '$HOME/.config/alacritty/tinct-colors.toml',\n]\n",
+			want:   "import = [\n  '$HOME/.config/alacritty/tinct-colors.toml',\n]\n",
+			added:  false,
+		},
+		{
+			name:   "theme path only in a comment",
+			config: "# was " + theme + "\nimport = []\n",
+			want:   "# was " + theme + "\nimport = [\"" + theme + "\"]\n",
+			added:  true,
+		},
+		{
+			name:   "bracket inside an earlier import path",
+			config: "import = [\"~/themes/[dark]/base.toml\"]\n",
+			want:   "import = [\"~/themes/[dark]/base.toml\", \"" + theme + "\"]\n",
+			added:  true,
+		},
+		{
+			name:   "bracket in a comment inside the array",
+			config: "import = [\n  \"~/themes/base.toml\", # see [docs]\n]\n",
+			want:   "import = [\n  \"~/themes/base.toml\", \"" + theme + "\", # see [docs]\n]\n",
+			added:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, added := addImport(tt.config, theme)
+			if added != tt.added {
+				t.Errorf("addImport() added = %v, want %v", added, tt.added)
+			}
+			if got != tt.want {
+				t.Errorf("addImport() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPostExecuteImport tests that --alacritty.import updates the config once.
+func TestPostExecuteImport(t *testing.T) {
+	dir := t.TempDir()
+	plugin := New()
+	plugin.outputDir = dir
+	plugin.addImport = true
+	plugin.configPath = filepath.Join(dir, "alacritty.toml")
+
+	written := []string{filepath.Join(dir, "tinct-colors.toml")}
+	for range 2 {
+		if err := plugin.PostExecute(context.Background(), output.ExecutionContext{}, written); err != nil {
+			t.Fatalf("PostExecute() error = %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(plugin.configPath)
+	if err != nil {
+		t.Fatalf("alacritty.toml not written: %v", err)
+	}
+	if n := strings.Count(string(content), written[0]); n != 1 {
+		t.Errorf("expected the theme to be imported once, found %d times:\n%s", n, content)
+	}
+
+	// Dry runs leave the config untouched.
+	dryPlugin := New()
+	dryPlugin.addImport = true
+	dryPlugin.configPath = filepath.Join(dir, "dry-run.toml")
+	if err := dryPlugin.PostExecute(context.Background(), output.ExecutionContext{DryRun: true}, written); err != nil {
+		t.Fatalf("PostExecute() error = %v", err)
+	}
+	if _, err := os.Stat(dryPlugin.configPath); !os.IsNotExist(err) {
+		t.Error("dry run should not create alacritty.toml")
+	}
+}
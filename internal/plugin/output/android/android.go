@@ -0,0 +1,280 @@
+// Package android provides an output plugin for Android colour resources.
+package android
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// Generated resource files, relative to the output directory (an Android source set).
+var (
+	colorsFile      = filepath.Join("res", "values", "colors.xml")
+	nightColorsFile = filepath.Join("res", "values-night", "colors.xml")
+	themeFile       = filepath.Join("res", "values", "tinct_theme.xml")
+)
+
+// materialColour maps a MaterialComponents theme attribute to the role that sets it.
+type materialColour struct {
+	Attribute string
+	Role      colour.Role
+}
+
+// materialColours are the MaterialComponents colour attributes set by Theme.Tinct.
+var materialColours = []materialColour{
+	{"colorPrimary", colour.RoleAccent1},
+	{"colorPrimaryVariant", colour.RoleAccent1Muted},
+	{"colorOnPrimary", colour.RoleOnAccent1},
+	{"colorSecondary", colour.RoleAccent2},
+	{"colorSecondaryVariant", colour.RoleAccent2Muted},
+	{"colorOnSecondary", colour.RoleOnAccent2},
+	{"android:colorBackground", colour.RoleBackground},
+	{"colorOnBackground", colour.RoleForeground},
+	{"colorSurface", colour.RoleSurface},
+	{"colorOnSurface", colour.RoleOnSurface},
+	{"colorError", colour.RoleDanger},
+	{"colorOnError", colour.RoleOnDanger},
+}
+
+// Plugin implements the output.Plugin interface for Android.
+type Plugin struct {
+	outputDir string
+	night     bool
+	verbose   bool
+}
+
+// New creates a new Android output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		night:     false,
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "android"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Android colour resources (res/values/colors.xml and a MaterialComponents theme, values-night via --android.night)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "android.output-dir", "", "Output directory, e.g. app/src/main (default: ~/.config/tinct/android)")
+	cmd.Flags().BoolVar(&p.night, "android.night", false, "Also write res/values-night/colors.xml, regenerating the palette for the opposite theme")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "android.output-dir", Type: "string", Default: "", Description: "Output directory, e.g. app/src/main (default: ~/.config/tinct/android)", Required: false},
+		{Name: "android.night", Type: "bool", Default: "false", Description: "Also write res/values-night/colors.xml, regenerating the palette for the opposite theme", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/tinct/android"
+	}
+	return filepath.Join(home, ".config", "tinct", "android")
+}
+
+// Generate creates the colour resources and the Material theme.
+// With --android.night the light palette is written to values and the dark palette
+// to values-night, regenerating whichever one the input theme is not.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if len(themeData.OrderedRoles()) == 0 {
+		return nil, fmt.Errorf("palette has no role colours")
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = colorsFile
+
+	day, night := themeData, (*colour.ThemeData)(nil)
+	if p.night {
+		palette, err := themeData.ThemeVariant(oppositeTheme(themeData.Palette()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to categorise the opposite theme: %w", err)
+		}
+
+		inverse := colour.NewThemeData(palette, themeData.WallpaperPath, themeData.ThemeName)
+		inverse.OutputDir = themeData.OutputDir
+		inverse.ColorFileName = nightColorsFile
+		inverse.ColorSpace = themeData.ColorSpace
+
+		night = inverse
+		if isLight(inverse.Palette()) {
+			day, night = inverse, themeData
+			day.ColorFileName, night.ColorFileName = colorsFile, nightColorsFile
+		}
+	}
+
+	files := make(map[string][]byte)
+	content, err := p.render("colors.xml.tmpl", day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate colors.xml: %w", err)
+	}
+	files[colorsFile] = content
+
+	if night != nil {
+		content, err := p.render("colors.xml.tmpl", night)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate night colors.xml: %w", err)
+		}
+		files[nightColorsFile] = content
+	}
+
+	content, err = p.render("tinct_theme.xml.tmpl", day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tinct_theme.xml: %w", err)
+	}
+	files[themeFile] = content
+
+	return files, nil
+}
+
+// render executes the named template against themeData.
+func (p *Plugin) render(name string, themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("android", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for %s\n", name)
+	}
+
+	funcs := template.FuncMap{
+		"androidName":     androidName,
+		"argb":            func(cv colour.ColorValue) string { return cv.HexAlphaFirst() },
+		"materialColours": presentMaterialColours,
+	}
+	tmpl, err := template.New(name).Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute %s: %w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// androidName returns the colour resource name for a role, e.g. tinct_background_muted.
+// Resource names may only contain lowercase letters, digits and underscores.
+func androidName(role colour.Role) string {
+	return "tinct_" + strings.NewReplacer("-", "_", ".", "_").Replace(common.KebabCase(string(role)))
+}
+
+// presentMaterialColours returns the Material attributes whose roles are in the palette.
+func presentMaterialColours(themeData *colour.ThemeData) []materialColour {
+	present := make([]materialColour, 0, len(materialColours))
+	for _, mc := range materialColours {
+		if _, ok := themeData.Palette().Get(mc.Role); ok {
+			present = append(present, mc)
+		}
+	}
+	return present
+}
+
+// isLight reports whether a palette is a light theme, using the background
+// luminance when the theme type was not resolved.
+func isLight(palette *colour.CategorisedPalette) bool {
+	if palette.ThemeType == colour.ThemeAuto {
+		bg, ok := palette.Get(colour.RoleBackground)
+		return ok && bg.IsLight
+	}
+	return palette.ThemeType == colour.ThemeLight
+}
+
+// oppositeTheme returns the theme type to regenerate the night (or day) resources with.
+func oppositeTheme(palette *colour.CategorisedPalette) colour.ThemeType {
+	if isLight(palette) {
+		return colour.ThemeDark
+	}
+	return colour.ThemeLight
+}
+
+// PostExecute provides instructions for using the resources.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Android colour resources written to %s\n", filepath.Join(p.DefaultOutputDir(), "res"))
+	fmt.Fprintf(os.Stderr, "   Point --android.output-dir at a source set (e.g. app/src/main) to use them directly,\n")
+	fmt.Fprintf(os.Stderr, "   then reference @color/tinct_<role> or apply @style/Theme.Tinct.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
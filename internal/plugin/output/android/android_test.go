@@ -0,0 +1,200 @@
+package android
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// resources is the parsed content of an Android values resource file.
+type resources struct {
+	Colors []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"color"`
+	Style struct {
+		Name  string `xml:"name,attr"`
+		Items []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"item"`
+	} `xml:"style"`
+}
+
+// parseResources parses a generated resource file, failing the test if it is not valid XML.
+func parseResources(t *testing.T, content []byte) resources {
+	t.Helper()
+	var res resources
+	if err := xml.Unmarshal(content, &res); err != nil {
+		t.Fatalf("invalid resource XML: %v\n%s", err, content)
+	}
+	return res
+}
+
+// colours returns the colour resources in a generated colors.xml by name.
+func colours(t *testing.T, content []byte) map[string]string {
+	t.Helper()
+	values := make(map[string]string)
+	for _, c := range parseResources(t, content).Colors {
+		values[c.Name] = c.Value
+	}
+	return values
+}
+
+// TestAndroidPlugin runs all standard plugin tests using shared utilities.
+func TestAndroidPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "android",
+		ExpectedFiles:        []string{"res/values/colors.xml", "res/values/tinct_theme.xml"},
+		ExpectedDirSubstring: ".config/tinct/android",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestAndroidPlugin_ContentValidation tests every role is written as an ARGB colour.
+func TestAndroidPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := files["res/values-night/colors.xml"]; ok {
+		t.Error("values-night should only be written with --android.night")
+	}
+
+	values := colours(t, files["res/values/colors.xml"])
+	if len(values) != len(palette.Colours) {
+		t.Errorf("colors.xml has %d colours, want %d", len(values), len(palette.Colours))
+	}
+	for role := range palette.Colours {
+		if got, want := values[androidName(role)], helper.Get(role).HexAlphaFirst(); got != want {
+			t.Errorf("%s = %q, want %q", androidName(role), got, want)
+		}
+	}
+	if got := values["tinct_background"]; len(got) != 9 || got[:3] != "#ff" {
+		t.Errorf("tinct_background = %q, want opaque #ffrrggbb", got)
+	}
+}
+
+// TestAndroidPlugin_Theme tests the Material attributes reference generated colours.
+func TestAndroidPlugin_Theme(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	style := parseResources(t, files["res/values/tinct_theme.xml"]).Style
+	if style.Name != "Theme.Tinct" {
+		t.Errorf("style name = %q, want Theme.Tinct", style.Name)
+	}
+
+	values := colours(t, files["res/values/colors.xml"])
+	items := make(map[string]string)
+	for _, item := range style.Items {
+		items[item.Name] = item.Value
+		if name := item.Value[len("@color/"):]; values[name] == "" {
+			t.Errorf("%s references missing colour %s", item.Name, item.Value)
+		}
+	}
+
+	want := map[string]string{
+		"colorPrimary":            "@color/tinct_accent1",
+		"colorSurface":            "@color/tinct_surface",
+		"colorOnSurface":          "@color/tinct_on_surface",
+		"colorError":              "@color/tinct_danger",
+		"android:colorBackground": "@color/tinct_background",
+	}
+	for attr, value := range want {
+		if items[attr] != value {
+			t.Errorf("%s = %q, want %q", attr, items[attr], value)
+		}
+	}
+}
+
+// TestAndroidPlugin_Night tests --android.night writes light values and dark values-night.
+func TestAndroidPlugin_Night(t *testing.T) {
+	for _, themeType := range []colour.ThemeType{colour.ThemeDark, colour.ThemeLight} {
+		t.Run(themeType.String(), func(t *testing.T) {
+			themeData := plugintesting.CreateTestThemeData(themeType)
+			helper := colour.NewPaletteHelper(themeData.Palette())
+
+			plugin := New()
+			plugin.night = true
+			files, err := plugin.Generate(themeData)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			day := colours(t, files["res/values/colors.xml"])
+			night := colours(t, files["res/values-night/colors.xml"])
+			if len(night) == 0 {
+				t.Fatal("missing res/values-night/colors.xml")
+			}
+
+			// The input palette is kept as-is for its own theme.
+			original, regenerated := day, night
+			if themeType == colour.ThemeDark {
+				original, regenerated = night, day
+			}
+			if got, want := original["tinct_background"], helper.Get(colour.RoleBackground).HexAlphaFirst(); got != want {
+				t.Errorf("input theme background = %s, want %s", got, want)
+			}
+			if regenerated["tinct_background"] == original["tinct_background"] {
+				t.Error("opposite theme should be regenerated with a different background")
+			}
+
+			// Day resources use a light background, night resources a dark one.
+			if !isLightARGB(day["tinct_background"]) || isLightARGB(night["tinct_background"]) {
+				t.Errorf("values background %s should be light and values-night %s dark",
+					day["tinct_background"], night["tinct_background"])
+			}
+		})
+	}
+}
+
+// TestAndroidPlugin_NightWithoutCategoriser tests --android.night needs the
+// categoriser the generate command provides.
+func TestAndroidPlugin_NightWithoutCategoriser(t *testing.T) {
+	plugin := New()
+	plugin.night = true
+	themeData := colour.NewThemeData(plugintesting.CreateTestPalette(colour.ThemeDark), "", "")
+	if _, err := plugin.Generate(themeData); err == nil {
+		t.Error("Generate() expected an error without a categoriser for the opposite theme")
+	}
+}
+
+// isLightARGB reports whether an #aarrggbb colour is light.
+func isLightARGB(argb string) bool {
+	var c color.RGBA
+	if _, err := fmt.Sscanf(argb, "#%02x%02x%02x%02x", &c.A, &c.R, &c.G, &c.B); err != nil {
+		return false
+	}
+	return colour.Luminance(c) > 0.5
+}
+
+// TestAndroidName tests role names are converted to valid resource names.
+func TestAndroidName(t *testing.T) {
+	tests := map[colour.Role]string{
+		colour.RoleBackground:                             "tinct_background",
+		colour.RoleAccent1Muted:                           "tinct_accent1_muted",
+		colour.RoleSurfaceContainerHighest:                "tinct_surface_container_highest",
+		colour.MonitorRole(1, colour.RolePositionTopLeft): "tinct_monitor1_position_top_left",
+	}
+
+	for role, want := range tests {
+		if got := androidName(role); got != want {
+			t.Errorf("androidName(%q) = %q, want %q", role, got, want)
+		}
+	}
+}
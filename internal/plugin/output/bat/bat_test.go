@@ -0,0 +1,94 @@
+package bat
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// scopeRule matches a tmTheme scope rule and captures its scope and foreground.
+var scopeRule = regexp.MustCompile(`(?s)<key>scope</key>\s*<string>([^<]+)</string>\s*<key>settings</key>\s*<dict>\s*<key>foreground</key>\s*<string>(#[0-9a-f]{6})</string>`)
+
+// TestBatPlugin runs all standard plugin tests using shared utilities.
+func TestBatPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "bat",
+		ExpectedFiles:      []string{"tinct.tmTheme"},
+		ExpectedBinaryName: "bat",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestBatPlugin_ContentValidation tests the theme is valid XML with the expected scope colours.
+func TestBatPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["tinct.tmTheme"])
+
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatalf("theme is not valid XML: %v", err)
+			}
+			break
+		}
+	}
+
+	helper := colour.NewPaletteHelper(palette)
+	for role, key := range map[colour.Role]string{
+		colour.RoleBackground:      "background",
+		colour.RoleForeground:      "foreground",
+		colour.RoleAccent1:         "caret",
+		colour.RoleBackgroundMuted: "selection",
+	} {
+		want := "<key>" + key + "</key>\n\t\t\t\t<string>" + helper.Get(role).Hex() + "</string>"
+		if !strings.Contains(content, want) {
+			t.Errorf("global %s should be %s (%s)", key, helper.Get(role).Hex(), role)
+		}
+	}
+
+	scopes := make(map[string]string)
+	for _, m := range scopeRule.FindAllStringSubmatch(content, -1) {
+		scopes[strings.SplitN(m[1], ",", 2)[0]] = m[2]
+	}
+	expected := map[string]colour.Role{
+		"comment":              colour.RoleForegroundMuted,
+		"string":               colour.RoleSuccess,
+		"keyword":              colour.RoleAccent2,
+		"entity.name.function": colour.RoleAccent1,
+		"constant":             colour.RoleWarning,
+	}
+	for scope, role := range expected {
+		if got, want := scopes[scope], helper.Get(role).Hex(); got != want {
+			t.Errorf("scope %s foreground = %q, want %s (%s)", scope, got, want, role)
+		}
+	}
+}
+
+// TestBatPlugin_PostExecuteDryRun tests the cache is not rebuilt in dry-run mode.
+func TestBatPlugin_PostExecuteDryRun(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	plugin := New()
+
+	err := plugin.PostExecute(context.Background(), output.ExecutionContext{DryRun: true}, []string{"tinct.tmTheme"})
+	if err != nil {
+		t.Errorf("PostExecute() error = %v", err)
+	}
+}
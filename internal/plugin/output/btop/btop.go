@@ -0,0 +1,234 @@
+// Package btop provides an output plugin for btop resource monitor themes.
+package btop
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// themeFileName is the name of the generated theme file.
+const themeFileName = "tinct.theme"
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1, colour.RoleAccent2, colour.RoleAccent3, colour.RoleAccent4,
+	colour.RoleDanger, colour.RoleWarning, colour.RoleSuccess,
+}
+
+// Plugin implements the output.Plugin interface for btop.
+type Plugin struct {
+	outputDir    string
+	gradientFrom string
+	gradientTo   string
+	verbose      bool
+}
+
+// New creates a new btop output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir:    "",
+		gradientFrom: string(colour.RoleAccent1),
+		gradientTo:   string(colour.RoleAccent2),
+		verbose:      false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "btop"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "btop resource monitor theme"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "btop.output-dir", "", "Output directory (default: ~/.config/btop/themes)")
+	cmd.Flags().StringVar(&p.gradientFrom, "btop.gradient-from", string(colour.RoleAccent1), "Role used at the start of CPU, memory and network graph gradients")
+	cmd.Flags().StringVar(&p.gradientTo, "btop.gradient-to", string(colour.RoleAccent2), "Role used at the end of CPU, memory and network graph gradients")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "btop.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/btop/themes)", Required: false},
+		{Name: "btop.gradient-from", Type: "string", Default: string(colour.RoleAccent1), Description: "Role used at the start of CPU, memory and network graph gradients", Required: false},
+		{Name: "btop.gradient-to", Type: "string", Default: string(colour.RoleAccent2), Description: "Role used at the end of CPU, memory and network graph gradients", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+// Gradient roles are checked against the palette in Generate.
+func (p *Plugin) Validate() error {
+	if p.gradientFrom == "" || p.gradientTo == "" {
+		return fmt.Errorf("gradient roles cannot be empty")
+	}
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/btop/themes"
+	}
+	return filepath.Join(home, ".config", "btop", "themes")
+}
+
+// Generate creates the theme file.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = themeFileName
+
+	content, err := p.generateTheme(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate theme: %w", err)
+	}
+
+	return map[string][]byte{themeFileName: content}, nil
+}
+
+// generateTheme creates the theme file content.
+func (p *Plugin) generateTheme(themeData *colour.ThemeData) ([]byte, error) {
+	from, ok := themeData.GetSafe(colour.Role(p.gradientFrom))
+	if !ok {
+		return nil, fmt.Errorf("gradient-from role %q not found in palette", p.gradientFrom)
+	}
+	to, ok := themeData.GetSafe(colour.Role(p.gradientTo))
+	if !ok {
+		return nil, fmt.Errorf("gradient-to role %q not found in palette", p.gradientTo)
+	}
+
+	// Load template with custom override support.
+	loader := tmplloader.New("btop", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct.theme.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.theme.tmpl\n")
+	}
+
+	// The gradient funcs expose --btop.gradient-from/--btop.gradient-to to the template.
+	funcs := template.FuncMap{
+		"gradientFrom":  func() string { return p.gradientFrom },
+		"gradientTo":    func() string { return p.gradientTo },
+		"gradientStart": func() colour.ColorValue { return from },
+		"gradientMid":   func() colour.ColorValue { return from.Mix(to, 0.5) },
+		"gradientEnd":   func() colour.ColorValue { return to },
+	}
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse theme template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute theme template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreExecute checks if btop is available before generating the theme.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if btop executable exists on PATH.
+	_, err = exec.LookPath("btop")
+	if err != nil {
+		return true, "btop executable not found on $PATH", nil
+	}
+
+	// Check if themes directory exists, create if it doesn't.
+	themesDir := p.DefaultOutputDir()
+	if _, err := os.Stat(themesDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(themesDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("btop themes directory not found and could not be created: %s", themesDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created btop themes directory: %s\n", themesDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides usage instructions for selecting the theme.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   btop theme generated successfully!\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Select \"tinct\" in btop's options menu, or set in btop.conf:\n")
+	fmt.Fprintf(os.Stderr, "   color_theme = \"%s\"\n", filepath.Join(p.DefaultOutputDir(), themeFileName))
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package btop
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// themeLine matches a btop theme entry.
+var themeLine = regexp.MustCompile(`^theme\[([a-z_]+)\]="([^"]*)"$`)
+
+// parseTheme returns the theme[key]="value" entries of a btop theme.
+func parseTheme(content string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		if m := themeLine.FindStringSubmatch(line); m != nil {
+			values[m[1]] = m[2]
+		}
+	}
+	return values
+}
+
+// TestBtopPlugin runs all standard plugin tests using shared utilities.
+func TestBtopPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "btop",
+		ExpectedFiles:      []string{"tinct.theme"},
+		ExpectedBinaryName: "btop",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestBtopPlugin_ContentValidation tests btop-specific content requirements.
+func TestBtopPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	values := parseTheme(string(files["tinct.theme"]))
+	helper := colour.NewPaletteHelper(palette)
+
+	expected := map[string]colour.Role{
+		"main_bg":   colour.RoleBackground,
+		"main_fg":   colour.RoleForeground,
+		"hi_fg":     colour.RoleAccent1,
+		"cpu_box":   colour.RoleAccent1,
+		"mem_box":   colour.RoleAccent2,
+		"net_box":   colour.RoleAccent3,
+		"proc_box":  colour.RoleAccent4,
+		"cpu_start": colour.RoleAccent1,
+		"cpu_end":   colour.RoleAccent2,
+		"temp_end":  colour.RoleDanger,
+	}
+	for key, role := range expected {
+		if got, want := values[key], helper.Get(role).Hex(); got != want {
+			t.Errorf("theme[%s] = %q, want %s (%s)", key, got, want, role)
+		}
+	}
+
+	for _, key := range []string{"selected_bg", "selected_fg", "cpu_mid", "used_mid", "download_mid"} {
+		if _, ok := values[key]; !ok {
+			t.Errorf("missing theme[%s]", key)
+		}
+	}
+
+	hexColour := regexp.MustCompile(`^#[0-9a-f]{6}$`)
+	for key, value := range values {
+		if !hexColour.MatchString(value) {
+			t.Errorf("theme[%s] = %q is not in #rrggbb format", key, value)
+		}
+	}
+}
+
+// TestBtopPlugin_Gradient tests that graph gradients interpolate between the selected roles.
+func TestBtopPlugin_Gradient(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+	plugin := New()
+	plugin.gradientFrom = "danger"
+	plugin.gradientTo = "success"
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	values := parseTheme(string(files["tinct.theme"]))
+
+	from := helper.Get(colour.RoleDanger)
+	to := helper.Get(colour.RoleSuccess)
+	mid := from.Mix(to, 0.5).Hex()
+
+	for _, prefix := range []string{"cpu", "used", "download", "upload"} {
+		if values[prefix+"_start"] != from.Hex() {
+			t.Errorf("theme[%s_start] = %s, want %s", prefix, values[prefix+"_start"], from.Hex())
+		}
+		if values[prefix+"_mid"] != mid {
+			t.Errorf("theme[%s_mid] = %s, want %s", prefix, values[prefix+"_mid"], mid)
+		}
+		if values[prefix+"_end"] != to.Hex() {
+			t.Errorf("theme[%s_end] = %s, want %s", prefix, values[prefix+"_end"], to.Hex())
+		}
+	}
+}
+
+// TestBtopPlugin_UnknownGradientRole tests that an unknown gradient role is rejected.
+func TestBtopPlugin_UnknownGradientRole(t *testing.T) {
+	plugin := New()
+	plugin.gradientTo = "notARole"
+
+	_, err := plugin.Generate(colour.NewThemeData(plugintesting.CreateTestPalette(colour.ThemeDark), "", ""))
+	if err == nil || !strings.Contains(err.Error(), "notARole") {
+		t.Errorf("Generate() error = %v, want unknown role error", err)
+	}
+}
@@ -0,0 +1,299 @@
+// Package cava provides an output plugin for cava audio visualiser gradient colours.
+package cava
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+const (
+	// configFileName is cava's main config, updated in place.
+	configFileName = "config"
+
+	// maxGradientCount is the largest gradient_count cava accepts.
+	maxGradientCount = 8
+)
+
+var (
+	// sectionHeaderPattern matches any INI section header.
+	sectionHeaderPattern = regexp.MustCompile(`^\s*\[`)
+
+	// colorSectionPattern matches the header of the section written by this plugin.
+	colorSectionPattern = regexp.MustCompile(`^\s*\[\s*color\s*\]`)
+)
+
+// gradientStop is a numbered gradient colour exposed to the template.
+type gradientStop struct {
+	N      int
+	Colour colour.ColorValue
+}
+
+// Plugin implements the output.Plugin interface for cava.
+type Plugin struct {
+	outputDir    string
+	count        int
+	gradientFrom string
+	gradientTo   string
+	verbose      bool
+}
+
+// New creates a new cava output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir:    "",
+		count:        maxGradientCount,
+		gradientFrom: string(colour.RoleAccent1),
+		gradientTo:   string(colour.RoleAccent2),
+		verbose:      false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "cava"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "cava audio visualiser gradient colours"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "cava.output-dir", "", "Output directory (default: ~/.config/cava)")
+	cmd.Flags().IntVar(&p.count, "cava.count", maxGradientCount, "Number of gradient stops (2-8)")
+	cmd.Flags().StringVar(&p.gradientFrom, "cava.gradient-from", string(colour.RoleAccent1), "Role used at the bottom of the gradient")
+	cmd.Flags().StringVar(&p.gradientTo, "cava.gradient-to", string(colour.RoleAccent2), "Role used at the top of the gradient")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "cava.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/cava)", Required: false},
+		{Name: "cava.count", Type: "int", Default: "8", Description: "Number of gradient stops (2-8)", Required: false},
+		{Name: "cava.gradient-from", Type: "string", Default: string(colour.RoleAccent1), Description: "Role used at the bottom of the gradient", Required: false},
+		{Name: "cava.gradient-to", Type: "string", Default: string(colour.RoleAccent2), Description: "Role used at the top of the gradient", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+// Gradient roles are checked against the palette in Generate.
+func (p *Plugin) Validate() error {
+	if p.count < 2 || p.count > maxGradientCount {
+		return fmt.Errorf("gradient count must be between 2 and %d, got %d", maxGradientCount, p.count)
+	}
+	if p.gradientFrom == "" || p.gradientTo == "" {
+		return fmt.Errorf("gradient roles cannot be empty")
+	}
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/cava"
+	}
+	return filepath.Join(home, ".config", "cava")
+}
+
+// Generate creates the cava config.
+// If a config already exists only its [color] section is replaced, so the rest of the
+// user's settings are kept and repeated runs produce the same file.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = configFileName
+
+	section, err := p.generateColorSection(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate colour section: %w", err)
+	}
+
+	configPath := filepath.Join(p.DefaultOutputDir(), configFileName)
+	existing, err := os.ReadFile(configPath) // #nosec G304 - Reading the user's own cava config
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	return map[string][]byte{configFileName: []byte(replaceColorSection(string(existing), string(section)))}, nil
+}
+
+// generateColorSection renders the [color] section.
+func (p *Plugin) generateColorSection(themeData *colour.ThemeData) ([]byte, error) {
+	from, ok := themeData.GetSafe(colour.Role(p.gradientFrom))
+	if !ok {
+		return nil, fmt.Errorf("gradient-from role %q not found in palette", p.gradientFrom)
+	}
+	to, ok := themeData.GetSafe(colour.Role(p.gradientTo))
+	if !ok {
+		return nil, fmt.Errorf("gradient-to role %q not found in palette", p.gradientTo)
+	}
+
+	stops := make([]gradientStop, 0, p.count)
+	for i, c := range colour.Gradient(from, to, p.count) {
+		stops = append(stops, gradientStop{N: i + 1, Colour: c})
+	}
+
+	// Load template with custom override support.
+	loader := tmplloader.New("cava", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("color.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read colour template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for color.tmpl\n")
+	}
+
+	// The gradient funcs expose the cava.count and gradient role flags to the template.
+	funcs := template.FuncMap{
+		"gradientFrom":  func() string { return p.gradientFrom },
+		"gradientTo":    func() string { return p.gradientTo },
+		"gradientStops": func() []gradientStop { return stops },
+	}
+	tmpl, err := template.New("color").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse colour template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute colour template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// replaceColorSection returns config with its [color] section replaced by section.
+// The section keeps its position; if config has none it is appended.
+func replaceColorSection(config, section string) string {
+	section = strings.TrimRight(section, "\n") + "\n"
+	if strings.TrimSpace(config) == "" {
+		return section
+	}
+
+	var b strings.Builder
+	replaced := false
+	skipping := false
+	for line := range strings.Lines(config) {
+		if sectionHeaderPattern.MatchString(line) {
+			skipping = colorSectionPattern.MatchString(line)
+			if skipping {
+				if !replaced {
+					b.WriteString(section + "\n")
+					replaced = true
+				}
+				continue
+			}
+		}
+		if !skipping {
+			b.WriteString(line)
+		}
+	}
+
+	merged := strings.TrimRight(b.String(), "\n") + "\n"
+	if !replaced {
+		merged += "\n" + section
+	}
+	return merged
+}
+
+// PreExecute checks if cava is available before generating the config.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if cava executable exists on PATH.
+	_, err = exec.LookPath("cava")
+	if err != nil {
+		return true, "cava executable not found on $PATH", nil
+	}
+
+	// Check if config directory exists, create if it doesn't.
+	configDir := p.DefaultOutputDir()
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("cava config directory not found and could not be created: %s", configDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created cava config directory: %s\n", configDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides instructions for reloading cava.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   cava [color] section updated in %s\n", filepath.Join(p.DefaultOutputDir(), configFileName))
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Reload colours in a running cava by pressing 'c', or with:\n")
+	fmt.Fprintf(os.Stderr, "   pkill -USR2 cava\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package cava
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestCavaPlugin runs all standard plugin tests using shared utilities.
+func TestCavaPlugin(t *testing.T) {
+	plugin := New()
+	plugin.outputDir = filepath.Join(t.TempDir(), "cava")
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "cava",
+		ExpectedFiles:      []string{"config"},
+		ExpectedBinaryName: "cava",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestCavaPlugin_Gradient tests the gradient keys and their end points.
+func TestCavaPlugin_Gradient(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	for _, count := range []int{2, 5, 8} {
+		plugin := New()
+		plugin.outputDir = t.TempDir()
+		plugin.count = count
+
+		files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		content := string(files["config"])
+
+		if !strings.HasPrefix(content, "[color]\n") {
+			t.Errorf("count %d: config should start with [color]:\n%s", count, content)
+		}
+		for _, want := range []string{"gradient = 1\n", fmt.Sprintf("gradient_count = %d\n", count)} {
+			if !strings.Contains(content, want) {
+				t.Errorf("count %d: missing %q", count, want)
+			}
+		}
+
+		stops := regexp.MustCompile(`(?m)^gradient_color_(\d+) = '(#[0-9a-f]{6})'$`).FindAllStringSubmatch(content, -1)
+		if len(stops) != count {
+			t.Fatalf("count %d: got %d gradient colours", count, len(stops))
+		}
+		if got, want := stops[0][2], helper.Get(colour.RoleAccent1).Hex(); got != want {
+			t.Errorf("count %d: gradient_color_1 = %s, want accent1 %s", count, got, want)
+		}
+		if got, want := stops[count-1][2], helper.Get(colour.RoleAccent2).Hex(); got != want {
+			t.Errorf("count %d: last gradient colour = %s, want accent2 %s", count, got, want)
+		}
+	}
+}
+
+// TestCavaPlugin_ReplacesOnlyColorSection tests that other settings survive and reruns are stable.
+func TestCavaPlugin_ReplacesOnlyColorSection(t *testing.T) {
+	dir := t.TempDir()
+	existing := `[general]
+framerate = 60
+bars = 0
+
+[color]
+gradient = 1
+gradient_count = 2
+gradient_color_1 = '#000000'
+gradient_color_2 = '#111111'
+
+[smoothing]
+noise_reduction = 77
+`
+	configPath := filepath.Join(dir, "config")
+	if err := os.WriteFile(configPath, []byte(existing), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	plugin := New()
+	plugin.outputDir = dir
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["config"])
+
+	for _, want := range []string{"[general]\nframerate = 60\nbars = 0\n\n[color]\n", "[smoothing]\nnoise_reduction = 77\n"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("merged config lost %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "#111111") {
+		t.Errorf("merged config kept the old gradient:\n%s", content)
+	}
+	if n := strings.Count(content, "[color]"); n != 1 {
+		t.Errorf("merged config has %d [color] sections, want 1", n)
+	}
+	if strings.Index(content, "[color]") > strings.Index(content, "[smoothing]") {
+		t.Error("[color] section should keep its position")
+	}
+
+	// Running again over the merged config changes nothing.
+	if err := os.WriteFile(configPath, files["config"], 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	again, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if string(again["config"]) != content {
+		t.Errorf("second run changed the config:\n%s", again["config"])
+	}
+}
+
+// TestCavaPlugin_AppendsColorSection tests that a config without [color] gets one appended.
+func TestCavaPlugin_AppendsColorSection(t *testing.T) {
+	got := replaceColorSection("[general]\nbars = 0\n", "[color]\ngradient = 1\n")
+	want := "[general]\nbars = 0\n\n[color]\ngradient = 1\n"
+	if got != want {
+		t.Errorf("replaceColorSection() = %q, want %q", got, want)
+	}
+}
+
+// TestCavaPlugin_Validate tests gradient count validation.
+func TestCavaPlugin_Validate(t *testing.T) {
+	tests := []struct {
+		count   int
+		wantErr bool
+	}{
+		{1, true},
+		{2, false},
+		{8, false},
+		{9, true},
+	}
+	for _, tt := range tests {
+		plugin := New()
+		plugin.count = tt.count
+		if err := plugin.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate() with count %d error = %v, wantErr %v", tt.count, err, tt.wantErr)
+		}
+	}
+}
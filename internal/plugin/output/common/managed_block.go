@@ -0,0 +1,44 @@
+// Package common provides shared utilities for output plugins.
+package common
+
+import "strings"
+
+const (
+	// ManagedBlockBegin and ManagedBlockEnd wrap the lines Tinct manages inside a
+	// config file that also holds the user's own settings. Both are comments in any
+	// format that uses # for comments.
+	ManagedBlockBegin = "# >>> tinct"
+	ManagedBlockEnd   = "# <<< tinct"
+
+	// CSSManagedBlockBegin and CSSManagedBlockEnd are the Tinct markers for
+	// stylesheets, which have no line comments.
+	CSSManagedBlockBegin = "/* >>> tinct */"
+	CSSManagedBlockEnd   = "/* <<< tinct */"
+)
+
+// ReplaceManagedBlock returns config with the lines between the Tinct markers
+// replaced by block. The block keeps its position; if config has none it is appended.
+// Everything outside the markers is left untouched.
+func ReplaceManagedBlock(config, block string) string {
+	return ReplaceManagedBlockWithMarkers(config, block, ManagedBlockBegin, ManagedBlockEnd)
+}
+
+// ReplaceManagedBlockWithMarkers is ReplaceManagedBlock for a format whose
+// comments need different begin and end marker lines.
+func ReplaceManagedBlockWithMarkers(config, block, beginMarker, endMarker string) string {
+	managed := beginMarker + "\n" + strings.TrimRight(block, "\n") + "\n" + endMarker + "\n"
+
+	begin := strings.Index(config, beginMarker+"\n")
+	if begin >= 0 && (begin == 0 || config[begin-1] == '\n') {
+		if end := strings.Index(config[begin:], endMarker); end >= 0 {
+			rest := config[begin+end+len(endMarker):]
+			rest = strings.TrimPrefix(rest, "\n")
+			return config[:begin] + managed + rest
+		}
+	}
+
+	if strings.TrimSpace(config) == "" {
+		return managed
+	}
+	return strings.TrimRight(config, "\n") + "\n\n" + managed
+}
@@ -0,0 +1,45 @@
+package common
+
+import "testing"
+
+// TestReplaceManagedBlock tests inserting and replacing the managed block.
+func TestReplaceManagedBlock(t *testing.T) {
+	block := "set default-bg \"#111111\"\n"
+	managed := ManagedBlockBegin + "\n" + block + ManagedBlockEnd + "\n"
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{"empty", "", managed},
+		{"append", "set font \"mono 10\"\n", "set font \"mono 10\"\n\n" + managed},
+		{"replace", "a\n" + ManagedBlockBegin + "\nold\n" + ManagedBlockEnd + "\nb\n", "a\n" + managed + "b\n"},
+		{"unterminated", ManagedBlockBegin + "\nold\n", ManagedBlockBegin + "\nold\n\n" + managed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ReplaceManagedBlock(tt.config, block)
+			if got != tt.want {
+				t.Errorf("ReplaceManagedBlock() =\n%q\nwant\n%q", got, tt.want)
+			}
+			if again := ReplaceManagedBlock(got, block); tt.name != "unterminated" && again != got {
+				t.Errorf("ReplaceManagedBlock() is not idempotent:\n%q", again)
+			}
+		})
+	}
+}
+
+// TestReplaceManagedBlockWithMarkers tests the block with stylesheet markers.
+func TestReplaceManagedBlockWithMarkers(t *testing.T) {
+	block := "@define-color accent_color #111111;\n"
+	managed := CSSManagedBlockBegin + "\n" + block + CSSManagedBlockEnd + "\n"
+	config := "window { padding: 0; }\n\n" + CSSManagedBlockBegin + "\nold\n" + CSSManagedBlockEnd + "\nlabel { color: red; }\n"
+
+	got := ReplaceManagedBlockWithMarkers(config, block, CSSManagedBlockBegin, CSSManagedBlockEnd)
+	want := "window { padding: 0; }\n\n" + managed + "label { color: red; }\n"
+	if got != want {
+		t.Errorf("ReplaceManagedBlockWithMarkers() =\n%q\nwant\n%q", got, want)
+	}
+}
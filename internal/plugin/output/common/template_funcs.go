@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"github.com/jmylchreest/tinct/internal/colour"
 )
@@ -22,16 +23,21 @@ func TemplateFuncs() template.FuncMap {
 		// ANSI color matching.
 		"ansi":     ansiFunc,
 		"ansiSafe": ansiSafeFunc,
+		"ansi16":   ansi16Func,
 
 		// Format conversion.
 		"hex":         hexFunc,
 		"hexAlpha":    hexAlphaFunc,
 		"hexNoHash":   hexNoHashFunc,
+		"hexUpper":    hexUpperFunc,
+		"hexShort":    hexShortFunc,
 		"rgb":         rgbFunc,
 		"rgba":        rgbaFunc,
 		"rgbDecimal":  rgbDecimalFunc,
 		"rgbaDecimal": rgbaDecimalFunc,
 		"rgbSpaces":   rgbSpacesFunc,
+		"cssColor":    cssColorFunc,
+		"xterm256":    xterm256Func,
 
 		// Alpha manipulation.
 		"withAlpha": withAlphaFunc,
@@ -41,15 +47,17 @@ func TemplateFuncs() template.FuncMap {
 		"index": indexFunc,
 
 		// Palette metadata.
-		"themeType": themeTypeFunc,
-		"allRoles":  allRolesFunc,
-		"allColors": allColorsFunc,
-		"count":     countFunc,
+		"themeType":      themeTypeFunc,
+		"allRoles":       allRolesFunc,
+		"allColors":      allColorsFunc,
+		"count":          countFunc,
+		"extractedCount": extractedCountFunc,
 
 		// String manipulation (custom wrappers for pipe-friendly argument order).
 		"trimPrefix": trimPrefixFunc,
 		"trimSuffix": trimSuffixFunc,
 		"replace":    replaceFunc,
+		"kebabCase":  kebabCaseFunc,
 		"toLower":    strings.ToLower,
 		"toUpper":    strings.ToUpper,
 	}
@@ -120,6 +128,21 @@ func hexNoHashFunc(cv colour.ColorValue) string {
 	return cv.HexNoHash()
 }
 
+// hexUpperFunc returns color in #RRGGBB format with uppercase digits.
+func hexUpperFunc(cv colour.ColorValue) string {
+	return cv.HexWith(colour.HexOptions{Uppercase: true})
+}
+
+// hexShortFunc returns color in #RGB shorthand when possible, otherwise #rrggbb (for CSS).
+func hexShortFunc(cv colour.ColorValue) string {
+	return cv.HexWith(colour.HexOptions{Shorthand: true})
+}
+
+// xterm256Func returns the nearest xterm 256-colour index (16-255), e.g. for Vim's ctermfg.
+func xterm256Func(cv colour.ColorValue) int {
+	return cv.Xterm256()
+}
+
 // rgbFunc returns color in CSS rgb(r,g,b) format.
 func rgbFunc(cv colour.ColorValue) string {
 	return cv.RGB()
@@ -184,6 +207,30 @@ func replaceFunc(old, newStr, s string) string {
 	return strings.ReplaceAll(s, old, newStr)
 }
 
+// kebabCaseFunc converts a role or other camelCase name to kebab-case:
+//
+//	{{ role $colour | kebabCase }}
+func kebabCaseFunc(name any) string {
+	return KebabCase(fmt.Sprint(name))
+}
+
+// KebabCase converts a camelCase name such as a role to kebab-case, e.g.
+// surfaceContainerLow to surface-container-low. Digits stay attached to the
+// preceding word (accent1Muted becomes accent1-muted).
+func KebabCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // themeTypeFunc returns the theme type string ("dark" or "light").
 // Accepts both *ThemeData and *PaletteHelper for backward compatibility.
 func themeTypeFunc(data any) string {
@@ -212,6 +259,13 @@ func countFunc(data any) int {
 	return ph.Count()
 }
 
+// extractedCountFunc returns the number of colours extracted from the source image or input.
+// Accepts both *ThemeData and *PaletteHelper for backward compatibility.
+func extractedCountFunc(data any) int {
+	ph := extractPaletteHelper(data)
+	return ph.ExtractedCount()
+}
+
 // ansiFunc finds the closest color to a given ANSI color name.
 // Panics if color name is not found - use ansiSafe to check first.
 // Supported names: black, red, green, yellow, blue, magenta, cyan, white,.
@@ -237,3 +291,25 @@ func ansiSafeFunc(data any, colorName string) (colour.ColorValue, error) {
 	}
 	return cv, nil
 }
+
+// ansi16Func returns the canonical colour for ANSI slot 0-15, shared by all terminal plugins.
+// Panics if slot is out of range.
+// Accepts both *ThemeData and *PaletteHelper for backward compatibility.
+func ansi16Func(data any, slot int) colour.ColorValue {
+	if slot < 0 || slot > 15 {
+		panic(fmt.Sprintf("ANSI slot %d out of range (0-15)", slot))
+	}
+	ph := extractPaletteHelper(data)
+	return ph.ANSI16()[slot]
+}
+
+// cssColorFunc formats a color as a CSS Color 4 value in the colour space selected
+// with --color-space, e.g. {{ get . "accent1" | cssColor . }}.
+// sRGB is used when data carries no colour space (e.g. a bare *PaletteHelper).
+func cssColorFunc(data any, cv colour.ColorValue) string {
+	space := colour.ColorSpaceSRGB
+	if td, ok := data.(*colour.ThemeData); ok && td.ColorSpace != "" {
+		space = td.ColorSpace
+	}
+	return cv.CSSColor(space)
+}
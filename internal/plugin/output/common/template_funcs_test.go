@@ -4,6 +4,7 @@ package common
 import (
 	"bytes"
 	"image/color"
+	"strconv"
 	"strings"
 	"testing"
 	"text/template"
@@ -187,6 +188,24 @@ func TestTemplateFuncs_HexFormats(t *testing.T) {
 			template: `{{ get . "background" | hexNoHash }}`,
 			check:    func(s string) bool { return !strings.HasPrefix(s, "#") && len(s) == 6 },
 		},
+		{
+			name:     "HexUpper",
+			template: `{{ get . "background" | hexUpper }}`,
+			check:    func(s string) bool { return strings.HasPrefix(s, "#") && len(s) == 7 && s == strings.ToUpper(s) },
+		},
+		{
+			name:     "HexShort",
+			template: `{{ get . "background" | hexShort }}`,
+			check:    func(s string) bool { return strings.HasPrefix(s, "#") && (len(s) == 4 || len(s) == 7) },
+		},
+		{
+			name:     "Xterm256",
+			template: `{{ get . "background" | xterm256 }}`,
+			check: func(s string) bool {
+				n, err := strconv.Atoi(s)
+				return err == nil && n >= 16 && n <= 255
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -289,6 +308,39 @@ func TestTemplateFuncs_WithAlpha(t *testing.T) {
 	}
 }
 
+// TestTemplateFuncs_CSSColor tests that cssColor follows the theme data's colour space.
+func TestTemplateFuncs_CSSColor(t *testing.T) {
+	palette := createTestPalette()
+
+	tmpl, err := template.New("test").Funcs(TemplateFuncs()).Parse(`{{ get . "accent1" | cssColor . }}`)
+	if err != nil {
+		t.Fatalf("Template parse error: %v", err)
+	}
+
+	tests := []struct {
+		space colour.ColorSpace
+		want  string
+	}{
+		{"", "#"},
+		{colour.ColorSpaceSRGB, "#"},
+		{colour.ColorSpaceDisplayP3, "color(display-p3 "},
+		{colour.ColorSpaceLinear, "color(srgb-linear "},
+	}
+
+	for _, tt := range tests {
+		themeData := colour.NewThemeData(palette, "", "")
+		themeData.ColorSpace = tt.space
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, themeData); err != nil {
+			t.Fatalf("Template execute error: %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), tt.want) {
+			t.Errorf("cssColor with space %q = %q, want prefix %q", tt.space, buf.String(), tt.want)
+		}
+	}
+}
+
 // TestTemplateFuncs_Metadata tests color metadata functions.
 func TestTemplateFuncs_Metadata(t *testing.T) {
 	palette := createTestPalette()
@@ -348,6 +400,11 @@ func TestTemplateFuncs_PaletteMetadata(t *testing.T) {
 			template: `{{ count . }}`,
 			check:    func(s string) bool { return s != "0" },
 		},
+		{
+			name:     "ExtractedCount",
+			template: `{{ extractedCount . }}`,
+			check:    func(s string) bool { return s == "8" },
+		},
 	}
 
 	for _, tt := range tests {
@@ -400,6 +457,11 @@ func TestTemplateFuncs_StringManipulation(t *testing.T) {
 			template: `{{ "a_b_c" | replace "_" "-" }}`,
 			checkLen: 5,
 		},
+		{
+			name:     "KebabCase",
+			template: `{{ get . "backgroundMuted" | role | kebabCase }}`,
+			checkLen: 16, // background-muted
+		},
 	}
 
 	for _, tt := range tests {
@@ -505,3 +567,18 @@ func TestTemplateFuncs_AllRolesIteration(t *testing.T) {
 		t.Error("Expected non-empty result from role iteration")
 	}
 }
+
+func TestKebabCase(t *testing.T) {
+	tests := map[string]string{
+		"background":             "background",
+		"accent1Muted":           "accent1-muted",
+		"surfaceContainerLowest": "surface-container-lowest",
+		"onAccent1":              "on-accent1",
+		"":                       "",
+	}
+	for in, want := range tests {
+		if got := KebabCase(in); got != want {
+			t.Errorf("KebabCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
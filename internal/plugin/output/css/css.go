@@ -0,0 +1,236 @@
+// Package css provides an output plugin for CSS custom properties.
+package css
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// stylesheetFileName is the generated stylesheet.
+const stylesheetFileName = "tinct.css"
+
+// Default flag values.
+const (
+	defaultPrefix   = "tinct"
+	defaultSelector = ":root"
+)
+
+// validPrefix matches prefixes that keep the custom property a valid CSS identifier.
+var validPrefix = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// Plugin implements the output.Plugin interface for CSS custom properties.
+type Plugin struct {
+	outputDir    string
+	prefix       string
+	selector     string
+	mediaQuery   bool
+	hexShorthand bool
+	verbose      bool
+}
+
+// New creates a new CSS output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir:    "",
+		prefix:       defaultPrefix,
+		selector:     defaultSelector,
+		mediaQuery:   false,
+		hexShorthand: false,
+		verbose:      false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "css"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "CSS custom properties for every role (--tinct-<role> colours and -rgb triplets)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "css.output-dir", "", "Output directory (default: ~/.config/tinct)")
+	cmd.Flags().StringVar(&p.prefix, "css.prefix", defaultPrefix, "Custom property prefix (--<prefix>-<role>, empty for --<role>)")
+	cmd.Flags().StringVar(&p.selector, "css.selector", defaultSelector, "Selector for the declaration block")
+	cmd.Flags().BoolVar(&p.mediaQuery, "css.media-query", false, "Wrap the block in @media (prefers-color-scheme: <theme type>)")
+	cmd.Flags().BoolVar(&p.hexShorthand, "css.hex-shorthand", false, "Write opaque sRGB colours as 3-digit #rgb when possible")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "css.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/tinct)", Required: false},
+		{Name: "css.prefix", Type: "string", Default: defaultPrefix, Description: "Custom property prefix (--<prefix>-<role>, empty for --<role>)", Required: false},
+		{Name: "css.selector", Type: "string", Default: defaultSelector, Description: "Selector for the declaration block", Required: false},
+		{Name: "css.media-query", Type: "bool", Default: "false", Description: "Wrap the block in @media (prefers-color-scheme: <theme type>)", Required: false},
+		{Name: "css.hex-shorthand", Type: "bool", Default: "false", Description: "Write opaque sRGB colours as 3-digit #rgb when possible", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	if !validPrefix.MatchString(p.prefix) {
+		return fmt.Errorf("invalid CSS prefix %q: use letters, digits, '-' and '_' only", p.prefix)
+	}
+	if strings.TrimSpace(p.selector) == "" || strings.ContainsAny(p.selector, "{};") {
+		return fmt.Errorf("invalid CSS selector %q", p.selector)
+	}
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/tinct"
+	}
+	return filepath.Join(home, ".config", "tinct")
+}
+
+// Generate creates the stylesheet.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if p.mediaQuery && themeData.ThemeType() != colour.ThemeDark && themeData.ThemeType() != colour.ThemeLight {
+		return nil, fmt.Errorf("--css.media-query needs a dark or light theme, got %s", themeData.ThemeTypeString())
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = stylesheetFileName
+
+	content, err := p.generateStylesheet(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate stylesheet: %w", err)
+	}
+
+	return map[string][]byte{stylesheetFileName: content}, nil
+}
+
+// generateStylesheet renders the stylesheet.
+func (p *Plugin) generateStylesheet(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("css", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct.css.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stylesheet template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.css.tmpl\n")
+	}
+
+	funcs := template.FuncMap{
+		"cssVar":     p.cssVar,
+		"selector":   func() string { return p.selector },
+		"mediaQuery": func() bool { return p.mediaQuery },
+		"cssColor":   p.cssColor,
+	}
+	tmpl, err := template.New("stylesheet").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stylesheet template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute stylesheet template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// cssColor formats cv as common's cssColor does, but collapses opaque sRGB hex
+// to #rgb shorthand when --css.hex-shorthand is set.
+func (p *Plugin) cssColor(themeData *colour.ThemeData, cv colour.ColorValue) string {
+	space := themeData.ColorSpace
+	if space == "" {
+		space = colour.ColorSpaceSRGB
+	}
+	if p.hexShorthand && space == colour.ColorSpaceSRGB && cv.A() == 255 {
+		return cv.HexWith(colour.HexOptions{Shorthand: true})
+	}
+	return cv.CSSColor(space)
+}
+
+// cssVar returns the custom property name for role, e.g. --tinct-surface-container-low
+// for surfaceContainerLow.
+func (p *Plugin) cssVar(role colour.Role) string {
+	name := common.KebabCase(string(role))
+	if p.prefix == "" {
+		return "--" + name
+	}
+	return "--" + p.prefix + "-" + name
+}
+
+// PostExecute provides instructions for using the stylesheet.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   CSS custom properties written to %s\n", filepath.Join(p.DefaultOutputDir(), stylesheetFileName))
+	fmt.Fprintf(os.Stderr, "   Link or @import it, then use e.g. var(%s).\n", p.cssVar(colour.RoleAccent1))
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package css
+
+import (
+	"fmt"
+	"image/color"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestCSSPlugin runs all standard plugin tests using shared utilities.
+func TestCSSPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "css",
+		ExpectedFiles:        []string{"tinct.css"},
+		ExpectedDirSubstring: ".config/tinct",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// declaration matches a custom property declaration and captures its name and value.
+var declaration = regexp.MustCompile(`^\s*(--[\w-]+): (.+);$`)
+
+// declarations returns the custom properties declared in css.
+func declarations(css string) map[string]string {
+	props := make(map[string]string)
+	for line := range strings.Lines(css) {
+		if m := declaration.FindStringSubmatch(strings.TrimRight(line, "\n")); m != nil {
+			props[m[1]] = m[2]
+		}
+	}
+	return props
+}
+
+// TestCSSPlugin_ContentValidation tests that every role is declared as a colour and an RGB triplet.
+func TestCSSPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	css := string(files["tinct.css"])
+
+	if !strings.Contains(css, " */\n\n:root {\n") {
+		t.Error("declarations should be in a :root block")
+	}
+	if strings.Contains(css, "@media") {
+		t.Error("@media should only be written with --css.media-query")
+	}
+
+	props := declarations(css)
+	if len(props) != 2*len(palette.Colours) {
+		t.Errorf("got %d declarations, want %d", len(props), 2*len(palette.Colours))
+	}
+	for role, cc := range palette.Colours {
+		name := New().cssVar(role)
+		// Translucent roles (scrim, shadow) keep their alpha as #rrggbbaa.
+		if want := helper.Get(role).CSSColor(colour.ColorSpaceSRGB); props[name] != want {
+			t.Errorf("%s = %q, want %s", name, props[name], want)
+		}
+		if want := fmt.Sprintf("%d, %d, %d", cc.RGB.R, cc.RGB.G, cc.RGB.B); props[name+"-rgb"] != want {
+			t.Errorf("%s-rgb = %q, want %q", name, props[name+"-rgb"], want)
+		}
+	}
+	if _, ok := props["--tinct-surface-container-low"]; !ok {
+		t.Error("camelCase roles should be kebab-cased")
+	}
+}
+
+// TestCSSPlugin_Options tests --css.prefix, --css.selector and --css.media-query.
+func TestCSSPlugin_Options(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeLight)
+
+	plugin := New()
+	plugin.prefix = "app"
+	plugin.selector = "[data-theme=\"tinct\"]"
+	plugin.mediaQuery = true
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	css := string(files["tinct.css"])
+
+	if !strings.Contains(css, "@media (prefers-color-scheme: light) {\n  [data-theme=\"tinct\"] {\n    --app-background: ") {
+		t.Errorf("expected a light media query wrapping the custom selector, got:\n%s", css)
+	}
+	if !strings.HasSuffix(css, "\n  }\n}\n") {
+		t.Errorf("media query block should be closed, got tail %q", css[len(css)-20:])
+	}
+	if strings.Contains(css, "--tinct-") {
+		t.Error("default prefix should be replaced")
+	}
+
+	plugin.prefix = ""
+	files, err = plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := declarations(string(files["tinct.css"]))["--background"]; !ok {
+		t.Error("an empty prefix should declare --<role>")
+	}
+}
+
+// TestCSSPlugin_HexShorthand tests that --css.hex-shorthand collapses sRGB hex where possible.
+func TestCSSPlugin_HexShorthand(t *testing.T) {
+	palette := colour.Categorise(colour.NewPalette([]color.Color{
+		color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		color.RGBA{R: 255, G: 0, B: 86, A: 255},
+	}), colour.DefaultCategorisationConfig())
+
+	plugin := New()
+	plugin.hexShorthand = true
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	decls := declarations(string(files["tinct.css"]))
+
+	for role, cc := range palette.Colours {
+		got := decls[plugin.cssVar(role)]
+		if cc.RGBA.A < 255 {
+			// Translucent roles keep their #rrggbbaa form.
+			if len(got) != len("#rrggbbaa") {
+				t.Errorf("%s = %q, want #rrggbbaa", role, got)
+			}
+			continue
+		}
+		want := cc.RGB.HexWith(colour.HexOptions{Shorthand: true})
+		if got != want {
+			t.Errorf("%s = %q, want %q", role, got, want)
+		}
+	}
+	if got := decls[plugin.cssVar(colour.RoleBackground)]; len(got) != 4 {
+		t.Errorf("background %q should collapse to #rgb", got)
+	}
+
+	// Other colour spaces are unaffected.
+	themeData := colour.NewThemeData(palette, "", "")
+	themeData.ColorSpace = colour.ColorSpaceDisplayP3
+	files, err = plugin.Generate(themeData)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got := declarations(string(files["tinct.css"]))[plugin.cssVar(colour.RoleBackground)]; !strings.HasPrefix(got, "color(display-p3 ") {
+		t.Errorf("display-p3 background = %q, want a color() value", got)
+	}
+}
+
+// TestCSSPlugin_Validate tests that invalid prefixes and selectors are rejected.
+func TestCSSPlugin_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		selector string
+		wantErr  bool
+	}{
+		{name: "defaults", prefix: defaultPrefix, selector: defaultSelector},
+		{name: "class selector", prefix: "my_app-2", selector: ".dark, .theme"},
+		{name: "prefix with space", prefix: "my app", selector: defaultSelector, wantErr: true},
+		{name: "prefix with colon", prefix: "a:b", selector: defaultSelector, wantErr: true},
+		{name: "empty selector", prefix: defaultPrefix, selector: " ", wantErr: true},
+		{name: "selector with brace", prefix: defaultPrefix, selector: ":root { }", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := New()
+			plugin.prefix = tt.prefix
+			plugin.selector = tt.selector
+			if err := plugin.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package discord provides an output plugin for Discord client CSS themes (Vesktop, BetterDiscord).
+package discord
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// themeFileName is the CSS theme loaded by the Discord client mod.
+const themeFileName = "discord-tinct.css"
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1,
+}
+
+// Plugin implements the output.Plugin interface for Discord.
+type Plugin struct {
+	outputDir string
+	verbose   bool
+}
+
+// New creates a new Discord output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "discord"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Discord CSS theme for Vesktop/Vencord and BetterDiscord"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "discord.output-dir", "", "Output directory (default: ~/.config/tinct)")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "discord.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/tinct)", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/tinct"
+	}
+	return filepath.Join(home, ".config", "tinct")
+}
+
+// Generate creates the Discord CSS theme.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = themeFileName
+
+	content, err := p.generateTheme(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate theme: %w", err)
+	}
+
+	return map[string][]byte{themeFileName: content}, nil
+}
+
+// generateTheme renders the CSS variable overrides.
+func (p *Plugin) generateTheme(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("discord", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("discord-tinct.css.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for discord-tinct.css.tmpl\n")
+	}
+
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse theme template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute theme template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PostExecute explains where to import the theme in each client.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if len(writtenFiles) == 0 {
+		return nil
+	}
+
+	// Discord has no standard theme location, so always show where the file goes.
+	themePath := filepath.Join(p.DefaultOutputDir(), themeFileName)
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Discord theme written to %s\n", themePath)
+	fmt.Fprintf(os.Stderr, "   Vesktop/Vencord: ln -sf %s ~/.config/vesktop/themes/ and enable it in Settings > Themes\n", themePath)
+	fmt.Fprintf(os.Stderr, "   BetterDiscord:   ln -sf %s ~/.config/BetterDiscord/themes/ and enable it in Settings > Themes\n", themePath)
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
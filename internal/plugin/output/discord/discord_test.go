@@ -0,0 +1,74 @@
+package discord
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// cssVariable matches a CSS custom property set to a #rrggbb colour.
+var cssVariable = regexp.MustCompile(`^(--[a-z-]+): (#[0-9a-f]{6});$`)
+
+// parseVariables returns the CSS custom properties defined in content.
+func parseVariables(content string) map[string]string {
+	vars := make(map[string]string)
+	for line := range strings.Lines(content) {
+		if m := cssVariable.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			vars[m[1]] = m[2]
+		}
+	}
+	return vars
+}
+
+// TestDiscordPlugin runs all standard plugin tests using shared utilities.
+func TestDiscordPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "discord",
+		ExpectedFiles:        []string{"discord-tinct.css"},
+		ExpectedDirSubstring: ".config/tinct",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestDiscordPlugin_ContentValidation tests the Discord variables and their roles.
+func TestDiscordPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["discord-tinct.css"])
+
+	if !strings.Contains(content, ":root {") {
+		t.Error("theme should override variables in a :root block")
+	}
+	if !strings.Contains(content, "@name Tinct") {
+		t.Error("theme should carry the @name metadata client mods require")
+	}
+
+	vars := parseVariables(content)
+	expected := map[string]colour.Role{
+		"--background-primary": colour.RoleBackground,
+		"--text-normal":        colour.RoleForeground,
+		"--text-muted":         colour.RoleForegroundMuted,
+		"--brand-experiment":   colour.RoleAccent1,
+	}
+	for name, role := range expected {
+		if want := helper.Get(role).Hex(); vars[name] != want {
+			t.Errorf("%s = %q, want %s (%s)", name, vars[name], want, role)
+		}
+	}
+	for _, name := range []string{"--background-secondary", "--background-tertiary", "--channeltextarea-background"} {
+		if vars[name] == "" {
+			t.Errorf("%s should always be defined", name)
+		}
+	}
+}
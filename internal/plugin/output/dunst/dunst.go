@@ -31,15 +31,17 @@ func GetEmbeddedTemplates() embed.FS {
 
 // Plugin implements the output.Plugin interface for Dunst.
 type Plugin struct {
-	outputDir string
-	verbose   bool
+	outputDir          string
+	criticalFromDanger bool
+	verbose            bool
 }
 
 // New creates a new Dunst output plugin with default settings.
 func New() *Plugin {
 	return &Plugin{
-		outputDir: "",
-		verbose:   false,
+		outputDir:          "",
+		criticalFromDanger: true,
+		verbose:            false,
 	}
 }
 
@@ -61,6 +63,7 @@ func (p *Plugin) Version() string {
 // RegisterFlags registers plugin-specific flags with the cobra command.
 func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&p.outputDir, "dunst.output-dir", "", "Output directory (default: ~/.config/dunst/dunstrc.d)")
+	cmd.Flags().BoolVar(&p.criticalFromDanger, "dunst.critical-from-danger", true, "Fill critical notifications with the danger colour (false: danger frame only)")
 }
 
 // SetVerbose enables or disables verbose logging for the plugin.
@@ -79,6 +82,7 @@ func (p *Plugin) GetEmbeddedFS() any {
 func (p *Plugin) GetFlagHelp() []input.FlagHelp {
 	return []input.FlagHelp{
 		{Name: "dunst.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/dunst/dunstrc.d)", Required: false},
+		{Name: "dunst.critical-from-danger", Type: "bool", Default: "true", Description: "Fill critical notifications with the danger colour (false: danger frame only)", Required: false},
 	}
 }
 
@@ -137,7 +141,9 @@ func (p *Plugin) generateTheme(themeData *colour.ThemeData) ([]byte, error) {
 		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.dunstrc.tmpl\n")
 	}
 
-	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	// criticalFromDanger exposes --dunst.critical-from-danger to the template.
+	funcs := template.FuncMap{"criticalFromDanger": func() bool { return p.criticalFromDanger }}
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse theme template: %w", err)
 	}
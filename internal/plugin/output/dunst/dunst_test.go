@@ -179,3 +179,89 @@ func TestDunstPlugin_ColorFormatting(t *testing.T) {
 		t.Error("urgency_normal should have timeout = 10")
 	}
 }
+
+// parseSections returns the key/value settings of each [section] in a dunstrc.
+// Commented-out lines are ignored.
+func parseSections(content string) map[string]map[string]string {
+	sections := make(map[string]map[string]string)
+	var current map[string]string
+	for line := range strings.Lines(content) {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			current = make(map[string]string)
+			sections[strings.Trim(line, "[]")] = current
+		case current != nil:
+			if key, value, ok := strings.Cut(line, "="); ok {
+				current[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+			}
+		}
+	}
+	return sections
+}
+
+// TestDunstPlugin_UrgencyColours tests each urgency level gets its own semantic colours.
+func TestDunstPlugin_UrgencyColours(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	tests := []struct {
+		name               string
+		criticalFromDanger bool
+		wantCriticalBg     string
+	}{
+		{"CriticalFromDanger", true, helper.Get(colour.RoleDanger).Hex()},
+		{"CriticalFrameOnly", false, helper.Get(colour.RoleBackground).Hex()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := New()
+			plugin.criticalFromDanger = tt.criticalFromDanger
+
+			files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			sections := parseSections(string(files["60-tinct.conf"]))
+
+			frames := map[string]colour.Role{
+				"urgency_low":      colour.RoleInfo,
+				"urgency_normal":   colour.RoleWarning,
+				"urgency_critical": colour.RoleDanger,
+			}
+			seenFrames := make(map[string]string)
+			seenBackgrounds := make(map[string]string)
+			for section, role := range frames {
+				settings, ok := sections[section]
+				if !ok {
+					t.Fatalf("missing [%s]", section)
+				}
+				for _, key := range []string{"frame_color", "background", "foreground"} {
+					if settings[key] == "" {
+						t.Errorf("[%s] missing %s", section, key)
+					}
+				}
+				if got, want := settings["frame_color"], helper.Get(role).Hex(); got != want {
+					t.Errorf("[%s] frame_color = %s, want %s (%s)", section, got, want, role)
+				}
+				if other, dup := seenFrames[settings["frame_color"]]; dup {
+					t.Errorf("[%s] and [%s] share frame_color %s", section, other, settings["frame_color"])
+				}
+				seenFrames[settings["frame_color"]] = section
+				seenBackgrounds[settings["background"]] = section
+			}
+
+			if len(seenBackgrounds) < 2 {
+				t.Errorf("urgency backgrounds should not all match, got %v", seenBackgrounds)
+			}
+			if got := sections["urgency_critical"]["background"]; got != tt.wantCriticalBg {
+				t.Errorf("[urgency_critical] background = %s, want %s", got, tt.wantCriticalBg)
+			}
+			if sections["urgency_normal"]["background"] == sections["urgency_low"]["background"] {
+				t.Error("[urgency_normal] background should be tinted towards warning")
+			}
+		})
+	}
+}
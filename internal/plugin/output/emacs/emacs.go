@@ -0,0 +1,202 @@
+// Package emacs provides an output plugin for Emacs custom themes.
+package emacs
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// themeFileName is the file Emacs loads for (load-theme 'tinct).
+const themeFileName = "tinct-theme.el"
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1, colour.RoleAccent2,
+	colour.RoleDanger, colour.RoleWarning, colour.RoleSuccess,
+}
+
+// Plugin implements the output.Plugin interface for Emacs.
+type Plugin struct {
+	outputDir string
+	verbose   bool
+}
+
+// New creates a new Emacs output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "emacs"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Emacs custom theme (deftheme with interface and font-lock faces)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "emacs.output-dir", "", "Output directory (default: ~/.config/emacs/themes)")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "emacs.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/emacs/themes)", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/emacs/themes"
+	}
+	return filepath.Join(home, ".config", "emacs", "themes")
+}
+
+// Generate creates the Emacs theme.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = themeFileName
+
+	content, err := p.generateTheme(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate theme: %w", err)
+	}
+
+	return map[string][]byte{themeFileName: content}, nil
+}
+
+// generateTheme renders the deftheme file.
+func (p *Plugin) generateTheme(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("emacs", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct-theme.el.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct-theme.el.tmpl\n")
+	}
+
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse theme template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute theme template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreExecute checks if Emacs is available before generating the theme.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if emacs executable exists on PATH.
+	if _, err := exec.LookPath("emacs"); err != nil {
+		return true, "emacs executable not found on $PATH", nil
+	}
+
+	// Check if themes directory exists, create if it doesn't.
+	themesDir := p.DefaultOutputDir()
+	if _, err := os.Stat(themesDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(themesDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("emacs themes directory not found and could not be created: %s", themesDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created emacs themes directory: %s\n", themesDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides instructions for loading the theme.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   emacs theme written to %s\n", filepath.Join(p.DefaultOutputDir(), themeFileName))
+	fmt.Fprintf(os.Stderr, "   Add the directory to custom-theme-load-path in init.el, then (load-theme 'tinct t).\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package emacs
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+var (
+	// faceLine matches a custom-theme-set-faces entry and captures the face and its attributes.
+	faceLine = regexp.MustCompile(`^'\(([a-z-]+) \(\(t \((.*)\)\)\)\)\)?$`)
+	// faceColour matches a :foreground or :background attribute.
+	faceColour = regexp.MustCompile(`:(foreground|background) "(#[0-9a-f]{6})"`)
+)
+
+// parseFaces returns the colours set for each face, keyed by face+".fg" and face+".bg".
+func parseFaces(content string) map[string]string {
+	faces := make(map[string]string)
+	for line := range strings.Lines(content) {
+		m := faceLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		for _, attr := range faceColour.FindAllStringSubmatch(m[2], -1) {
+			faces[m[1]+"."+attr[1][:1]+"g"] = attr[2]
+		}
+	}
+	return faces
+}
+
+// TestEmacsPlugin runs all standard plugin tests using shared utilities.
+func TestEmacsPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "emacs",
+		ExpectedFiles:        []string{"tinct-theme.el"},
+		ExpectedBinaryName:   "emacs",
+		ExpectedDirSubstring: ".config/emacs/themes",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestEmacsPlugin_ContentValidation tests the deftheme structure and face colours.
+func TestEmacsPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["tinct-theme.el"])
+
+	if !strings.Contains(content, "(deftheme tinct ") {
+		t.Error("theme should be declared with (deftheme tinct ...)")
+	}
+	if !strings.Contains(content, "(custom-theme-set-faces\n 'tinct") {
+		t.Error("faces should be set with (custom-theme-set-faces 'tinct ...)")
+	}
+	if !strings.Contains(strings.TrimSpace(content), "(provide-theme 'tinct)\n\n;;; tinct-theme.el ends here") {
+		t.Error("theme should end with (provide-theme 'tinct)")
+	}
+	if strings.Count(content, "(") != strings.Count(content, ")") {
+		t.Error("theme has unbalanced parentheses")
+	}
+
+	faces := parseFaces(content)
+	expected := map[string]colour.Role{
+		"default.bg":                      colour.RoleBackground,
+		"default.fg":                      colour.RoleForeground,
+		"cursor.bg":                       colour.RoleAccent1,
+		"region.bg":                       colour.RoleBackgroundMuted,
+		"font-lock-comment-face.fg":       colour.RoleForegroundMuted,
+		"font-lock-keyword-face.fg":       colour.RoleAccent2,
+		"font-lock-function-name-face.fg": colour.RoleAccent1,
+		"mode-line.fg":                    colour.RoleForeground,
+		"mode-line-inactive.fg":           colour.RoleForegroundMuted,
+		"error.fg":                        colour.RoleDanger,
+	}
+	for face, role := range expected {
+		if want := helper.Get(role).Hex(); faces[face] != want {
+			t.Errorf("%s = %q, want %s (%s)", face, faces[face], want, role)
+		}
+	}
+	for _, face := range []string{"font-lock-string-face.fg", "mode-line.bg", "mode-line-inactive.bg"} {
+		if faces[face] == "" {
+			t.Errorf("%s should always be set", face)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Package eww provides an output plugin for eww widget SCSS colour variables.
+package eww
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// scssFileName is the SCSS partial eww.scss imports as "tinct".
+const scssFileName = "_tinct.scss"
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1,
+	colour.RoleDanger, colour.RoleWarning, colour.RoleSuccess,
+}
+
+// namedRoles are the roles that already have a variable of the same name,
+// so --eww.all-roles does not define them twice.
+var namedRoles = []string{"surface", "border", "danger", "warning", "success"}
+
+// Plugin implements the output.Plugin interface for eww.
+type Plugin struct {
+	outputDir string
+	allRoles  bool
+	verbose   bool
+}
+
+// New creates a new eww output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		allRoles:  false,
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "eww"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "eww widget colours (SCSS variables for @import)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "eww.output-dir", "", "Output directory (default: ~/.config/eww)")
+	cmd.Flags().BoolVar(&p.allRoles, "eww.all-roles", false, "Also define a variable for every palette role, named by the role")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "eww.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/eww)", Required: false},
+		{Name: "eww.all-roles", Type: "bool", Default: "false", Description: "Also define a variable for every palette role, named by the role", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/eww"
+	}
+	return filepath.Join(home, ".config", "eww")
+}
+
+// Generate creates the SCSS colour variables.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = scssFileName
+
+	content, err := p.generateSCSS(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate colours: %w", err)
+	}
+
+	return map[string][]byte{scssFileName: content}, nil
+}
+
+// generateSCSS renders the SCSS variables.
+func (p *Plugin) generateSCSS(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("eww", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("_tinct.scss.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scss template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for _tinct.scss.tmpl\n")
+	}
+
+	funcs := template.FuncMap{
+		// allRoleVariables exposes --eww.all-roles to the template.
+		"allRoleVariables": func() bool { return p.allRoles },
+		"extraRoles":       extraRoles,
+		"scss":             scssColour,
+	}
+	tmpl, err := template.New("scss").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scss template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute scss template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// extraRoles returns the names of every role in the palette, in the standard role
+// order followed by any others (such as position roles) sorted by name, leaving out
+// the roles that already have a variable of the same name.
+func extraRoles(themeData *colour.ThemeData) []string {
+	var names []string
+	for _, cc := range themeData.OrderedRoles() {
+		if !slices.Contains(namedRoles, string(cc.Role)) {
+			names = append(names, string(cc.Role))
+		}
+	}
+	return names
+}
+
+// scssColour formats a colour as an SCSS value: #rrggbb when opaque, rgba() otherwise.
+func scssColour(cv colour.ColorValue) string {
+	if cv.A() == 255 {
+		return cv.Hex()
+	}
+	return cv.RGBA()
+}
+
+// PreExecute checks if eww is available before generating the variables.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if eww executable exists on PATH.
+	_, err = exec.LookPath("eww")
+	if err != nil {
+		return true, "eww executable not found on $PATH", nil
+	}
+
+	// Check if config directory exists, create if it doesn't.
+	configDir := p.DefaultOutputDir()
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("eww config directory not found and could not be created: %s", configDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created eww config directory: %s\n", configDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides instructions for importing the variables.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   eww colours written to %s\n", filepath.Join(p.DefaultOutputDir(), scssFileName))
+	fmt.Fprintf(os.Stderr, "   Add this line to the top of your eww.scss if it is not already there:\n")
+	fmt.Fprintf(os.Stderr, "     @import \"tinct\";\n")
+	fmt.Fprintf(os.Stderr, "   eww reloads its styles automatically when the file changes.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package eww
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// parseVariables returns the SCSS variables defined in content, failing on redefinitions.
+func parseVariables(t *testing.T, content string) map[string]string {
+	t.Helper()
+	vars := make(map[string]string)
+	for line := range strings.Lines(content) {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok || !strings.HasPrefix(name, "$") {
+			continue
+		}
+		if _, dup := vars[name]; dup {
+			t.Errorf("%s is defined more than once", name)
+		}
+		vars[name] = strings.TrimSuffix(value, ";")
+	}
+	return vars
+}
+
+// TestEwwPlugin runs all standard plugin tests using shared utilities.
+func TestEwwPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "eww",
+		ExpectedFiles:      []string{"_tinct.scss"},
+		ExpectedBinaryName: "eww",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestEwwPlugin_Variables tests the named variables map to the expected roles.
+func TestEwwPlugin_Variables(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	vars := parseVariables(t, string(files["_tinct.scss"]))
+
+	expected := map[string]colour.Role{
+		"$bg":      colour.RoleBackground,
+		"$fg":      colour.RoleForeground,
+		"$accent":  colour.RoleAccent1,
+		"$danger":  colour.RoleDanger,
+		"$warning": colour.RoleWarning,
+		"$success": colour.RoleSuccess,
+	}
+	for name, role := range expected {
+		if want := helper.Get(role).Hex(); vars[name] != want {
+			t.Errorf("%s = %q, want %s (%s)", name, vars[name], want, role)
+		}
+	}
+	for _, name := range []string{"$surface", "$border"} {
+		if vars[name] == "" {
+			t.Errorf("%s should always be defined", name)
+		}
+	}
+	if len(vars) != 8 {
+		t.Errorf("expected only the 8 named variables without --eww.all-roles, got %d", len(vars))
+	}
+}
+
+// TestEwwPlugin_AllRoles tests every palette role becomes a variable named by the role.
+func TestEwwPlugin_AllRoles(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+	plugin := New()
+	plugin.allRoles = true
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	vars := parseVariables(t, string(files["_tinct.scss"]))
+
+	for role := range palette.Colours {
+		name := "$" + string(role)
+		if _, ok := vars[name]; !ok {
+			t.Errorf("missing variable %s", name)
+		}
+	}
+	if want := helper.Get(colour.RoleForegroundMuted).Hex(); vars["$foregroundMuted"] != want {
+		t.Errorf("$foregroundMuted = %q, want %s", vars["$foregroundMuted"], want)
+	}
+}
+
+// TestScssColour tests translucent colours are written as rgba().
+func TestScssColour(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+	bg := helper.Get(colour.RoleBackground)
+
+	if got := scssColour(bg); got != bg.Hex() {
+		t.Errorf("scssColour(opaque) = %s, want %s", got, bg.Hex())
+	}
+	if got := scssColour(bg.WithAlpha(0.5)); !strings.HasPrefix(got, "rgba(") || strings.Count(got, "(") != 1 {
+		t.Errorf("scssColour(translucent) = %s, want rgba()", got)
+	}
+}
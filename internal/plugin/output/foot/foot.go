@@ -0,0 +1,201 @@
+// Package foot provides an output plugin for the foot Wayland terminal.
+package foot
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// themeFileName is the name of the generated theme file.
+const themeFileName = "tinct.ini"
+
+// Plugin implements the output.Plugin interface for foot.
+type Plugin struct {
+	outputDir string
+	alpha     bool
+	verbose   bool
+}
+
+// New creates a new foot output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		alpha:     false,
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "foot"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Foot terminal colour theme"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "foot.output-dir", "", "Output directory (default: ~/.config/foot/themes)")
+	cmd.Flags().BoolVar(&p.alpha, "foot.alpha", false, "Write the background opacity (alpha) from the background colour's alpha channel")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "foot.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/foot/themes)", Required: false},
+		{Name: "foot.alpha", Type: "bool", Default: "false", Description: "Write the background opacity (alpha) from the background colour's alpha channel", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/foot/themes"
+	}
+	return filepath.Join(home, ".config", "foot", "themes")
+}
+
+// Generate creates the theme file.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = themeFileName
+
+	content, err := p.generateTheme(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate theme: %w", err)
+	}
+
+	return map[string][]byte{themeFileName: content}, nil
+}
+
+// generateTheme creates the theme file content.
+func (p *Plugin) generateTheme(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("foot", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct.ini.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.ini.tmpl\n")
+	}
+
+	// writeAlpha exposes --foot.alpha to the template.
+	funcs := template.FuncMap{"writeAlpha": func() bool { return p.alpha }}
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse theme template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute theme template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreExecute checks if foot is available before generating the theme.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if foot executable exists on PATH.
+	_, err = exec.LookPath("foot")
+	if err != nil {
+		return true, "foot executable not found on $PATH", nil
+	}
+
+	// Check if themes directory exists, create if it doesn't.
+	themesDir := p.DefaultOutputDir()
+	if _, err := os.Stat(themesDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(themesDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("foot themes directory not found and could not be created: %s", themesDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created foot themes directory: %s\n", themesDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides usage instructions for including the theme.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Foot theme generated successfully!\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   To use this theme, add to your foot.ini:\n")
+	fmt.Fprintf(os.Stderr, "   include=%s\n", filepath.Join(p.DefaultOutputDir(), themeFileName))
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Note: New foot windows pick up the theme; running windows keep their colours.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
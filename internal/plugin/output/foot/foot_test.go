@@ -0,0 +1,166 @@
+package foot
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/output/kitty"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestFootPlugin runs all standard plugin tests using shared utilities.
+func TestFootPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "foot",
+		ExpectedFiles:      []string{"tinct.ini"},
+		ExpectedBinaryName: "foot",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestFootPlugin_ContentValidation tests foot-specific content requirements.
+func TestFootPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["tinct.ini"])
+	helper := colour.NewPaletteHelper(palette)
+
+	requiredStrings := []string{
+		"[colors]",
+		"background=" + helper.Get(colour.RoleBackground).HexNoHash() + "\n",
+		"foreground=" + helper.Get(colour.RoleForeground).HexNoHash() + "\n",
+	}
+	for i := range 8 {
+		requiredStrings = append(requiredStrings, fmt.Sprintf("regular%d=", i), fmt.Sprintf("bright%d=", i))
+	}
+	for _, required := range requiredStrings {
+		if !strings.Contains(content, required) {
+			t.Errorf("Generated content missing required string: %q", required)
+		}
+	}
+
+	// Foot expects colours without the leading '#'.
+	for line := range strings.Lines(content) {
+		if !strings.HasPrefix(line, "#") && strings.Contains(line, "#") {
+			t.Errorf("Colour line contains '#': %q", line)
+		}
+	}
+
+	if strings.Contains(content, "alpha=") {
+		t.Error("Generated content has alpha without --foot.alpha")
+	}
+}
+
+// TestFootPlugin_LightTheme tests that black and white follow the light background.
+func TestFootPlugin_LightTheme(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeLight)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["tinct.ini"])
+	helper := colour.NewPaletteHelper(palette)
+
+	if want := "regular0=" + helper.Get(colour.RoleForeground).HexNoHash(); !strings.Contains(content, want) {
+		t.Errorf("light theme regular0 should be the foreground, missing %q", want)
+	}
+	if want := "bright7=" + helper.Get(colour.RoleBackground).HexNoHash(); !strings.Contains(content, want) {
+		t.Errorf("light theme bright7 should be the background, missing %q", want)
+	}
+}
+
+// TestFootPlugin_Alpha tests the alpha key written with --foot.alpha.
+func TestFootPlugin_Alpha(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	bg := palette.Colours[colour.RoleBackground]
+	bg.RGBA.A = 204
+	palette.Colours[colour.RoleBackground] = bg
+
+	plugin := New()
+	plugin.alpha = true
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if content := string(files["tinct.ini"]); !strings.Contains(content, "alpha=0.80\n") {
+		t.Errorf("Generated content missing alpha=0.80:\n%s", content)
+	}
+}
+
+// TestFootPlugin_CustomOutputDir tests custom output directory handling.
+func TestFootPlugin_CustomOutputDir(t *testing.T) {
+	plugin := New()
+	plugin.outputDir = "/custom/path"
+
+	if dir := plugin.DefaultOutputDir(); dir != "/custom/path" {
+		t.Errorf("DefaultOutputDir() = %s, want /custom/path", dir)
+	}
+}
+
+// TestFootPlugin_MatchesKittyANSI verifies foot and kitty emit identical ANSI colours,
+// as both read the palette's canonical 16-colour mapping.
+func TestFootPlugin_MatchesKittyANSI(t *testing.T) {
+	for _, themeType := range []colour.ThemeType{colour.ThemeDark, colour.ThemeLight} {
+		t.Run(themeType.String(), func(t *testing.T) {
+			themeData := colour.NewThemeData(plugintesting.CreateTestPalette(themeType), "", "")
+
+			footFiles, err := New().Generate(themeData)
+			if err != nil {
+				t.Fatalf("foot Generate() error = %v", err)
+			}
+			kittyFiles, err := kitty.New().Generate(themeData)
+			if err != nil {
+				t.Fatalf("kitty Generate() error = %v", err)
+			}
+
+			footColours := parseKeyValues(string(footFiles["tinct.ini"]), "=")
+			kittyColours := parseKeyValues(string(kittyFiles["tinct.conf"]), " ")
+
+			for i := range 16 {
+				footKey := fmt.Sprintf("regular%d", i)
+				if i >= 8 {
+					footKey = fmt.Sprintf("bright%d", i-8)
+				}
+				footHex := "#" + footColours[footKey]
+				kittyHex := kittyColours[fmt.Sprintf("color%d", i)]
+				if footHex == "#" || kittyHex == "" {
+					t.Fatalf("ANSI colour %d missing (foot %q, kitty %q)", i, footHex, kittyHex)
+				}
+				if footHex != kittyHex {
+					t.Errorf("ANSI colour %d: foot %s, kitty %s", i, footHex, kittyHex)
+				}
+			}
+		})
+	}
+}
+
+// parseKeyValues parses "key<sep>value" lines into a map, ignoring comments.
+func parseKeyValues(content, sep string) map[string]string {
+	values := make(map[string]string)
+	for line := range strings.Lines(content) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, sep); ok {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,206 @@
+// Package ghostty provides an output plugin for Ghostty terminal colour themes.
+package ghostty
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// requiredRoles are the palette roles the theme template uses directly.
+var requiredRoles = []colour.Role{colour.RoleBackground, colour.RoleForeground, colour.RoleAccent1}
+
+// Plugin implements the output.Plugin interface for Ghostty.
+type Plugin struct {
+	outputDir string
+	themeName string
+	verbose   bool
+}
+
+// New creates a new Ghostty output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		themeName: "tinct",
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "ghostty"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Ghostty terminal colour theme"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "ghostty.output-dir", "", "Output directory (default: ~/.config/ghostty/themes)")
+	cmd.Flags().StringVar(&p.themeName, "ghostty.theme-name", "tinct", "Theme name, used as the file name and in the header comment")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "ghostty.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/ghostty/themes)", Required: false},
+		{Name: "ghostty.theme-name", Type: "string", Default: "tinct", Description: "Theme name, used as the file name and in the header comment", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+// Ghostty looks themes up by file name, so the name must be a plain file name.
+func (p *Plugin) Validate() error {
+	if p.themeName == "" {
+		return fmt.Errorf("theme name cannot be empty")
+	}
+	if strings.ContainsAny(p.themeName, `/\`) || p.themeName == "." || p.themeName == ".." {
+		return fmt.Errorf("theme name must be a file name, got %q", p.themeName)
+	}
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/ghostty/themes"
+	}
+	return filepath.Join(home, ".config", "ghostty", "themes")
+}
+
+// Generate creates the theme file.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = p.themeName
+	themeData.ThemeName = p.themeName
+
+	content, err := p.generateTheme(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate theme: %w", err)
+	}
+
+	return map[string][]byte{p.themeName: content}, nil
+}
+
+// generateTheme creates the theme file content.
+func (p *Plugin) generateTheme(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("ghostty", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("theme.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for theme.tmpl\n")
+	}
+
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse theme template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute theme template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreExecute checks if ghostty is available before generating the theme.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if ghostty executable exists on PATH.
+	_, err = exec.LookPath("ghostty")
+	if err != nil {
+		return true, "ghostty executable not found on $PATH", nil
+	}
+
+	// Check if themes directory exists, create if it doesn't.
+	themesDir := p.DefaultOutputDir()
+	if _, err := os.Stat(themesDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(themesDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("ghostty themes directory not found and could not be created: %s", themesDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created ghostty themes directory: %s\n", themesDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute reminds the user to select the theme in their Ghostty config.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, execCtx output.ExecutionContext, writtenFiles []string) error {
+	if execCtx.DryRun || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "   Ghostty: add \"theme = %s\" to ~/.config/ghostty/config to use this theme\n", p.themeName)
+	if p.verbose {
+		fmt.Fprintf(os.Stderr, "   Note: Reload the config (ctrl+shift+, by default) to apply theme changes.\n")
+	}
+	return nil
+}
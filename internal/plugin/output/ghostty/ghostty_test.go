@@ -0,0 +1,124 @@
+package ghostty
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestGhosttyPlugin runs all standard plugin tests using shared utilities.
+func TestGhosttyPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "ghostty",
+		ExpectedFiles:      []string{"tinct"},
+		ExpectedBinaryName: "ghostty",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestGhosttyPlugin_ContentValidation tests ghostty-specific content requirements.
+func TestGhosttyPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["tinct"])
+	helper := colour.NewPaletteHelper(palette)
+
+	requiredStrings := []string{
+		`# Ghostty theme "tinct" generated by Tinct`,
+		"theme = tinct",
+		"background = " + helper.Get(colour.RoleBackground).Hex(),
+		"foreground = " + helper.Get(colour.RoleForeground).Hex(),
+		"cursor-color = " + helper.Get(colour.RoleAccent1).Hex(),
+		"selection-background = " + helper.Get(colour.RoleAccent1).Hex(),
+		"Detected theme: dark",
+	}
+	for i := range 16 {
+		requiredStrings = append(requiredStrings, fmt.Sprintf("palette = %d=#", i))
+	}
+
+	for _, required := range requiredStrings {
+		if !strings.Contains(content, required) {
+			t.Errorf("Generated content missing required string: %s", required)
+		}
+	}
+}
+
+// TestGhosttyPlugin_MissingRole tests that a palette without a required role is rejected.
+func TestGhosttyPlugin_MissingRole(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	delete(palette.Colours, colour.RoleBackground)
+
+	_, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	var verr *colour.PaletteValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Generate() error = %v, want *colour.PaletteValidationError", err)
+	}
+}
+
+// TestGhosttyPlugin_ThemeName tests that the theme name sets the file name and header.
+func TestGhosttyPlugin_ThemeName(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeLight)
+	plugin := New()
+	plugin.themeName = "wallpaper-light"
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, ok := files["wallpaper-light"]
+	if !ok {
+		t.Fatalf("Generate() files = %v, want wallpaper-light", files)
+	}
+	if !strings.Contains(string(content), `# Ghostty theme "wallpaper-light" generated by Tinct`) {
+		t.Error("Generated content missing custom theme name in header")
+	}
+}
+
+// TestGhosttyPlugin_Validate tests theme name validation.
+func TestGhosttyPlugin_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		themeName string
+		wantErr   bool
+	}{
+		{"default", "tinct", false},
+		{"custom", "my-theme", false},
+		{"empty", "", true},
+		{"path", "../tinct", true},
+		{"dot", ".", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := New()
+			plugin.themeName = tt.themeName
+			if err := plugin.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestGhosttyPlugin_CustomOutputDir tests custom output directory handling.
+func TestGhosttyPlugin_CustomOutputDir(t *testing.T) {
+	plugin := New()
+	plugin.outputDir = "/custom/path"
+
+	if dir := plugin.DefaultOutputDir(); dir != "/custom/path" {
+		t.Errorf("DefaultOutputDir() = %s, want /custom/path", dir)
+	}
+}
@@ -0,0 +1,177 @@
+// Package gimp provides an output plugin for palette files used by GIMP and Aseprite.
+package gimp
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// Supported --gimp.format values.
+const (
+	formatGPL      = "gpl"
+	formatAseprite = "aseprite"
+)
+
+// paletteFiles maps each format to the palette file it writes.
+var paletteFiles = map[string]string{
+	formatGPL:      "tinct.gpl",
+	formatAseprite: "tinct.txt",
+}
+
+// Plugin implements the output.Plugin interface for GIMP and Aseprite palettes.
+type Plugin struct {
+	outputDir string
+	format    string
+	verbose   bool
+}
+
+// New creates a new GIMP palette output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		format:    formatGPL,
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "gimp"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Palette of every colour for GIMP/Inkscape (.gpl) or Aseprite (.txt), named by role"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "gimp.output-dir", "", "Output directory (default: ~/.config/tinct)")
+	cmd.Flags().StringVar(&p.format, "gimp.format", formatGPL, "Palette format (gpl, aseprite)")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "gimp.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/tinct)", Required: false},
+		{Name: "gimp.format", Type: "string", Default: formatGPL, Description: "Palette format (gpl, aseprite)", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	if _, ok := paletteFiles[p.format]; !ok {
+		return fmt.Errorf("invalid palette format %q (valid: %s, %s)", p.format, formatGPL, formatAseprite)
+	}
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/tinct"
+	}
+	return filepath.Join(home, ".config", "tinct")
+}
+
+// Generate creates the palette file in the selected format.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if themeData.Count() == 0 {
+		return nil, fmt.Errorf("palette has no colours")
+	}
+
+	fileName := paletteFiles[p.format]
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = fileName
+
+	content, err := p.generatePalette(themeData, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate palette: %w", err)
+	}
+
+	return map[string][]byte{fileName: content}, nil
+}
+
+// generatePalette renders the palette template for fileName.
+func (p *Plugin) generatePalette(themeData *colour.ThemeData, fileName string) ([]byte, error) {
+	templateName := fileName + ".tmpl"
+
+	// Load template with custom override support.
+	loader := tmplloader.New("gimp", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load(templateName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read palette template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for %s\n", templateName)
+	}
+
+	tmpl, err := template.New("gimp").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse palette template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute palette template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
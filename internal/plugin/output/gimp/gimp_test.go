@@ -0,0 +1,117 @@
+package gimp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestGIMPPlugin runs all standard plugin tests using shared utilities.
+func TestGIMPPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "gimp",
+		ExpectedFiles:        []string{"tinct.gpl"},
+		ExpectedDirSubstring: ".config/tinct",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// entryName returns the palette entry name for the colour at index i.
+func entryName(cv colour.ColorValue, i int) string {
+	if cv.Role() != "" {
+		return string(cv.Role())
+	}
+	return fmt.Sprintf("colour%d", i)
+}
+
+// TestGIMPPlugin_GPL tests the header and that every colour is written in order with its role name.
+func TestGIMPPlugin_GPL(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(files["tinct.gpl"]), "\n"), "\n")
+	if lines[0] != "GIMP Palette" || lines[1] != "Name: tinct" {
+		t.Fatalf("unexpected header: %q", lines[:2])
+	}
+
+	var entries []string
+	for _, line := range lines[2:] {
+		if !strings.HasPrefix(line, "#") {
+			entries = append(entries, line)
+		}
+	}
+	if len(entries) != helper.Count() {
+		t.Fatalf("got %d entries, want %d", len(entries), helper.Count())
+	}
+	for i, cv := range helper.AllColors() {
+		want := fmt.Sprintf("%3d %3d %3d\t%s", cv.R(), cv.G(), cv.B(), entryName(cv, i))
+		if entries[i] != want {
+			t.Errorf("entry %d = %q, want %q", i, entries[i], want)
+		}
+	}
+}
+
+// TestGIMPPlugin_Aseprite tests the Paint.NET-style hex list written for Aseprite.
+func TestGIMPPlugin_Aseprite(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeLight)
+	helper := colour.NewPaletteHelper(palette)
+
+	plugin := New()
+	plugin.format = formatAseprite
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := files["tinct.gpl"]; ok {
+		t.Error("aseprite format should not write tinct.gpl")
+	}
+
+	var hexes, comments []string
+	for line := range strings.Lines(string(files["tinct.txt"])) {
+		line = strings.TrimSpace(line)
+		if comment, ok := strings.CutPrefix(line, "; "); ok {
+			comments = append(comments, comment)
+		} else {
+			hexes = append(hexes, line)
+		}
+	}
+
+	if len(hexes) != helper.Count() {
+		t.Fatalf("got %d colours, want %d", len(hexes), helper.Count())
+	}
+	// The first three comments are the file header; the rest name each colour.
+	names := comments[len(comments)-helper.Count():]
+	for i, cv := range helper.AllColors() {
+		if want := "FF" + strings.ToUpper(cv.HexNoHash()); hexes[i] != want {
+			t.Errorf("colour %d = %q, want %q", i, hexes[i], want)
+		}
+		if want := entryName(cv, i); names[i] != want {
+			t.Errorf("colour %d comment = %q, want %q", i, names[i], want)
+		}
+	}
+}
+
+// TestGIMPPlugin_Format tests that unknown formats are rejected.
+func TestGIMPPlugin_Format(t *testing.T) {
+	plugin := New()
+	plugin.format = "act"
+
+	if err := plugin.Validate(); err == nil {
+		t.Error("Validate() should reject an unknown format")
+	}
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	if _, err := plugin.Generate(colour.NewThemeData(palette, "", "")); err == nil {
+		t.Error("Generate() should reject an unknown format")
+	}
+}
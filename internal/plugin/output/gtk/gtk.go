@@ -0,0 +1,231 @@
+// Package gtk provides an output plugin for GTK 3 and GTK 4 (libadwaita) colour overrides.
+package gtk
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// Supported --gtk.version values.
+const (
+	version3    = "3"
+	version4    = "4"
+	versionBoth = "both"
+)
+
+// cssFiles maps each GTK major version to its stylesheet, relative to the output directory.
+var cssFiles = map[int]string{
+	3: filepath.Join("gtk-3.0", "gtk.css"),
+	4: filepath.Join("gtk-4.0", "gtk.css"),
+}
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1,
+	colour.RoleDanger, colour.RoleWarning, colour.RoleSuccess,
+}
+
+// Plugin implements the output.Plugin interface for GTK.
+type Plugin struct {
+	outputDir string
+	version   string
+	verbose   bool
+}
+
+// New creates a new GTK output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		version:   versionBoth,
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "gtk"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "GTK 3/4 colour overrides (gtk.css named colours, libadwaita on GTK 4)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "gtk.output-dir", "", "Output directory containing gtk-3.0 and gtk-4.0 (default: ~/.config)")
+	cmd.Flags().StringVar(&p.version, "gtk.version", versionBoth, "GTK version to write colours for (3, 4, both)")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "gtk.output-dir", Type: "string", Default: "", Description: "Output directory containing gtk-3.0 and gtk-4.0 (default: ~/.config)", Required: false},
+		{Name: "gtk.version", Type: "string", Default: versionBoth, Description: "GTK version to write colours for (3, 4, both)", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	_, err := p.versions()
+	return err
+}
+
+// versions returns the GTK major versions selected by --gtk.version.
+func (p *Plugin) versions() ([]int, error) {
+	switch p.version {
+	case version3:
+		return []int{3}, nil
+	case version4:
+		return []int{4}, nil
+	case versionBoth:
+		return []int{3, 4}, nil
+	default:
+		return nil, fmt.Errorf("invalid GTK version %q (valid: 3, 4, both)", p.version)
+	}
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config"
+	}
+	return filepath.Join(home, ".config")
+}
+
+// Generate updates the Tinct-managed block of gtk.css for each selected GTK
+// version, keeping any rules the user has outside it.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	versions, err := p.versions()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+
+	files := make(map[string][]byte)
+	for _, version := range versions {
+		themeData.ColorFileName = cssFiles[version]
+
+		content, err := p.generateCSS(themeData, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate GTK %d stylesheet: %w", version, err)
+		}
+
+		cssPath := filepath.Join(themeData.OutputDir, cssFiles[version])
+		existing, err := os.ReadFile(cssPath) // #nosec G304 - Reading the user's own gtk.css
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", cssPath, err)
+		}
+
+		merged := common.ReplaceManagedBlockWithMarkers(string(existing), string(content), common.CSSManagedBlockBegin, common.CSSManagedBlockEnd)
+		files[cssFiles[version]] = []byte(merged)
+	}
+
+	return files, nil
+}
+
+// generateCSS renders the managed stylesheet block for one GTK major version.
+func (p *Plugin) generateCSS(themeData *colour.ThemeData, version int) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("gtk", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("gtk.css.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stylesheet template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for gtk.css.tmpl\n")
+	}
+
+	// gtkVersion exposes the GTK major version being rendered to the template.
+	funcs := template.FuncMap{"gtkVersion": func() int { return version }}
+	tmpl, err := template.New("gtk").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stylesheet template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute stylesheet template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PostExecute explains how the generated colours take effect.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   GTK colours generated successfully!\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Restart GTK applications to pick up the new colours.\n")
+	fmt.Fprintf(os.Stderr, "   GTK 4 colours apply to libadwaita applications.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
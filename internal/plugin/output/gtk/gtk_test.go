@@ -0,0 +1,185 @@
+package gtk
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// defineColor matches a GTK @define-color statement.
+var defineColor = regexp.MustCompile(`^@define-color ([a-z_]+) (#[0-9a-f]{6});$`)
+
+// parseColours returns the named colours defined in a GTK stylesheet.
+func parseColours(content string) map[string]string {
+	values := make(map[string]string)
+	for line := range strings.Lines(content) {
+		if m := defineColor.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			values[m[1]] = m[2]
+		}
+	}
+	return values
+}
+
+// TestGTKPlugin runs all standard plugin tests using shared utilities.
+func TestGTKPlugin(t *testing.T) {
+	// Generate reads the existing gtk.css files, so keep the user's own out of the test.
+	t.Setenv("HOME", t.TempDir())
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "gtk",
+		ExpectedFiles:        []string{"gtk-3.0/gtk.css", "gtk-4.0/gtk.css"},
+		ExpectedDirSubstring: ".config",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestGTKPlugin_ContentValidation tests the named colours and their roles.
+func TestGTKPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.outputDir = t.TempDir()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	helper := colour.NewPaletteHelper(palette)
+	shared := map[string]colour.Role{
+		"theme_bg_color":          colour.RoleBackground,
+		"theme_fg_color":          colour.RoleForeground,
+		"theme_selected_bg_color": colour.RoleAccent1,
+		"accent_color":            colour.RoleAccent1,
+		"window_bg_color":         colour.RoleBackground,
+		"headerbar_bg_color":      colour.RoleBackgroundMuted,
+	}
+	libadwaita := map[string]colour.Role{
+		"accent_bg_color":   colour.RoleAccent1,
+		"destructive_color": colour.RoleDanger,
+	}
+
+	for _, name := range []string{"gtk-3.0/gtk.css", "gtk-4.0/gtk.css"} {
+		values := parseColours(string(files[name]))
+		for key, role := range shared {
+			if got, want := values[key], helper.Get(role).Hex(); got != want {
+				t.Errorf("%s: %s = %q, want %q (%s)", name, key, got, want, role)
+			}
+		}
+	}
+
+	gtk3 := parseColours(string(files["gtk-3.0/gtk.css"]))
+	gtk4 := parseColours(string(files["gtk-4.0/gtk.css"]))
+	for key, role := range libadwaita {
+		if _, ok := gtk3[key]; ok {
+			t.Errorf("gtk-3.0/gtk.css should not define libadwaita colour %s", key)
+		}
+		if got, want := gtk4[key], helper.Get(role).Hex(); got != want {
+			t.Errorf("gtk-4.0/gtk.css: %s = %q, want %q (%s)", key, got, want, role)
+		}
+	}
+}
+
+// TestGTKPlugin_Version tests that --gtk.version selects the files written.
+func TestGTKPlugin_Version(t *testing.T) {
+	tests := []struct {
+		version string
+		want    []string
+		wantErr bool
+	}{
+		{version: "3", want: []string{"gtk-3.0/gtk.css"}},
+		{version: "4", want: []string{"gtk-4.0/gtk.css"}},
+		{version: "both", want: []string{"gtk-3.0/gtk.css", "gtk-4.0/gtk.css"}},
+		{version: "5", wantErr: true},
+	}
+
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			plugin := New()
+			plugin.outputDir = t.TempDir()
+			plugin.version = tt.version
+
+			if err := plugin.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Generate() expected error for invalid version")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			if len(files) != len(tt.want) {
+				t.Errorf("Generate() wrote %d files, want %d", len(files), len(tt.want))
+			}
+			for _, name := range tt.want {
+				if _, ok := files[name]; !ok {
+					t.Errorf("Generate() missing %s", name)
+				}
+			}
+		})
+	}
+}
+
+// TestGTKPlugin_PreservesUserRules verifies user CSS survives repeated runs.
+func TestGTKPlugin_PreservesUserRules(t *testing.T) {
+	dir := t.TempDir()
+	userRules := "window { padding: 4px; }\n"
+	userTail := "headerbar { min-height: 0; }\n"
+	for _, name := range []string{"gtk-3.0/gtk.css", "gtk-4.0/gtk.css"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(userRules), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	plugin := New()
+	plugin.outputDir = dir
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+
+	// Run twice, writing the output back as tinct would, with a user edit in between.
+	var files map[string][]byte
+	for run := 1; run <= 2; run++ {
+		var err error
+		files, err = plugin.Generate(colour.NewThemeData(palette, "", ""))
+		if err != nil {
+			t.Fatalf("run %d: Generate() error = %v", run, err)
+		}
+		for name, content := range files {
+			if run == 1 {
+				content = append(content, userTail...)
+			}
+			if err := os.WriteFile(filepath.Join(dir, name), content, 0o600); err != nil {
+				t.Fatalf("run %d: failed to write %s: %v", run, name, err)
+			}
+		}
+	}
+
+	for name, data := range files {
+		content := string(data)
+		if !strings.HasPrefix(content, userRules+"\n"+common.CSSManagedBlockBegin+"\n") {
+			t.Errorf("%s: rules before the block changed:\n%s", name, content)
+		}
+		if !strings.HasSuffix(content, common.CSSManagedBlockEnd+"\n"+userTail) {
+			t.Errorf("%s: rules after the block changed:\n%s", name, content)
+		}
+		if n := strings.Count(content, common.CSSManagedBlockBegin); n != 1 {
+			t.Errorf("%s has %d managed blocks, want 1", name, n)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package helix
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+var (
+	// paletteLine matches a [palette] entry.
+	paletteLine = regexp.MustCompile(`^([A-Za-z0-9]+) = "(#[0-9a-f]{6})"$`)
+	// scopeLine matches a scope assignment and captures the palette name it uses:
+	// the whole value, the fg, the bg, or the underline colour.
+	scopeLine = regexp.MustCompile(`^"([a-z.-]+)" = (?:"([A-Za-z0-9]+)"|\{ (?:fg = "([A-Za-z0-9]+)")?(?:, )?(?:bg = "([A-Za-z0-9]+)")?(?:underline = \{ color = "([A-Za-z0-9]+)")?.*\})$`)
+)
+
+// parseTheme returns the palette entries and the palette name each scope
+// uses, keyed by scope (fg) and scope+".bg" (bg).
+func parseTheme(t *testing.T, content string) (palette, scopes map[string]string) {
+	t.Helper()
+	palette = make(map[string]string)
+	scopes = make(map[string]string)
+
+	inPalette := false
+	for line := range strings.Lines(content) {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "[palette]":
+			inPalette = true
+		case line == "" || strings.HasPrefix(line, "#"):
+		case inPalette:
+			m := paletteLine.FindStringSubmatch(line)
+			if m == nil {
+				t.Fatalf("invalid palette line: %q", line)
+			}
+			palette[m[1]] = m[2]
+		default:
+			m := scopeLine.FindStringSubmatch(line)
+			if m == nil {
+				t.Fatalf("invalid scope line: %q", line)
+			}
+			if fg := m[2] + m[3] + m[5]; fg != "" {
+				scopes[m[1]] = fg
+			}
+			if m[4] != "" {
+				scopes[m[1]+".bg"] = m[4]
+			}
+		}
+	}
+	return palette, scopes
+}
+
+// TestHelixPlugin runs all standard plugin tests using shared utilities.
+func TestHelixPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "helix",
+		ExpectedFiles:      []string{"tinct.toml"},
+		ExpectedBinaryName: "hx",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestHelixPlugin_ContentValidation tests the palette and the roles behind each scope.
+func TestHelixPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	entries, scopes := parseTheme(t, string(files["tinct.toml"]))
+
+	// Every scope must reference a palette entry.
+	for scope, name := range scopes {
+		if _, ok := entries[name]; !ok {
+			t.Errorf("scope %s uses %q, which is not in [palette]", scope, name)
+		}
+	}
+
+	helper := colour.NewPaletteHelper(palette)
+	expected := map[string]colour.Role{
+		"ui.background.bg":   colour.RoleBackground,
+		"ui.text":            colour.RoleForeground,
+		"ui.cursor.bg":       colour.RoleForegroundMuted,
+		"ui.selection.bg":    colour.RoleBackgroundMuted,
+		"ui.menu.bg":         colour.RoleSurface,
+		"ui.popup.bg":        colour.RoleSurfaceContainer,
+		"comment":            colour.RoleForegroundMuted,
+		"keyword":            colour.RoleAccent2,
+		"string":             colour.RoleSuccess,
+		"function":           colour.RoleAccent1,
+		"diagnostic.error":   colour.RoleDanger,
+		"diagnostic.warning": colour.RoleWarning,
+	}
+	for scope, role := range expected {
+		if got, want := entries[scopes[scope]], helper.Get(role).Hex(); got != want {
+			t.Errorf("%s = %q, want %s (%s)", scope, got, want, role)
+		}
+	}
+}
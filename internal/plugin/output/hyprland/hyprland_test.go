@@ -1,6 +1,7 @@
 package hyprland
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -93,6 +94,40 @@ func TestHyprlandPlugin_ContentValidation(t *testing.T) {
 	}
 }
 
+// TestHyprlandPlugin_ShadowAndGroupColours tests the shadow, border and group bar colours.
+func TestHyprlandPlugin_ShadowAndGroupColours(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// The shadow variable keeps the role's alpha in Hyprland's no-hash rgba() format.
+	shadow := palette.Colours[colour.RoleShadow].RGBA
+	wantShadow := fmt.Sprintf("$shadowRGBA = rgba(%02x%02x%02x%02x)", shadow.R, shadow.G, shadow.B, shadow.A)
+	if shadow.A == 255 {
+		t.Fatalf("test palette shadow should be translucent, got alpha %d", shadow.A)
+	}
+	if colours := string(files["tinct-colours.conf"]); !strings.Contains(colours, wantShadow) {
+		t.Errorf("tinct-colours.conf missing %q", wantShadow)
+	}
+
+	stub := string(files["tinct.conf"])
+	for _, want := range []string{
+		"color = $shadowRGBA",
+		"col.inactive_border = $borderMutedRGB",
+		"col.border_inactive = $borderMutedRGB",
+		"col.active = $accent1RGB",
+		"col.inactive = $surfaceRGB",
+	} {
+		if !strings.Contains(stub, want) {
+			t.Errorf("tinct.conf missing %q", want)
+		}
+	}
+}
+
 // TestHyprlandPlugin_GenerateWithLightTheme tests light theme generation.
 func TestHyprlandPlugin_GenerateWithLightTheme(t *testing.T) {
 	palette := plugintesting.CreateTestPalette(colour.ThemeLight)
@@ -32,15 +32,17 @@ func GetEmbeddedTemplates() embed.FS {
 
 // Plugin implements the output.Plugin interface for Kitty terminal.
 type Plugin struct {
-	outputDir string
-	verbose   bool
+	outputDir     string
+	tabFromAccent bool
+	verbose       bool
 }
 
 // New creates a new Kitty output plugin with default settings.
 func New() *Plugin {
 	return &Plugin{
-		outputDir: "",
-		verbose:   false,
+		outputDir:     "",
+		tabFromAccent: true,
+		verbose:       false,
 	}
 }
 
@@ -62,6 +64,7 @@ func (p *Plugin) Version() string {
 // RegisterFlags registers plugin-specific flags with the cobra command.
 func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&p.outputDir, "kitty.output-dir", "", "Output directory (default: ~/.config/kitty/themes)")
+	cmd.Flags().BoolVar(&p.tabFromAccent, "kitty.tab-from-accent", true, "Fill the active tab with accent1 (false uses a surface background with accent1 text)")
 }
 
 // SetVerbose enables or disables verbose logging for the plugin.
@@ -80,6 +83,7 @@ func (p *Plugin) GetEmbeddedFS() any {
 func (p *Plugin) GetFlagHelp() []input.FlagHelp {
 	return []input.FlagHelp{
 		{Name: "kitty.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/kitty/themes)", Required: false},
+		{Name: "kitty.tab-from-accent", Type: "bool", Default: "true", Description: "Fill the active tab with accent1 (false uses a surface background with accent1 text)", Required: false},
 	}
 }
 
@@ -138,7 +142,9 @@ func (p *Plugin) generateTheme(themeData *colour.ThemeData) ([]byte, error) {
 		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.conf.tmpl\n")
 	}
 
-	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	// tabFromAccent exposes --kitty.tab-from-accent to the template.
+	funcs := template.FuncMap{"tabFromAccent": func() bool { return p.tabFromAccent }}
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse theme template: %w", err)
 	}
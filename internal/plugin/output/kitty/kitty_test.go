@@ -111,3 +111,91 @@ func TestKittyPlugin_GetEmbeddedTemplates(t *testing.T) {
 		t.Error("Template file tinct.conf.tmpl not found in embedded filesystem")
 	}
 }
+
+// TestKittyPlugin_TabAndURLColours tests that tab bar, URL and mark colours are valid hex values.
+func TestKittyPlugin_TabAndURLColours(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	tests := []struct {
+		name          string
+		tabFromAccent bool
+		wantActiveBg  string
+		wantActiveFg  string
+	}{
+		{"accent", true, helper.Get(colour.RoleAccent1).Hex(), ""},
+		{"surface", false, "", helper.Get(colour.RoleAccent1).Hex()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := New()
+			plugin.tabFromAccent = tt.tabFromAccent
+
+			files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			values := parseKittyColours(string(files["tinct.conf"]))
+			for _, key := range []string{
+				"url_color",
+				"tab_bar_background",
+				"active_tab_foreground",
+				"active_tab_background",
+				"inactive_tab_foreground",
+				"inactive_tab_background",
+				"mark1_foreground",
+				"mark1_background",
+				"mark2_foreground",
+				"mark2_background",
+				"mark3_foreground",
+				"mark3_background",
+			} {
+				value, ok := values[key]
+				if !ok {
+					t.Errorf("missing %s", key)
+					continue
+				}
+				if !isHexColour(value) {
+					t.Errorf("%s = %q, want a #rrggbb colour", key, value)
+				}
+			}
+
+			if tt.wantActiveBg != "" && values["active_tab_background"] != tt.wantActiveBg {
+				t.Errorf("active_tab_background = %s, want %s", values["active_tab_background"], tt.wantActiveBg)
+			}
+			if tt.wantActiveFg != "" && values["active_tab_foreground"] != tt.wantActiveFg {
+				t.Errorf("active_tab_foreground = %s, want %s", values["active_tab_foreground"], tt.wantActiveFg)
+			}
+			if values["url_color"] != helper.Get(colour.RoleInfo).Hex() {
+				t.Errorf("url_color = %s, want info %s", values["url_color"], helper.Get(colour.RoleInfo).Hex())
+			}
+		})
+	}
+}
+
+// parseKittyColours returns the key/value pairs of a kitty config, ignoring comments.
+func parseKittyColours(content string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && !strings.HasPrefix(fields[0], "#") {
+			values[fields[0]] = fields[1]
+		}
+	}
+	return values
+}
+
+// isHexColour reports whether s is a #rrggbb colour.
+func isHexColour(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
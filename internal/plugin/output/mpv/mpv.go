@@ -0,0 +1,242 @@
+// Package mpv provides an output plugin for mpv on-screen controller and OSD colours.
+package mpv
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// oscFileName is the OSC script-opts file, relative to the mpv config directory.
+// The colours go in a managed block so the user's other OSC options are kept.
+var oscFileName = filepath.Join("script-opts", "osc.conf")
+
+// osdFileName is the OSD fragment written with --mpv.osd, included from mpv.conf.
+const osdFileName = "tinct-osd.conf"
+
+// requiredRoles are the roles the templates read without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1, colour.RoleAccent2,
+}
+
+// Plugin implements the output.Plugin interface for mpv.
+type Plugin struct {
+	outputDir string
+	osd       bool
+	verbose   bool
+}
+
+// New creates a new mpv output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		osd:       false,
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "mpv"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "mpv on-screen controller colours (managed block in script-opts/osc.conf)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "mpv.output-dir", "", "mpv config directory (default: ~/.config/mpv)")
+	cmd.Flags().BoolVar(&p.osd, "mpv.osd", false, "Also write OSD text colours to tinct-osd.conf, for include= in mpv.conf")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "mpv.output-dir", Type: "string", Default: "", Description: "mpv config directory (default: ~/.config/mpv)", Required: false},
+		{Name: "mpv.osd", Type: "bool", Default: "false", Description: "Also write OSD text colours to tinct-osd.conf, for include= in mpv.conf", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/mpv"
+	}
+	return filepath.Join(home, ".config", "mpv")
+}
+
+// Generate creates osc.conf with the Tinct-managed block updated, and the OSD
+// fragment when --mpv.osd is set.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = oscFileName
+
+	block, err := p.render(themeData, "osc.conf.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OSC colours: %w", err)
+	}
+
+	oscPath := filepath.Join(p.DefaultOutputDir(), oscFileName)
+	existing, err := os.ReadFile(oscPath) // #nosec G304 - Reading the user's own osc.conf
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", oscPath, err)
+	}
+
+	files := map[string][]byte{
+		oscFileName: []byte(common.ReplaceManagedBlock(string(existing), string(block))),
+	}
+
+	if p.osd {
+		osd, err := p.render(themeData, "tinct-osd.conf.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate OSD colours: %w", err)
+		}
+		files[osdFileName] = osd
+	}
+
+	return files, nil
+}
+
+// render executes the named template.
+func (p *Plugin) render(themeData *colour.ThemeData, name string) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("mpv", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s template: %w", name, err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for %s\n", name)
+	}
+
+	// mpvColor writes mpv option colours, which put alpha first (#AARRGGBB).
+	funcs := template.FuncMap{"mpvColor": mpvColor}
+	tmpl, err := template.New(name).Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute %s template: %w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mpvColor formats a colour for an mpv option: #RRGGBB when opaque, otherwise
+// #AARRGGBB with the alpha channel first.
+func mpvColor(cv colour.ColorValue) string {
+	if cv.A() == 255 {
+		return cv.Hex()
+	}
+	return cv.HexAlphaFirst()
+}
+
+// PreExecute checks if mpv is available before generating the colours.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if mpv executable exists on PATH.
+	if _, err := exec.LookPath("mpv"); err != nil {
+		return true, "mpv executable not found on $PATH", nil
+	}
+
+	// Check if the script-opts directory exists, create if it doesn't.
+	scriptOptsDir := filepath.Dir(filepath.Join(p.DefaultOutputDir(), oscFileName))
+	if _, err := os.Stat(scriptOptsDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(scriptOptsDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("mpv script-opts directory not found and could not be created: %s", scriptOptsDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created mpv script-opts directory: %s\n", scriptOptsDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides instructions for applying the colours.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   mpv OSC colours written to %s\n", filepath.Join(p.DefaultOutputDir(), oscFileName))
+	if p.osd {
+		fmt.Fprintf(os.Stderr, "   Add include=\"~~/%s\" to mpv.conf for the OSD colours.\n", osdFileName)
+	}
+	fmt.Fprintf(os.Stderr, "   The colours apply to newly started mpv windows.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package mpv
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// optionLine matches a key=value option line.
+var optionLine = regexp.MustCompile(`^([a-z_-]+)=(\S+)$`)
+
+// parseOptions returns the options set in an mpv config file.
+func parseOptions(content string) map[string]string {
+	values := make(map[string]string)
+	for line := range strings.Lines(content) {
+		if m := optionLine.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			values[m[1]] = m[2]
+		}
+	}
+	return values
+}
+
+// TestMpvPlugin runs all standard plugin tests using shared utilities.
+func TestMpvPlugin(t *testing.T) {
+	// Generate reads the existing osc.conf, so keep the user's own out of the test.
+	t.Setenv("HOME", t.TempDir())
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "mpv",
+		ExpectedFiles:      []string{filepath.Join("script-opts", "osc.conf")},
+		ExpectedBinaryName: "mpv",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestMpvPlugin_ContentValidation tests the OSC colour options and their roles.
+func TestMpvPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.outputDir = t.TempDir()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := files[osdFileName]; ok {
+		t.Errorf("%s should only be written with --mpv.osd", osdFileName)
+	}
+
+	content := string(files[oscFileName])
+	if !strings.HasPrefix(content, common.ManagedBlockBegin+"\n") || !strings.HasSuffix(content, common.ManagedBlockEnd+"\n") {
+		t.Errorf("osc.conf should be wrapped in tinct markers, got:\n%s", content)
+	}
+
+	values := parseOptions(content)
+	helper := colour.NewPaletteHelper(palette)
+	expected := map[string]colour.Role{
+		"background_color":   colour.RoleBackground,
+		"title_color":        colour.RoleForeground,
+		"buttons_color":      colour.RoleForeground,
+		"hover_effect_color": colour.RoleAccent1,
+		"held_element_color": colour.RoleAccent2,
+		"seekbarfg_color":    colour.RoleAccent1,
+		"seekbarbg_color":    colour.RoleBackgroundMuted,
+	}
+	for key, role := range expected {
+		if got, want := values[key], helper.Get(role).Hex(); got != want {
+			t.Errorf("%s = %q, want %q (%s)", key, got, want, role)
+		}
+	}
+}
+
+// TestMpvPlugin_PreservesUserSettings verifies only the managed block of osc.conf changes.
+func TestMpvPlugin_PreservesUserSettings(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "script-opts"), 0o755); err != nil {
+		t.Fatalf("failed to create script-opts: %v", err)
+	}
+	existing := "layout=bottombar\n\n" +
+		common.ManagedBlockBegin + "\nseekbarfg_color=#123456\n" + common.ManagedBlockEnd + "\n" +
+		"boxvideo=yes\n"
+	if err := os.WriteFile(filepath.Join(dir, oscFileName), []byte(existing), 0o600); err != nil {
+		t.Fatalf("failed to write osc.conf: %v", err)
+	}
+
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.outputDir = dir
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files[oscFileName])
+
+	if !strings.HasPrefix(content, "layout=bottombar\n\n"+common.ManagedBlockBegin+"\n") {
+		t.Errorf("options before the block changed:\n%s", content)
+	}
+	if !strings.HasSuffix(content, common.ManagedBlockEnd+"\nboxvideo=yes\n") {
+		t.Errorf("options after the block changed:\n%s", content)
+	}
+	if strings.Contains(content, "#123456") {
+		t.Error("old managed colours were not replaced")
+	}
+}
+
+// TestMpvPlugin_OSD tests the OSD fragment written with --mpv.osd.
+func TestMpvPlugin_OSD(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.outputDir = t.TempDir()
+	plugin.osd = true
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	osd, ok := files[osdFileName]
+	if !ok {
+		t.Fatalf("Generate() with --mpv.osd did not return %s", osdFileName)
+	}
+
+	values := parseOptions(string(osd))
+	helper := colour.NewPaletteHelper(palette)
+	if got, want := values["osd-color"], helper.Get(colour.RoleForeground).Hex(); got != want {
+		t.Errorf("osd-color = %q, want %q", got, want)
+	}
+	if got, want := values["osd-border-color"], helper.Get(colour.RoleBackground).Hex(); got != want {
+		t.Errorf("osd-border-color = %q, want %q", got, want)
+	}
+}
+
+// TestMpvColor tests that translucent colours put alpha first.
+func TestMpvColor(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	bg := colour.NewPaletteHelper(palette).Get(colour.RoleBackground)
+
+	if got := mpvColor(bg); got != bg.Hex() {
+		t.Errorf("mpvColor(opaque) = %q, want %q", got, bg.Hex())
+	}
+
+	// Alpha comes first, then the unchanged RGB channels.
+	got := mpvColor(bg.WithAlpha(0.5))
+	if len(got) != 9 || !strings.EqualFold(got[3:], bg.Hex()[1:]) || strings.EqualFold(got[1:3], "ff") {
+		t.Errorf("mpvColor(translucent) = %q, want #AARRGGBB ending in %s", got, bg.Hex()[1:])
+	}
+}
@@ -31,17 +31,19 @@ func GetEmbeddedTemplates() embed.FS {
 
 // Plugin implements the output.Plugin interface for Neovim.
 type Plugin struct {
-	outputDir string
-	themeName string
-	verbose   bool
+	outputDir      string
+	themeName      string
+	terminalColors bool
+	verbose        bool
 }
 
 // New creates a new Neovim output plugin with default settings.
 func New() *Plugin {
 	return &Plugin{
-		outputDir: "",
-		themeName: "tinct",
-		verbose:   false,
+		outputDir:      "",
+		themeName:      "tinct",
+		terminalColors: true,
+		verbose:        false,
 	}
 }
 
@@ -64,6 +66,7 @@ func (p *Plugin) Version() string {
 func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&p.outputDir, "neovim.output-dir", "", "Output directory (default: ~/.config/nvim/colors)")
 	cmd.Flags().StringVar(&p.themeName, "neovim.theme-name", "tinct", "Theme name for the colorscheme")
+	cmd.Flags().BoolVar(&p.terminalColors, "neovim.terminal-colors", true, "Set vim.g.terminal_color_0-15 so :terminal matches the theme")
 }
 
 // SetVerbose enables or disables verbose logging for the plugin.
@@ -83,6 +86,7 @@ func (p *Plugin) GetFlagHelp() []input.FlagHelp {
 	return []input.FlagHelp{
 		{Name: "neovim.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/nvim/colors)", Required: false},
 		{Name: "neovim.theme-name", Type: "string", Default: "tinct", Description: "Theme name for the colorscheme", Required: false},
+		{Name: "neovim.terminal-colors", Type: "bool", Default: "true", Description: "Set vim.g.terminal_color_0-15 so :terminal matches the theme", Required: false},
 	}
 }
 
@@ -149,7 +153,9 @@ func (p *Plugin) generateTheme(themeData *colour.ThemeData) ([]byte, error) {
 		fmt.Fprintf(os.Stderr, "   Using custom template for theme.lua.tmpl\n")
 	}
 
-	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	// terminalColors exposes --neovim.terminal-colors to the template.
+	funcs := template.FuncMap{"terminalColors": func() bool { return p.terminalColors }}
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse theme template: %w", err)
 	}
@@ -1,6 +1,7 @@
 package neovim
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -49,3 +50,35 @@ func TestNeovimPlugin_ContentValidation(t *testing.T) {
 		}
 	}
 }
+
+// TestNeovimPlugin_TerminalColors tests the :terminal colour assignments and their flag.
+func TestNeovimPlugin_TerminalColors(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["tinct.lua"])
+	for i := range 16 {
+		assignment := fmt.Sprintf("vim.g.terminal_color_%d = colors.", i)
+		if !strings.Contains(content, assignment) {
+			t.Errorf("Generated content missing %q", assignment)
+		}
+	}
+	brightRed, _ := colour.NewPaletteHelper(palette).FindClosestANSIColor("brightred")
+	if want := "bright_red = '" + brightRed.Hex() + "'"; !strings.Contains(content, want) {
+		t.Errorf("Generated content missing ANSI colour definition %q", want)
+	}
+
+	plugin.terminalColors = false
+	files, err = plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if strings.Contains(string(files["tinct.lua"]), "vim.g.terminal_color_") {
+		t.Error("Generated content sets terminal colours with --neovim.terminal-colors=false")
+	}
+}
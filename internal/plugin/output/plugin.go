@@ -93,6 +93,7 @@ type TemplateProvider interface {
 // ExecutionContext provides context for hook execution.
 type ExecutionContext struct {
 	DryRun        bool   // Whether this is a dry-run
+	NoReload      bool   // Whether plugins should skip reloading running applications
 	Verbose       bool   // Whether verbose output is enabled
 	OutputDir     string // The output directory being used
 	WallpaperPath string // Optional path to source wallpaper (from input plugin)
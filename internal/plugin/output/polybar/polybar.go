@@ -0,0 +1,216 @@
+// Package polybar provides an output plugin for Polybar status bar colours.
+package polybar
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// colorsFileName is the file holding the [colors] section.
+const colorsFileName = "colors.ini"
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1, colour.RoleAccent2,
+	colour.RoleDanger,
+}
+
+// Plugin implements the output.Plugin interface for Polybar.
+type Plugin struct {
+	outputDir  string
+	alphaFirst bool
+	verbose    bool
+}
+
+// New creates a new Polybar output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir:  "",
+		alphaFirst: false,
+		verbose:    false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "polybar"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Polybar status bar colours (include-able [colors] section)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "polybar.output-dir", "", "Output directory (default: ~/.config/polybar)")
+	cmd.Flags().BoolVar(&p.alphaFirst, "polybar.alpha-first", false, "Write colours in Polybar's #aarrggbb format, keeping each colour's alpha")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "polybar.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/polybar)", Required: false},
+		{Name: "polybar.alpha-first", Type: "bool", Default: "false", Description: "Write colours in Polybar's #aarrggbb format, keeping each colour's alpha", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/polybar"
+	}
+	return filepath.Join(home, ".config", "polybar")
+}
+
+// Generate creates the colours file.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = colorsFileName
+
+	content, err := p.generateColors(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate colours: %w", err)
+	}
+
+	return map[string][]byte{colorsFileName: content}, nil
+}
+
+// generateColors renders the [colors] section.
+func (p *Plugin) generateColors(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("polybar", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("colors.ini.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read colours template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for colors.ini.tmpl\n")
+	}
+
+	// color exposes the colour format selected by --polybar.alpha-first to the template.
+	funcs := template.FuncMap{"color": func(cv colour.ColorValue) string {
+		if p.alphaFirst {
+			return cv.HexAlphaFirst()
+		}
+		return cv.Hex()
+	}}
+	tmpl, err := template.New("colors").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse colours template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute colours template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreExecute checks if polybar is available before generating the colours.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if polybar executable exists on PATH.
+	_, err = exec.LookPath("polybar")
+	if err != nil {
+		return true, "polybar executable not found on $PATH", nil
+	}
+
+	// Check if config directory exists, create if it doesn't.
+	configDir := p.DefaultOutputDir()
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("polybar config directory not found and could not be created: %s", configDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created polybar config directory: %s\n", configDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides instructions for including the colours.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   polybar colours written to %s\n", filepath.Join(p.DefaultOutputDir(), colorsFileName))
+	fmt.Fprintf(os.Stderr, "   Add this line to your polybar config if it is not already there:\n")
+	fmt.Fprintf(os.Stderr, "     include-file = %s\n", filepath.Join(p.DefaultOutputDir(), colorsFileName))
+	fmt.Fprintf(os.Stderr, "   Then restart polybar (polybar-msg cmd restart) to apply them.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package polybar
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// parseColors returns the key/value pairs of the [colors] section.
+func parseColors(content string) map[string]string {
+	values := make(map[string]string)
+	inColors := false
+	for line := range strings.Lines(content) {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, ";"):
+		case strings.HasPrefix(line, "["):
+			inColors = line == "[colors]"
+		case inColors:
+			if key, value, ok := strings.Cut(line, "="); ok {
+				values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return values
+}
+
+// TestPolybarPlugin runs all standard plugin tests using shared utilities.
+func TestPolybarPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "polybar",
+		ExpectedFiles:      []string{"colors.ini"},
+		ExpectedBinaryName: "polybar",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestPolybarPlugin_ContentValidation tests the colour keys and their roles.
+func TestPolybarPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	tests := []struct {
+		name       string
+		alphaFirst bool
+		format     func(colour.ColorValue) string
+	}{
+		{"Hex", false, colour.ColorValue.Hex},
+		{"AlphaFirst", true, colour.ColorValue.HexAlphaFirst},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := New()
+			plugin.alphaFirst = tt.alphaFirst
+
+			files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			values := parseColors(string(files["colors.ini"]))
+
+			expected := map[string]colour.Role{
+				"background":     colour.RoleBackground,
+				"background-alt": colour.RoleSurface,
+				"foreground":     colour.RoleForeground,
+				"foreground-alt": colour.RoleForegroundMuted,
+				"primary":        colour.RoleAccent1,
+				"secondary":      colour.RoleAccent2,
+				"alert":          colour.RoleDanger,
+			}
+			for key, role := range expected {
+				if got, want := values[key], tt.format(helper.Get(role)); got != want {
+					t.Errorf("%s = %q, want %s (%s)", key, got, want, role)
+				}
+			}
+
+			wantLen := len("#rrggbb")
+			if tt.alphaFirst {
+				wantLen = len("#aarrggbb")
+			}
+			if got := values["disabled"]; len(got) != wantLen || !strings.HasPrefix(got, "#") {
+				t.Errorf("disabled = %q, want a %d character colour", got, wantLen)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+// Package qt provides an output plugin for qt5ct/qt6ct colour schemes.
+package qt
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// schemeFileName is the colour scheme qt5ct and qt6ct list as "tinct".
+const schemeFileName = "tinct.conf"
+
+// requiredRoles are the roles the palette reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1, colour.RoleAccent2,
+}
+
+// Colour roles in QPalette::ColorRole order. Each colour list in the scheme has one
+// entry per role, in exactly this order.
+const (
+	windowText = iota
+	button
+	light
+	midlight
+	dark
+	mid
+	text
+	brightText
+	buttonText
+	base
+	window
+	shadow
+	highlight
+	highlightedText
+	link
+	linkVisited
+	alternateBase
+	noRole
+	toolTipBase
+	toolTipText
+	placeholderText
+	colorRoleCount
+)
+
+// Plugin implements the output.Plugin interface for qt5ct/qt6ct.
+type Plugin struct {
+	outputDir string
+	verbose   bool
+}
+
+// New creates a new Qt output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "qt"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Qt colour scheme for qt5ct/qt6ct (active, inactive and disabled palettes)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "qt.output-dir", "", "Output directory (default: ~/.config/qt5ct/colors, use ~/.config/qt6ct/colors for Qt 6)")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "qt.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/qt5ct/colors, use ~/.config/qt6ct/colors for Qt 6)", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/qt5ct/colors"
+	}
+	return filepath.Join(home, ".config", "qt5ct", "colors")
+}
+
+// Generate creates the Qt colour scheme.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = schemeFileName
+
+	content, err := p.generateScheme(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate colour scheme: %w", err)
+	}
+
+	return map[string][]byte{schemeFileName: content}, nil
+}
+
+// generateScheme renders the colour scheme.
+func (p *Plugin) generateScheme(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("qt", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct.conf.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read colour scheme template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.conf.tmpl\n")
+	}
+
+	funcs := template.FuncMap{"qtColors": qtColors}
+	tmpl, err := template.New("scheme").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse colour scheme template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute colour scheme template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// qtColors returns the colour list for a palette group ("active", "inactive" or
+// "disabled") as comma-separated #AARRGGBB values in QPalette::ColorRole order.
+func qtColors(themeData *colour.ThemeData, group string) (string, error) {
+	var colors [colorRoleCount]colour.ColorValue
+	switch group {
+	case "active", "inactive":
+		colors = activePalette(themeData)
+	case "disabled":
+		colors = disabledPalette(themeData)
+	default:
+		return "", fmt.Errorf("unknown Qt colour group %q (want active, inactive or disabled)", group)
+	}
+
+	values := make([]string, len(colors))
+	for i, cv := range colors {
+		values[i] = strings.ToLower(cv.HexAlphaFirst())
+	}
+	return strings.Join(values, ", "), nil
+}
+
+// activePalette maps the theme onto every Qt colour role. Window and Base follow the
+// background and surface, Highlight follows accent1, and the 3D bevel shades (Light,
+// Midlight, Mid, Dark and Shadow) are the button colour lightened or darkened.
+func activePalette(td *colour.ThemeData) [colorRoleCount]colour.ColorValue {
+	bg := td.Get(colour.RoleBackground)
+	fg := td.Get(colour.RoleForeground)
+	buttonColour := roleOr(td, colour.RoleSurfaceContainer, colour.RoleBackgroundMuted)
+	white := colour.NewColorValue(colour.RGBA{R: 255, G: 255, B: 255, A: 255}, "", -1)
+	black := colour.NewColorValue(colour.RGBA{R: 0, G: 0, B: 0, A: 255}, "", -1)
+
+	// BrightText must stand out against Dark, so use whichever of the foreground and
+	// background is lighter.
+	bright := fg
+	if td.ThemeType() == colour.ThemeLight {
+		bright = bg
+	}
+
+	var colors [colorRoleCount]colour.ColorValue
+	colors[windowText] = fg
+	colors[button] = buttonColour
+	colors[light] = buttonColour.Mix(white, 0.25)
+	colors[midlight] = buttonColour.Mix(white, 0.12)
+	colors[dark] = buttonColour.Mix(black, 0.5)
+	colors[mid] = buttonColour.Mix(black, 0.25)
+	colors[text] = fg
+	colors[brightText] = bright
+	colors[buttonText] = fg
+	colors[base] = roleOr(td, colour.RoleSurface, colour.RoleBackground)
+	colors[window] = bg
+	colors[shadow] = buttonColour.Mix(black, 0.75)
+	colors[highlight] = td.Get(colour.RoleAccent1)
+	colors[highlightedText] = roleOr(td, colour.RoleOnAccent1, colour.RoleBackground)
+	colors[link] = td.Get(colour.RoleAccent2)
+	colors[linkVisited] = roleOr(td, colour.RoleAccent3, colour.RoleAccent1)
+	colors[alternateBase] = td.Get(colour.RoleBackgroundMuted)
+	colors[noRole] = bg
+	colors[toolTipBase] = roleOr(td, colour.RoleSurfaceContainerHigh, colour.RoleBackgroundMuted)
+	colors[toolTipText] = fg
+	colors[placeholderText] = td.Get(colour.RoleForegroundMuted)
+	return colors
+}
+
+// disabledPalette is the active palette with dimmed text and a muted highlight.
+func disabledPalette(td *colour.ThemeData) [colorRoleCount]colour.ColorValue {
+	colors := activePalette(td)
+	dimmed := td.Get(colour.RoleForegroundMuted).Mix(td.Get(colour.RoleBackground), 0.4)
+	for _, role := range []int{windowText, text, buttonText, placeholderText} {
+		colors[role] = dimmed
+	}
+	colors[highlight] = td.Get(colour.RoleBackgroundMuted)
+	colors[highlightedText] = td.Get(colour.RoleForegroundMuted)
+	return colors
+}
+
+// roleOr returns role, or fallback when the palette does not have role.
+func roleOr(td *colour.ThemeData, role, fallback colour.Role) colour.ColorValue {
+	if td.Has(role) {
+		return td.Get(role)
+	}
+	return td.Get(fallback)
+}
+
+// PreExecute checks if qt5ct or qt6ct is available before generating the scheme.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	if !qtctInstalled() {
+		return true, "qt5ct or qt6ct executable not found on $PATH", nil
+	}
+
+	// Check if colors directory exists, create if it doesn't.
+	colorsDir := p.DefaultOutputDir()
+	if _, err := os.Stat(colorsDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(colorsDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("qt colors directory not found and could not be created: %s", colorsDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created qt colors directory: %s\n", colorsDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// qtctInstalled reports whether qt5ct or qt6ct is on $PATH.
+func qtctInstalled() bool {
+	for _, name := range []string{"qt5ct", "qt6ct"} {
+		if _, err := exec.LookPath(name); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// PostExecute provides instructions for selecting the colour scheme.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Qt colour scheme written to %s\n", filepath.Join(p.DefaultOutputDir(), schemeFileName))
+	fmt.Fprintf(os.Stderr, "   Select it in qt5ct (or qt6ct) under Palette > Custom > tinct, with QT_QPA_PLATFORMTHEME=qt5ct.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package qt
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// argbValue matches one #AARRGGBB entry of a colour list.
+var argbValue = regexp.MustCompile(`^#[0-9a-f]{8}$`)
+
+// parseScheme returns the colour lists of a scheme, keyed by group.
+func parseScheme(t *testing.T, content string) map[string][]string {
+	t.Helper()
+	groups := make(map[string][]string)
+	for line := range strings.Lines(content) {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || !strings.HasSuffix(key, "_colors") {
+			continue
+		}
+		groups[strings.TrimSuffix(key, "_colors")] = strings.Split(value, ", ")
+	}
+	return groups
+}
+
+// brightness returns the channel sum of an #AARRGGBB value, for ordering shades.
+func brightness(t *testing.T, argb string) int {
+	t.Helper()
+	sum := 0
+	for i := 3; i < 9; i += 2 {
+		v, err := strconv.ParseUint(argb[i:i+2], 16, 8)
+		if err != nil {
+			t.Fatalf("invalid colour %q: %v", argb, err)
+		}
+		sum += int(v)
+	}
+	return sum
+}
+
+// argb returns the #AARRGGBB form of an opaque role colour.
+func argb(helper *colour.PaletteHelper, role colour.Role) string {
+	return "#ff" + strings.TrimPrefix(helper.Get(role).Hex(), "#")
+}
+
+// TestQtPlugin runs all standard plugin tests using shared utilities.
+func TestQtPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "qt",
+		ExpectedFiles:        []string{"tinct.conf"},
+		ExpectedBinaryName:   "qt5ct",
+		ExpectedDirSubstring: "qt5ct/colors",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestQtPlugin_ContentValidation tests the colour lists and their role order.
+func TestQtPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["tinct.conf"])
+	if !strings.Contains(content, "[ColorScheme]\n") {
+		t.Error("scheme should have a [ColorScheme] section")
+	}
+
+	groups := parseScheme(t, content)
+	for _, group := range []string{"active", "inactive", "disabled"} {
+		values := groups[group]
+		if len(values) != colorRoleCount {
+			t.Fatalf("%s_colors has %d values, want %d", group, len(values), colorRoleCount)
+		}
+		for i, v := range values {
+			if !argbValue.MatchString(v) {
+				t.Errorf("%s_colors[%d] = %q, want #aarrggbb", group, i, v)
+			}
+		}
+	}
+
+	active := groups["active"]
+	expected := map[int]colour.Role{
+		windowText:    colour.RoleForeground,
+		text:          colour.RoleForeground,
+		buttonText:    colour.RoleForeground,
+		window:        colour.RoleBackground,
+		highlight:     colour.RoleAccent1,
+		link:          colour.RoleAccent2,
+		alternateBase: colour.RoleBackgroundMuted,
+	}
+	for index, role := range expected {
+		if got, want := active[index], argb(helper, role); got != want {
+			t.Errorf("active_colors[%d] = %s, want %s (%s)", index, got, want, role)
+		}
+	}
+
+	// Bevel shades run from Light down to Shadow around the button colour.
+	shades := []int{light, midlight, button, mid, dark, shadow}
+	for i := 1; i < len(shades); i++ {
+		if brightness(t, active[shades[i-1]]) <= brightness(t, active[shades[i]]) {
+			t.Errorf("active_colors[%d] should be lighter than active_colors[%d]", shades[i-1], shades[i])
+		}
+	}
+
+	if strings.Join(groups["inactive"], ",") != strings.Join(active, ",") {
+		t.Error("inactive_colors should match active_colors")
+	}
+	if groups["disabled"][text] == active[text] {
+		t.Error("disabled text should be dimmed")
+	}
+}
+
+// TestQtColorsUnknownGroup tests that a custom template asking for an unknown group fails.
+func TestQtColorsUnknownGroup(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	if _, err := qtColors(colour.NewThemeData(palette, "", ""), "normal"); err == nil {
+		t.Error("qtColors() with an unknown group should return an error")
+	}
+}
@@ -0,0 +1,202 @@
+// Package rofi provides an output plugin for Rofi launcher themes.
+package rofi
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// themeFileName is the name of the generated theme file.
+const themeFileName = "tinct.rasi"
+
+// Plugin implements the output.Plugin interface for Rofi.
+type Plugin struct {
+	outputDir    string
+	transparency bool
+	verbose      bool
+}
+
+// New creates a new Rofi output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir:    "",
+		transparency: false,
+		verbose:      false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "rofi"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Rofi launcher theme (rasi)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "rofi.output-dir", "", "Output directory (default: ~/.config/rofi)")
+	cmd.Flags().BoolVar(&p.transparency, "rofi.transparency", false, "Write the background with an alpha suffix from the background colour's alpha channel")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "rofi.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/rofi)", Required: false},
+		{Name: "rofi.transparency", Type: "bool", Default: "false", Description: "Write the background with an alpha suffix from the background colour's alpha channel", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/rofi"
+	}
+	return filepath.Join(home, ".config", "rofi")
+}
+
+// Generate creates the theme file.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = themeFileName
+
+	content, err := p.generateTheme(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate theme: %w", err)
+	}
+
+	return map[string][]byte{themeFileName: content}, nil
+}
+
+// generateTheme creates the theme file content.
+func (p *Plugin) generateTheme(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("rofi", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct.rasi.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.rasi.tmpl\n")
+	}
+
+	// transparency exposes --rofi.transparency to the template.
+	funcs := template.FuncMap{"transparency": func() bool { return p.transparency }}
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse theme template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute theme template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreExecute checks if rofi is available before generating the theme.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if rofi executable exists on PATH.
+	_, err = exec.LookPath("rofi")
+	if err != nil {
+		return true, "rofi executable not found on $PATH", nil
+	}
+
+	// Check if config directory exists, create if it doesn't.
+	configDir := p.DefaultOutputDir()
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("rofi config directory not found and could not be created: %s", configDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created rofi config directory: %s\n", configDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides usage instructions for applying the theme.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	themePath := filepath.Join(p.DefaultOutputDir(), themeFileName)
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Rofi theme generated successfully!\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   To use this theme, add to your config.rasi:\n")
+	fmt.Fprintf(os.Stderr, "   @theme \"%s\"\n", themePath)
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Or for a single run: rofi -show drun -theme %s\n", themePath)
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
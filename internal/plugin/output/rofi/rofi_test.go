@@ -0,0 +1,91 @@
+package rofi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestRofiPlugin runs all standard plugin tests using shared utilities.
+func TestRofiPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "rofi",
+		ExpectedFiles:      []string{"tinct.rasi"},
+		ExpectedBinaryName: "rofi",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestRofiPlugin_ContentValidation tests rofi-specific content requirements.
+func TestRofiPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["tinct.rasi"])
+	helper := colour.NewPaletteHelper(palette)
+
+	requiredStrings := []string{
+		"bg:       " + helper.Get(colour.RoleBackground).Hex() + ";",
+		"fg:       " + helper.Get(colour.RoleForeground).Hex() + ";",
+		"accent:   " + helper.Get(colour.RoleAccent1).Hex() + ";",
+		"urgent:   " + helper.Get(colour.RoleDanger).Hex() + ";",
+		"selected: " + helper.Get(colour.RoleAccent2).Hex() + ";",
+		"window {",
+		"listview {",
+		"element {",
+		"element selected {",
+		"Detected theme: dark",
+	}
+
+	for _, required := range requiredStrings {
+		if !strings.Contains(content, required) {
+			t.Errorf("Generated content missing required string: %s", required)
+		}
+	}
+}
+
+// TestRofiPlugin_Transparency tests the background alpha written with --rofi.transparency.
+func TestRofiPlugin_Transparency(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	bg := palette.Colours[colour.RoleBackground]
+	bg.RGBA.A = 0xcc
+	palette.Colours[colour.RoleBackground] = bg
+
+	plugin := New()
+	opaque, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.Contains(string(opaque["tinct.rasi"]), "bg:       "+bg.Hex+";") {
+		t.Error("background should not have an alpha suffix without --rofi.transparency")
+	}
+
+	plugin.transparency = true
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if want := "bg:       " + bg.Hex + "cc;"; !strings.Contains(string(files["tinct.rasi"]), want) {
+		t.Errorf("Generated content missing %q", want)
+	}
+}
+
+// TestRofiPlugin_CustomOutputDir tests custom output directory handling.
+func TestRofiPlugin_CustomOutputDir(t *testing.T) {
+	plugin := New()
+	plugin.outputDir = "/custom/path"
+
+	if dir := plugin.DefaultOutputDir(); dir != "/custom/path" {
+		t.Errorf("DefaultOutputDir() = %s, want /custom/path", dir)
+	}
+}
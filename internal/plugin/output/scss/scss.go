@@ -0,0 +1,203 @@
+// Package scss provides an output plugin for Sass variables and colour maps.
+package scss
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// Generated file names: a plain stylesheet for @import, or a partial for @use with --scss.module.
+const (
+	importFileName = "tinct.scss"
+	moduleFileName = "_tinct.scss"
+)
+
+// Plugin implements the output.Plugin interface for Sass.
+type Plugin struct {
+	outputDir string
+	module    bool
+	verbose   bool
+}
+
+// New creates a new SCSS output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		module:    false,
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "scss"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Sass variables and a $tinct-colors map for every role (@use partial with !default values via --scss.module)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "scss.output-dir", "", "Output directory (default: ~/.config/tinct)")
+	cmd.Flags().BoolVar(&p.module, "scss.module", false, "Write a _tinct.scss partial with !default variables for @use")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "scss.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/tinct)", Required: false},
+		{Name: "scss.module", Type: "bool", Default: "false", Description: "Write a _tinct.scss partial with !default variables for @use", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/tinct"
+	}
+	return filepath.Join(home, ".config", "tinct")
+}
+
+// fileName returns the file written in the selected mode.
+func (p *Plugin) fileName() string {
+	if p.module {
+		return moduleFileName
+	}
+	return importFileName
+}
+
+// Generate creates the Sass stylesheet.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if len(themeData.OrderedRoles()) == 0 {
+		return nil, fmt.Errorf("palette has no role colours")
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = p.fileName()
+
+	content, err := p.generateSCSS(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate scss: %w", err)
+	}
+
+	return map[string][]byte{p.fileName(): content}, nil
+}
+
+// generateSCSS renders the Sass variables and map.
+func (p *Plugin) generateSCSS(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("scss", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct.scss.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scss template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.scss.tmpl\n")
+	}
+
+	funcs := template.FuncMap{
+		// module exposes --scss.module to the template.
+		"module": func() bool { return p.module },
+		"scss":   scssColour,
+	}
+	tmpl, err := template.New("scss").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scss template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute scss template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// scssColour formats a colour as a Sass value: lowercase #rrggbb when opaque, rgba() otherwise.
+func scssColour(cv colour.ColorValue) string {
+	if cv.A() == 255 {
+		return cv.Hex()
+	}
+	return cv.RGBA()
+}
+
+// PostExecute provides instructions for loading the stylesheet.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Sass colours written to %s\n", filepath.Join(p.DefaultOutputDir(), p.fileName()))
+	if p.module {
+		fmt.Fprintf(os.Stderr, "   Load them with @use \"tinct\"; add %s to your Sass load paths.\n", p.DefaultOutputDir())
+	} else {
+		fmt.Fprintf(os.Stderr, "   Load them with @import, or use --scss.module for a @use partial.\n")
+	}
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
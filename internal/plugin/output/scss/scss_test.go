@@ -0,0 +1,130 @@
+package scss
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestSCSSPlugin runs all standard plugin tests using shared utilities.
+func TestSCSSPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "scss",
+		ExpectedFiles:        []string{"tinct.scss"},
+		ExpectedDirSubstring: ".config/tinct",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// variable matches a top-level Sass variable and captures its name, value and !default flag.
+var variable = regexp.MustCompile(`^(\$[\w-]+): ([^;(]+?)( !default)?;$`)
+
+// mapEntry matches an entry of the $tinct-colors map and captures its key and value.
+var mapEntry = regexp.MustCompile(`^  "([\w-]+)": (\$[\w-]+),$`)
+
+// parseSCSS returns the variables and $tinct-colors entries in content, and whether
+// every variable and the map are declared !default.
+func parseSCSS(t *testing.T, content string) (vars, entries map[string]string, allDefault bool) {
+	t.Helper()
+	vars = make(map[string]string)
+	entries = make(map[string]string)
+	allDefault = true
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\n")
+		if m := variable.FindStringSubmatch(line); m != nil {
+			vars[m[1]] = m[2]
+			allDefault = allDefault && m[3] != ""
+		}
+		if m := mapEntry.FindStringSubmatch(line); m != nil {
+			entries[m[1]] = m[2]
+		}
+		if strings.HasPrefix(line, ")") {
+			allDefault = allDefault && line == ") !default;"
+		}
+	}
+	return vars, entries, allDefault
+}
+
+// TestSCSSPlugin_ContentValidation tests the variables, the map and the theme type.
+func TestSCSSPlugin_ContentValidation(t *testing.T) {
+	for _, themeType := range []colour.ThemeType{colour.ThemeDark, colour.ThemeLight} {
+		t.Run(themeType.String(), func(t *testing.T) {
+			palette := plugintesting.CreateTestPalette(themeType)
+			helper := colour.NewPaletteHelper(palette)
+
+			files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			content := string(files["tinct.scss"])
+			vars, entries, _ := parseSCSS(t, content)
+
+			if vars["$tinct-theme"] != themeType.String() {
+				t.Errorf("$tinct-theme = %q, want %s", vars["$tinct-theme"], themeType)
+			}
+			if !strings.Contains(content, "\n$tinct-colors: (\n") {
+				t.Error("missing $tinct-colors map")
+			}
+			if strings.Contains(content, "!default") {
+				t.Error("!default should only be written with --scss.module")
+			}
+
+			for role := range palette.Colours {
+				name := common.KebabCase(string(role))
+				cv := helper.Get(role)
+				if cv.A() == 255 {
+					if want := strings.ToLower(cv.Hex()); vars["$tinct-"+name] != want {
+						t.Errorf("$tinct-%s = %q, want %s", name, vars["$tinct-"+name], want)
+					}
+				}
+				if entries[name] != "$tinct-"+name {
+					t.Errorf("$tinct-colors %q = %q, want $tinct-%s", name, entries[name], name)
+				}
+			}
+			if len(entries) != len(palette.Colours) {
+				t.Errorf("$tinct-colors has %d entries, want %d", len(entries), len(palette.Colours))
+			}
+		})
+	}
+}
+
+// TestSCSSPlugin_Module tests that --scss.module writes a partial with !default values.
+func TestSCSSPlugin_Module(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+
+	plugin := New()
+	plugin.module = true
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := files["tinct.scss"]; ok {
+		t.Error("module mode should write the _tinct.scss partial only")
+	}
+
+	vars, entries, allDefault := parseSCSS(t, string(files["_tinct.scss"]))
+	if !allDefault {
+		t.Error("every variable and the map should be !default")
+	}
+	if vars["$tinct-theme"] != "dark" || len(entries) != len(palette.Colours) {
+		t.Errorf("module content differs from the import stylesheet: theme %q, %d map entries", vars["$tinct-theme"], len(entries))
+	}
+}
+
+// TestScssColour tests translucent colours are written as rgba().
+func TestScssColour(t *testing.T) {
+	opaque := colour.NewColorValue(colour.RGBA{R: 0xAB, G: 0xCD, B: 0xEF, A: 255}, colour.RoleAccent1, 0)
+	if got := scssColour(opaque); got != "#abcdef" {
+		t.Errorf("scssColour(opaque) = %s, want #abcdef", got)
+	}
+	if got := scssColour(opaque.WithAlpha(0.5)); got != "rgba(171, 205, 239, 0.50)" {
+		t.Errorf("scssColour(translucent) = %s, want rgba(171, 205, 239, 0.50)", got)
+	}
+}
@@ -0,0 +1,295 @@
+// Package starship provides an output plugin for Starship prompt colour palettes.
+package starship
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+const (
+	// paletteName is the name of the generated Starship palette.
+	paletteName = "tinct"
+
+	// configFileName is Starship's main config, updated in place by default.
+	configFileName = "starship.toml"
+
+	// paletteFileName is the standalone fragment written with --starship.palette-only.
+	paletteFileName = "tinct-palette.toml"
+)
+
+var (
+	// paletteKeyPattern matches a top-level palette selection.
+	paletteKeyPattern = regexp.MustCompile(`^\s*palette\s*=`)
+
+	// tableHeaderPattern matches any TOML table or array-of-tables header.
+	tableHeaderPattern = regexp.MustCompile(`^\s*\[`)
+
+	// tinctTablePattern matches the header of the generated palette table.
+	tinctTablePattern = regexp.MustCompile(`^\s*\[\s*palettes\.` + paletteName + `\s*\]`)
+)
+
+// Plugin implements the output.Plugin interface for Starship.
+type Plugin struct {
+	outputDir   string
+	paletteOnly bool
+	verbose     bool
+}
+
+// New creates a new Starship output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir:   "",
+		paletteOnly: false,
+		verbose:     false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "starship"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Starship prompt colour palette"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "starship.output-dir", "", "Output directory (default: ~/.config)")
+	cmd.Flags().BoolVar(&p.paletteOnly, "starship.palette-only", false, "Write only the [palettes.tinct] table to tinct-palette.toml instead of updating starship.toml")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "starship.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config)", Required: false},
+		{Name: "starship.palette-only", Type: "bool", Default: "false", Description: "Write only the [palettes.tinct] table to tinct-palette.toml instead of updating starship.toml", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config"
+	}
+	return filepath.Join(home, ".config")
+}
+
+// Generate creates the palette file.
+// By default the existing starship.toml is updated in place: the palette selection
+// and the managed [palettes.tinct] block are replaced and the rest of the prompt
+// layout is kept.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ThemeName = paletteName
+
+	fragment, err := p.generatePalette(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate palette: %w", err)
+	}
+
+	if p.paletteOnly {
+		themeData.ColorFileName = paletteFileName
+		return map[string][]byte{paletteFileName: fragment}, nil
+	}
+
+	themeData.ColorFileName = configFileName
+	configPath := filepath.Join(p.DefaultOutputDir(), configFileName)
+	existing, err := os.ReadFile(configPath) // #nosec G304 - Reading the user's own Starship config
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	return map[string][]byte{configFileName: []byte(mergeConfig(string(existing), string(fragment)))}, nil
+}
+
+// generatePalette renders the [palettes.tinct] table.
+func (p *Plugin) generatePalette(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("starship", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("palette.toml.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read palette template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for palette.toml.tmpl\n")
+	}
+
+	tmpl, err := template.New("palette").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse palette template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute palette template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mergeConfig returns config with palette = "tinct" selected at the top and fragment
+// in a Tinct managed block, which replaces the previous block in place. Any other
+// [palettes.tinct] table is removed, as TOML does not allow it twice.
+func mergeConfig(config, fragment string) string {
+	var lines []string
+	inTopLevel := true
+	inBlock := false
+	skipping := false
+
+	for line := range strings.Lines(config) {
+		line = strings.TrimRight(line, "\r\n")
+
+		// Keep the managed block as is; ReplaceManagedBlock rewrites its contents.
+		switch strings.TrimSpace(line) {
+		case common.ManagedBlockBegin:
+			inBlock = true
+		case common.ManagedBlockEnd:
+			// The block holds a table, so what follows is not top level.
+			inBlock = false
+			inTopLevel = false
+			lines = append(lines, line)
+			continue
+		}
+		if inBlock {
+			lines = append(lines, line)
+			continue
+		}
+
+		if tableHeaderPattern.MatchString(line) {
+			inTopLevel = false
+			skipping = tinctTablePattern.MatchString(line)
+		}
+		if skipping || (inTopLevel && paletteKeyPattern.MatchString(line)) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	config = fmt.Sprintf("palette = %q\n", paletteName)
+	if lines = trimBlankLines(lines); len(lines) > 0 {
+		config += "\n" + strings.Join(lines, "\n") + "\n"
+	}
+	return common.ReplaceManagedBlock(config, fragment)
+}
+
+// trimBlankLines removes leading and trailing blank lines.
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// PreExecute checks if starship is available before generating the palette.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if starship executable exists on PATH.
+	_, err = exec.LookPath("starship")
+	if err != nil {
+		return true, "starship executable not found on $PATH", nil
+	}
+
+	// Check if config directory exists, create if it doesn't.
+	configDir := p.DefaultOutputDir()
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("starship config directory not found and could not be created: %s", configDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created starship config directory: %s\n", configDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides usage instructions for the generated palette.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	if p.paletteOnly {
+		fmt.Fprintf(os.Stderr, "   Starship palette written to %s\n", filepath.Join(p.DefaultOutputDir(), paletteFileName))
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "   Copy or include the [palettes.%s] table in your starship.toml and select it with:\n", paletteName)
+		fmt.Fprintf(os.Stderr, "   palette = \"%s\"\n", paletteName)
+	} else {
+		fmt.Fprintf(os.Stderr, "   Starship palette \"%s\" updated in %s\n", paletteName, filepath.Join(p.DefaultOutputDir(), configFileName))
+	}
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Use the palette colours in modules, e.g. style = \"bold accent1\"\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
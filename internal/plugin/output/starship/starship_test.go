@@ -0,0 +1,168 @@
+package starship
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestStarshipPlugin runs all standard plugin tests using shared utilities.
+func TestStarshipPlugin(t *testing.T) {
+	plugin := New()
+	plugin.outputDir = filepath.Join(t.TempDir(), "starship")
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "starship",
+		ExpectedFiles:      []string{"starship.toml"},
+		ExpectedBinaryName: "starship",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestStarshipPlugin_ContentValidation tests the generated palette entries.
+func TestStarshipPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.outputDir = t.TempDir()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["starship.toml"])
+	if !strings.HasPrefix(content, "palette = \"tinct\"\n") {
+		t.Errorf("starship.toml should select the palette first, got:\n%s", content)
+	}
+
+	helper := colour.NewPaletteHelper(palette)
+	roles := []colour.Role{
+		colour.RoleBackground, colour.RoleForeground,
+		colour.RoleAccent1, colour.RoleAccent2, colour.RoleAccent3, colour.RoleAccent4,
+		colour.RoleDanger, colour.RoleWarning, colour.RoleSuccess, colour.RoleInfo,
+	}
+	for _, role := range roles {
+		want := string(role) + ` = "` + helper.Get(role).Hex() + `"`
+		if !strings.Contains(content, want) {
+			t.Errorf("Generated content missing %q", want)
+		}
+	}
+}
+
+// TestStarshipPlugin_PreservesConfig tests that updating starship.toml keeps the prompt layout.
+func TestStarshipPlugin_PreservesConfig(t *testing.T) {
+	dir := t.TempDir()
+	existing := `"$schema" = 'https://starship.rs/config-schema.json'
+palette = "catppuccin"
+add_newline = false
+
+[character]
+success_symbol = "[>](bold green)"
+
+# My prompt colours, kept by Tinct
+[palettes.tinct]
+background = "#000000"
+
+[directory]
+style = "bold accent1"
+`
+	if err := os.WriteFile(filepath.Join(dir, "starship.toml"), []byte(existing), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	plugin := New()
+	plugin.outputDir = dir
+	files, err := plugin.Generate(colour.NewThemeData(plugintesting.CreateTestPalette(colour.ThemeDark), "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["starship.toml"])
+
+	for _, want := range []string{
+		"add_newline = false",
+		"[character]\nsuccess_symbol = \"[>](bold green)\"",
+		"[directory]\nstyle = \"bold accent1\"",
+		"# My prompt colours, kept by Tinct",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("merged config lost %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "catppuccin") || strings.Contains(content, `background = "#000000"`) {
+		t.Errorf("merged config kept the old palette:\n%s", content)
+	}
+	if n := strings.Count(content, "[palettes.tinct]"); n != 1 {
+		t.Errorf("merged config has %d [palettes.tinct] tables, want 1", n)
+	}
+	if n := strings.Count(content, "palette = "); n != 1 {
+		t.Errorf("merged config has %d palette selections, want 1", n)
+	}
+
+	// Regenerating from the merged output is stable.
+	files, err = plugin.Generate(colour.NewThemeData(plugintesting.CreateTestPalette(colour.ThemeDark), "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got := string(files["starship.toml"]); got != content {
+		t.Errorf("Generate() is not idempotent:\n%s", got)
+	}
+}
+
+// TestMergeConfig_Idempotent tests that merging repeatedly gives the same config,
+// including a fresh config where the palette is the first table.
+func TestMergeConfig_Idempotent(t *testing.T) {
+	themeData := colour.NewThemeData(plugintesting.CreateTestPalette(colour.ThemeDark), "", "")
+	themeData.ThemeName = paletteName
+	fragment, err := New().generatePalette(themeData)
+	if err != nil {
+		t.Fatalf("generatePalette() error = %v", err)
+	}
+
+	for name, config := range map[string]string{
+		"empty":         "",
+		"palette first": "add_newline = false\n\n[palettes.tinct]\nbackground = \"#000000\"\n\n[character]\nsuccess_symbol = \"[>](bold green)\"\n",
+		"user config":   "format = \"$all\"\n\n[directory]\nstyle = \"bold accent1\"\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			once := mergeConfig(config, string(fragment))
+			twice := mergeConfig(once, string(fragment))
+			if twice != once {
+				t.Errorf("second merge changed the config:\nfirst:\n%s\nsecond:\n%s", once, twice)
+			}
+			if n := strings.Count(twice, "# Starship palette generated by Tinct"); n != 1 {
+				t.Errorf("merged config has %d generated headers, want 1:\n%s", n, twice)
+			}
+			if n := strings.Count(twice, "[palettes.tinct]"); n != 1 {
+				t.Errorf("merged config has %d [palettes.tinct] tables, want 1:\n%s", n, twice)
+			}
+		})
+	}
+}
+
+// TestStarshipPlugin_PaletteOnly tests writing only the palette fragment.
+func TestStarshipPlugin_PaletteOnly(t *testing.T) {
+	plugin := New()
+	plugin.outputDir = t.TempDir()
+	plugin.paletteOnly = true
+
+	files, err := plugin.Generate(colour.NewThemeData(plugintesting.CreateTestPalette(colour.ThemeDark), "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if _, ok := files["starship.toml"]; ok {
+		t.Error("palette-only mode must not write starship.toml")
+	}
+	content := string(files["tinct-palette.toml"])
+	if !strings.Contains(content, "[palettes.tinct]") {
+		t.Errorf("palette fragment missing table header:\n%s", content)
+	}
+	if strings.Contains(content, "palette = ") {
+		t.Error("palette fragment must not select the palette")
+	}
+}
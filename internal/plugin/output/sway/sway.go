@@ -0,0 +1,222 @@
+// Package sway provides an output plugin for sway and i3 window colours.
+package sway
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// colorsFileName is the file holding the client colour lines.
+const colorsFileName = "tinct-colors"
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1, colour.RoleAccent2,
+	colour.RoleDanger,
+}
+
+// Plugin implements the output.Plugin interface for sway and i3.
+type Plugin struct {
+	outputDir string
+	i3        bool
+	verbose   bool
+}
+
+// New creates a new sway output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		i3:        false,
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "sway"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "sway/i3 window border colours (client.* lines for include)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "sway.output-dir", "", "Output directory (default: ~/.config/sway, or ~/.config/i3 with --sway.i3)")
+	cmd.Flags().BoolVar(&p.i3, "sway.i3", false, "Write the colours for i3 instead of sway")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "sway.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/sway, or ~/.config/i3 with --sway.i3)", Required: false},
+		{Name: "sway.i3", Type: "bool", Default: "false", Description: "Write the colours for i3 instead of sway", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// windowManager returns the binary and config directory name of the target window manager.
+func (p *Plugin) windowManager() string {
+	if p.i3 {
+		return "i3"
+	}
+	return "sway"
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".config", p.windowManager())
+	}
+	return filepath.Join(home, ".config", p.windowManager())
+}
+
+// Generate creates the client colour lines.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = colorsFileName
+
+	content, err := p.generateColors(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate colours: %w", err)
+	}
+
+	return map[string][]byte{colorsFileName: content}, nil
+}
+
+// generateColors renders the client colour lines.
+func (p *Plugin) generateColors(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("sway", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct-colors.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read colours template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct-colors.tmpl\n")
+	}
+
+	// wmName exposes the target window manager (sway or i3) to the template.
+	funcs := template.FuncMap{"wmName": p.windowManager}
+	tmpl, err := template.New("colors").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse colours template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute colours template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreExecute checks if sway (or i3) is available before generating the colours.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	wm := p.windowManager()
+
+	// Check if the window manager executable exists on PATH.
+	_, err = exec.LookPath(wm)
+	if err != nil {
+		return true, fmt.Sprintf("%s executable not found on $PATH", wm), nil
+	}
+
+	// Check if config directory exists, create if it doesn't.
+	configDir := p.DefaultOutputDir()
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("%s config directory not found and could not be created: %s", wm, configDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created %s config directory: %s\n", wm, configDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides instructions for including the colours.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	wm := p.windowManager()
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   %s colours written to %s\n", wm, filepath.Join(p.DefaultOutputDir(), colorsFileName))
+	fmt.Fprintf(os.Stderr, "   Add this line to your %s config if it is not already there:\n", wm)
+	fmt.Fprintf(os.Stderr, "     include %s\n", filepath.Join(p.DefaultOutputDir(), colorsFileName))
+	fmt.Fprintf(os.Stderr, "   Then reload %s to apply them.\n", wm)
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
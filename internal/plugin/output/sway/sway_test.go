@@ -0,0 +1,105 @@
+package sway
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// hexColour matches a #rrggbb colour.
+var hexColour = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// parseClients returns the colours of each client.* line.
+func parseClients(t *testing.T, content string) map[string][]string {
+	t.Helper()
+	clients := make(map[string][]string)
+	for line := range strings.Lines(content) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "client.") {
+			continue
+		}
+		for _, field := range fields[1:] {
+			if !hexColour.MatchString(field) {
+				t.Errorf("%s: %q is not a #rrggbb colour", fields[0], field)
+			}
+		}
+		clients[fields[0]] = fields[1:]
+	}
+	return clients
+}
+
+// TestSwayPlugin runs all standard plugin tests using shared utilities.
+func TestSwayPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "sway",
+		ExpectedFiles:      []string{"tinct-colors"},
+		ExpectedBinaryName: "sway",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestSwayPlugin_ContentValidation tests each client class gets five colours from the expected roles.
+func TestSwayPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	clients := parseClients(t, string(files["tinct-colors"]))
+
+	// border, background, text, indicator, child_border.
+	expected := map[string][]colour.Role{
+		"client.focused":          {colour.RoleAccent1, colour.RoleAccent1, colour.RoleOnAccent1, colour.RoleAccent2, colour.RoleAccent1},
+		"client.focused_inactive": {colour.RoleSurface, colour.RoleSurface, colour.RoleForeground, colour.RoleSurface, colour.RoleSurface},
+		"client.unfocused":        {colour.RoleBackground, colour.RoleBackground, colour.RoleForegroundMuted, colour.RoleBackground, colour.RoleBackground},
+		"client.urgent":           {colour.RoleDanger, colour.RoleDanger, colour.RoleOnDanger, colour.RoleDanger, colour.RoleDanger},
+	}
+	for class, roles := range expected {
+		got := clients[class]
+		if len(got) != 5 {
+			t.Errorf("%s has %d colours, want 5", class, len(got))
+			continue
+		}
+		for i, role := range roles {
+			if want := helper.Get(role).Hex(); got[i] != want {
+				t.Errorf("%s colour %d = %s, want %s (%s)", class, i+1, got[i], want, role)
+			}
+		}
+	}
+
+	if got := clients["client.background"]; len(got) != 1 || got[0] != helper.Get(colour.RoleBackground).Hex() {
+		t.Errorf("client.background = %v, want %s", got, helper.Get(colour.RoleBackground).Hex())
+	}
+}
+
+// TestSwayPlugin_I3 tests the i3 compatibility flag.
+func TestSwayPlugin_I3(t *testing.T) {
+	t.Setenv("HOME", "/home/test")
+	plugin := New()
+	plugin.i3 = true
+
+	if got, want := plugin.DefaultOutputDir(), "/home/test/.config/i3"; got != want {
+		t.Errorf("DefaultOutputDir() = %s, want %s", got, want)
+	}
+
+	files, err := plugin.Generate(colour.NewThemeData(plugintesting.CreateTestPalette(colour.ThemeDark), "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["tinct-colors"])
+	if !strings.Contains(content, "include /home/test/.config/i3/tinct-colors") {
+		t.Errorf("i3 colours should reference the i3 config, got:\n%s", content)
+	}
+	if len(parseClients(t, content)) != 5 {
+		t.Error("i3 colours should contain the same client lines as sway")
+	}
+}
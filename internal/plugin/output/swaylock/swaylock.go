@@ -0,0 +1,268 @@
+// Package swaylock provides an output plugin for swaylock screen locker colours.
+package swaylock
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// configFileName is the swaylock config file the colours are written to.
+const configFileName = "config"
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted, colour.RoleForeground,
+	colour.RoleAccent1, colour.RoleAccent2,
+	colour.RoleDanger, colour.RoleWarning, colour.RoleSuccess,
+}
+
+// Plugin implements the output.Plugin interface for swaylock.
+type Plugin struct {
+	outputDir string
+	verbose   bool
+}
+
+// New creates a new swaylock output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "swaylock"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "swaylock screen locker colours"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "swaylock.output-dir", "", "Output directory (default: ~/.config/swaylock)")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "swaylock.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/swaylock)", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/swaylock"
+	}
+	return filepath.Join(home, ".config", "swaylock")
+}
+
+// Generate creates the swaylock config.
+// swaylock has no include directive, so the colours are merged into the existing
+// config: colour options are replaced and all other options are kept.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = configFileName
+
+	colours, err := p.generateColours(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate colours: %w", err)
+	}
+
+	configPath := filepath.Join(p.DefaultOutputDir(), configFileName)
+	existing, err := os.ReadFile(configPath) // #nosec G304 - Reading the user's own swaylock config
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	return map[string][]byte{configFileName: []byte(mergeConfig(string(existing), string(colours)))}, nil
+}
+
+// generateColours renders the colour options.
+func (p *Plugin) generateColours(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("swaylock", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("config.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for config.tmpl\n")
+	}
+
+	tmpl, err := template.New("config").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute config template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// generatedHeader starts the block of colour options written by Tinct.
+const generatedHeader = "# Swaylock colours generated by Tinct"
+
+// mergeConfig returns config with the options set in colours replaced.
+// Other options and comments are kept in order, and colours is appended. The header
+// comments of a previous Tinct block are dropped so repeated runs are idempotent.
+func mergeConfig(config, colours string) string {
+	colours = strings.TrimRight(colours, "\n") + "\n"
+
+	keys := make(map[string]bool)
+	for line := range strings.Lines(colours) {
+		if key, ok := optionKey(line); ok {
+			keys[key] = true
+		}
+	}
+
+	var b strings.Builder
+	inHeader := false
+	for line := range strings.Lines(config) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == generatedHeader {
+			inHeader = true
+			continue
+		}
+		if inHeader && strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		inHeader = false
+
+		if key, ok := optionKey(line); ok && keys[key] {
+			continue
+		}
+		// Collapse the blank lines left behind by removed options.
+		if trimmed == "" && (b.Len() == 0 || strings.HasSuffix(b.String(), "\n\n")) {
+			continue
+		}
+		b.WriteString(line)
+	}
+
+	kept := strings.TrimRight(b.String(), "\n")
+	if kept == "" {
+		return colours
+	}
+	return kept + "\n\n" + colours
+}
+
+// optionKey returns the option name of a "key=value" or "key" config line.
+// Comments and blank lines return false.
+func optionKey(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", false
+	}
+	key, _, _ := strings.Cut(line, "=")
+	return strings.TrimSpace(key), true
+}
+
+// PreExecute checks if swaylock is available before generating the config.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if swaylock executable exists on PATH.
+	_, err = exec.LookPath("swaylock")
+	if err != nil {
+		return true, "swaylock executable not found on $PATH", nil
+	}
+
+	// Check if config directory exists, create if it doesn't.
+	configDir := p.DefaultOutputDir()
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("swaylock config directory not found and could not be created: %s", configDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created swaylock config directory: %s\n", configDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides usage instructions for the generated config.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   swaylock colours written to %s\n", filepath.Join(p.DefaultOutputDir(), configFileName))
+	fmt.Fprintf(os.Stderr, "   They apply the next time swaylock starts.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
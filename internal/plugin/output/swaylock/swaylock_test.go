@@ -0,0 +1,126 @@
+package swaylock
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// parseOptions returns the key=value options of a swaylock config.
+func parseOptions(content string) map[string]string {
+	values := make(map[string]string)
+	for line := range strings.Lines(content) {
+		if key, ok := optionKey(line); ok {
+			_, value, _ := strings.Cut(strings.TrimSpace(line), "=")
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// TestSwaylockPlugin runs all standard plugin tests using shared utilities.
+func TestSwaylockPlugin(t *testing.T) {
+	// Generate reads the existing config, so keep the user's own out of the test.
+	t.Setenv("HOME", t.TempDir())
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "swaylock",
+		ExpectedFiles:      []string{"config"},
+		ExpectedBinaryName: "swaylock",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestSwaylockPlugin_ContentValidation tests the colour options and their roles.
+func TestSwaylockPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.outputDir = t.TempDir()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["config"])
+	if strings.Contains(content, "=#") {
+		t.Error("swaylock colours must not have a '#' prefix")
+	}
+
+	values := parseOptions(content)
+	helper := colour.NewPaletteHelper(palette)
+
+	expected := map[string]colour.Role{
+		"color":            colour.RoleBackground,
+		"inside-color":     colour.RoleBackground,
+		"ring-color":       colour.RoleBackgroundMuted,
+		"line-color":       colour.RoleBackground,
+		"text-color":       colour.RoleForeground,
+		"key-hl-color":     colour.RoleAccent1,
+		"bs-hl-color":      colour.RoleDanger,
+		"ring-ver-color":   colour.RoleSuccess,
+		"text-ver-color":   colour.RoleSuccess,
+		"ring-wrong-color": colour.RoleDanger,
+		"text-wrong-color": colour.RoleDanger,
+		"ring-clear-color": colour.RoleWarning,
+		"text-clear-color": colour.RoleWarning,
+	}
+	for key, role := range expected {
+		if got, want := values[key], helper.Get(role).HexNoHash(); got != want {
+			t.Errorf("%s = %q, want %q (%s)", key, got, want, role)
+		}
+	}
+}
+
+// TestSwaylockPlugin_PreservesExistingOptions verifies non-colour options survive regeneration.
+func TestSwaylockPlugin_PreservesExistingOptions(t *testing.T) {
+	dir := t.TempDir()
+	existing := "# my lock screen\nimage=~/wall.png\nshow-failed-attempts\nring-color=ff0000\nindicator-radius=100\n"
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte(existing), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.outputDir = dir
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	first := string(files["config"])
+
+	for _, want := range []string{"# my lock screen\n", "image=~/wall.png\n", "show-failed-attempts\n", "indicator-radius=100\n"} {
+		if !strings.Contains(first, want) {
+			t.Errorf("config lost existing line %q", want)
+		}
+	}
+	if strings.Contains(first, "ring-color=ff0000") {
+		t.Error("existing ring-color was not replaced")
+	}
+	if n := strings.Count(first, "\nring-color="); n != 1 {
+		t.Errorf("config has %d ring-color options, want 1", n)
+	}
+
+	// Regenerating from the merged config must not change it.
+	second := mergeConfig(first, string(mustColours(t, plugin, palette)))
+	if second != first {
+		t.Errorf("mergeConfig is not idempotent:\n--- first ---\n%s\n--- second ---\n%s", first, second)
+	}
+}
+
+// mustColours renders the colour options for palette.
+func mustColours(t *testing.T, plugin *Plugin, palette *colour.CategorisedPalette) []byte {
+	t.Helper()
+	colours, err := plugin.generateColours(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("generateColours() error = %v", err)
+	}
+	return colours
+}
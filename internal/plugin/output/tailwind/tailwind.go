@@ -0,0 +1,234 @@
+// Package tailwind provides an output plugin for Tailwind CSS colour configuration.
+package tailwind
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// colorsFileName is the generated module exporting the colors object.
+const colorsFileName = "tinct-colors.js"
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleForeground,
+	colour.RoleAccent1, colour.RoleAccent2,
+	colour.RoleDanger, colour.RoleWarning, colour.RoleSuccess, colour.RoleInfo,
+	colour.RoleSurface,
+}
+
+// shadeSteps are Tailwind's shade names, lightest to darkest. The base colour is 500.
+var shadeSteps = []int{50, 100, 200, 300, 400, 500, 600, 700, 800, 900, 950}
+
+// Lightness of the 50 and 950 shades. Shades in between are interpolated linearly
+// from the base colour's lightness towards these ends.
+const (
+	lightestShade = 0.97
+	darkestShade  = 0.10
+)
+
+// Plugin implements the output.Plugin interface for Tailwind CSS.
+type Plugin struct {
+	outputDir string
+	scale     bool
+	verbose   bool
+}
+
+// New creates a new Tailwind output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		scale:     false,
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "tailwind"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Tailwind CSS colors module (primary, secondary, semantic and surface colours, optional 50-950 shades)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "tailwind.output-dir", "", "Output directory (default: ~/.config/tinct)")
+	cmd.Flags().BoolVar(&p.scale, "tailwind.scale", false, "Generate 50-950 shades for each base colour")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "tailwind.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/tinct)", Required: false},
+		{Name: "tailwind.scale", Type: "bool", Default: "false", Description: "Generate 50-950 shades for each base colour", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/tinct"
+	}
+	return filepath.Join(home, ".config", "tinct")
+}
+
+// Generate creates the Tailwind colors module.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = colorsFileName
+
+	content, err := p.generateColors(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate colors: %w", err)
+	}
+
+	return map[string][]byte{colorsFileName: content}, nil
+}
+
+// generateColors renders the colors module.
+func (p *Plugin) generateColors(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("tailwind", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct-colors.js.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read colors template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct-colors.js.tmpl\n")
+	}
+
+	funcs := template.FuncMap{"shade": p.shadeFunc}
+	tmpl, err := template.New("colors").Funcs(common.TemplateFuncs()).Funcs(funcs).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse colors template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute colors template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// shade is one step of a Tailwind shade scale.
+type shade struct {
+	Step   int
+	Colour colour.ColorValue
+}
+
+// baseColour is a role colour and, with --tailwind.scale, its shades.
+type baseColour struct {
+	Colour colour.ColorValue
+	Shades []shade
+}
+
+// shadeFunc returns the colour for role, with shades when --tailwind.scale is set.
+func (p *Plugin) shadeFunc(themeData *colour.ThemeData, role string) baseColour {
+	cv := themeData.Get(colour.Role(role))
+	if !p.scale {
+		return baseColour{Colour: cv}
+	}
+	return baseColour{Colour: cv, Shades: shadeScale(cv)}
+}
+
+// shadeScale returns Tailwind's 50-950 shades for cv. The base colour is used as-is
+// for 500; other shades keep its hue and saturation and interpolate HSL lightness
+// towards lightestShade (50) or darkestShade (950).
+func shadeScale(cv colour.ColorValue) []shade {
+	_, _, base := cv.HSL()
+	shades := make([]shade, 0, len(shadeSteps))
+	for _, step := range shadeSteps {
+		c := cv
+		switch {
+		case step < 500:
+			c = cv.WithLightness(base + (lightestShade-base)*float64(500-step)/450)
+		case step > 500:
+			c = cv.WithLightness(base - (base-darkestShade)*float64(step-500)/450)
+		}
+		shades = append(shades, shade{Step: step, Colour: c})
+	}
+	return shades
+}
+
+// PostExecute provides instructions for using the colors in a Tailwind config.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Tailwind colours written to %s\n", filepath.Join(p.DefaultOutputDir(), colorsFileName))
+	fmt.Fprintf(os.Stderr, "   Spread them into theme.extend.colors in tailwind.config.js (see the file header).\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package tailwind
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestTailwindPlugin runs all standard plugin tests using shared utilities.
+func TestTailwindPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "tailwind",
+		ExpectedFiles:        []string{"tinct-colors.js"},
+		ExpectedDirSubstring: ".config/tinct",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestTailwindPlugin_ContentValidation tests the role mapping without shades.
+func TestTailwindPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["tinct-colors.js"])
+
+	expected := map[string]colour.Role{
+		"background": colour.RoleBackground,
+		"foreground": colour.RoleForeground,
+		"primary":    colour.RoleAccent1,
+		"secondary":  colour.RoleAccent2,
+		"danger":     colour.RoleDanger,
+		"warning":    colour.RoleWarning,
+		"success":    colour.RoleSuccess,
+		"info":       colour.RoleInfo,
+		"lowest":     colour.RoleSurfaceContainerLowest,
+		"highest":    colour.RoleSurfaceContainerHighest,
+	}
+	for key, role := range expected {
+		want := key + `: "` + helper.Get(role).Hex() + `",`
+		if !strings.Contains(content, want) {
+			t.Errorf("missing %s", want)
+		}
+	}
+	if want := `surface: {` + "\n" + `    DEFAULT: "` + helper.Get(colour.RoleSurface).Hex() + `",`; !strings.Contains(content, want) {
+		t.Errorf("missing surface scale, want %s", want)
+	}
+	if strings.Contains(content, "500:") {
+		t.Error("shades should only be written with --tailwind.scale")
+	}
+	if !strings.Contains(content, "module.exports = { colors };") {
+		t.Error("missing module.exports")
+	}
+}
+
+// shadeLine matches a shade entry and captures its step and colour.
+var shadeLine = regexp.MustCompile(`^\s+(\d+): "(#[0-9a-f]{6})",$`)
+
+// TestTailwindPlugin_Scale tests that --tailwind.scale writes monotonic 50-950 shades
+// with the base colour at 500.
+func TestTailwindPlugin_Scale(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	plugin := New()
+	plugin.scale = true
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// Collect the shades of each scaled colour in file order.
+	scales := make(map[string][]string)
+	var current string
+	for line := range strings.Lines(string(files["tinct-colors.js"])) {
+		line = strings.TrimRight(line, "\n")
+		if name, ok := strings.CutSuffix(strings.TrimSpace(line), ": {"); ok {
+			current = name
+			continue
+		}
+		if m := shadeLine.FindStringSubmatch(line); m != nil {
+			scales[current] = append(scales[current], m[1]+"="+m[2])
+		}
+	}
+
+	for name, role := range map[string]colour.Role{
+		"primary":   colour.RoleAccent1,
+		"secondary": colour.RoleAccent2,
+		"danger":    colour.RoleDanger,
+		"warning":   colour.RoleWarning,
+		"success":   colour.RoleSuccess,
+		"info":      colour.RoleInfo,
+	} {
+		shades := scales[name]
+		if len(shades) != len(shadeSteps) {
+			t.Errorf("%s has %d shades, want %d", name, len(shades), len(shadeSteps))
+			continue
+		}
+		if want := "500=" + helper.Get(role).Hex(); shades[5] != want {
+			t.Errorf("%s shade %s, want %s", name, shades[5], want)
+		}
+	}
+	if _, ok := scales["surface"]; ok {
+		t.Error("surface should use named elevation steps, not shades")
+	}
+}
+
+// TestShadeScale tests that shades get darker from 50 to 950 and keep the hue.
+func TestShadeScale(t *testing.T) {
+	blue := colour.NewColorValue(colour.RGBA{R: 51, G: 102, B: 255, A: 255}, colour.RoleAccent1, 0)
+	shades := shadeScale(blue)
+
+	if len(shades) != 11 || shades[0].Step != 50 || shades[10].Step != 950 {
+		t.Fatalf("shadeScale() steps = %v, want 50-950", shades)
+	}
+	if shades[5].Colour.Hex() != blue.Hex() {
+		t.Errorf("500 = %s, want base colour %s", shades[5].Colour.Hex(), blue.Hex())
+	}
+
+	prev := 1.1
+	for _, s := range shades {
+		h, _, l := s.Colour.HSL()
+		if l >= prev {
+			t.Errorf("shade %d lightness %.3f is not darker than the previous shade (%.3f)", s.Step, l, prev)
+		}
+		if h < 220 || h > 230 {
+			t.Errorf("shade %d hue = %.1f, want about 225", s.Step, h)
+		}
+		prev = l
+	}
+	if _, _, l := shades[0].Colour.HSL(); l < 0.95 {
+		t.Errorf("50 lightness = %.3f, want about %.2f", l, lightestShade)
+	}
+	if _, _, l := shades[10].Colour.HSL(); l > 0.12 {
+		t.Errorf("950 lightness = %.3f, want about %.2f", l, darkestShade)
+	}
+}
@@ -2,6 +2,7 @@
 package testing
 
 import (
+	"bytes"
 	"context"
 	"image/color"
 	"strings"
@@ -71,6 +72,24 @@ func TestGeneration(t *testing.T, p output.Plugin, expectedFiles []string) {
 		}
 	})
 
+	t.Run("GenerateIsDeterministic", func(t *testing.T) {
+		palette := CreateTestPalette(colour.ThemeDark)
+		first, err := p.Generate(colour.NewThemeData(palette, "", ""))
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		second, err := p.Generate(colour.NewThemeData(palette, "", ""))
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		for name, content := range first {
+			if !bytes.Equal(content, second[name]) {
+				t.Errorf("Generate() produced different bytes for %s across runs", name)
+			}
+		}
+	})
+
 	t.Run("GenerateNilPalette", func(t *testing.T) {
 		_, err := p.Generate(nil)
 		if err == nil {
@@ -175,6 +194,16 @@ func CreateTestPalette(themeType colour.ThemeType) *colour.CategorisedPalette {
 	return colour.Categorise(palette, config)
 }
 
+// CreateTestThemeData creates theme data for the test palette that can also be
+// categorised for another theme type, as the generate command provides.
+func CreateTestThemeData(themeType colour.ThemeType) *colour.ThemeData {
+	themeData := colour.NewThemeData(CreateTestPalette(themeType), "", "")
+	themeData.SetCategoriser(func(variant colour.ThemeType) (*colour.CategorisedPalette, error) {
+		return CreateTestPalette(variant), nil
+	})
+	return themeData
+}
+
 // RunAllTests runs all standard tests for a plugin.
 func RunAllTests(t *testing.T, p output.Plugin, config TestConfig) {
 	TestBasicInterface(t, p, config.ExpectedName, config.ExpectedDirSubstring)
@@ -0,0 +1,117 @@
+package tmux
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestTmuxPlugin runs all standard plugin tests using shared utilities.
+func TestTmuxPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "tmux",
+		ExpectedFiles:      []string{"tinct.conf"},
+		ExpectedBinaryName: "tmux",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestTmuxPlugin_ContentValidation tests tmux-specific content requirements.
+func TestTmuxPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["tinct.conf"])
+	helper := colour.NewPaletteHelper(palette)
+	bg := helper.Get(colour.RoleBackground).Hex()
+	fg := helper.Get(colour.RoleForeground).Hex()
+	accent := helper.Get(colour.RoleAccent1).Hex()
+	border := helper.Get(colour.RoleBorder).Hex()
+
+	requiredStrings := []string{
+		`set -g status-style "bg=` + bg + `,fg=` + fg + `"`,
+		`set -g window-status-current-style "bg=` + accent + `,fg=` + bg + `,bold"`,
+		`set -g pane-border-style "fg=` + border + `"`,
+		`set -g pane-active-border-style "fg=` + accent + `"`,
+		`set -g message-style "bg=` + bg + `,fg=` + accent + `"`,
+		"Status bar position: bottom",
+	}
+	for _, required := range requiredStrings {
+		if !strings.Contains(content, required) {
+			t.Errorf("Generated content missing required string: %s", required)
+		}
+	}
+
+	// Every colour must use tmux's #rrggbb format.
+	for _, match := range regexp.MustCompile(`[bf]g=([^,"]+)`).FindAllStringSubmatch(content, -1) {
+		if !regexp.MustCompile(`^#[0-9a-f]{6}$`).MatchString(match[1]) {
+			t.Errorf("colour %q is not in #rrggbb format", match[1])
+		}
+	}
+}
+
+// TestTmuxPlugin_StatusPosition tests that the status position only changes the header.
+func TestTmuxPlugin_StatusPosition(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+
+	bottom, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	plugin := New()
+	plugin.statusPosition = "top"
+	top, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	topContent := string(top["tinct.conf"])
+	if !strings.Contains(topContent, "#   set -g status-position top") {
+		t.Error("header does not mention the top status position")
+	}
+	if strings.Contains(topContent, "\nset -g status-position") {
+		t.Error("status position must only appear in the header comment")
+	}
+
+	// Outside the comment header the output is identical.
+	settings := func(content string) string {
+		var lines []string
+		for line := range strings.Lines(content) {
+			if !strings.HasPrefix(line, "#") {
+				lines = append(lines, line)
+			}
+		}
+		return strings.Join(lines, "")
+	}
+	if settings(topContent) != settings(string(bottom["tinct.conf"])) {
+		t.Error("status position changed settings outside the header")
+	}
+}
+
+// TestTmuxPlugin_Validate tests status position validation.
+func TestTmuxPlugin_Validate(t *testing.T) {
+	plugin := New()
+	for _, position := range []string{"top", "bottom"} {
+		plugin.statusPosition = position
+		if err := plugin.Validate(); err != nil {
+			t.Errorf("Validate() with %q error = %v", position, err)
+		}
+	}
+
+	plugin.statusPosition = "left"
+	if err := plugin.Validate(); err == nil {
+		t.Error("Validate() expected error for invalid status position")
+	}
+}
@@ -0,0 +1,202 @@
+// Package vim provides an output plugin for Vim colorschemes.
+package vim
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// colorschemeFileName is the colorscheme Vim loads with `colorscheme tinct`.
+const colorschemeFileName = "tinct.vim"
+
+// requiredRoles are the roles the template reads without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1, colour.RoleAccent2,
+	colour.RoleDanger, colour.RoleWarning,
+}
+
+// Plugin implements the output.Plugin interface for Vim.
+type Plugin struct {
+	outputDir string
+	verbose   bool
+}
+
+// New creates a new Vim output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "vim"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Vim colorscheme (highlight groups with gui and xterm-256 cterm colours)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "vim.output-dir", "", "Output directory (default: ~/.vim/colors)")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "vim.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.vim/colors)", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".vim/colors"
+	}
+	return filepath.Join(home, ".vim", "colors")
+}
+
+// Generate creates the Vim colorscheme.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = colorschemeFileName
+
+	content, err := p.generateColorscheme(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate colorscheme: %w", err)
+	}
+
+	return map[string][]byte{colorschemeFileName: content}, nil
+}
+
+// generateColorscheme renders the colorscheme.
+func (p *Plugin) generateColorscheme(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("vim", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct.vim.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read colorscheme template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.vim.tmpl\n")
+	}
+
+	tmpl, err := template.New("colorscheme").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse colorscheme template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute colorscheme template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreExecute checks if vim is available before generating the colorscheme.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if vim executable exists on PATH.
+	if _, err := exec.LookPath("vim"); err != nil {
+		return true, "vim executable not found on $PATH", nil
+	}
+
+	// Check if colors directory exists, create if it doesn't.
+	colorsDir := p.DefaultOutputDir()
+	if _, err := os.Stat(colorsDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(colorsDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("vim colors directory not found and could not be created: %s", colorsDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created vim colors directory: %s\n", colorsDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides instructions for selecting the colorscheme.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   vim colorscheme written to %s\n", filepath.Join(p.DefaultOutputDir(), colorschemeFileName))
+	fmt.Fprintf(os.Stderr, "   Select it with colorscheme tinct in ~/.vimrc, or :colorscheme tinct in a running editor.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
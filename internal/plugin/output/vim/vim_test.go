@@ -0,0 +1,113 @@
+package vim
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// highlightLine matches a highlight command and captures the group and its attributes.
+var highlightLine = regexp.MustCompile(`^highlight ([A-Za-z]+) (.+)$`)
+
+// parseHighlights returns the attributes of each highlight group, e.g. "Normal.guifg".
+func parseHighlights(content string) map[string]string {
+	attrs := make(map[string]string)
+	for line := range strings.Lines(content) {
+		m := highlightLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		for field := range strings.FieldsSeq(m[2]) {
+			if key, value, ok := strings.Cut(field, "="); ok {
+				attrs[m[1]+"."+key] = value
+			}
+		}
+	}
+	return attrs
+}
+
+// TestVimPlugin runs all standard plugin tests using shared utilities.
+func TestVimPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "vim",
+		ExpectedFiles:        []string{"tinct.vim"},
+		ExpectedBinaryName:   "vim",
+		ExpectedDirSubstring: ".vim/colors",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestVimPlugin_ContentValidation tests the highlight groups and their gui and cterm colours.
+func TestVimPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["tinct.vim"])
+
+	for _, want := range []string{"set background=dark", `let g:colors_name = "tinct"`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("colorscheme should contain %q", want)
+		}
+	}
+
+	attrs := parseHighlights(content)
+	expected := map[string]colour.Role{
+		"Normal.guifg":     colour.RoleForeground,
+		"Normal.guibg":     colour.RoleBackground,
+		"Comment.guifg":    colour.RoleForegroundMuted,
+		"Identifier.guifg": colour.RoleAccent1,
+		"Statement.guifg":  colour.RoleAccent2,
+		"Visual.guibg":     colour.RoleBackgroundMuted,
+		"StatusLine.guifg": colour.RoleForeground,
+		"Error.guifg":      colour.RoleDanger,
+		"WarningMsg.guifg": colour.RoleWarning,
+	}
+	for key, role := range expected {
+		cv := helper.Get(role)
+		if attrs[key] != cv.Hex() {
+			t.Errorf("%s = %q, want %s (%s)", key, attrs[key], cv.Hex(), role)
+		}
+		// Every gui colour has a matching cterm fallback.
+		ctermKey := strings.Replace(key, ".gui", ".cterm", 1)
+		if want := strconv.Itoa(cv.Xterm256()); attrs[ctermKey] != want {
+			t.Errorf("%s = %q, want %s", ctermKey, attrs[ctermKey], want)
+		}
+	}
+
+	for _, group := range []string{"Constant", "Type", "CursorLine", "StatusLine"} {
+		found := false
+		for key := range attrs {
+			if strings.HasPrefix(key, group+".") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("highlight group %s should always be defined", group)
+		}
+	}
+}
+
+// TestVimPlugin_LightBackground tests that light themes set background=light.
+func TestVimPlugin_LightBackground(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeLight)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.Contains(string(files["tinct.vim"]), "set background=light") {
+		t.Error("light theme should set background=light")
+	}
+}
@@ -0,0 +1,218 @@
+// Package vscode provides an output plugin for Visual Studio Code colour themes.
+package vscode
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+const (
+	// themeFileName is the standalone theme written to the output directory.
+	themeFileName = "vscode-tinct-color-theme.json"
+	// manifestFileName is the extension manifest written with --vscode.extension-dir.
+	manifestFileName = "package.json"
+)
+
+// extensionThemeFile is the theme path inside an extension, as referenced by package.json.
+var extensionThemeFile = filepath.Join("themes", "tinct-color-theme.json")
+
+// requiredRoles are the roles the templates read without a fallback.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1, colour.RoleAccent2,
+	colour.RoleDanger, colour.RoleWarning,
+}
+
+// Plugin implements the output.Plugin interface for VS Code.
+type Plugin struct {
+	outputDir    string
+	extensionDir string
+	verbose      bool
+}
+
+// New creates a new VS Code output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir:    "",
+		extensionDir: "",
+		verbose:      false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "vscode"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "Visual Studio Code colour theme (optionally as an installable extension)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "vscode.output-dir", "", "Output directory (default: ~/.config/tinct)")
+	cmd.Flags().StringVar(&p.extensionDir, "vscode.extension-dir", "", "Write an installable extension (package.json and themes/) to this directory instead")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "vscode.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/tinct)", Required: false},
+		{Name: "vscode.extension-dir", Type: "string", Default: "", Description: "Write an installable extension (package.json and themes/) to this directory instead", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	if p.outputDir != "" && p.extensionDir != "" {
+		return fmt.Errorf("--vscode.output-dir and --vscode.extension-dir cannot be used together")
+	}
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.extensionDir != "" {
+		return p.extensionDir
+	}
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/tinct"
+	}
+	return filepath.Join(home, ".config", "tinct")
+}
+
+// Generate creates the VS Code colour theme, plus an extension manifest when
+// --vscode.extension-dir is set.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	themeFile := themeFileName
+	if p.extensionDir != "" {
+		themeFile = extensionThemeFile
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = themeFile
+
+	theme, err := p.render(themeData, themeFileName+".tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate theme: %w", err)
+	}
+	files := map[string][]byte{themeFile: theme}
+
+	if p.extensionDir != "" {
+		manifest, err := p.render(themeData, manifestFileName+".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate extension manifest: %w", err)
+		}
+		files[manifestFileName] = manifest
+	}
+
+	return files, nil
+}
+
+// render executes the named template against the theme data.
+func (p *Plugin) render(themeData *colour.ThemeData, name string) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("vscode", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", name, err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for %s\n", name)
+	}
+
+	tmpl, err := template.New(name).Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PostExecute explains how to load the theme in VS Code.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	if p.extensionDir != "" {
+		fmt.Fprintf(os.Stderr, "   VS Code extension written to %s\n", p.extensionDir)
+		fmt.Fprintf(os.Stderr, "   Install: ln -sf %s ~/.vscode/extensions/tinct-theme, then select \"Tinct\" in Preferences: Color Theme\n", p.extensionDir)
+	} else {
+		// A bare theme file is not loaded by VS Code on its own, so always show the next step.
+		themePath := filepath.Join(p.DefaultOutputDir(), themeFileName)
+		fmt.Fprintf(os.Stderr, "   VS Code theme written to %s\n", themePath)
+		fmt.Fprintf(os.Stderr, "   Use --vscode.extension-dir to write an installable extension around it.\n")
+	}
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package vscode
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// colorTheme is the subset of a VS Code colour theme checked by the tests.
+type colorTheme struct {
+	Type        string            `json:"type"`
+	Colors      map[string]string `json:"colors"`
+	TokenColors []struct {
+		Scope    []string `json:"scope"`
+		Settings struct {
+			Foreground string `json:"foreground"`
+		} `json:"settings"`
+	} `json:"tokenColors"`
+}
+
+// TestVSCodePlugin runs all standard plugin tests using shared utilities.
+func TestVSCodePlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "vscode",
+		ExpectedFiles:        []string{"vscode-tinct-color-theme.json"},
+		ExpectedDirSubstring: ".config/tinct",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestVSCodePlugin_ContentValidation tests the workbench colours and token scopes.
+func TestVSCodePlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	var theme colorTheme
+	if err := json.Unmarshal(files["vscode-tinct-color-theme.json"], &theme); err != nil {
+		t.Fatalf("theme is not valid JSON: %v", err)
+	}
+	if theme.Type != "dark" {
+		t.Errorf("type = %q, want dark", theme.Type)
+	}
+
+	expected := map[string]colour.Role{
+		"editor.background":         colour.RoleBackground,
+		"editor.foreground":         colour.RoleForeground,
+		"activityBar.background":    colour.RoleBackgroundMuted,
+		"statusBar.background":      colour.RoleBackgroundMuted,
+		"titleBar.activeBackground": colour.RoleBackgroundMuted,
+		"sideBar.foreground":        colour.RoleForeground,
+	}
+	for key, role := range expected {
+		if want := helper.Get(role).Hex(); theme.Colors[key] != want {
+			t.Errorf("%s = %q, want %s (%s)", key, theme.Colors[key], want, role)
+		}
+	}
+
+	scopes := make(map[string]string)
+	for _, token := range theme.TokenColors {
+		for _, scope := range token.Scope {
+			scopes[scope] = token.Settings.Foreground
+		}
+	}
+	tokens := map[string]colour.Role{
+		"comment":              colour.RoleForegroundMuted,
+		"keyword":              colour.RoleAccent2,
+		"entity.name.function": colour.RoleAccent1,
+	}
+	for scope, role := range tokens {
+		if want := helper.Get(role).Hex(); scopes[scope] != want {
+			t.Errorf("scope %s = %q, want %s (%s)", scope, scopes[scope], want, role)
+		}
+	}
+	if scopes["string"] == "" {
+		t.Error("string scope should always be coloured")
+	}
+}
+
+// TestVSCodePlugin_ExtensionDir tests the installable extension scaffold.
+func TestVSCodePlugin_ExtensionDir(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeLight)
+	plugin := New()
+	plugin.extensionDir = t.TempDir()
+
+	if got := plugin.DefaultOutputDir(); got != plugin.extensionDir {
+		t.Errorf("DefaultOutputDir() = %q, want %q", got, plugin.extensionDir)
+	}
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("expected package.json and theme, got %d files", len(files))
+	}
+
+	var manifest struct {
+		Contributes struct {
+			Themes []struct {
+				UITheme string `json:"uiTheme"`
+				Path    string `json:"path"`
+			} `json:"themes"`
+		} `json:"contributes"`
+	}
+	if err := json.Unmarshal(files["package.json"], &manifest); err != nil {
+		t.Fatalf("package.json is not valid JSON: %v", err)
+	}
+	if len(manifest.Contributes.Themes) != 1 {
+		t.Fatalf("expected one contributed theme, got %d", len(manifest.Contributes.Themes))
+	}
+	contributed := manifest.Contributes.Themes[0]
+	if contributed.UITheme != "vs" {
+		t.Errorf("uiTheme = %q, want vs for a light theme", contributed.UITheme)
+	}
+
+	// The manifest must point at the theme file that was generated.
+	themePath := filepath.Clean(contributed.Path)
+	var theme colorTheme
+	if err := json.Unmarshal(files[themePath], &theme); err != nil {
+		t.Fatalf("theme %s is missing or invalid: %v", themePath, err)
+	}
+	if theme.Type != "light" {
+		t.Errorf("type = %q, want light", theme.Type)
+	}
+}
+
+// TestVSCodePlugin_ValidateConflictingDirs tests that both directory flags are rejected.
+func TestVSCodePlugin_ValidateConflictingDirs(t *testing.T) {
+	plugin := New()
+	plugin.outputDir = "/tmp/theme"
+	plugin.extensionDir = "/tmp/extension"
+
+	if err := plugin.Validate(); err == nil {
+		t.Error("Validate() should reject --vscode.output-dir with --vscode.extension-dir")
+	}
+}
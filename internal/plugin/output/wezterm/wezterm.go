@@ -0,0 +1,233 @@
+// Package wezterm provides an output plugin for WezTerm terminal colour themes.
+package wezterm
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+const (
+	// luaFileName is the Lua colour table loaded from wezterm.lua.
+	luaFileName = "tinct.lua"
+
+	// colorschemeFileName is the named colour scheme written with --wezterm.as-colorscheme.
+	colorschemeFileName = "tinct.toml"
+)
+
+// requiredRoles are the palette roles the templates use directly, including the
+// roles the canonical ANSI mapping falls back to for small palettes.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleBackgroundMuted,
+	colour.RoleForeground, colour.RoleForegroundMuted,
+	colour.RoleAccent1, colour.RoleAccent2, colour.RoleAccent3, colour.RoleAccent4,
+	colour.RoleDanger, colour.RoleWarning, colour.RoleSuccess, colour.RoleInfo,
+}
+
+// Plugin implements the output.Plugin interface for WezTerm.
+type Plugin struct {
+	outputDir     string
+	asColorscheme bool
+	verbose       bool
+}
+
+// New creates a new WezTerm output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir:     "",
+		asColorscheme: false,
+		verbose:       false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "wezterm"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "WezTerm terminal colour theme (Lua or TOML colour scheme)"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "wezterm.output-dir", "", "Output directory (default: ~/.config/wezterm, or ~/.config/wezterm/colors with --wezterm.as-colorscheme)")
+	cmd.Flags().BoolVar(&p.asColorscheme, "wezterm.as-colorscheme", false, "Write a named TOML colour scheme (tinct.toml) instead of a Lua colour table")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "wezterm.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/wezterm, or ~/.config/wezterm/colors with --wezterm.as-colorscheme)", Required: false},
+		{Name: "wezterm.as-colorscheme", Type: "bool", Default: "false", Description: "Write a named TOML colour scheme instead of a Lua colour table", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+// Colour schemes go in the colors directory WezTerm searches for named schemes.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	dir := filepath.Join(".config", "wezterm")
+	if home, err := os.UserHomeDir(); err == nil {
+		dir = filepath.Join(home, dir)
+	}
+	if p.asColorscheme {
+		dir = filepath.Join(dir, "colors")
+	}
+	return dir
+}
+
+// fileName returns the name of the generated file for the selected format.
+func (p *Plugin) fileName() string {
+	if p.asColorscheme {
+		return colorschemeFileName
+	}
+	return luaFileName
+}
+
+// Generate creates the theme file.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = p.fileName()
+
+	content, err := p.generateTheme(themeData, p.fileName()+".tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate theme: %w", err)
+	}
+
+	return map[string][]byte{p.fileName(): content}, nil
+}
+
+// generateTheme renders the named template.
+func (p *Plugin) generateTheme(themeData *colour.ThemeData, templateName string) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("wezterm", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load(templateName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for %s\n", templateName)
+	}
+
+	tmpl, err := template.New("theme").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse theme template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute theme template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreExecute checks if wezterm is available before generating the theme.
+// Implements the output.PreExecuteHook interface.
+func (p *Plugin) PreExecute(_ context.Context) (skip bool, reason string, err error) {
+	// Check if wezterm executable exists on PATH.
+	_, err = exec.LookPath("wezterm")
+	if err != nil {
+		return true, "wezterm executable not found on $PATH", nil
+	}
+
+	// Check if config directory exists, create if it doesn't.
+	configDir := p.DefaultOutputDir()
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir, 0o755); err != nil { // #nosec G301 - Config directory needs standard permissions
+			return true, fmt.Sprintf("failed to create wezterm config directory: %s", configDir), nil
+		}
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Created wezterm config directory: %s\n", configDir)
+		}
+	}
+
+	return false, "", nil
+}
+
+// PostExecute provides usage instructions for applying the theme.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(_ context.Context, _ output.ExecutionContext, writtenFiles []string) error {
+	if !p.verbose || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   WezTerm theme generated successfully!\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   To use this theme, add to your wezterm.lua:\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	if p.asColorscheme {
+		fmt.Fprintf(os.Stderr, "   config.color_scheme = \"Tinct\"\n")
+	} else {
+		fmt.Fprintf(os.Stderr, "   config.colors = dofile(\"%s\")\n", filepath.Join(p.DefaultOutputDir(), luaFileName))
+	}
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "   Note: WezTerm reloads its config automatically when watched files change.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package wezterm
+
+import (
+	"image/color"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// TestWeztermPlugin runs all standard plugin tests using shared utilities.
+func TestWeztermPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "wezterm",
+		ExpectedFiles:      []string{"tinct.lua"},
+		ExpectedBinaryName: "wezterm",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestWeztermPlugin_ContentValidation tests the Lua colour table.
+func TestWeztermPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["tinct.lua"])
+
+	requiredStrings := []string{
+		"-- WezTerm colour theme generated by Tinct",
+		"return {",
+		"foreground = ",
+		"background = ",
+		"cursor_bg = ",
+		"cursor_fg = ",
+		"selection_bg = ",
+		"ansi = {",
+		"brights = {",
+		"Detected theme: dark",
+	}
+
+	for _, required := range requiredStrings {
+		if !strings.Contains(content, required) {
+			t.Errorf("Generated content missing required string: %s", required)
+		}
+	}
+
+	if got := strings.Count(content, `", -- `); got != 16 {
+		t.Errorf("Generated content has %d ANSI entries, want 16", got)
+	}
+}
+
+// TestWeztermPlugin_AsColorscheme tests the TOML colour scheme output.
+func TestWeztermPlugin_AsColorscheme(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.asColorscheme = true
+
+	if dir := plugin.DefaultOutputDir(); filepath.Base(dir) != "colors" {
+		t.Errorf("DefaultOutputDir() = %s, want a colors directory", dir)
+	}
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := files["tinct.lua"]; ok {
+		t.Error("Generate() wrote tinct.lua in colour scheme mode")
+	}
+
+	content := string(files["tinct.toml"])
+	for _, required := range []string{"[colors]", "ansi = [", "brights = [", "[metadata]", `name = "Tinct"`} {
+		if !strings.Contains(content, required) {
+			t.Errorf("Generated content missing required string: %s", required)
+		}
+	}
+}
+
+// TestWeztermPlugin_SmallPalette tests that palettes with fewer than 16 colours
+// still fill every ANSI slot.
+func TestWeztermPlugin_SmallPalette(t *testing.T) {
+	palette := colour.Categorise(&colour.Palette{Colors: []color.Color{
+		color.RGBA{R: 20, G: 20, B: 30, A: 255},
+		color.RGBA{R: 230, G: 230, B: 240, A: 255},
+		color.RGBA{R: 200, G: 80, B: 60, A: 255},
+	}}, colour.DefaultCategorisationConfig())
+	plugin := New()
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["tinct.lua"])
+	if got := strings.Count(content, `", -- `); got != 16 {
+		t.Errorf("Generated content has %d ANSI entries, want 16", got)
+	}
+
+	// Red falls back to the danger role rather than the closest extracted colour.
+	danger := colour.NewPaletteHelper(palette).Get(colour.RoleDanger).Hex()
+	if !strings.Contains(content, `"`+danger+`", -- red`) {
+		t.Errorf("Generated content does not use danger %s for red", danger)
+	}
+}
+
+// TestWeztermPlugin_CustomOutputDir tests custom output directory handling.
+func TestWeztermPlugin_CustomOutputDir(t *testing.T) {
+	plugin := New()
+	plugin.outputDir = "/custom/path"
+	plugin.asColorscheme = true
+
+	if dir := plugin.DefaultOutputDir(); dir != "/custom/path" {
+		t.Errorf("DefaultOutputDir() = %s, want /custom/path", dir)
+	}
+}
+
+// TestWeztermPlugin_GetEmbeddedTemplates tests embedded template access.
+func TestWeztermPlugin_GetEmbeddedTemplates(t *testing.T) {
+	fs := GetEmbeddedTemplates()
+
+	for _, name := range []string{"tinct.lua.tmpl", "tinct.toml.tmpl"} {
+		if _, err := fs.ReadFile(name); err != nil {
+			t.Errorf("Template file %s not found in embedded filesystem: %v", name, err)
+		}
+	}
+}
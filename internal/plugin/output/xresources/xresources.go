@@ -0,0 +1,196 @@
+// Package xresources provides an output plugin for X resources (.Xresources).
+package xresources
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/input"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	tmplloader "github.com/jmylchreest/tinct/internal/plugin/output/template"
+)
+
+//go:embed *.tmpl
+var templates embed.FS
+
+// GetEmbeddedTemplates returns the embedded template filesystem.
+// This is used by the template management commands.
+func GetEmbeddedTemplates() embed.FS {
+	return templates
+}
+
+// resourcesFileName is the generated resource file merged with xrdb.
+const resourcesFileName = "tinct.Xresources"
+
+// requiredRoles are the roles the template reads directly.
+var requiredRoles = []colour.Role{
+	colour.RoleBackground, colour.RoleForeground, colour.RoleAccent1,
+}
+
+// Plugin implements the output.Plugin interface for X resources.
+type Plugin struct {
+	outputDir string
+	verbose   bool
+}
+
+// New creates a new Xresources output plugin with default settings.
+func New() *Plugin {
+	return &Plugin{
+		outputDir: "",
+		verbose:   false,
+	}
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string {
+	return "xresources"
+}
+
+// Description returns the plugin description.
+func (p *Plugin) Description() string {
+	return "X resources colours (background, foreground, cursor and ANSI 0-15), merged with xrdb"
+}
+
+// Version returns the plugin version.
+func (p *Plugin) Version() string {
+	return "0.0.1"
+}
+
+// RegisterFlags registers plugin-specific flags with the cobra command.
+func (p *Plugin) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.outputDir, "xresources.output-dir", "", "Output directory (default: ~/.config/tinct)")
+}
+
+// SetVerbose enables or disables verbose logging for the plugin.
+// Implements the output.VerbosePlugin interface.
+func (p *Plugin) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// GetEmbeddedFS returns the embedded template filesystem.
+// Implements the output.TemplateProvider interface.
+func (p *Plugin) GetEmbeddedFS() any {
+	return templates
+}
+
+// GetFlagHelp returns help information for all plugin flags.
+func (p *Plugin) GetFlagHelp() []input.FlagHelp {
+	return []input.FlagHelp{
+		{Name: "xresources.output-dir", Type: "string", Default: "", Description: "Output directory (default: ~/.config/tinct)", Required: false},
+	}
+}
+
+// Validate checks if the plugin configuration is valid.
+func (p *Plugin) Validate() error {
+	return nil
+}
+
+// DefaultOutputDir returns the default output directory for this plugin.
+func (p *Plugin) DefaultOutputDir() string {
+	if p.outputDir != "" {
+		return p.outputDir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config/tinct"
+	}
+	return filepath.Join(home, ".config", "tinct")
+}
+
+// Generate creates the X resources file.
+// Returns map of filename -> content.
+func (p *Plugin) Generate(themeData *colour.ThemeData) (map[string][]byte, error) {
+	if themeData == nil {
+		return nil, fmt.Errorf("theme data cannot be nil")
+	}
+
+	if err := themeData.Palette().Validate(requiredRoles...); err != nil {
+		return nil, err
+	}
+
+	// Populate template metadata fields.
+	themeData.OutputDir = p.DefaultOutputDir()
+	themeData.ColorFileName = resourcesFileName
+
+	content, err := p.generateResources(themeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate resources: %w", err)
+	}
+
+	return map[string][]byte{resourcesFileName: content}, nil
+}
+
+// generateResources renders the X resources file.
+func (p *Plugin) generateResources(themeData *colour.ThemeData) ([]byte, error) {
+	// Load template with custom override support.
+	loader := tmplloader.New("xresources", templates)
+	if p.verbose {
+		loader.WithVerbose(true, common.NewVerboseLogger(os.Stderr))
+	}
+	tmplContent, fromCustom, err := loader.Load("tinct.Xresources.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resources template: %w", err)
+	}
+
+	// Log if using custom template.
+	if p.verbose && fromCustom {
+		fmt.Fprintf(os.Stderr, "   Using custom template for tinct.Xresources.tmpl\n")
+	}
+
+	tmpl, err := template.New("xresources").Funcs(common.TemplateFuncs()).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resources template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, themeData); err != nil {
+		return nil, fmt.Errorf("failed to execute resources template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PostExecute merges the generated resources into the X server's resource database
+// with xrdb, so newly started X applications pick up the colours. It does nothing on
+// a dry run, with --no-reload, or when xrdb is not on $PATH.
+// Implements the output.PostExecuteHook interface.
+func (p *Plugin) PostExecute(ctx context.Context, execCtx output.ExecutionContext, writtenFiles []string) error {
+	if execCtx.DryRun || execCtx.NoReload || len(writtenFiles) == 0 {
+		return nil
+	}
+
+	resourcesPath := filepath.Join(p.DefaultOutputDir(), resourcesFileName)
+	if _, err := exec.LookPath("xrdb"); err != nil {
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   xrdb not found - load the colours with: xrdb -merge %s\n", resourcesPath)
+		}
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, "xrdb", "-merge", resourcesPath) // #nosec G204 - path is the file tinct just wrote
+	if err := cmd.Run(); err != nil {
+		// xrdb fails without a running X server; inform the user but don't treat it as an error.
+		if p.verbose {
+			fmt.Fprintf(os.Stderr, "   Note: Could not merge X resources automatically: %v\n", err)
+			fmt.Fprintf(os.Stderr, "   Run xrdb -merge %s from an X session to apply changes\n", resourcesPath)
+		}
+		return nil
+	}
+
+	if p.verbose {
+		fmt.Fprintf(os.Stderr, "   X resources merged with xrdb\n")
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package xresources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/output"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// resourceLine matches a resource assignment and captures its name and value.
+var resourceLine = regexp.MustCompile(`^\*(\w+):\s+(\S+)$`)
+
+// TestXresourcesPlugin runs all standard plugin tests using shared utilities.
+func TestXresourcesPlugin(t *testing.T) {
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:         "xresources",
+		ExpectedFiles:        []string{"tinct.Xresources"},
+		ExpectedBinaryName:   "xrdb",
+		ExpectedDirSubstring: ".config/tinct",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestXresourcesPlugin_ContentValidation tests the special colours and the 16 ANSI colours.
+func TestXresourcesPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	helper := colour.NewPaletteHelper(palette)
+
+	files, err := New().Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	resources := make(map[string]string)
+	for line := range strings.Lines(string(files["tinct.Xresources"])) {
+		if m := resourceLine.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			resources[m[1]] = m[2]
+		}
+	}
+
+	expected := map[string]string{
+		"background":  helper.Get(colour.RoleBackground).Hex(),
+		"foreground":  helper.Get(colour.RoleForeground).Hex(),
+		"cursorColor": helper.Get(colour.RoleAccent1).Hex(),
+	}
+	for i, cv := range palette.ANSI16() {
+		expected["color"+strconv.Itoa(i)] = cv.Hex()
+	}
+
+	if len(resources) != len(expected) {
+		t.Errorf("got %d resources, want %d", len(resources), len(expected))
+	}
+	for name, want := range expected {
+		if resources[name] != want {
+			t.Errorf("*%s = %q, want %s", name, resources[name], want)
+		}
+	}
+}
+
+// fakeXrdb puts an xrdb script on $PATH that records its arguments, returning the
+// file the arguments are written to.
+func fakeXrdb(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake xrdb script needs a POSIX shell")
+	}
+
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\n"
+	if err := os.WriteFile(filepath.Join(binDir, "xrdb"), []byte(script), 0o755); err != nil { // #nosec G306 - test script must be executable
+		t.Fatalf("failed to write fake xrdb: %v", err)
+	}
+	t.Setenv("PATH", binDir)
+	return argsFile
+}
+
+// TestXresourcesPlugin_PostExecuteMerges tests the written file is merged with xrdb.
+func TestXresourcesPlugin_PostExecuteMerges(t *testing.T) {
+	argsFile := fakeXrdb(t)
+	plugin := New()
+	plugin.outputDir = t.TempDir()
+
+	err := plugin.PostExecute(context.Background(), output.ExecutionContext{}, []string{"tinct.Xresources"})
+	if err != nil {
+		t.Fatalf("PostExecute() error = %v", err)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("xrdb was not run: %v", err)
+	}
+	want := "-merge " + filepath.Join(plugin.outputDir, "tinct.Xresources")
+	if got := strings.TrimSpace(string(args)); got != want {
+		t.Errorf("xrdb arguments = %q, want %q", got, want)
+	}
+}
+
+// TestXresourcesPlugin_PostExecuteNoReload tests xrdb is not run with --no-reload or --dry-run.
+func TestXresourcesPlugin_PostExecuteNoReload(t *testing.T) {
+	for name, execCtx := range map[string]output.ExecutionContext{
+		"no-reload": {NoReload: true},
+		"dry-run":   {DryRun: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			argsFile := fakeXrdb(t)
+
+			err := New().PostExecute(context.Background(), execCtx, []string{"tinct.Xresources"})
+			if err != nil {
+				t.Fatalf("PostExecute() error = %v", err)
+			}
+			if _, err := os.Stat(argsFile); !os.IsNotExist(err) {
+				t.Error("xrdb should not run")
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package zathura
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/internal/plugin/output/common"
+	plugintesting "github.com/jmylchreest/tinct/internal/plugin/output/testing"
+)
+
+// setLine matches a zathurarc colour option.
+var setLine = regexp.MustCompile(`^set ([a-z-]+) "(#[0-9a-f]{6})"$`)
+
+// parseOptions returns the colour options set in a zathurarc.
+func parseOptions(content string) map[string]string {
+	values := make(map[string]string)
+	for line := range strings.Lines(content) {
+		if m := setLine.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			values[m[1]] = m[2]
+		}
+	}
+	return values
+}
+
+// TestZathuraPlugin runs all standard plugin tests using shared utilities.
+func TestZathuraPlugin(t *testing.T) {
+	// Generate reads the existing zathurarc, so keep the user's own out of the test.
+	t.Setenv("HOME", t.TempDir())
+	plugin := New()
+
+	config := plugintesting.TestConfig{
+		ExpectedName:       "zathura",
+		ExpectedFiles:      []string{"zathurarc"},
+		ExpectedBinaryName: "zathura",
+	}
+
+	plugintesting.RunAllTests(t, plugin, config)
+}
+
+// TestZathuraPlugin_ContentValidation tests the colour options and their roles.
+func TestZathuraPlugin_ContentValidation(t *testing.T) {
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.outputDir = t.TempDir()
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content := string(files["zathurarc"])
+	if !strings.HasPrefix(content, common.ManagedBlockBegin+"\n") || !strings.HasSuffix(content, common.ManagedBlockEnd+"\n") {
+		t.Errorf("zathurarc should be wrapped in tinct markers, got:\n%s", content)
+	}
+
+	values := parseOptions(content)
+	helper := colour.NewPaletteHelper(palette)
+	expected := map[string]colour.Role{
+		"default-bg":             colour.RoleBackground,
+		"default-fg":             colour.RoleForeground,
+		"statusbar-bg":           colour.RoleBackgroundMuted,
+		"statusbar-fg":           colour.RoleForeground,
+		"inputbar-bg":            colour.RoleBackground,
+		"inputbar-fg":            colour.RoleForeground,
+		"highlight-color":        colour.RoleAccent2,
+		"highlight-active-color": colour.RoleAccent1,
+		"recolor-darkcolor":      colour.RoleForeground,
+		"recolor-lightcolor":     colour.RoleBackground,
+	}
+	for key, role := range expected {
+		if got, want := values[key], helper.Get(role).Hex(); got != want {
+			t.Errorf("%s = %q, want %q (%s)", key, got, want, role)
+		}
+	}
+}
+
+// TestZathuraPlugin_PreservesUserSettings verifies only the managed block changes.
+func TestZathuraPlugin_PreservesUserSettings(t *testing.T) {
+	dir := t.TempDir()
+	existing := "set selection-clipboard clipboard\n\n" +
+		common.ManagedBlockBegin + "\nset default-bg \"#123456\"\n" + common.ManagedBlockEnd + "\n" +
+		"map J zoom out\n"
+	if err := os.WriteFile(filepath.Join(dir, "zathurarc"), []byte(existing), 0o600); err != nil {
+		t.Fatalf("failed to write zathurarc: %v", err)
+	}
+
+	palette := plugintesting.CreateTestPalette(colour.ThemeDark)
+	plugin := New()
+	plugin.outputDir = dir
+
+	files, err := plugin.Generate(colour.NewThemeData(palette, "", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(files["zathurarc"])
+
+	if !strings.HasPrefix(content, "set selection-clipboard clipboard\n\n"+common.ManagedBlockBegin+"\n") {
+		t.Errorf("settings before the block changed:\n%s", content)
+	}
+	if !strings.HasSuffix(content, common.ManagedBlockEnd+"\nmap J zoom out\n") {
+		t.Errorf("settings after the block changed:\n%s", content)
+	}
+	if strings.Contains(content, `"#123456"`) {
+		t.Error("old managed colours were not replaced")
+	}
+	if n := strings.Count(content, common.ManagedBlockBegin); n != 1 {
+		t.Errorf("zathurarc has %d managed blocks, want 1", n)
+	}
+}
@@ -0,0 +1,96 @@
+// Package security provides protection against writing output over critical files.
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProtectedPathsEnv names the environment variable holding extra protected paths,
+// separated by the OS path list separator (":" on Unix).
+const ProtectedPathsEnv = "TINCT_PROTECTED_PATHS"
+
+// DefaultProtectedPaths are the paths output files must not be written to without
+// explicit confirmation. Directories protect everything beneath them; "~/" is the
+// user's home directory.
+var DefaultProtectedPaths = []string{
+	"/bin", "/boot", "/dev", "/etc", "/lib", "/lib64", "/proc",
+	"/sbin", "/sys", "/usr",
+	"~/.bashrc", "~/.bash_profile", "~/.bash_login", "~/.profile",
+	"~/.zshrc", "~/.zshenv", "~/.zprofile",
+	"~/.config/fish/config.fish",
+	"~/.ssh", "~/.gnupg",
+}
+
+// ProtectedPaths returns DefaultProtectedPaths plus any paths listed in
+// the TINCT_PROTECTED_PATHS environment variable.
+func ProtectedPaths() []string {
+	paths := append([]string(nil), DefaultProtectedPaths...)
+	for _, p := range filepath.SplitList(os.Getenv(ProtectedPathsEnv)) {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// MatchProtectedPath reports whether path is one of protected or lies beneath one.
+// It returns the protected entry that matched. Both sides are expanded ("~/"),
+// made absolute and cleaned before comparison, and are also compared with symlinks
+// resolved, so a link into a protected location is caught.
+func MatchProtectedPath(path string, protected []string) (string, bool) {
+	target := normalisePath(path)
+	if target == "" {
+		return "", false
+	}
+	targets := []string{target, resolveSymlinks(target)}
+
+	for _, entry := range protected {
+		p := normalisePath(entry)
+		if p == "" {
+			continue
+		}
+		for _, e := range []string{p, resolveSymlinks(p)} {
+			for _, t := range targets {
+				if t == e || strings.HasPrefix(t, e+string(filepath.Separator)) {
+					return entry, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// normalisePath expands a leading "~/" and returns the absolute, cleaned path.
+// An empty string is returned if the path cannot be resolved.
+func normalisePath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	return abs
+}
+
+// resolveSymlinks resolves the symlinks in an absolute, cleaned path. The path need
+// not exist: the deepest existing ancestor is resolved and the rest is appended.
+func resolveSymlinks(path string) string {
+	rest := ""
+	for dir := path; ; dir = filepath.Dir(dir) {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(resolved, rest)
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return path
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+	}
+}
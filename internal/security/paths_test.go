@@ -0,0 +1,88 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchProtectedPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name      string
+		path      string
+		wantMatch string
+		wantOK    bool
+	}{
+		{"system directory itself", "/etc", "/etc", true},
+		{"file beneath system directory", "/etc/hosts", "/etc", true},
+		{"unclean path", "/tmp/../etc/passwd", "/etc", true},
+		{"shell rc file", filepath.Join(home, ".bashrc"), "~/.bashrc", true},
+		{"tilde path", "~/.ssh/config", "~/.ssh", true},
+		{"similar prefix", "/etcetera/file", "", false},
+		{"config file", filepath.Join(home, ".config", "kitty", "tinct.conf"), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := MatchProtectedPath(tt.path, DefaultProtectedPaths)
+			if ok != tt.wantOK || match != tt.wantMatch {
+				t.Errorf("MatchProtectedPath(%q) = %q, %v, want %q, %v", tt.path, match, ok, tt.wantMatch, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestProtectedPathsFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ProtectedPathsEnv, dir+string(filepath.ListSeparator)+" ")
+
+	paths := ProtectedPaths()
+	if len(paths) != len(DefaultProtectedPaths)+1 {
+		t.Fatalf("ProtectedPaths() returned %d paths, want %d", len(paths), len(DefaultProtectedPaths)+1)
+	}
+	if _, ok := MatchProtectedPath(filepath.Join(dir, "theme.conf"), paths); !ok {
+		t.Errorf("path beneath %s should be protected", ProtectedPathsEnv)
+	}
+}
+
+func TestMatchProtectedPathSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	protected := filepath.Join(dir, "protected")
+	if err := os.Mkdir(protected, 0o700); err != nil {
+		t.Fatalf("failed to create %s: %v", protected, err)
+	}
+	if err := os.WriteFile(filepath.Join(protected, "authorized_keys"), nil, 0o600); err != nil {
+		t.Fatalf("failed to create protected file: %v", err)
+	}
+
+	linkDir := filepath.Join(dir, "theme")
+	linkFile := filepath.Join(dir, "theme.conf")
+	if err := os.Symlink(protected, linkDir); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(protected, "authorized_keys"), linkFile); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		wantOK bool
+	}{
+		{"symlinked file", linkFile, true},
+		{"existing file under symlinked directory", filepath.Join(linkDir, "authorized_keys"), true},
+		{"new file under symlinked directory", filepath.Join(linkDir, "new", "tinct.conf"), true},
+		{"unrelated file", filepath.Join(dir, "other.conf"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := MatchProtectedPath(tt.path, []string{protected}); ok != tt.wantOK {
+				t.Errorf("MatchProtectedPath(%q) = %v, want %v", tt.path, ok, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Package http provides HTTP utilities for fetching remote resources.
+package http
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy bounds retries of transient HTTP failures.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first request.
+	MaxAttempts int
+
+	// BaseDelay is the backoff before the first retry; it doubles for each retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff. A Retry-After longer than this is not waited for;
+	// the response is returned to the caller instead.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used for remote API calls.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   time.Second,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// RetryTransport is an http.RoundTripper that retries 429 and 5xx responses with
+// exponential backoff and jitter, honouring Retry-After and request cancellation.
+type RetryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+
+	// sleep waits for d or until ctx is done (replaced in tests).
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryTransport wraps base (http.DefaultTransport if nil) with retries.
+func NewRetryTransport(base http.RoundTripper, policy RetryPolicy) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{
+		base:   base,
+		policy: policy,
+		sleep:  sleepContext,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests whose body cannot be replayed are sent once.
+	canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 1; ; attempt++ {
+		// A RoundTripper must not modify the caller's request, so retries send a
+		// clone with a fresh body.
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil || !isRetryableStatus(resp.StatusCode) || !canReplay || attempt >= t.policy.MaxAttempts {
+			return resp, err
+		}
+
+		delay, ok := t.retryDelay(resp, attempt)
+		if !ok {
+			return resp, nil
+		}
+
+		// Discard the failed response so the connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay returns how long to wait before the next attempt.
+// Returns false if the server asked for a longer wait than the policy allows.
+func (t *RetryTransport) retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		if retryAfter > t.policy.MaxDelay {
+			return 0, false
+		}
+		return retryAfter, true
+	}
+
+	backoff := t.policy.BaseDelay << (attempt - 1)
+	if backoff <= 0 || backoff > t.policy.MaxDelay {
+		backoff = t.policy.MaxDelay
+	}
+
+	// Jitter between half and the full backoff to spread out concurrent clients.
+	half := backoff / 2
+	if half <= 0 {
+		return backoff, true
+	}
+	return half + rand.N(half+1), true //nolint:gosec // Jitter does not need a secure source
+}
+
+// isRetryableStatus reports whether an HTTP status indicates a transient failure.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses a Retry-After header in delay-seconds or HTTP-date form.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return max(when.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
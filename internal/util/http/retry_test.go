@@ -0,0 +1,224 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// statusServer responds with the given statuses in order, then 200 OK.
+func statusServer(t *testing.T, headers http.Header, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" && r.Method == http.MethodPost {
+			t.Errorf("attempt %d received body %q, want %q", n, body, "payload")
+		}
+		if n <= len(statuses) {
+			for k, v := range headers {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// recordingTransport returns a retry transport whose sleeps are recorded instead of waited.
+func recordingTransport(policy RetryPolicy, delays *[]time.Duration) *RetryTransport {
+	transport := NewRetryTransport(nil, policy)
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return ctx.Err()
+	}
+	return transport
+}
+
+func TestRetryTransportRetriesUntilSuccess(t *testing.T) {
+	server, requests := statusServer(t, nil, http.StatusTooManyRequests, http.StatusTooManyRequests)
+
+	var delays []time.Duration
+	policy := RetryPolicy{MaxAttempts: 4, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	client := &http.Client{Transport: recordingTransport(policy, &delays)}
+
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+	if len(delays) != 2 {
+		t.Fatalf("expected 2 backoff sleeps, got %v", delays)
+	}
+
+	// Exponential backoff with jitter: each delay lies in [backoff/2, backoff].
+	for i, d := range delays {
+		backoff := policy.BaseDelay << i
+		if d < backoff/2 || d > backoff {
+			t.Errorf("delay %d = %v, want within [%v, %v]", i, d, backoff/2, backoff)
+		}
+	}
+}
+
+func TestRetryTransportDoesNotModifyRequest(t *testing.T) {
+	server, requests := statusServer(t, nil, http.StatusServiceUnavailable)
+
+	var delays []time.Duration
+	transport := recordingTransport(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second}, &delays)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	body := req.Body
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+	if req.Body != body {
+		t.Error("RoundTrip() replaced the caller's request body")
+	}
+}
+
+func TestRetryTransportGivesUpAfterMaxAttempts(t *testing.T) {
+	server, requests := statusServer(t, nil,
+		http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	var delays []time.Duration
+	client := &http.Client{Transport: recordingTransport(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}, &delays)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", resp.StatusCode)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	server, requests := statusServer(t, nil, http.StatusBadRequest)
+
+	var delays []time.Duration
+	client := &http.Client{Transport: recordingTransport(DefaultRetryPolicy(), &delays)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest || requests.Load() != 1 {
+		t.Errorf("status = %d after %d requests, want 400 after 1", resp.StatusCode, requests.Load())
+	}
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	server, _ := statusServer(t, http.Header{"Retry-After": {"3"}}, http.StatusTooManyRequests)
+
+	var delays []time.Duration
+	client := &http.Client{Transport: recordingTransport(DefaultRetryPolicy(), &delays)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if len(delays) != 1 || delays[0] != 3*time.Second {
+		t.Errorf("delays = %v, want [3s]", delays)
+	}
+}
+
+func TestRetryTransportRetryAfterBeyondMaxDelay(t *testing.T) {
+	server, requests := statusServer(t, http.Header{"Retry-After": {"3600"}}, http.StatusTooManyRequests)
+
+	var delays []time.Duration
+	client := &http.Client{Transport: recordingTransport(DefaultRetryPolicy(), &delays)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests || requests.Load() != 1 || len(delays) != 0 {
+		t.Errorf("expected the 429 to be returned without waiting, got status %d after %d requests", resp.StatusCode, requests.Load())
+	}
+}
+
+func TestRetryTransportStopsOnCancel(t *testing.T) {
+	server, requests := statusServer(t, nil, http.StatusTooManyRequests, http.StatusTooManyRequests)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	transport := NewRetryTransport(nil, RetryPolicy{MaxAttempts: 4, BaseDelay: time.Hour, MaxDelay: time.Hour})
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return sleepContext(ctx, d)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	_, err = (&http.Client{Transport: transport}).Do(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -49,7 +49,7 @@ func String() string {
 	info := GetInfo()
 	if Commit != "unknown" && Date != "unknown" {
 		return fmt.Sprintf("tinct version %s (commit: %s, built: %s, %s, %s)",
-			info.Version, info.Commit[:8], info.Date, info.GoVersion, info.Platform)
+			info.Version, info.Commit[:min(8, len(info.Commit))], info.Date, info.GoVersion, info.Platform)
 	}
 	return fmt.Sprintf("tinct version %s (%s, %s)", info.Version, info.GoVersion, info.Platform)
 }
@@ -0,0 +1,44 @@
+// Package palette provides colour queries over tinct palettes for external tools and plugins.
+package palette
+
+import (
+	"slices"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/pkg/plugin"
+)
+
+// ColourRole is the name of a semantic colour role (e.g. "background", "accent1"),
+// matching the keys of plugin.PaletteData.Colours.
+type ColourRole string
+
+// NearestRole returns the role whose colour is closest to hex (#RRGGBB or #RGB, the "#"
+// optional) and its distance as a CIE76 colour difference (0 is an exact match; around
+// 2.3 is the smallest difference most people notice). When several roles are equally
+// close, the first by role name wins, so results are stable.
+//
+// It returns an empty role and a distance of -1 if hex cannot be parsed or the palette
+// has no role colours.
+func NearestRole(p *plugin.PaletteData, hex string) (ColourRole, float64) {
+	target, err := colour.ParseHex(hex)
+	if err != nil || p == nil || len(p.Colours) == 0 {
+		return "", -1
+	}
+
+	roles := make([]string, 0, len(p.Colours))
+	for role := range p.Colours {
+		roles = append(roles, role)
+	}
+	slices.Sort(roles)
+
+	nearest, best := "", -1.0
+	for _, role := range roles {
+		c := p.Colours[role].RGB
+		d := colour.DeltaE(target, colour.RGB{R: c.R, G: c.G, B: c.B})
+		if best < 0 || d < best {
+			nearest, best = role, d
+		}
+	}
+
+	return ColourRole(nearest), best
+}
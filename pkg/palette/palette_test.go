@@ -0,0 +1,117 @@
+package palette
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jmylchreest/tinct/internal/colour"
+	"github.com/jmylchreest/tinct/pkg/plugin"
+)
+
+// testPalette builds palette data with the given role -> RGB colours.
+func testPalette(roles map[string]plugin.RGBColour) *plugin.PaletteData {
+	p := &plugin.PaletteData{Colours: make(map[string]plugin.CategorisedColour, len(roles))}
+	for role, rgb := range roles {
+		p.Colours[role] = plugin.CategorisedColour{RGB: rgb, Role: role}
+	}
+	return p
+}
+
+func TestNearestRole(t *testing.T) {
+	p := testPalette(map[string]plugin.RGBColour{
+		"background": {R: 0x1e, G: 0x1e, B: 0x2e},
+		"foreground": {R: 0xcd, G: 0xd6, B: 0xf4},
+		"accent1":    {R: 0x33, G: 0x66, B: 0xff},
+		"danger":     {R: 0xf3, G: 0x8b, B: 0xa8},
+		"success":    {R: 0xa6, G: 0xe3, B: 0xa1},
+	})
+
+	tests := []struct {
+		name string
+		hex  string
+		want ColourRole
+	}{
+		{name: "exact match", hex: "#3366ff", want: "accent1"},
+		{name: "without hash", hex: "3366FF", want: "accent1"},
+		{name: "near blue", hex: "#2f5fe8", want: "accent1"},
+		{name: "near black", hex: "#101018", want: "background"},
+		{name: "near white", hex: "#e0e8ff", want: "foreground"},
+		{name: "pinkish red", hex: "#ff8080", want: "danger"},
+		{name: "pale green", hex: "#99dd99", want: "success"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, dist := NearestRole(p, tt.hex)
+			if role != tt.want {
+				t.Fatalf("NearestRole(%q) role = %q, want %q", tt.hex, role, tt.want)
+			}
+
+			target, _ := colour.ParseHex(tt.hex)
+			c := p.Colours[string(role)].RGB
+			if want := colour.DeltaE(target, colour.RGB{R: c.R, G: c.G, B: c.B}); math.Abs(dist-want) > 1e-9 {
+				t.Errorf("NearestRole(%q) distance = %v, want %v", tt.hex, dist, want)
+			}
+		})
+	}
+
+	if _, dist := NearestRole(p, "#3366ff"); dist != 0 {
+		t.Errorf("exact match distance = %v, want 0", dist)
+	}
+	if _, dist := NearestRole(p, "#36f"); dist != 0 {
+		t.Errorf("shorthand exact match distance = %v, want 0", dist)
+	}
+}
+
+func TestNearestRoleAmbiguous(t *testing.T) {
+	// accent1 and info share a colour, so every query is a tie between them.
+	blue := plugin.RGBColour{R: 0x33, G: 0x66, B: 0xff}
+	p := testPalette(map[string]plugin.RGBColour{
+		"info":    blue,
+		"accent1": blue,
+		"danger":  {R: 0xff, G: 0x00, B: 0x00},
+	})
+
+	for range 20 { // Map iteration order must not affect the result.
+		if role, _ := NearestRole(p, "#3366ff"); role != "accent1" {
+			t.Fatalf("NearestRole() tie = %q, want accent1 (first by name)", role)
+		}
+	}
+
+	// A colour between two roles is matched to whichever is perceptually closer,
+	// and the distance reflects how far it is from both.
+	p = testPalette(map[string]plugin.RGBColour{
+		"background": {R: 0, G: 0, B: 0},
+		"foreground": {R: 255, G: 255, B: 255},
+	})
+	role, dist := NearestRole(p, "#808080")
+	if role != "foreground" {
+		t.Errorf("NearestRole(#808080) = %q, want foreground (mid-grey has L* 54)", role)
+	}
+	if dist < 40 || dist > 60 {
+		t.Errorf("NearestRole(#808080) distance = %v, want roughly 50", dist)
+	}
+}
+
+func TestNearestRoleInvalid(t *testing.T) {
+	p := testPalette(map[string]plugin.RGBColour{"accent1": {R: 0x33, G: 0x66, B: 0xff}})
+
+	tests := []struct {
+		name string
+		p    *plugin.PaletteData
+		hex  string
+	}{
+		{name: "wrong length", p: p, hex: "#3366f"},
+		{name: "not hex", p: p, hex: "#zzzzzz"},
+		{name: "empty palette", p: &plugin.PaletteData{}, hex: "#3366ff"},
+		{name: "nil palette", p: nil, hex: "#3366ff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if role, dist := NearestRole(tt.p, tt.hex); role != "" || dist != -1 {
+				t.Errorf("NearestRole() = (%q, %v), want (\"\", -1)", role, dist)
+			}
+		})
+	}
+}
@@ -2,11 +2,33 @@
 package plugin
 
 // InputOptions holds options for input plugin generation.
+//
+// Count, Backend, SeedMode, SeedValue, ExtractAmbience and Regions are well-known
+// extraction options set from tinct's generic --input-* flags. Zero values mean the
+// user did not set them and the plugin should use its own defaults.
 type InputOptions struct {
 	Verbose         bool           `json:"verbose"`
 	DryRun          bool           `json:"dry_run"`
 	ColourOverrides []string       `json:"colour_overrides,omitempty"`
 	PluginArgs      map[string]any `json:"plugin_args,omitempty"`
+
+	// Count is the number of colours to produce.
+	Count int `json:"count,omitempty"`
+
+	// Backend is the colour extraction algorithm, e.g. "kmeans".
+	Backend string `json:"backend,omitempty"`
+
+	// SeedMode is how to seed non-deterministic extraction: content, filepath, name, manual or random.
+	SeedMode string `json:"seed_mode,omitempty"`
+
+	// SeedValue is the seed to use, set with SeedMode "manual".
+	SeedValue *int64 `json:"seed_value,omitempty"`
+
+	// ExtractAmbience requests edge and corner colours for ambient lighting.
+	ExtractAmbience bool `json:"extract_ambience,omitempty"`
+
+	// Regions is the number of ambient regions to extract (4, 8, 12 or 16).
+	Regions int `json:"regions,omitempty"`
 }
 
 // PaletteData is the palette data sent to output plugins.